@@ -10,7 +10,7 @@ import (
 )
 
 // GetAccountId gets the Account ID for the currently logged in IAM User.
-func GetAccountId(t *testing.T) string {
+func GetAccountId(t testing.TB) string {
 	id, err := GetAccountIdE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -19,7 +19,7 @@ func GetAccountId(t *testing.T) string {
 }
 
 // GetAccountIdE gets the Account ID for the currently logged in IAM User.
-func GetAccountIdE(t *testing.T) (string, error) {
+func GetAccountIdE(t testing.TB) (string, error) {
 	stsClient, err := NewStsClientE(t, defaultRegion)
 	if err != nil {
 		return "", err
@@ -46,8 +46,8 @@ func extractAccountIDFromARN(arn string) (string, error) {
 }
 
 // NewStsClientE creates a new STS client.
-func NewStsClientE(t *testing.T, region string) (*sts.STS, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewStsClientE(t testing.TB, region string) (*sts.STS, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -1,13 +1,19 @@
 package aws
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
 )
 
 // GetAcmCertificateArn gets the ACM certificate for the given domain name in the given region.
-func GetAcmCertificateArn(t *testing.T, awsRegion string, certDomainName string) string {
+func GetAcmCertificateArn(t testing.TB, awsRegion string, certDomainName string) string {
 	arn, err := GetAcmCertificateArnE(t, awsRegion, certDomainName)
 	if err != nil {
 		t.Fatal(err)
@@ -15,29 +21,120 @@ func GetAcmCertificateArn(t *testing.T, awsRegion string, certDomainName string)
 	return arn
 }
 
-// GetAcmCertificateArnE gets the ACM certificate for the given domain name in the given region.
-func GetAcmCertificateArnE(t *testing.T, awsRegion string, certDomainName string) (string, error) {
+// GetAcmCertificateArnE gets the ACM certificate for the given domain name in the given region. A NotFoundError is
+// returned if there is no certificate for the domain name.
+func GetAcmCertificateArnE(t testing.TB, awsRegion string, certDomainName string) (string, error) {
 	acmClient, err := NewAcmClientE(t, awsRegion)
 	if err != nil {
 		return "", err
 	}
 
-	result, err := acmClient.ListCertificates(&acm.ListCertificatesInput{})
+	arn := ""
+	err = acmClient.ListCertificatesPages(&acm.ListCertificatesInput{}, func(page *acm.ListCertificatesOutput, lastPage bool) bool {
+		for _, summary := range page.CertificateSummaryList {
+			if aws.StringValue(summary.DomainName) == certDomainName {
+				arn = aws.StringValue(summary.CertificateArn)
+				return false
+			}
+		}
+		return true
+	})
 	if err != nil {
 		return "", err
 	}
 
-	for _, summary := range result.CertificateSummaryList {
-		if *summary.DomainName == certDomainName {
-			return *summary.CertificateArn, nil
+	if arn == "" {
+		return "", NewNotFoundError("ACM certificate for domain", certDomainName, awsRegion)
+	}
+	return arn, nil
+}
+
+// AssertAcmCertificateIssued checks that the ACM certificate with the given ARN has been issued, and fails the test if
+// it has not.
+func AssertAcmCertificateIssued(t testing.TB, awsRegion string, certArn string) {
+	err := AssertAcmCertificateIssuedE(t, awsRegion, certArn)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertAcmCertificateIssuedE checks that the ACM certificate with the given ARN has been issued, and returns an error
+// if it has not.
+func AssertAcmCertificateIssuedE(t testing.TB, awsRegion string, certArn string) error {
+	status, err := getAcmCertificateStatusE(t, awsRegion, certArn)
+	if err != nil {
+		return err
+	}
+	if status != acm.CertificateStatusIssued {
+		return fmt.Errorf("Expected ACM certificate %s to be %s, but it is %s", certArn, acm.CertificateStatusIssued, status)
+	}
+	return nil
+}
+
+// WaitUntilAcmCertificateIssued waits until the ACM certificate with the given ARN has been issued, retrying the check
+// for the specified amount of times, sleeping for the provided duration between each try. This will fail the test if
+// the certificate is not issued in time.
+func WaitUntilAcmCertificateIssued(t testing.TB, awsRegion string, certArn string, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilAcmCertificateIssuedE(t, awsRegion, certArn, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilAcmCertificateIssuedE waits until the ACM certificate with the given ARN has been issued, retrying the check
+// for the specified amount of times, sleeping for the provided duration between each try. This is useful right after a
+// certificate is created, as DNS validation can take several minutes. Statuses a certificate can't recover from, such
+// as FAILED or VALIDATION_TIMED_OUT, return an error right away.
+func WaitUntilAcmCertificateIssuedE(t testing.TB, awsRegion string, certArn string, maxRetries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "aws.WaitUntilAcmCertificateIssued", time.Now(), &err)
+
+	description := fmt.Sprintf("Waiting for ACM certificate %s to be issued", certArn)
+
+	msg, err := retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		status, err := getAcmCertificateStatusE(t, awsRegion, certArn)
+		if err != nil {
+			return "", err
 		}
+		return checkAcmCertificateIssued(certArn, status)
+	})
+	if err != nil {
+		logger.Logf(t, "Timed out waiting for ACM certificate %s to be issued: %s", certArn, err)
+		return err
 	}
+	logger.Log(t, msg)
+	return nil
+}
 
-	return "", nil
+// getAcmCertificateStatusE returns the status of the ACM certificate with the given ARN, e.g. PENDING_VALIDATION or
+// ISSUED.
+func getAcmCertificateStatusE(t testing.TB, awsRegion string, certArn string) (string, error) {
+	acmClient, err := NewAcmClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := acmClient.DescribeCertificate(&acm.DescribeCertificateInput{CertificateArn: aws.String(certArn)})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(output.Certificate.Status), nil
+}
+
+// checkAcmCertificateIssued returns an error if the given status of the given ACM certificate is not ISSUED. Statuses
+// that waiting won't change are wrapped in a retry.FatalError.
+func checkAcmCertificateIssued(certArn string, status string) (string, error) {
+	switch status {
+	case acm.CertificateStatusIssued:
+		return fmt.Sprintf("ACM certificate %s is issued", certArn), nil
+	case acm.CertificateStatusPendingValidation:
+		return "", fmt.Errorf("ACM certificate %s is %s", certArn, status)
+	default:
+		return "", retry.FatalError{Underlying: fmt.Errorf("ACM certificate %s is %s and will not be issued", certArn, status)}
+	}
 }
 
 // NewAcmClient create a new ACM client.
-func NewAcmClient(t *testing.T, region string) *acm.ACM {
+func NewAcmClient(t testing.TB, region string) *acm.ACM {
 	client, err := NewAcmClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -46,8 +143,8 @@ func NewAcmClient(t *testing.T, region string) *acm.ACM {
 }
 
 // NewAcmClientE creates a new ACM client.
-func NewAcmClientE(t *testing.T, awsRegion string) (*acm.ACM, error) {
-	sess, err := NewAuthenticatedSession(awsRegion)
+func NewAcmClientE(t testing.TB, awsRegion string) (*acm.ACM, error) {
+	sess, err := newTimedSession(t, awsRegion)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAcmCertificateIssued(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		status      string
+		expectErr   bool
+		expectFatal bool
+	}{
+		{"ISSUED", false, false},
+		{"PENDING_VALIDATION", true, false},
+		{"FAILED", true, true},
+		{"VALIDATION_TIMED_OUT", true, true},
+		{"REVOKED", true, true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.status, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := checkAcmCertificateIssued("arn:aws:acm:us-east-1:000000000000:certificate/test", testCase.status)
+			if !testCase.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			_, isFatal := err.(retry.FatalError)
+			assert.Equal(t, testCase.expectFatal, isFatal)
+		})
+	}
+}
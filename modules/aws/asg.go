@@ -21,14 +21,14 @@ type AsgCapacityInfo struct {
 }
 
 // GetCapacityInfoForAsg returns the capacity info for the queried asg as a struct, AsgCapacityInfo.
-func GetCapacityInfoForAsg(t *testing.T, asgName string, awsRegion string) AsgCapacityInfo {
+func GetCapacityInfoForAsg(t testing.TB, asgName string, awsRegion string) AsgCapacityInfo {
 	capacityInfo, err := GetCapacityInfoForAsgE(t, asgName, awsRegion)
 	require.NoError(t, err)
 	return capacityInfo
 }
 
 // GetCapacityInfoForAsgE returns the capacity info for the queried asg as a struct, AsgCapacityInfo.
-func GetCapacityInfoForAsgE(t *testing.T, asgName string, awsRegion string) (AsgCapacityInfo, error) {
+func GetCapacityInfoForAsgE(t testing.TB, asgName string, awsRegion string) (AsgCapacityInfo, error) {
 	asgClient, err := NewAsgClientE(t, awsRegion)
 	if err != nil {
 		return AsgCapacityInfo{}, err
@@ -53,7 +53,7 @@ func GetCapacityInfoForAsgE(t *testing.T, asgName string, awsRegion string) (Asg
 }
 
 // GetInstanceIdsForAsg gets the IDs of EC2 Instances in the given ASG.
-func GetInstanceIdsForAsg(t *testing.T, asgName string, awsRegion string) []string {
+func GetInstanceIdsForAsg(t testing.TB, asgName string, awsRegion string) []string {
 	ids, err := GetInstanceIdsForAsgE(t, asgName, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -62,7 +62,7 @@ func GetInstanceIdsForAsg(t *testing.T, asgName string, awsRegion string) []stri
 }
 
 // GetInstanceIdsForAsgE gets the IDs of EC2 Instances in the given ASG.
-func GetInstanceIdsForAsgE(t *testing.T, asgName string, awsRegion string) ([]string, error) {
+func GetInstanceIdsForAsgE(t testing.TB, asgName string, awsRegion string) ([]string, error) {
 	asgClient, err := NewAsgClientE(t, awsRegion)
 	if err != nil {
 		return nil, err
@@ -86,7 +86,7 @@ func GetInstanceIdsForAsgE(t *testing.T, asgName string, awsRegion string) ([]st
 
 // WaitForCapacity waits for the currently set desired capacity to be reached on the ASG
 func WaitForCapacity(
-	t *testing.T,
+	t testing.TB,
 	asgName string,
 	region string,
 	maxRetries int,
@@ -98,7 +98,7 @@ func WaitForCapacity(
 
 // WaitForCapacityE waits for the currently set desired capacity to be reached on the ASG
 func WaitForCapacityE(
-	t *testing.T,
+	t testing.TB,
 	asgName string,
 	region string,
 	maxRetries int,
@@ -126,7 +126,7 @@ func WaitForCapacityE(
 }
 
 // NewAsgClient creates an Auto Scaling Group client.
-func NewAsgClient(t *testing.T, region string) *autoscaling.AutoScaling {
+func NewAsgClient(t testing.TB, region string) *autoscaling.AutoScaling {
 	client, err := NewAsgClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -135,8 +135,8 @@ func NewAsgClient(t *testing.T, region string) *autoscaling.AutoScaling {
 }
 
 // NewAsgClientE creates an Auto Scaling Group client.
-func NewAsgClientE(t *testing.T, region string) (*autoscaling.AutoScaling, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewAsgClientE(t testing.TB, region string) (*autoscaling.AutoScaling, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -2,28 +2,55 @@ package aws
 
 import (
 	"fmt"
+	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/gruntwork-io/terratest/modules/timing"
+	"github.com/gruntwork-io/terratest/modules/vcr"
 	"github.com/pquerna/otp/totp"
 )
 
 // NewAuthenticatedSession gets an AWS Session, checking that the user has credentials properly configured in their environment.
+// Requests made with the session go through the active vcr recorder, if there is one.
 func NewAuthenticatedSession(region string) (*session.Session, error) {
 	sess, err := session.NewSession(aws.NewConfig().WithRegion(region))
 	if err != nil {
 		return nil, err
 	}
 
+	// Replaying a cassette doesn't need real credentials
+	if vcr.Replaying() {
+		return sess.Copy(aws.NewConfig().WithHTTPClient(vcr.HttpClient()).WithCredentials(credentials.NewStaticCredentials("vcr-replay", "vcr-replay", ""))), nil
+	}
+
 	if _, err = sess.Config.Credentials.Get(); err != nil {
 		return nil, CredentialsError{UnderlyingErr: err}
 	}
 
+	// The credentials are looked up before switching to the vcr client, so they are never recorded
+	return sess.Copy(aws.NewConfig().WithHTTPClient(vcr.HttpClient())), nil
+}
+
+// newTimedSession gets an AWS Session like NewAuthenticatedSession, which records how long each API call made with it
+// takes in the timing summary of the given test (see the timing package), under the name of the service and the
+// operation, e.g. "aws ec2.DescribeInstances".
+func newTimedSession(t testing.TB, region string) (*session.Session, error) {
+	sess, err := NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Complete handlers run once per call, after all the retries of the SDK, so r.Time covers the whole call
+	sess.Handlers.Complete.PushBack(func(r *request.Request) {
+		timing.Record(t, fmt.Sprintf("aws %s.%s", r.ClientInfo.ServiceName, r.Operation.Name), time.Since(r.Time), r.Error)
+	})
 	return sess, nil
 }
 
@@ -8,7 +8,7 @@ import (
 )
 
 // GetCloudWatchLogEntries returns the CloudWatch log messages in the given region for the given log stream and log group.
-func GetCloudWatchLogEntries(t *testing.T, awsRegion string, logStreamName string, logGroupName string) []string {
+func GetCloudWatchLogEntries(t testing.TB, awsRegion string, logStreamName string, logGroupName string) []string {
 	out, err := GetCloudWatchLogEntriesE(t, awsRegion, logStreamName, logGroupName)
 	if err != nil {
 		t.Fatal(err)
@@ -17,7 +17,7 @@ func GetCloudWatchLogEntries(t *testing.T, awsRegion string, logStreamName strin
 }
 
 // GetCloudWatchLogEntriesE returns the CloudWatch log messages in the given region for the given log stream and log group.
-func GetCloudWatchLogEntriesE(t *testing.T, awsRegion string, logStreamName string, logGroupName string) ([]string, error) {
+func GetCloudWatchLogEntriesE(t testing.TB, awsRegion string, logStreamName string, logGroupName string) ([]string, error) {
 	client, err := NewCloudWatchLogsClientE(t, awsRegion)
 	if err != nil {
 		return nil, err
@@ -41,7 +41,7 @@ func GetCloudWatchLogEntriesE(t *testing.T, awsRegion string, logStreamName stri
 }
 
 // NewCloudWatchLogsClient creates a new CloudWatch Logs client.
-func NewCloudWatchLogsClient(t *testing.T, region string) *cloudwatchlogs.CloudWatchLogs {
+func NewCloudWatchLogsClient(t testing.TB, region string) *cloudwatchlogs.CloudWatchLogs {
 	client, err := NewCloudWatchLogsClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -50,8 +50,8 @@ func NewCloudWatchLogsClient(t *testing.T, region string) *cloudwatchlogs.CloudW
 }
 
 // NewCloudWatchLogsClientE creates a new CloudWatch Logs client.
-func NewCloudWatchLogsClientE(t *testing.T, region string) (*cloudwatchlogs.CloudWatchLogs, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewCloudWatchLogsClientE(t testing.TB, region string) (*cloudwatchlogs.CloudWatchLogs, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -1,24 +1,36 @@
 package aws
 
 import (
+	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/stretchr/testify/require"
 )
 
 // GetDynamoDbTableTags fetches resource tags of a specified dynamoDB table. This will fail the test if there are any errors
-func GetDynamoDbTableTags(t *testing.T, region string, tableName string) []*dynamodb.Tag {
+func GetDynamoDbTableTags(t testing.TB, region string, tableName string) []*dynamodb.Tag {
 	tags, err := GetDynamoDbTableTagsE(t, region, tableName)
 	require.NoError(t, err)
 	return tags
 }
 
 // GetDynamoDbTableTagsE fetches resource tags of a specified dynamoDB table.
-func GetDynamoDbTableTagsE(t *testing.T, region string, tableName string) ([]*dynamodb.Tag, error) {
-	table := GetDynamoDBTable(t, region, tableName)
-	out, err := NewDynamoDBClient(t, region).ListTagsOfResource(&dynamodb.ListTagsOfResourceInput{
+func GetDynamoDbTableTagsE(t testing.TB, region string, tableName string) ([]*dynamodb.Tag, error) {
+	table, err := GetDynamoDBTableE(t, region, tableName)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewDynamoDBClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.ListTagsOfResource(&dynamodb.ListTagsOfResourceInput{
 		ResourceArn: table.TableArn,
 	})
 	if err != nil {
@@ -28,15 +40,19 @@ func GetDynamoDbTableTagsE(t *testing.T, region string, tableName string) ([]*dy
 }
 
 // GetDynamoDBTableTimeToLive fetches information about the TTL configuration of a specified dynamoDB table. This will fail the test if there are any errors.
-func GetDynamoDBTableTimeToLive(t *testing.T, region string, tableName string) *dynamodb.TimeToLiveDescription {
+func GetDynamoDBTableTimeToLive(t testing.TB, region string, tableName string) *dynamodb.TimeToLiveDescription {
 	ttl, err := GetDynamoDBTableTimeToLiveE(t, region, tableName)
 	require.NoError(t, err)
 	return ttl
 }
 
 // GetDynamoDBTableTimeToLiveE fetches information about the TTL configuration of a specified dynamoDB table.
-func GetDynamoDBTableTimeToLiveE(t *testing.T, region string, tableName string) (*dynamodb.TimeToLiveDescription, error) {
-	out, err := NewDynamoDBClient(t, region).DescribeTimeToLive(&dynamodb.DescribeTimeToLiveInput{
+func GetDynamoDBTableTimeToLiveE(t testing.TB, region string, tableName string) (*dynamodb.TimeToLiveDescription, error) {
+	client, err := NewDynamoDBClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.DescribeTimeToLive(&dynamodb.DescribeTimeToLiveInput{
 		TableName: aws.String(tableName),
 	})
 	if err != nil {
@@ -46,15 +62,19 @@ func GetDynamoDBTableTimeToLiveE(t *testing.T, region string, tableName string)
 }
 
 // GetDynamoDBTable fetches information about the specified dynamoDB table. This will fail the test if there are any errors.
-func GetDynamoDBTable(t *testing.T, region string, tableName string) *dynamodb.TableDescription {
+func GetDynamoDBTable(t testing.TB, region string, tableName string) *dynamodb.TableDescription {
 	table, err := GetDynamoDBTableE(t, region, tableName)
 	require.NoError(t, err)
 	return table
 }
 
 // GetDynamoDBTableE fetches information about the specified dynamoDB table.
-func GetDynamoDBTableE(t *testing.T, region string, tableName string) (*dynamodb.TableDescription, error) {
-	out, err := NewDynamoDBClient(t, region).DescribeTable(&dynamodb.DescribeTableInput{
+func GetDynamoDBTableE(t testing.TB, region string, tableName string) (*dynamodb.TableDescription, error) {
+	client, err := NewDynamoDBClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.DescribeTable(&dynamodb.DescribeTableInput{
 		TableName: aws.String(tableName),
 	})
 	if err != nil {
@@ -63,16 +83,185 @@ func GetDynamoDBTableE(t *testing.T, region string, tableName string) (*dynamodb
 	return out.Table, err
 }
 
+// AssertDynamoDBTableKeySchema checks that the key schema of the specified dynamoDB table consists of exactly the expected
+// key attributes, and fails the test if it does not.
+func AssertDynamoDBTableKeySchema(t testing.TB, region string, tableName string, expectedKeySchema []*dynamodb.KeySchemaElement) {
+	require.NoError(t, AssertDynamoDBTableKeySchemaE(t, region, tableName, expectedKeySchema))
+}
+
+// AssertDynamoDBTableKeySchemaE checks that the key schema of the specified dynamoDB table consists of exactly the expected
+// key attributes, in any order, and returns an error if it does not.
+func AssertDynamoDBTableKeySchemaE(t testing.TB, region string, tableName string, expectedKeySchema []*dynamodb.KeySchemaElement) error {
+	table, err := GetDynamoDBTableE(t, region, tableName)
+	if err != nil {
+		return err
+	}
+	return checkDynamoDBTableKeySchema(tableName, expectedKeySchema, table.KeySchema)
+}
+
+// AssertDynamoDBPointInTimeRecoveryEnabled checks that point-in-time recovery is enabled for the specified dynamoDB table,
+// and fails the test if it is not.
+func AssertDynamoDBPointInTimeRecoveryEnabled(t testing.TB, region string, tableName string) {
+	require.NoError(t, AssertDynamoDBPointInTimeRecoveryEnabledE(t, region, tableName))
+}
+
+// AssertDynamoDBPointInTimeRecoveryEnabledE checks that point-in-time recovery is enabled for the specified dynamoDB
+// table, and returns an error if it is not.
+func AssertDynamoDBPointInTimeRecoveryEnabledE(t testing.TB, region string, tableName string) error {
+	client, err := NewDynamoDBClientE(t, region)
+	if err != nil {
+		return err
+	}
+	out, err := client.DescribeContinuousBackups(&dynamodb.DescribeContinuousBackupsInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return err
+	}
+
+	status := dynamodb.PointInTimeRecoveryStatusDisabled
+	if out.ContinuousBackupsDescription != nil && out.ContinuousBackupsDescription.PointInTimeRecoveryDescription != nil {
+		status = aws.StringValue(out.ContinuousBackupsDescription.PointInTimeRecoveryDescription.PointInTimeRecoveryStatus)
+	}
+	if status != dynamodb.PointInTimeRecoveryStatusEnabled {
+		return NewDynamoDBPointInTimeRecoveryNotEnabledError(tableName, region, status)
+	}
+	return nil
+}
+
+// PutDynamoDBItem writes the given item to the specified dynamoDB table, replacing any item with the same key. The item
+// is marshalled with dynamodbattribute.MarshalMap, so it can be a struct with dynamodbav tags or a map. This will fail the
+// test if there are any errors.
+func PutDynamoDBItem(t testing.TB, region string, tableName string, item interface{}) {
+	require.NoError(t, PutDynamoDBItemE(t, region, tableName, item))
+}
+
+// PutDynamoDBItemE writes the given item to the specified dynamoDB table, replacing any item with the same key. The item
+// is marshalled with dynamodbattribute.MarshalMap, so it can be a struct with dynamodbav tags or a map.
+func PutDynamoDBItemE(t testing.TB, region string, tableName string, item interface{}) error {
+	if dryrun.Skip(t, "put item into DynamoDB table %s in %s", tableName, region) {
+		return nil
+	}
+
+	logger.Logf(t, "Putting item into DynamoDB table %s", tableName)
+
+	attributes, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewDynamoDBClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item:      attributes,
+	})
+	return err
+}
+
+// GetDynamoDBItem reads the item with the given key from the specified dynamoDB table and unmarshals it into out, e.g. a
+// pointer to a struct with dynamodbav tags. The key holds just the key attributes of the item. This will fail the test
+// if there are any errors, including if there is no item with the given key.
+func GetDynamoDBItem(t testing.TB, region string, tableName string, key interface{}, out interface{}) {
+	require.NoError(t, GetDynamoDBItemE(t, region, tableName, key, out))
+}
+
+// GetDynamoDBItemE reads the item with the given key from the specified dynamoDB table and unmarshals it into out, e.g.
+// a pointer to a struct with dynamodbav tags. The key holds just the key attributes of the item. The read is strongly
+// consistent, so it sees an item that was just written by PutDynamoDBItemE. A NotFoundError is returned if there is no
+// item with the given key.
+func GetDynamoDBItemE(t testing.TB, region string, tableName string, key interface{}, out interface{}) error {
+	keyAttributes, err := dynamodbattribute.MarshalMap(key)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewDynamoDBClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	output, err := client.GetItem(&dynamodb.GetItemInput{
+		TableName:      aws.String(tableName),
+		Key:            keyAttributes,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return err
+	}
+	if len(output.Item) == 0 {
+		return NewNotFoundError(fmt.Sprintf("Item in DynamoDB table %s", tableName), fmt.Sprintf("%v", key), region)
+	}
+
+	return dynamodbattribute.UnmarshalMap(output.Item, out)
+}
+
+// DeleteDynamoDBItem deletes the item with the given key from the specified dynamoDB table, e.g. to clean up an item
+// written by PutDynamoDBItem. This will fail the test if there are any errors.
+func DeleteDynamoDBItem(t testing.TB, region string, tableName string, key interface{}) {
+	require.NoError(t, DeleteDynamoDBItemE(t, region, tableName, key))
+}
+
+// DeleteDynamoDBItemE deletes the item with the given key from the specified dynamoDB table, e.g. to clean up an item
+// written by PutDynamoDBItemE.
+func DeleteDynamoDBItemE(t testing.TB, region string, tableName string, key interface{}) error {
+	if dryrun.Skip(t, "delete item from DynamoDB table %s in %s", tableName, region) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting item from DynamoDB table %s", tableName)
+
+	keyAttributes, err := dynamodbattribute.MarshalMap(key)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewDynamoDBClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(tableName),
+		Key:       keyAttributes,
+	})
+	return err
+}
+
+// checkDynamoDBTableKeySchema returns an error if the actual key schema of the given table does not consist of exactly
+// the expected key attributes. The order of the key attributes doesn't matter, as each one has its own key type.
+func checkDynamoDBTableKeySchema(tableName string, expectedKeySchema []*dynamodb.KeySchemaElement, actualKeySchema []*dynamodb.KeySchemaElement) error {
+	expected := formatDynamoDBKeySchema(expectedKeySchema)
+	actual := formatDynamoDBKeySchema(actualKeySchema)
+	if expected != actual {
+		return NewDynamoDBKeySchemaMismatchError(tableName, expected, actual)
+	}
+	return nil
+}
+
+// formatDynamoDBKeySchema formats the given key schema as a sorted list of "<attribute> (<key type>)".
+func formatDynamoDBKeySchema(keySchema []*dynamodb.KeySchemaElement) string {
+	keys := []string{}
+	for _, key := range keySchema {
+		keys = append(keys, fmt.Sprintf("%s (%s)", aws.StringValue(key.AttributeName), aws.StringValue(key.KeyType)))
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%v", keys)
+}
+
 // NewDynamoDBClient creates a DynamoDB client.
-func NewDynamoDBClient(t *testing.T, region string) *dynamodb.DynamoDB {
+func NewDynamoDBClient(t testing.TB, region string) *dynamodb.DynamoDB {
 	client, err := NewDynamoDBClientE(t, region)
 	require.NoError(t, err)
 	return client
 }
 
 // NewDynamoDBClientE creates a DynamoDB client.
-func NewDynamoDBClientE(t *testing.T, region string) (*dynamodb.DynamoDB, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewDynamoDBClientE(t testing.TB, region string) (*dynamodb.DynamoDB, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,67 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDynamoDBTableKeySchema(t *testing.T) {
+	t.Parallel()
+
+	actualKeySchema := []*dynamodb.KeySchemaElement{
+		{AttributeName: aws.String("userId"), KeyType: aws.String("HASH")},
+		{AttributeName: aws.String("department"), KeyType: aws.String("RANGE")},
+	}
+
+	testCases := []struct {
+		name              string
+		expectedKeySchema []*dynamodb.KeySchemaElement
+		expectedErr       error
+	}{
+		{
+			"same order",
+			[]*dynamodb.KeySchemaElement{
+				{AttributeName: aws.String("userId"), KeyType: aws.String("HASH")},
+				{AttributeName: aws.String("department"), KeyType: aws.String("RANGE")},
+			},
+			nil,
+		},
+		{
+			"different order",
+			[]*dynamodb.KeySchemaElement{
+				{AttributeName: aws.String("department"), KeyType: aws.String("RANGE")},
+				{AttributeName: aws.String("userId"), KeyType: aws.String("HASH")},
+			},
+			nil,
+		},
+		{
+			"missing range key",
+			[]*dynamodb.KeySchemaElement{
+				{AttributeName: aws.String("userId"), KeyType: aws.String("HASH")},
+			},
+			NewDynamoDBKeySchemaMismatchError("users", "[userId (HASH)]", "[department (RANGE) userId (HASH)]"),
+		},
+		{
+			"swapped key types",
+			[]*dynamodb.KeySchemaElement{
+				{AttributeName: aws.String("userId"), KeyType: aws.String("RANGE")},
+				{AttributeName: aws.String("department"), KeyType: aws.String("HASH")},
+			},
+			NewDynamoDBKeySchemaMismatchError("users", "[department (HASH) userId (RANGE)]", "[department (RANGE) userId (HASH)]"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expectedErr, checkDynamoDBTableKeySchema("users", testCase.expectedKeySchema, actualKeySchema))
+		})
+	}
+}
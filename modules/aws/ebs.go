@@ -5,11 +5,12 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 // DeleteEbsSnapshot deletes the given EBS snapshot
-func DeleteEbsSnapshot(t *testing.T, region string, snapshot string) {
+func DeleteEbsSnapshot(t testing.TB, region string, snapshot string) {
 	err := DeleteEbsSnapshotE(t, region, snapshot)
 	if err != nil {
 		t.Fatal(err)
@@ -17,7 +18,11 @@ func DeleteEbsSnapshot(t *testing.T, region string, snapshot string) {
 }
 
 // DeleteEbsSnapshot deletes the given EBS snapshot
-func DeleteEbsSnapshotE(t *testing.T, region string, snapshot string) error {
+func DeleteEbsSnapshotE(t testing.TB, region string, snapshot string) error {
+	if dryrun.Skip(t, "delete EBS snapshot %s in %s", snapshot, region) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting EBS snapshot %s", snapshot)
 	ec2Client, err := NewEc2ClientE(t, region)
 	if err != nil {
@@ -6,19 +6,20 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/stretchr/testify/require"
 )
 
 // GetPrivateIpOfEc2Instance gets the private IP address of the given EC2 Instance in the given region.
-func GetPrivateIpOfEc2Instance(t *testing.T, instanceID string, awsRegion string) string {
+func GetPrivateIpOfEc2Instance(t testing.TB, instanceID string, awsRegion string) string {
 	ip, err := GetPrivateIpOfEc2InstanceE(t, instanceID, awsRegion)
 	require.NoError(t, err)
 	return ip
 }
 
 // GetPrivateIpOfEc2InstanceE gets the private IP address of the given EC2 Instance in the given region.
-func GetPrivateIpOfEc2InstanceE(t *testing.T, instanceID string, awsRegion string) (string, error) {
+func GetPrivateIpOfEc2InstanceE(t testing.TB, instanceID string, awsRegion string) (string, error) {
 	ips, err := GetPrivateIpsOfEc2InstancesE(t, []string{instanceID}, awsRegion)
 	if err != nil {
 		return "", err
@@ -34,42 +35,37 @@ func GetPrivateIpOfEc2InstanceE(t *testing.T, instanceID string, awsRegion strin
 }
 
 // GetPrivateIpsOfEc2Instances gets the private IP address of the given EC2 Instance in the given region. Returns a map of instance ID to IP address.
-func GetPrivateIpsOfEc2Instances(t *testing.T, instanceIDs []string, awsRegion string) map[string]string {
+func GetPrivateIpsOfEc2Instances(t testing.TB, instanceIDs []string, awsRegion string) map[string]string {
 	ips, err := GetPrivateIpsOfEc2InstancesE(t, instanceIDs, awsRegion)
 	require.NoError(t, err)
 	return ips
 }
 
 // GetPrivateIpsOfEc2InstancesE gets the private IP address of the given EC2 Instance in the given region. Returns a map of instance ID to IP address.
-func GetPrivateIpsOfEc2InstancesE(t *testing.T, instanceIDs []string, awsRegion string) (map[string]string, error) {
-	ec2Client := NewEc2Client(t, awsRegion)
-	// TODO: implement pagination for cases that extend beyond limit (1000 instances)
-	input := ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)}
-	output, err := ec2Client.DescribeInstances(&input)
+func GetPrivateIpsOfEc2InstancesE(t testing.TB, instanceIDs []string, awsRegion string) (map[string]string, error) {
+	instances, err := describeEc2InstancesE(t, awsRegion, &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)})
 	if err != nil {
 		return nil, err
 	}
 
 	ips := map[string]string{}
 
-	for _, reserveration := range output.Reservations {
-		for _, instance := range reserveration.Instances {
-			ips[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.PrivateIpAddress)
-		}
+	for _, instance := range instances {
+		ips[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.PrivateIpAddress)
 	}
 
 	return ips, nil
 }
 
 // GetPrivateHostnameOfEc2Instance gets the private IP address of the given EC2 Instance in the given region.
-func GetPrivateHostnameOfEc2Instance(t *testing.T, instanceID string, awsRegion string) string {
+func GetPrivateHostnameOfEc2Instance(t testing.TB, instanceID string, awsRegion string) string {
 	ip, err := GetPrivateHostnameOfEc2InstanceE(t, instanceID, awsRegion)
 	require.NoError(t, err)
 	return ip
 }
 
 // GetPrivateHostnameOfEc2InstanceE gets the private IP address of the given EC2 Instance in the given region.
-func GetPrivateHostnameOfEc2InstanceE(t *testing.T, instanceID string, awsRegion string) (string, error) {
+func GetPrivateHostnameOfEc2InstanceE(t testing.TB, instanceID string, awsRegion string) (string, error) {
 	hostnames, err := GetPrivateHostnamesOfEc2InstancesE(t, []string{instanceID}, awsRegion)
 	if err != nil {
 		return "", err
@@ -85,45 +81,37 @@ func GetPrivateHostnameOfEc2InstanceE(t *testing.T, instanceID string, awsRegion
 }
 
 // GetPrivateHostnamesOfEc2Instances gets the private IP address of the given EC2 Instance in the given region. Returns a map of instance ID to IP address.
-func GetPrivateHostnamesOfEc2Instances(t *testing.T, instanceIDs []string, awsRegion string) map[string]string {
+func GetPrivateHostnamesOfEc2Instances(t testing.TB, instanceIDs []string, awsRegion string) map[string]string {
 	ips, err := GetPrivateHostnamesOfEc2InstancesE(t, instanceIDs, awsRegion)
 	require.NoError(t, err)
 	return ips
 }
 
 // GetPrivateHostnamesOfEc2InstancesE gets the private IP address of the given EC2 Instance in the given region. Returns a map of instance ID to IP address.
-func GetPrivateHostnamesOfEc2InstancesE(t *testing.T, instanceIDs []string, awsRegion string) (map[string]string, error) {
-	ec2Client, err := NewEc2ClientE(t, awsRegion)
-	if err != nil {
-		return nil, err
-	}
-	// TODO: implement pagination for cases that extend beyond limit (1000 instances)
-	input := ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)}
-	output, err := ec2Client.DescribeInstances(&input)
+func GetPrivateHostnamesOfEc2InstancesE(t testing.TB, instanceIDs []string, awsRegion string) (map[string]string, error) {
+	instances, err := describeEc2InstancesE(t, awsRegion, &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)})
 	if err != nil {
 		return nil, err
 	}
 
 	hostnames := map[string]string{}
 
-	for _, reserveration := range output.Reservations {
-		for _, instance := range reserveration.Instances {
-			hostnames[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.PrivateDnsName)
-		}
+	for _, instance := range instances {
+		hostnames[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.PrivateDnsName)
 	}
 
 	return hostnames, nil
 }
 
 // GetPublicIpOfEc2Instance gets the public IP address of the given EC2 Instance in the given region.
-func GetPublicIpOfEc2Instance(t *testing.T, instanceID string, awsRegion string) string {
+func GetPublicIpOfEc2Instance(t testing.TB, instanceID string, awsRegion string) string {
 	ip, err := GetPublicIpOfEc2InstanceE(t, instanceID, awsRegion)
 	require.NoError(t, err)
 	return ip
 }
 
 // GetPublicIpOfEc2InstanceE gets the public IP address of the given EC2 Instance in the given region.
-func GetPublicIpOfEc2InstanceE(t *testing.T, instanceID string, awsRegion string) (string, error) {
+func GetPublicIpOfEc2InstanceE(t testing.TB, instanceID string, awsRegion string) (string, error) {
 	ips, err := GetPublicIpsOfEc2InstancesE(t, []string{instanceID}, awsRegion)
 	if err != nil {
 		return "", err
@@ -131,7 +119,8 @@ func GetPublicIpOfEc2InstanceE(t *testing.T, instanceID string, awsRegion string
 
 	ip, containsIP := ips[instanceID]
 
-	if !containsIP {
+	// Instances in private subnets don't have a public IP, so an empty IP means there is no public IP to return
+	if !containsIP || ip == "" {
 		return "", IpForEc2InstanceNotFound{InstanceId: instanceID, AwsRegion: awsRegion, Type: "public"}
 	}
 
@@ -139,42 +128,37 @@ func GetPublicIpOfEc2InstanceE(t *testing.T, instanceID string, awsRegion string
 }
 
 // GetPublicIpsOfEc2Instances gets the public IP address of the given EC2 Instance in the given region. Returns a map of instance ID to IP address.
-func GetPublicIpsOfEc2Instances(t *testing.T, instanceIDs []string, awsRegion string) map[string]string {
+func GetPublicIpsOfEc2Instances(t testing.TB, instanceIDs []string, awsRegion string) map[string]string {
 	ips, err := GetPublicIpsOfEc2InstancesE(t, instanceIDs, awsRegion)
 	require.NoError(t, err)
 	return ips
 }
 
 // GetPublicIpsOfEc2InstancesE gets the public IP address of the given EC2 Instance in the given region. Returns a map of instance ID to IP address.
-func GetPublicIpsOfEc2InstancesE(t *testing.T, instanceIDs []string, awsRegion string) (map[string]string, error) {
-	ec2Client := NewEc2Client(t, awsRegion)
-	// TODO: implement pagination for cases that extend beyond limit (1000 instances)
-	input := ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)}
-	output, err := ec2Client.DescribeInstances(&input)
+func GetPublicIpsOfEc2InstancesE(t testing.TB, instanceIDs []string, awsRegion string) (map[string]string, error) {
+	instances, err := describeEc2InstancesE(t, awsRegion, &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)})
 	if err != nil {
 		return nil, err
 	}
 
 	ips := map[string]string{}
 
-	for _, reserveration := range output.Reservations {
-		for _, instance := range reserveration.Instances {
-			ips[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.PublicIpAddress)
-		}
+	for _, instance := range instances {
+		ips[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.PublicIpAddress)
 	}
 
 	return ips, nil
 }
 
 // GetEc2InstanceIdsByTag returns all the IDs of EC2 instances in the given region with the given tag.
-func GetEc2InstanceIdsByTag(t *testing.T, region string, tagName string, tagValue string) []string {
+func GetEc2InstanceIdsByTag(t testing.TB, region string, tagName string, tagValue string) []string {
 	out, err := GetEc2InstanceIdsByTagE(t, region, tagName, tagValue)
 	require.NoError(t, err)
 	return out
 }
 
 // GetEc2InstanceIdsByTagE returns all the IDs of EC2 instances in the given region with the given tag.
-func GetEc2InstanceIdsByTagE(t *testing.T, region string, tagName string, tagValue string) ([]string, error) {
+func GetEc2InstanceIdsByTagE(t testing.TB, region string, tagName string, tagValue string) ([]string, error) {
 	ec2Filters := map[string][]string{
 		fmt.Sprintf("tag:%s", tagName): {tagValue},
 	}
@@ -183,7 +167,7 @@ func GetEc2InstanceIdsByTagE(t *testing.T, region string, tagName string, tagVal
 
 // GetEc2InstanceIdsByFilters returns all the IDs of EC2 instances in the given region which match to EC2 filter list
 // as per https://docs.aws.amazon.com/sdk-for-go/api/service/ec2/#DescribeInstancesInput.
-func GetEc2InstanceIdsByFilters(t *testing.T, region string, ec2Filters map[string][]string) []string {
+func GetEc2InstanceIdsByFilters(t testing.TB, region string, ec2Filters map[string][]string) []string {
 	out, err := GetEc2InstanceIdsByFiltersE(t, region, ec2Filters)
 	require.NoError(t, err)
 	return out
@@ -191,44 +175,55 @@ func GetEc2InstanceIdsByFilters(t *testing.T, region string, ec2Filters map[stri
 
 // GetEc2InstanceIdsByFilters returns all the IDs of EC2 instances in the given region which match to EC2 filter list
 // as per https://docs.aws.amazon.com/sdk-for-go/api/service/ec2/#DescribeInstancesInput.
-func GetEc2InstanceIdsByFiltersE(t *testing.T, region string, ec2Filters map[string][]string) ([]string, error) {
-	client, err := NewEc2ClientE(t, region)
-	if err != nil {
-		return nil, err
-	}
-
+func GetEc2InstanceIdsByFiltersE(t testing.TB, region string, ec2Filters map[string][]string) ([]string, error) {
 	ec2FilterList := []*ec2.Filter{}
 
 	for name, values := range ec2Filters {
 		ec2FilterList = append(ec2FilterList, &ec2.Filter{Name: aws.String(name), Values: aws.StringSlice(values)})
 	}
 
-	// TODO: implement pagination for cases that extend beyond limit (1000 instances)
-	output, err := client.DescribeInstances(&ec2.DescribeInstancesInput{Filters: ec2FilterList})
+	instances, err := describeEc2InstancesE(t, region, &ec2.DescribeInstancesInput{Filters: ec2FilterList})
 	if err != nil {
 		return nil, err
 	}
 
 	instanceIDs := []string{}
 
-	for _, reservation := range output.Reservations {
-		for _, instance := range reservation.Instances {
-			instanceIDs = append(instanceIDs, *instance.InstanceId)
-		}
+	for _, instance := range instances {
+		instanceIDs = append(instanceIDs, aws.StringValue(instance.InstanceId))
 	}
 
-	return instanceIDs, err
+	return instanceIDs, nil
+}
+
+// describeEc2InstancesE returns all the EC2 Instances in the given region that match the given input, going through
+// every page of results, as DescribeInstances only returns up to 1000 instances per call.
+func describeEc2InstancesE(t testing.TB, region string, input *ec2.DescribeInstancesInput) ([]*ec2.Instance, error) {
+	client, err := NewEc2ClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []*ec2.Instance{}
+	err = client.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		return true
+	})
+
+	return instances, err
 }
 
 // GetTagsForEc2Instance returns all the tags for the given EC2 Instance.
-func GetTagsForEc2Instance(t *testing.T, region string, instanceID string) map[string]string {
+func GetTagsForEc2Instance(t testing.TB, region string, instanceID string) map[string]string {
 	tags, err := GetTagsForEc2InstanceE(t, region, instanceID)
 	require.NoError(t, err)
 	return tags
 }
 
 // GetTagsForEc2InstanceE returns all the tags for the given EC2 Instance.
-func GetTagsForEc2InstanceE(t *testing.T, region string, instanceID string) (map[string]string, error) {
+func GetTagsForEc2InstanceE(t testing.TB, region string, instanceID string) (map[string]string, error) {
 	client, err := NewEc2ClientE(t, region)
 	if err != nil {
 		return nil, err
@@ -247,27 +242,32 @@ func GetTagsForEc2InstanceE(t *testing.T, region string, instanceID string) (map
 		},
 	}
 
-	out, err := client.DescribeTags(&input)
-	if err != nil {
-		return nil, err
-	}
-
 	tags := map[string]string{}
 
-	for _, tag := range out.Tags {
-		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	err = client.DescribeTagsPages(&input, func(page *ec2.DescribeTagsOutput, lastPage bool) bool {
+		for _, tag := range page.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return tags, nil
 }
 
 // DeleteAmi deletes the given AMI in the given region.
-func DeleteAmi(t *testing.T, region string, imageID string) {
+func DeleteAmi(t testing.TB, region string, imageID string) {
 	require.NoError(t, DeleteAmiE(t, region, imageID))
 }
 
 // DeleteAmiE deletes the given AMI in the given region.
-func DeleteAmiE(t *testing.T, region string, imageID string) error {
+func DeleteAmiE(t testing.TB, region string, imageID string) error {
+	if dryrun.Skip(t, "delete AMI %s in %s", imageID, region) {
+		return nil
+	}
+
 	logger.Logf(t, "Deregistering AMI %s", imageID)
 
 	client, err := NewEc2ClientE(t, region)
@@ -280,12 +280,16 @@ func DeleteAmiE(t *testing.T, region string, imageID string) error {
 }
 
 // AddTagsToResource adds the tags to the given taggable AWS resource such as EC2, AMI or VPC.
-func AddTagsToResource(t *testing.T, region string, resource string, tags map[string]string) {
+func AddTagsToResource(t testing.TB, region string, resource string, tags map[string]string) {
 	require.NoError(t, AddTagsToResourceE(t, region, resource, tags))
 }
 
 // AddTagsToResourceE adds the tags to the given taggable AWS resource such as EC2, AMI or VPC.
-func AddTagsToResourceE(t *testing.T, region string, resource string, tags map[string]string) error {
+func AddTagsToResourceE(t testing.TB, region string, resource string, tags map[string]string) error {
+	if dryrun.Skip(t, "tag %s in %s with %v", resource, region, tags) {
+		return nil
+	}
+
 	client, err := NewEc2ClientE(t, region)
 	if err != nil {
 		return err
@@ -308,12 +312,16 @@ func AddTagsToResourceE(t *testing.T, region string, resource string, tags map[s
 }
 
 // TerminateInstance terminates the EC2 instance with the given ID in the given region.
-func TerminateInstance(t *testing.T, region string, instanceID string) {
+func TerminateInstance(t testing.TB, region string, instanceID string) {
 	require.NoError(t, TerminateInstanceE(t, region, instanceID))
 }
 
 // TerminateInstanceE terminates the EC2 instance with the given ID in the given region.
-func TerminateInstanceE(t *testing.T, region string, instanceID string) error {
+func TerminateInstanceE(t testing.TB, region string, instanceID string) error {
+	if dryrun.Skip(t, "terminate EC2 instance %s in %s", instanceID, region) {
+		return nil
+	}
+
 	logger.Logf(t, "Terminating Instance %s", instanceID)
 
 	client, err := NewEc2ClientE(t, region)
@@ -331,14 +339,14 @@ func TerminateInstanceE(t *testing.T, region string, instanceID string) error {
 }
 
 // GetAmiPubliclyAccessible returns whether the AMI is publicly accessible or not
-func GetAmiPubliclyAccessible(t *testing.T, awsRegion string, amiID string) bool {
+func GetAmiPubliclyAccessible(t testing.TB, awsRegion string, amiID string) bool {
 	output, err := GetAmiPubliclyAccessibleE(t, awsRegion, amiID)
 	require.NoError(t, err)
 	return output
 }
 
 // GetAmiPubliclyAccessibleE returns whether the AMI is publicly accessible or not
-func GetAmiPubliclyAccessibleE(t *testing.T, awsRegion string, amiID string) (bool, error) {
+func GetAmiPubliclyAccessibleE(t testing.TB, awsRegion string, amiID string) (bool, error) {
 	launchPermissions, err := GetLaunchPermissionsForAmiE(t, awsRegion, amiID)
 	if err != nil {
 		return false, err
@@ -352,14 +360,14 @@ func GetAmiPubliclyAccessibleE(t *testing.T, awsRegion string, amiID string) (bo
 }
 
 // GetAccountsWithLaunchPermissionsForAmi returns list of accounts that the AMI is shared with
-func GetAccountsWithLaunchPermissionsForAmi(t *testing.T, awsRegion string, amiID string) []string {
+func GetAccountsWithLaunchPermissionsForAmi(t testing.TB, awsRegion string, amiID string) []string {
 	output, err := GetAccountsWithLaunchPermissionsForAmiE(t, awsRegion, amiID)
 	require.NoError(t, err)
 	return output
 }
 
 // GetAccountsWithLaunchPermissionsForAmiE returns list of accounts that the AMI is shared with
-func GetAccountsWithLaunchPermissionsForAmiE(t *testing.T, awsRegion string, amiID string) ([]string, error) {
+func GetAccountsWithLaunchPermissionsForAmiE(t testing.TB, awsRegion string, amiID string) ([]string, error) {
 	accountIDs := []string{}
 	launchPermissions, err := GetLaunchPermissionsForAmiE(t, awsRegion, amiID)
 	if err != nil {
@@ -374,8 +382,12 @@ func GetAccountsWithLaunchPermissionsForAmiE(t *testing.T, awsRegion string, ami
 }
 
 // GetLaunchPermissionsForAmiE returns launchPermissions as configured in AWS
-func GetLaunchPermissionsForAmiE(t *testing.T, awsRegion string, amiID string) ([]*ec2.LaunchPermission, error) {
-	client := NewEc2Client(t, awsRegion)
+func GetLaunchPermissionsForAmiE(t testing.TB, awsRegion string, amiID string) ([]*ec2.LaunchPermission, error) {
+	client, err := NewEc2ClientE(t, awsRegion)
+	if err != nil {
+		return []*ec2.LaunchPermission{}, err
+	}
+
 	input := &ec2.DescribeImageAttributeInput{
 		Attribute: aws.String("launchPermission"),
 		ImageId:   aws.String(amiID),
@@ -389,15 +401,15 @@ func GetLaunchPermissionsForAmiE(t *testing.T, awsRegion string, amiID string) (
 }
 
 // NewEc2Client creates an EC2 client.
-func NewEc2Client(t *testing.T, region string) *ec2.EC2 {
+func NewEc2Client(t testing.TB, region string) *ec2.EC2 {
 	client, err := NewEc2ClientE(t, region)
 	require.NoError(t, err)
 	return client
 }
 
 // NewEc2ClientE creates an EC2 client.
-func NewEc2ClientE(t *testing.T, region string) (*ec2.EC2, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewEc2ClientE(t testing.TB, region string) (*ec2.EC2, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
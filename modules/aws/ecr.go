@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/gruntwork-io/terratest/modules/docker"
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// LoginToEcr logs in to the ECR registry of the current AWS account in the given region with 'docker login', using an
+// authorization token from the ECR API. This will fail the test if there is an error.
+func LoginToEcr(t testing.TB, awsRegion string, log *logger.Logger) {
+	err := LoginToEcrE(t, awsRegion, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// LoginToEcrE logs in to the ECR registry of the current AWS account in the given region with 'docker login', using an
+// authorization token from the ECR API.
+func LoginToEcrE(t testing.TB, awsRegion string, log *logger.Logger) error {
+	client, err := NewEcrClientE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	output, err := client.GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return err
+	}
+	if len(output.AuthorizationData) == 0 {
+		return fmt.Errorf("ECR did not return an authorization token in region %s", awsRegion)
+	}
+	authorizationData := output.AuthorizationData[0]
+
+	username, password, err := decodeEcrAuthorizationToken(aws.StringValue(authorizationData.AuthorizationToken))
+	if err != nil {
+		return err
+	}
+
+	return docker.LoginE(t, &docker.LoginOptions{
+		Registry: aws.StringValue(authorizationData.ProxyEndpoint),
+		Username: username,
+		Password: password,
+		Logger:   log,
+	})
+}
+
+// decodeEcrAuthorizationToken decodes an ECR authorization token, which is the base64 encoding of
+// "<username>:<password>".
+func decodeEcrAuthorizationToken(token string) (string, string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to decode ECR authorization token: %v", err)
+	}
+
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("ECR authorization token is not in the form <username>:<password>")
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// NewEcrClient creates a new ECR client.
+func NewEcrClient(t testing.TB, region string) *ecr.ECR {
+	client, err := NewEcrClientE(t, region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// NewEcrClientE creates a new ECR client.
+func NewEcrClientE(t testing.TB, region string) (*ecr.ECR, error) {
+	sess, err := newTimedSession(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return ecr.New(sess), nil
+}
@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEcrAuthorizationToken(t *testing.T) {
+	t.Parallel()
+
+	username, password, err := decodeEcrAuthorizationToken(base64.StdEncoding.EncodeToString([]byte("AWS:pass:with:colons")))
+	require.NoError(t, err)
+	assert.Equal(t, "AWS", username)
+	assert.Equal(t, "pass:with:colons", password)
+
+	_, _, err = decodeEcrAuthorizationToken("not base64!")
+	assert.Error(t, err)
+
+	_, _, err = decodeEcrAuthorizationToken(base64.StdEncoding.EncodeToString([]byte("no-separator")))
+	assert.Error(t, err)
+}
@@ -3,21 +3,26 @@ package aws
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
 	"github.com/stretchr/testify/require"
 )
 
 // GetEcsCluster fetches information about specified ECS cluster.
-func GetEcsCluster(t *testing.T, region string, name string) *ecs.Cluster {
+func GetEcsCluster(t testing.TB, region string, name string) *ecs.Cluster {
 	cluster, err := GetEcsClusterE(t, region, name)
 	require.NoError(t, err)
 	return cluster
 }
 
 // GetEcsClusterE fetches information about specified ECS cluster.
-func GetEcsClusterE(t *testing.T, region string, name string) (*ecs.Cluster, error) {
+func GetEcsClusterE(t testing.TB, region string, name string) (*ecs.Cluster, error) {
 	client, err := NewEcsClientE(t, region)
 	if err != nil {
 		return nil, err
@@ -42,25 +47,32 @@ func GetEcsClusterE(t *testing.T, region string, name string) (*ecs.Cluster, err
 }
 
 // GetDefaultEcsClusterE fetches information about default ECS cluster.
-func GetDefaultEcsClusterE(t *testing.T, region string) (*ecs.Cluster, error) {
+func GetDefaultEcsClusterE(t testing.TB, region string) (*ecs.Cluster, error) {
 	return GetEcsClusterE(t, region, "default")
 }
 
 // GetDefaultEcsCluster fetches information about default ECS cluster.
-func GetDefaultEcsCluster(t *testing.T, region string) *ecs.Cluster {
+func GetDefaultEcsCluster(t testing.TB, region string) *ecs.Cluster {
 	return GetEcsCluster(t, region, "default")
 }
 
 // CreateEcsCluster creates ECS cluster in the given region under the given name.
-func CreateEcsCluster(t *testing.T, region string, name string) *ecs.Cluster {
+func CreateEcsCluster(t testing.TB, region string, name string) *ecs.Cluster {
 	cluster, err := CreateEcsClusterE(t, region, name)
 	require.NoError(t, err)
 	return cluster
 }
 
 // CreateEcsClusterE creates ECS cluster in the given region under the given name.
-func CreateEcsClusterE(t *testing.T, region string, name string) (*ecs.Cluster, error) {
-	client := NewEcsClient(t, region)
+func CreateEcsClusterE(t testing.TB, region string, name string) (*ecs.Cluster, error) {
+	if dryrun.Skip(t, "create ECS cluster %s in %s", name, region) {
+		return &ecs.Cluster{ClusterName: aws.String(name), ClusterArn: aws.String(fmt.Sprintf("arn:aws:ecs:%s:000000000000:cluster/%s", region, name))}, nil
+	}
+
+	client, err := NewEcsClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
 	cluster, err := client.CreateCluster(&ecs.CreateClusterInput{
 		ClusterName: aws.String(name),
 	})
@@ -70,30 +82,41 @@ func CreateEcsClusterE(t *testing.T, region string, name string) (*ecs.Cluster,
 	return cluster.Cluster, nil
 }
 
-func DeleteEcsCluster(t *testing.T, region string, cluster *ecs.Cluster) {
+func DeleteEcsCluster(t testing.TB, region string, cluster *ecs.Cluster) {
 	err := DeleteEcsClusterE(t, region, cluster)
 	require.NoError(t, err)
 }
 
 // DeleteEcsClusterE deletes existing ECS cluster in the given region.
-func DeleteEcsClusterE(t *testing.T, region string, cluster *ecs.Cluster) error {
-	client := NewEcsClient(t, region)
-	_, err := client.DeleteCluster(&ecs.DeleteClusterInput{
+func DeleteEcsClusterE(t testing.TB, region string, cluster *ecs.Cluster) error {
+	if dryrun.Skip(t, "delete ECS cluster %s in %s", aws.StringValue(cluster.ClusterName), region) {
+		return nil
+	}
+
+	client, err := NewEcsClientE(t, region)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteCluster(&ecs.DeleteClusterInput{
 		Cluster: aws.String(*cluster.ClusterName),
 	})
 	return err
 }
 
 // GetEcsService fetches information about specified ECS service.
-func GetEcsService(t *testing.T, region string, clusterName string, serviceName string) *ecs.Service {
+func GetEcsService(t testing.TB, region string, clusterName string, serviceName string) *ecs.Service {
 	service, err := GetEcsServiceE(t, region, clusterName, serviceName)
 	require.NoError(t, err)
 	return service
 }
 
 // GetEcsServiceE fetches information about specified ECS service.
-func GetEcsServiceE(t *testing.T, region string, clusterName string, serviceName string) (*ecs.Service, error) {
-	output, err := NewEcsClient(t, region).DescribeServices(&ecs.DescribeServicesInput{
+func GetEcsServiceE(t testing.TB, region string, clusterName string, serviceName string) (*ecs.Service, error) {
+	client, err := NewEcsClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.DescribeServices(&ecs.DescribeServicesInput{
 		Cluster: aws.String(clusterName),
 		Services: []*string{
 			aws.String(serviceName),
@@ -112,16 +135,55 @@ func GetEcsServiceE(t *testing.T, region string, clusterName string, serviceName
 	return output.Services[0], nil
 }
 
+// WaitUntilEcsServiceStable waits until the specified ECS service is stable, retrying the check for the specified amount
+// of times, sleeping for the provided duration between each try. This will fail the test if the service does not become
+// stable in time.
+func WaitUntilEcsServiceStable(t testing.TB, region string, clusterName string, serviceName string, retries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilEcsServiceStableE(t, region, clusterName, serviceName, retries, sleepBetweenRetries)
+	require.NoError(t, err)
+}
+
+// WaitUntilEcsServiceStableE waits until the specified ECS service is stable, retrying the check for the specified
+// amount of times, sleeping for the provided duration between each try. A service is stable once a rollout has
+// completed, i.e. it only has its primary deployment left and runs the desired number of tasks.
+func WaitUntilEcsServiceStableE(t testing.TB, region string, clusterName string, serviceName string, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "aws.WaitUntilEcsServiceStable", time.Now(), &err)
+
+	msg, err := retry.DoWithRetryE(
+		t,
+		fmt.Sprintf("Waiting for ECS service %s in cluster %s to be stable.", serviceName, clusterName),
+		retries,
+		sleepBetweenRetries,
+		func() (string, error) {
+			service, err := GetEcsServiceE(t, region, clusterName, serviceName)
+			if err != nil {
+				return "", err
+			}
+			return checkEcsServiceStable(service)
+		},
+	)
+	if err != nil {
+		logger.Logf(t, "Timedout waiting for ECS service %s in cluster %s to be stable: %s", serviceName, clusterName, err)
+		return err
+	}
+	logger.Log(t, msg)
+	return nil
+}
+
 // GetEcsTaskDefinition fetches information about specified ECS task definition.
-func GetEcsTaskDefinition(t *testing.T, region string, taskDefinition string) *ecs.TaskDefinition {
+func GetEcsTaskDefinition(t testing.TB, region string, taskDefinition string) *ecs.TaskDefinition {
 	task, err := GetEcsTaskDefinitionE(t, region, taskDefinition)
 	require.NoError(t, err)
 	return task
 }
 
 // GetEcsTaskDefinitionE fetches information about specified ECS task definition.
-func GetEcsTaskDefinitionE(t *testing.T, region string, taskDefinition string) (*ecs.TaskDefinition, error) {
-	output, err := NewEcsClient(t, region).DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+func GetEcsTaskDefinitionE(t testing.TB, region string, taskDefinition string) (*ecs.TaskDefinition, error) {
+	client, err := NewEcsClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
 		TaskDefinition: aws.String(taskDefinition),
 	})
 	if err != nil {
@@ -130,16 +192,77 @@ func GetEcsTaskDefinitionE(t *testing.T, region string, taskDefinition string) (
 	return output.TaskDefinition, nil
 }
 
+// AssertContainerImage checks that the container with the given name in the specified ECS task definition uses the
+// expected image, and fails the test if it does not.
+func AssertContainerImage(t testing.TB, region string, taskDefinition string, containerName string, expectedImage string) {
+	err := AssertContainerImageE(t, region, taskDefinition, containerName, expectedImage)
+	require.NoError(t, err)
+}
+
+// AssertContainerImageE checks that the container with the given name in the specified ECS task definition uses the
+// expected image, and returns an error if it does not. The task definition can be a family, family:revision, or ARN,
+// e.g. the TaskDefinition of the service returned by GetEcsServiceE.
+func AssertContainerImageE(t testing.TB, region string, taskDefinition string, containerName string, expectedImage string) error {
+	task, err := GetEcsTaskDefinitionE(t, region, taskDefinition)
+	if err != nil {
+		return err
+	}
+	return checkContainerImage(task, containerName, expectedImage)
+}
+
+// checkEcsServiceStable returns an error if the given ECS service isn't stable yet. Errors that waiting won't resolve
+// are wrapped in a retry.FatalError.
+func checkEcsServiceStable(service *ecs.Service) (string, error) {
+	serviceName := aws.StringValue(service.ServiceName)
+
+	status := aws.StringValue(service.Status)
+	if status != "ACTIVE" {
+		return "", retry.FatalError{Underlying: fmt.Errorf("ECS service %s is %s", serviceName, status)}
+	}
+
+	numDeployments := len(service.Deployments)
+	if numDeployments != 1 {
+		return "", fmt.Errorf("ECS service %s has %d deployments in progress", serviceName, numDeployments)
+	}
+
+	runningCount := aws.Int64Value(service.RunningCount)
+	desiredCount := aws.Int64Value(service.DesiredCount)
+	if runningCount != desiredCount {
+		return "", fmt.Errorf("ECS service %s is running %d tasks, but wants %d", serviceName, runningCount, desiredCount)
+	}
+
+	return fmt.Sprintf("ECS service %s is now stable with %d running tasks", serviceName, runningCount), nil
+}
+
+// checkContainerImage returns an error if the container with the given name in the given task definition doesn't use
+// the expected image.
+func checkContainerImage(task *ecs.TaskDefinition, containerName string, expectedImage string) error {
+	for _, container := range task.ContainerDefinitions {
+		if aws.StringValue(container.Name) != containerName {
+			continue
+		}
+		image := aws.StringValue(container.Image)
+		if image != expectedImage {
+			return fmt.Errorf("Expected container '%s' in ECS task definition '%s' to use image '%s', but it uses '%s'",
+				containerName, aws.StringValue(task.TaskDefinitionArn), expectedImage, image)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("Expected to find container '%s' in ECS task definition '%s', but it was not found",
+		containerName, aws.StringValue(task.TaskDefinitionArn))
+}
+
 // NewEcsClient creates en ECS client.
-func NewEcsClient(t *testing.T, region string) *ecs.ECS {
+func NewEcsClient(t testing.TB, region string) *ecs.ECS {
 	client, err := NewEcsClientE(t, region)
 	require.NoError(t, err)
 	return client
 }
 
 // NewEcsClientE creates an ECS client.
-func NewEcsClientE(t *testing.T, region string) (*ecs.ECS, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewEcsClientE(t testing.TB, region string) (*ecs.ECS, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
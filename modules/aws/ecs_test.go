@@ -3,6 +3,9 @@ package aws
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/gruntwork-io/terratest/modules/retry"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,3 +24,69 @@ func TestEcsCluster(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "terratest", *c2.ClusterName)
 }
+
+func TestCheckEcsServiceStable(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		service     *ecs.Service
+		expectErr   bool
+		expectFatal bool
+	}{
+		{"stable", newTestEcsService("ACTIVE", 1, 2, 2), false, false},
+		{"rollout in progress", newTestEcsService("ACTIVE", 2, 2, 2), true, false},
+		{"tasks starting", newTestEcsService("ACTIVE", 1, 2, 1), true, false},
+		{"draining", newTestEcsService("DRAINING", 1, 0, 0), true, true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := checkEcsServiceStable(testCase.service)
+			if !testCase.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			_, isFatal := err.(retry.FatalError)
+			assert.Equal(t, testCase.expectFatal, isFatal)
+		})
+	}
+}
+
+func TestCheckContainerImage(t *testing.T) {
+	t.Parallel()
+
+	task := &ecs.TaskDefinition{
+		TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:000000000000:task-definition/app:3"),
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{Name: aws.String("app"), Image: aws.String("example/app:1.2.3")},
+			{Name: aws.String("sidecar"), Image: aws.String("example/sidecar:latest")},
+		},
+	}
+
+	assert.NoError(t, checkContainerImage(task, "app", "example/app:1.2.3"))
+	assert.NoError(t, checkContainerImage(task, "sidecar", "example/sidecar:latest"))
+	assert.Error(t, checkContainerImage(task, "app", "example/app:1.2.4"))
+	assert.Error(t, checkContainerImage(task, "missing", "example/app:1.2.3"))
+}
+
+func newTestEcsService(status string, numDeployments int, desiredCount int64, runningCount int64) *ecs.Service {
+	deployments := []*ecs.Deployment{}
+	for i := 0; i < numDeployments; i++ {
+		deployments = append(deployments, &ecs.Deployment{})
+	}
+	return &ecs.Service{
+		ServiceName:  aws.String("app"),
+		Status:       aws.String(status),
+		Deployments:  deployments,
+		DesiredCount: aws.Int64(desiredCount),
+		RunningCount: aws.Int64(runningCount),
+	}
+}
@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// The prefix of the bearer tokens that the EKS API server accepts, followed by a base64 encoded presigned STS URL
+const eksTokenPrefix = "k8s-aws-v1."
+
+// The header that binds a presigned STS URL to the EKS cluster it is meant for
+const eksClusterIDHeader = "x-k8s-aws-id"
+
+// How long the presigned STS URL in an EKS token is valid for. EKS itself only accepts tokens for 15 minutes.
+const eksTokenPresignExpiry = 60 * time.Second
+
+// GetEksCluster fetches information about the specified EKS cluster. This will fail the test if there are any errors.
+func GetEksCluster(t testing.TB, region string, clusterName string) *eks.Cluster {
+	cluster, err := GetEksClusterE(t, region, clusterName)
+	require.NoError(t, err)
+	return cluster
+}
+
+// GetEksClusterE fetches information about the specified EKS cluster.
+func GetEksClusterE(t testing.TB, region string, clusterName string) (*eks.Cluster, error) {
+	client, err := NewEksClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeCluster(&eks.DescribeClusterInput{
+		Name: aws.String(clusterName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Cluster, nil
+}
+
+// GetEksToken returns a bearer token to authenticate to the specified EKS cluster as the current AWS identity, the same
+// way `aws-iam-authenticator token` does. This will fail the test if there are any errors.
+func GetEksToken(t testing.TB, region string, clusterName string) string {
+	token, err := GetEksTokenE(t, region, clusterName)
+	require.NoError(t, err)
+	return token
+}
+
+// GetEksTokenE returns a bearer token to authenticate to the specified EKS cluster as the current AWS identity, the same
+// way `aws-iam-authenticator token` does: the token is a presigned STS GetCallerIdentity request, which the cluster
+// calls to find out who we are. EKS only accepts the token for 15 minutes.
+func GetEksTokenE(t testing.TB, region string, clusterName string) (string, error) {
+	sess, err := NewAuthenticatedSession(region)
+	if err != nil {
+		return "", err
+	}
+
+	request, _ := sts.New(sess).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	request.HTTPRequest.Header.Add(eksClusterIDHeader, clusterName)
+
+	presignedURL, err := request.Presign(eksTokenPresignExpiry)
+	if err != nil {
+		return "", err
+	}
+
+	token := getEksTokenFromPresignedURL(presignedURL)
+	logger.RegisterSecret(token)
+	return token, nil
+}
+
+// getEksTokenFromPresignedURL returns the EKS bearer token for the given presigned STS GetCallerIdentity URL.
+func getEksTokenFromPresignedURL(presignedURL string) string {
+	return eksTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presignedURL))
+}
+
+// NewEksClient creates an EKS client.
+func NewEksClient(t testing.TB, region string) *eks.EKS {
+	client, err := NewEksClientE(t, region)
+	require.NoError(t, err)
+	return client
+}
+
+// NewEksClientE creates an EKS client.
+func NewEksClientE(t testing.TB, region string) (*eks.EKS, error) {
+	sess, err := newTimedSession(t, region)
+	if err != nil {
+		return nil, err
+	}
+	return eks.New(sess), nil
+}
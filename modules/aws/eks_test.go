@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEksTokenFromPresignedURL(t *testing.T) {
+	t.Parallel()
+
+	presignedURL := "https://sts.us-east-1.amazonaws.com/?Action=GetCallerIdentity&Version=2011-06-15&X-Amz-Expires=60"
+	token := getEksTokenFromPresignedURL(presignedURL)
+
+	require.True(t, strings.HasPrefix(token, "k8s-aws-v1."))
+	assert.NotContains(t, token, "=")
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, "k8s-aws-v1."))
+	require.NoError(t, err)
+	assert.Equal(t, presignedURL, string(decoded))
+}
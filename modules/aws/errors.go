@@ -2,6 +2,13 @@ package aws
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/gruntwork-io/terratest/modules/collections"
+	"github.com/gruntwork-io/terratest/modules/customerrors"
 )
 
 // IpForEc2InstanceNotFound is an error that occurs when the IP for an EC2 instance is not found.
@@ -99,3 +106,227 @@ func (err NoBucketPolicyError) Error() string {
 func NewNoBucketPolicyError(s3BucketName string, awsRegion string, bucketPolicy string) NoBucketPolicyError {
 	return NoBucketPolicyError{s3BucketName: s3BucketName, awsRegion: awsRegion, bucketPolicy: bucketPolicy}
 }
+
+// BucketPolicyMismatchError is returned when the policy attached to an S3 bucket is not the expected one
+type BucketPolicyMismatchError struct {
+	s3BucketName   string
+	awsRegion      string
+	expectedPolicy string
+	actualPolicy   string
+}
+
+func (err BucketPolicyMismatchError) Error() string {
+	return fmt.Sprintf(
+		"The policy for bucket %s in the %s region is %s, but expected %s",
+		err.s3BucketName,
+		err.awsRegion,
+		err.actualPolicy,
+		err.expectedPolicy,
+	)
+}
+
+func NewBucketPolicyMismatchError(s3BucketName string, awsRegion string, expectedPolicy string, actualPolicy string) BucketPolicyMismatchError {
+	return BucketPolicyMismatchError{s3BucketName: s3BucketName, awsRegion: awsRegion, expectedPolicy: expectedPolicy, actualPolicy: actualPolicy}
+}
+
+// RdsNotEncryptedError is returned when the storage of an RDS instance that should be encrypted is not
+type RdsNotEncryptedError struct {
+	dbInstanceID string
+	awsRegion    string
+}
+
+func (err RdsNotEncryptedError) Error() string {
+	return fmt.Sprintf("The storage of RDS instance %s in the %s region is not encrypted", err.dbInstanceID, err.awsRegion)
+}
+
+func NewRdsNotEncryptedError(dbInstanceID string, awsRegion string) RdsNotEncryptedError {
+	return RdsNotEncryptedError{dbInstanceID: dbInstanceID, awsRegion: awsRegion}
+}
+
+// SnsSubscriptionNotFoundError is returned when an SNS topic does not have a confirmed subscription for an endpoint
+type SnsSubscriptionNotFoundError struct {
+	snsTopicArn         string
+	protocol            string
+	endpoint            string
+	pendingConfirmation bool
+}
+
+func (err SnsSubscriptionNotFoundError) Error() string {
+	if err.pendingConfirmation {
+		return fmt.Sprintf("The %s subscription of SNS topic %s to %s is still pending confirmation", err.protocol, err.snsTopicArn, err.endpoint)
+	}
+	return fmt.Sprintf("SNS topic %s does not have a %s subscription to %s", err.snsTopicArn, err.protocol, err.endpoint)
+}
+
+func NewSnsSubscriptionNotFoundError(snsTopicArn string, protocol string, endpoint string, pendingConfirmation bool) SnsSubscriptionNotFoundError {
+	return SnsSubscriptionNotFoundError{snsTopicArn: snsTopicArn, protocol: protocol, endpoint: endpoint, pendingConfirmation: pendingConfirmation}
+}
+
+// DynamoDBKeySchemaMismatchError is returned when the key schema of a DynamoDB table is not the expected one
+type DynamoDBKeySchemaMismatchError struct {
+	tableName         string
+	expectedKeySchema string
+	actualKeySchema   string
+}
+
+func (err DynamoDBKeySchemaMismatchError) Error() string {
+	return fmt.Sprintf("The key schema of DynamoDB table %s is %s, but expected %s", err.tableName, err.actualKeySchema, err.expectedKeySchema)
+}
+
+func NewDynamoDBKeySchemaMismatchError(tableName string, expectedKeySchema string, actualKeySchema string) DynamoDBKeySchemaMismatchError {
+	return DynamoDBKeySchemaMismatchError{tableName: tableName, expectedKeySchema: expectedKeySchema, actualKeySchema: actualKeySchema}
+}
+
+// DynamoDBPointInTimeRecoveryNotEnabledError is returned when point-in-time recovery is not enabled for a DynamoDB table
+type DynamoDBPointInTimeRecoveryNotEnabledError struct {
+	tableName string
+	awsRegion string
+	status    string
+}
+
+func (err DynamoDBPointInTimeRecoveryNotEnabledError) Error() string {
+	return fmt.Sprintf("Point-in-time recovery for DynamoDB table %s in the %s region is %s, but expected ENABLED", err.tableName, err.awsRegion, err.status)
+}
+
+func NewDynamoDBPointInTimeRecoveryNotEnabledError(tableName string, awsRegion string, status string) DynamoDBPointInTimeRecoveryNotEnabledError {
+	return DynamoDBPointInTimeRecoveryNotEnabledError{tableName: tableName, awsRegion: awsRegion, status: status}
+}
+
+// KmsKeyRotationNotEnabledError is returned when automatic key rotation is not enabled for a KMS key that should have it
+type KmsKeyRotationNotEnabledError struct {
+	cmkID     string
+	awsRegion string
+}
+
+func (err KmsKeyRotationNotEnabledError) Error() string {
+	return fmt.Sprintf("Automatic key rotation is not enabled for KMS key %s in the %s region", err.cmkID, err.awsRegion)
+}
+
+func NewKmsKeyRotationNotEnabledError(cmkID string, awsRegion string) KmsKeyRotationNotEnabledError {
+	return KmsKeyRotationNotEnabledError{cmkID: cmkID, awsRegion: awsRegion}
+}
+
+// ActionNotDeniedError is returned when an action that was expected to be denied for an IAM Role either succeeded or
+// failed for a reason other than access being denied.
+type ActionNotDeniedError struct {
+	ActionDescription string
+	RoleArn           string
+	UnderlyingErr     error
+}
+
+func (err ActionNotDeniedError) Error() string {
+	if err.UnderlyingErr == nil {
+		return fmt.Sprintf("Expected '%s' to be denied for role %s, but it succeeded", err.ActionDescription, err.RoleArn)
+	}
+	return fmt.Sprintf("Expected '%s' to be denied for role %s, but it failed with a different error: %v", err.ActionDescription, err.RoleArn, err.UnderlyingErr)
+}
+
+// IamPolicySimulationMismatchError is returned when the IAM policy simulator does not allow (or deny) actions that were
+// expected to be allowed (or denied) for an IAM user, group, or role.
+type IamPolicySimulationMismatchError struct {
+	policySourceArn string
+	expectAllowed   bool
+	mismatches      []string
+}
+
+func (err IamPolicySimulationMismatchError) Error() string {
+	expectedDecision := "denied"
+	if err.expectAllowed {
+		expectedDecision = "allowed"
+	}
+	return fmt.Sprintf(
+		"Expected all actions to be %s for %s, but: %s",
+		expectedDecision,
+		err.policySourceArn,
+		strings.Join(err.mismatches, "; "),
+	)
+}
+
+func NewIamPolicySimulationMismatchError(policySourceArn string, expectAllowed bool, mismatches []string) IamPolicySimulationMismatchError {
+	return IamPolicySimulationMismatchError{policySourceArn: policySourceArn, expectAllowed: expectAllowed, mismatches: mismatches}
+}
+
+// SsmCommandFailed is returned when a command run on an EC2 Instance via SSM does not succeed.
+type SsmCommandFailed struct {
+	InstanceId string
+	CommandId  string
+	Status     string
+	Stderr     string
+}
+
+func (err SsmCommandFailed) Error() string {
+	return fmt.Sprintf("SSM command %s on EC2 Instance %s finished with status %s: %s", err.CommandId, err.InstanceId, err.Status, err.Stderr)
+}
+
+// LambdaFunctionError is returned when a Lambda function was invoked successfully, but the function itself failed.
+type LambdaFunctionError struct {
+	FunctionName  string
+	FunctionError string // Unhandled if the function crashed or timed out, Handled if the function returned an error
+	StatusCode    int64
+	Payload       []byte // The error object the function responded with
+}
+
+func (err LambdaFunctionError) Error() string {
+	return fmt.Sprintf("Lambda function %s failed with a %s error (status code %d): %s", err.FunctionName, err.FunctionError, err.StatusCode, string(err.Payload))
+}
+
+// UnsupportedPresignMethod is returned when asked to presign an S3 URL for an HTTP method other than GET or PUT.
+type UnsupportedPresignMethod string
+
+func (method UnsupportedPresignMethod) Error() string {
+	return fmt.Sprintf("Presigned S3 URLs are only supported for GET and PUT, not %s", string(method))
+}
+
+// AWS error codes that mean the caller hit an API rate limit
+var throttlingErrorCodes = []string{
+	"Throttling",
+	"ThrottlingException",
+	"ThrottledException",
+	"RequestLimitExceeded",
+	"RequestThrottled",
+	"TooManyRequestsException",
+	"ProvisionedThroughputExceededException",
+	"SlowDown",
+}
+
+// AWS error codes that mean the caller's credentials are missing or invalid. Codes that mean the credentials are valid
+// but not allowed to perform the action are in accessDeniedErrorCodes.
+var invalidCredentialsErrorCodes = []string{
+	"AuthFailure",
+	"ExpiredToken",
+	"ExpiredTokenException",
+	"InvalidClientTokenId",
+	"SignatureDoesNotMatch",
+	"UnrecognizedClientException",
+}
+
+// wrapAwsError converts an error returned by the AWS API about the given resource into a customerrors.NotFoundError,
+// customerrors.ThrottledError, or customerrors.AuthError, so test code can branch on the category of the error. Any
+// other error is returned unchanged.
+func wrapAwsError(err error, kind string, name string, region string) error {
+	awsErr, isAwsErr := err.(awserr.Error)
+	if !isAwsErr {
+		return err
+	}
+
+	ctx := customerrors.ResourceContext{Kind: kind, Name: name, Region: region}
+	code := awsErr.Code()
+
+	switch {
+	case isNotFoundErrorCode(code):
+		return customerrors.NotFoundError{ResourceContext: ctx, Underlying: err}
+	case collections.ListContains(throttlingErrorCodes, code) || request.IsErrorThrottle(err):
+		return customerrors.ThrottledError{ResourceContext: ctx, Underlying: err}
+	case collections.ListContains(accessDeniedErrorCodes, code) || collections.ListContains(invalidCredentialsErrorCodes, code):
+		return customerrors.AuthError{ResourceContext: ctx, Underlying: err}
+	default:
+		return err
+	}
+}
+
+// isNotFoundErrorCode returns true if the given AWS error code means the resource does not exist. AWS is not
+// consistent here: there are codes like NoSuchKey, ResourceNotFoundException, ParameterNotFound, and
+// InvalidInstanceID.NotFound.
+func isNotFoundErrorCode(code string) bool {
+	return strings.HasPrefix(code, "NoSuch") || strings.Contains(code, "NotFound")
+}
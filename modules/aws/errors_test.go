@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gruntwork-io/terratest/modules/customerrors"
+)
+
+func TestWrapAwsError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		err          error
+		expectedType interface{}
+	}{
+		{"no such key", awserr.New("NoSuchKey", "The specified key does not exist.", nil), customerrors.NotFoundError{}},
+		{"resource not found", awserr.New("ResourceNotFoundException", "Secrets Manager can't find the secret.", nil), customerrors.NotFoundError{}},
+		{"parameter not found", awserr.New("ParameterNotFound", "", nil), customerrors.NotFoundError{}},
+		{"instance not found", awserr.New("InvalidInstanceID.NotFound", "", nil), customerrors.NotFoundError{}},
+		{"throttling", awserr.New("ThrottlingException", "Rate exceeded", nil), customerrors.ThrottledError{}},
+		{"request limit", awserr.New("RequestLimitExceeded", "", nil), customerrors.ThrottledError{}},
+		{"access denied", awserr.New("AccessDenied", "", nil), customerrors.AuthError{}},
+		{"expired token", awserr.New("ExpiredToken", "", nil), customerrors.AuthError{}},
+		{"other aws error", awserr.New("ValidationException", "", nil), awserr.New("", "", nil)},
+		{"not an aws error", errors.New("boom"), errors.New("")},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.IsType(t, testCase.expectedType, wrapAwsError(testCase.err, "S3 object", "bucket/key", "us-east-1"))
+		})
+	}
+}
+
+func TestWrapAwsErrorIncludesResource(t *testing.T) {
+	t.Parallel()
+
+	err := wrapAwsError(awserr.New("NoSuchKey", "The specified key does not exist.", nil), "S3 object", "bucket/key", "us-east-1")
+	assert.True(t, customerrors.IsNotFound(err))
+	assert.Contains(t, err.Error(), "S3 object bucket/key in us-east-1 not found")
+}
@@ -1,16 +1,18 @@
 package aws
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 // GetIamCurrentUserName gets the username for the current IAM user.
-func GetIamCurrentUserName(t *testing.T) string {
+func GetIamCurrentUserName(t testing.TB) string {
 	out, err := GetIamCurrentUserNameE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -19,7 +21,7 @@ func GetIamCurrentUserName(t *testing.T) string {
 }
 
 // GetIamCurrentUserNameE gets the username for the current IAM user.
-func GetIamCurrentUserNameE(t *testing.T) (string, error) {
+func GetIamCurrentUserNameE(t testing.TB) (string, error) {
 	iamClient, err := NewIamClientE(t, defaultRegion)
 	if err != nil {
 		return "", err
@@ -34,7 +36,7 @@ func GetIamCurrentUserNameE(t *testing.T) (string, error) {
 }
 
 // GetIamCurrentUserArn gets the ARN for the current IAM user.
-func GetIamCurrentUserArn(t *testing.T) string {
+func GetIamCurrentUserArn(t testing.TB) string {
 	out, err := GetIamCurrentUserArnE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -43,7 +45,7 @@ func GetIamCurrentUserArn(t *testing.T) string {
 }
 
 // GetIamCurrentUserArnE gets the ARN for the current IAM user.
-func GetIamCurrentUserArnE(t *testing.T) (string, error) {
+func GetIamCurrentUserArnE(t testing.TB) (string, error) {
 	iamClient, err := NewIamClientE(t, defaultRegion)
 	if err != nil {
 		return "", err
@@ -58,7 +60,7 @@ func GetIamCurrentUserArnE(t *testing.T) (string, error) {
 }
 
 // CreateMfaDevice creates an MFA device using the given IAM client.
-func CreateMfaDevice(t *testing.T, iamClient *iam.IAM, deviceName string) *iam.VirtualMFADevice {
+func CreateMfaDevice(t testing.TB, iamClient *iam.IAM, deviceName string) *iam.VirtualMFADevice {
 	mfaDevice, err := CreateMfaDeviceE(t, iamClient, deviceName)
 	if err != nil {
 		t.Fatal(err)
@@ -67,7 +69,11 @@ func CreateMfaDevice(t *testing.T, iamClient *iam.IAM, deviceName string) *iam.V
 }
 
 // CreateMfaDeviceE creates an MFA device using the given IAM client.
-func CreateMfaDeviceE(t *testing.T, iamClient *iam.IAM, deviceName string) (*iam.VirtualMFADevice, error) {
+func CreateMfaDeviceE(t testing.TB, iamClient *iam.IAM, deviceName string) (*iam.VirtualMFADevice, error) {
+	if dryrun.Skip(t, "create MFA device %s", deviceName) {
+		return &iam.VirtualMFADevice{SerialNumber: aws.String(dryrun.Id("arn:aws:iam::mfa/")), Base32StringSeed: []byte("JBSWY3DPEHPK3PXP")}, nil
+	}
+
 	logger.Logf(t, "Creating an MFA device called %s", deviceName)
 
 	output, err := iamClient.CreateVirtualMFADevice(&iam.CreateVirtualMFADeviceInput{
@@ -86,7 +92,7 @@ func CreateMfaDeviceE(t *testing.T, iamClient *iam.IAM, deviceName string) (*iam
 
 // EnableMfaDevice enables a newly created MFA Device by supplying the first two one-time passwords, so that it can be used for future
 // logins by the given IAM User.
-func EnableMfaDevice(t *testing.T, iamClient *iam.IAM, mfaDevice *iam.VirtualMFADevice) {
+func EnableMfaDevice(t testing.TB, iamClient *iam.IAM, mfaDevice *iam.VirtualMFADevice) {
 	err := EnableMfaDeviceE(t, iamClient, mfaDevice)
 	if err != nil {
 		t.Fatal(err)
@@ -95,7 +101,7 @@ func EnableMfaDevice(t *testing.T, iamClient *iam.IAM, mfaDevice *iam.VirtualMFA
 
 // EnableMfaDeviceE enables a newly created MFA Device by supplying the first two one-time passwords, so that it can be used for future
 // logins by the given IAM User.
-func EnableMfaDeviceE(t *testing.T, iamClient *iam.IAM, mfaDevice *iam.VirtualMFADevice) error {
+func EnableMfaDeviceE(t testing.TB, iamClient *iam.IAM, mfaDevice *iam.VirtualMFADevice) error {
 	logger.Logf(t, "Enabling MFA device %s", aws.StringValue(mfaDevice.SerialNumber))
 
 	iamUserName, err := GetIamCurrentUserArnE(t)
@@ -133,8 +139,114 @@ func EnableMfaDeviceE(t *testing.T, iamClient *iam.IAM, mfaDevice *iam.VirtualMF
 	return nil
 }
 
+// AssertIamPolicyAllows uses the IAM policy simulator to check that the policies attached to the given IAM user, group,
+// or role allow every one of the given actions on every one of the given resources, and fails the test if they don't.
+func AssertIamPolicyAllows(t testing.TB, policySourceArn string, actions []string, resourceArns []string) {
+	err := AssertIamPolicyAllowsE(t, policySourceArn, actions, resourceArns)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertIamPolicyAllowsE uses the IAM policy simulator to check that the policies attached to the given IAM user, group,
+// or role allow every one of the given actions on every one of the given resources, and returns an error if they don't.
+// If resourceArns is empty, the actions are simulated on all resources ("*").
+func AssertIamPolicyAllowsE(t testing.TB, policySourceArn string, actions []string, resourceArns []string) error {
+	return assertIamPolicyDecisionE(t, policySourceArn, actions, resourceArns, true)
+}
+
+// AssertIamPolicyDenies uses the IAM policy simulator to check that the policies attached to the given IAM user, group,
+// or role deny every one of the given actions on every one of the given resources, and fails the test if they don't.
+func AssertIamPolicyDenies(t testing.TB, policySourceArn string, actions []string, resourceArns []string) {
+	err := AssertIamPolicyDeniesE(t, policySourceArn, actions, resourceArns)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertIamPolicyDeniesE uses the IAM policy simulator to check that the policies attached to the given IAM user, group,
+// or role deny every one of the given actions on every one of the given resources, and returns an error if they don't.
+// Actions are denied either explicitly by a Deny statement or implicitly by the lack of an Allow statement. If
+// resourceArns is empty, the actions are simulated on all resources ("*").
+func AssertIamPolicyDeniesE(t testing.TB, policySourceArn string, actions []string, resourceArns []string) error {
+	return assertIamPolicyDecisionE(t, policySourceArn, actions, resourceArns, false)
+}
+
+// assertIamPolicyDecisionE simulates the given actions on the given resources for the given IAM user, group, or role,
+// and returns an IamPolicySimulationMismatchError if any of them are not allowed (or denied, if expectAllowed is false).
+func assertIamPolicyDecisionE(t testing.TB, policySourceArn string, actions []string, resourceArns []string, expectAllowed bool) error {
+	logger.Logf(t, "Simulating actions %v on resources %v for %s", actions, resourceArns, policySourceArn)
+
+	results, err := SimulateIamPrincipalPolicyE(t, policySourceArn, actions, resourceArns)
+	if err != nil {
+		return err
+	}
+
+	mismatches := getIamSimulationMismatches(results, expectAllowed)
+	if len(mismatches) > 0 {
+		return NewIamPolicySimulationMismatchError(policySourceArn, expectAllowed, mismatches)
+	}
+
+	return nil
+}
+
+// SimulateIamPrincipalPolicy runs the IAM policy simulator for the given actions on the given resources with the
+// policies attached to the given IAM user, group, or role, and returns the result for each action and resource.
+func SimulateIamPrincipalPolicy(t testing.TB, policySourceArn string, actions []string, resourceArns []string) []*iam.EvaluationResult {
+	out, err := SimulateIamPrincipalPolicyE(t, policySourceArn, actions, resourceArns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// SimulateIamPrincipalPolicyE runs the IAM policy simulator for the given actions on the given resources with the
+// policies attached to the given IAM user, group, or role, and returns the result for each action and resource.
+func SimulateIamPrincipalPolicyE(t testing.TB, policySourceArn string, actions []string, resourceArns []string) ([]*iam.EvaluationResult, error) {
+	iamClient, err := NewIamClientE(t, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(policySourceArn),
+		ActionNames:     aws.StringSlice(actions),
+	}
+	if len(resourceArns) > 0 {
+		input.ResourceArns = aws.StringSlice(resourceArns)
+	}
+
+	results := []*iam.EvaluationResult{}
+	err = iamClient.SimulatePrincipalPolicyPages(input, func(page *iam.SimulatePolicyResponse, lastPage bool) bool {
+		results = append(results, page.EvaluationResults...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// getIamSimulationMismatches returns a description of each of the given policy simulation results that is not allowed,
+// or that is allowed if expectAllowed is false.
+func getIamSimulationMismatches(results []*iam.EvaluationResult, expectAllowed bool) []string {
+	mismatches := []string{}
+
+	for _, result := range results {
+		decision := aws.StringValue(result.EvalDecision)
+		isAllowed := decision == iam.PolicyEvaluationDecisionTypeAllowed
+
+		if isAllowed != expectAllowed {
+			mismatches = append(mismatches, fmt.Sprintf("%s on %s is %s", aws.StringValue(result.EvalActionName), aws.StringValue(result.EvalResourceName), decision))
+		}
+	}
+
+	return mismatches
+}
+
 // NewIamClient creates a new IAM client.
-func NewIamClient(t *testing.T, region string) *iam.IAM {
+func NewIamClient(t testing.TB, region string) *iam.IAM {
 	client, err := NewIamClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -143,8 +255,8 @@ func NewIamClient(t *testing.T, region string) *iam.IAM {
 }
 
 // NewIamClientE creates a new IAM client.
-func NewIamClientE(t *testing.T, region string) (*iam.IAM, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewIamClientE(t testing.TB, region string) (*iam.IAM, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
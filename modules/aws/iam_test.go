@@ -3,6 +3,8 @@ package aws
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,3 +21,44 @@ func TestGetIamCurrentUserArn(t *testing.T) {
 	username := GetIamCurrentUserArn(t)
 	assert.Regexp(t, "^arn:aws:iam::[0-9]{12}:user/.+$", username)
 }
+
+func TestGetIamSimulationMismatches(t *testing.T) {
+	t.Parallel()
+
+	results := []*iam.EvaluationResult{
+		{EvalActionName: aws.String("s3:GetObject"), EvalResourceName: aws.String("arn:aws:s3:::bucket/*"), EvalDecision: aws.String("allowed")},
+		{EvalActionName: aws.String("s3:PutObject"), EvalResourceName: aws.String("arn:aws:s3:::bucket/*"), EvalDecision: aws.String("implicitDeny")},
+		{EvalActionName: aws.String("s3:DeleteObject"), EvalResourceName: aws.String("arn:aws:s3:::bucket/*"), EvalDecision: aws.String("explicitDeny")},
+	}
+
+	testCases := []struct {
+		name          string
+		expectAllowed bool
+		expected      []string
+	}{
+		{
+			"expect allowed",
+			true,
+			[]string{
+				"s3:PutObject on arn:aws:s3:::bucket/* is implicitDeny",
+				"s3:DeleteObject on arn:aws:s3:::bucket/* is explicitDeny",
+			},
+		},
+		{
+			"expect denied",
+			false,
+			[]string{"s3:GetObject on arn:aws:s3:::bucket/* is allowed"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, getIamSimulationMismatches(results, testCase.expectAllowed))
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 // GetCmkArn gets the ARN of a KMS Customer Master Key (CMK) in the given region with the given ID. The ID can be an alias, such
 // as "alias/my-cmk".
-func GetCmkArn(t *testing.T, region string, cmkID string) string {
+func GetCmkArn(t testing.TB, region string, cmkID string) string {
 	out, err := GetCmkArnE(t, region, cmkID)
 	if err != nil {
 		t.Fatal(err)
@@ -19,7 +19,7 @@ func GetCmkArn(t *testing.T, region string, cmkID string) string {
 
 // GetCmkArnE gets the ARN of a KMS Customer Master Key (CMK) in the given region with the given ID. The ID can be an alias, such
 // as "alias/my-cmk".
-func GetCmkArnE(t *testing.T, region string, cmkID string) (string, error) {
+func GetCmkArnE(t testing.TB, region string, cmkID string) (string, error) {
 	kmsClient, err := NewKmsClientE(t, region)
 	if err != nil {
 		return "", err
@@ -36,8 +36,80 @@ func GetCmkArnE(t *testing.T, region string, cmkID string) (string, error) {
 	return *result.KeyMetadata.Arn, nil
 }
 
+// GetKmsKeyPolicy gets the key policy of the KMS Customer Master Key (CMK) in the given region with the given ID, as a JSON
+// document. The ID can be an alias, such as "alias/my-cmk".
+func GetKmsKeyPolicy(t testing.TB, region string, cmkID string) string {
+	out, err := GetKmsKeyPolicyE(t, region, cmkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// GetKmsKeyPolicyE gets the key policy of the KMS Customer Master Key (CMK) in the given region with the given ID, as a
+// JSON document. The ID can be an alias, such as "alias/my-cmk".
+func GetKmsKeyPolicyE(t testing.TB, region string, cmkID string) (string, error) {
+	// GetKeyPolicy doesn't accept aliases, so look up the ARN of the key first
+	cmkArn, err := GetCmkArnE(t, region, cmkID)
+	if err != nil {
+		return "", err
+	}
+
+	kmsClient, err := NewKmsClientE(t, region)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := kmsClient.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId: aws.String(cmkArn),
+		// This is the only policy name KMS supports
+		PolicyName: aws.String("default"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(result.Policy), nil
+}
+
+// AssertKmsKeyRotationEnabled checks that automatic key rotation is enabled for the KMS Customer Master Key (CMK) in the
+// given region with the given ID, and fails the test if it is not. The ID can be an alias, such as "alias/my-cmk".
+func AssertKmsKeyRotationEnabled(t testing.TB, region string, cmkID string) {
+	err := AssertKmsKeyRotationEnabledE(t, region, cmkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertKmsKeyRotationEnabledE checks that automatic key rotation is enabled for the KMS Customer Master Key (CMK) in the
+// given region with the given ID, and returns an error if it is not. The ID can be an alias, such as "alias/my-cmk".
+func AssertKmsKeyRotationEnabledE(t testing.TB, region string, cmkID string) error {
+	// GetKeyRotationStatus doesn't accept aliases, so look up the ARN of the key first
+	cmkArn, err := GetCmkArnE(t, region, cmkID)
+	if err != nil {
+		return err
+	}
+
+	kmsClient, err := NewKmsClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	result, err := kmsClient.GetKeyRotationStatus(&kms.GetKeyRotationStatusInput{
+		KeyId: aws.String(cmkArn),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !aws.BoolValue(result.KeyRotationEnabled) {
+		return NewKmsKeyRotationNotEnabledError(cmkID, region)
+	}
+	return nil
+}
+
 // NewKmsClient creates a KMS client.
-func NewKmsClient(t *testing.T, region string) *kms.KMS {
+func NewKmsClient(t testing.TB, region string) *kms.KMS {
 	client, err := NewKmsClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -46,8 +118,8 @@ func NewKmsClient(t *testing.T, region string) *kms.KMS {
 }
 
 // NewKmsClientE creates a KMS client.
-func NewKmsClientE(t *testing.T, region string) (*kms.KMS, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewKmsClientE(t testing.TB, region string) (*kms.KMS, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
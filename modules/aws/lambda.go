@@ -0,0 +1,209 @@
+package aws
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// LambdaOptions are the options for invoking a Lambda function.
+type LambdaOptions struct {
+	// The payload to send to the function. Byte slices and json.RawMessage values are sent as is, anything else is
+	// marshalled to JSON. If nil, no payload is sent.
+	Payload interface{}
+
+	// The invocation type, e.g. lambda.InvocationTypeEvent to invoke the function asynchronously. Defaults to
+	// lambda.InvocationTypeRequestResponse.
+	InvocationType string
+
+	// Whether to fetch the last 4 KB of the logs of the invocation, which are then logged and returned in LogResult.
+	// Only supported for synchronous invocations.
+	IncludeLogs bool
+}
+
+// LambdaOutput is the result of invoking a Lambda function.
+type LambdaOutput struct {
+	// The HTTP status code of the invocation, e.g. 200 for a synchronous invocation
+	StatusCode int64
+
+	// The response of the function, which is the error object if the function failed
+	Payload []byte
+
+	// The last 4 KB of the logs of the invocation, if IncludeLogs was set
+	LogResult string
+}
+
+// InvokeFunction invokes the given Lambda function synchronously with the given payload and returns the response of
+// the function. This will fail the test if there is an error invoking the function, or if the function itself fails.
+func InvokeFunction(t testing.TB, awsRegion string, functionName string, payload interface{}) []byte {
+	out, err := InvokeFunctionE(t, awsRegion, functionName, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// InvokeFunctionE invokes the given Lambda function synchronously with the given payload and returns the response of
+// the function. If the function itself fails, a LambdaFunctionError is returned along with the error object the
+// function responded with. See InvokeFunctionWithParamsE for how the payload is marshalled.
+func InvokeFunctionE(t testing.TB, awsRegion string, functionName string, payload interface{}) ([]byte, error) {
+	out, err := InvokeFunctionWithParamsE(t, awsRegion, functionName, &LambdaOptions{Payload: payload})
+	if out == nil {
+		return nil, err
+	}
+	return out.Payload, err
+}
+
+// InvokeFunctionAndUnmarshal invokes the given Lambda function synchronously with the given payload and unmarshals its
+// JSON response into the given value, e.g. a pointer to a struct. This will fail the test if there is an error
+// invoking the function, or if the function itself fails.
+func InvokeFunctionAndUnmarshal(t testing.TB, awsRegion string, functionName string, payload interface{}, response interface{}) {
+	err := InvokeFunctionAndUnmarshalE(t, awsRegion, functionName, payload, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// InvokeFunctionAndUnmarshalE invokes the given Lambda function synchronously with the given payload and unmarshals its
+// JSON response into the given value, e.g. a pointer to a struct.
+func InvokeFunctionAndUnmarshalE(t testing.TB, awsRegion string, functionName string, payload interface{}, response interface{}) error {
+	out, err := InvokeFunctionE(t, awsRegion, functionName, payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(out, response)
+}
+
+// InvokeFunctionWithParams invokes the given Lambda function with the given options, or with the defaults if options is
+// nil. This will fail the test if there is an error invoking the function, or if the function itself fails.
+func InvokeFunctionWithParams(t testing.TB, awsRegion string, functionName string, options *LambdaOptions) *LambdaOutput {
+	out, err := InvokeFunctionWithParamsE(t, awsRegion, functionName, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// InvokeFunctionWithParamsE invokes the given Lambda function with the given options, or with the defaults if options
+// is nil. If the function itself fails, the output is returned along with a LambdaFunctionError, so the error object
+// the function responded with can still be asserted on.
+func InvokeFunctionWithParamsE(t testing.TB, awsRegion string, functionName string, options *LambdaOptions) (*LambdaOutput, error) {
+	logger.Logf(t, "Invoking Lambda function %s", functionName)
+
+	client, err := NewLambdaClientE(t, awsRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := getLambdaInvokeInput(functionName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	invokeOutput, err := client.Invoke(input)
+	if err != nil {
+		return nil, err
+	}
+
+	logResult, err := decodeLambdaLogResult(aws.StringValue(invokeOutput.LogResult))
+	if err != nil {
+		return nil, err
+	}
+	if logResult != "" {
+		logger.Logf(t, "Logs of Lambda function %s:\n%s", functionName, logResult)
+	}
+
+	out := &LambdaOutput{
+		StatusCode: aws.Int64Value(invokeOutput.StatusCode),
+		Payload:    invokeOutput.Payload,
+		LogResult:  logResult,
+	}
+
+	if invokeOutput.FunctionError != nil {
+		return out, LambdaFunctionError{
+			FunctionName:  functionName,
+			FunctionError: aws.StringValue(invokeOutput.FunctionError),
+			StatusCode:    out.StatusCode,
+			Payload:       out.Payload,
+		}
+	}
+
+	return out, nil
+}
+
+// getLambdaInvokeInput returns the input to invoke the given Lambda function with the given options, or with the
+// defaults if options is nil.
+func getLambdaInvokeInput(functionName string, options *LambdaOptions) (*lambda.InvokeInput, error) {
+	if options == nil {
+		options = &LambdaOptions{}
+	}
+
+	invocationType := options.InvocationType
+	if invocationType == "" {
+		invocationType = lambda.InvocationTypeRequestResponse
+	}
+
+	input := &lambda.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		InvocationType: aws.String(invocationType),
+	}
+
+	if options.IncludeLogs {
+		input.LogType = aws.String(lambda.LogTypeTail)
+	}
+
+	payload, err := marshalLambdaPayload(options.Payload)
+	if err != nil {
+		return nil, err
+	}
+	input.Payload = payload
+
+	return input, nil
+}
+
+// marshalLambdaPayload returns the bytes to send to a Lambda function for the given payload. Byte slices and
+// json.RawMessage values are returned as is, as marshalling them would turn them into a base64 encoded JSON string.
+func marshalLambdaPayload(payload interface{}) ([]byte, error) {
+	switch typedPayload := payload.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return typedPayload, nil
+	case json.RawMessage:
+		return typedPayload, nil
+	default:
+		return json.Marshal(payload)
+	}
+}
+
+// decodeLambdaLogResult decodes the base64 encoded logs that Lambda returns when invoked with the Tail log type.
+func decodeLambdaLogResult(logResult string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(logResult)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// NewLambdaClient creates a new Lambda client.
+func NewLambdaClient(t testing.TB, region string) *lambda.Lambda {
+	client, err := NewLambdaClientE(t, region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// NewLambdaClientE creates a new Lambda client.
+func NewLambdaClientE(t testing.TB, region string) (*lambda.Lambda, error) {
+	sess, err := newTimedSession(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return lambda.New(sess), nil
+}
@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalLambdaPayload(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		payload  interface{}
+		expected []byte
+	}{
+		{"nil", nil, nil},
+		{"bytes", []byte(`{"name":"raw"}`), []byte(`{"name":"raw"}`)},
+		{"raw message", json.RawMessage(`{"name":"raw"}`), []byte(`{"name":"raw"}`)},
+		{"map", map[string]string{"name": "terratest"}, []byte(`{"name":"terratest"}`)},
+		{"struct", struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		}{"terratest", 2}, []byte(`{"name":"terratest","count":2}`)},
+		{"string", "hello", []byte(`"hello"`)},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := marshalLambdaPayload(testCase.payload)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestDecodeLambdaLogResult(t *testing.T) {
+	t.Parallel()
+
+	logs, err := decodeLambdaLogResult("U1RBUlQgUmVxdWVzdElkOiAxMjMKRU5EIFJlcXVlc3RJZDogMTIzCg==")
+	require.NoError(t, err)
+	assert.Equal(t, "START RequestId: 123\nEND RequestId: 123\n", logs)
+
+	logs, err = decodeLambdaLogResult("")
+	require.NoError(t, err)
+	assert.Equal(t, "", logs)
+
+	_, err = decodeLambdaLogResult("not base64!")
+	assert.Error(t, err)
+}
+
+func TestGetLambdaInvokeInput(t *testing.T) {
+	t.Parallel()
+
+	input, err := getLambdaInvokeInput("my-function", &LambdaOptions{Payload: map[string]int{"id": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, "my-function", aws.StringValue(input.FunctionName))
+	assert.Equal(t, lambda.InvocationTypeRequestResponse, aws.StringValue(input.InvocationType))
+	assert.Nil(t, input.LogType)
+	assert.Equal(t, []byte(`{"id":1}`), input.Payload)
+
+	input, err = getLambdaInvokeInput("my-function", &LambdaOptions{InvocationType: lambda.InvocationTypeEvent, IncludeLogs: true})
+	require.NoError(t, err)
+	assert.Equal(t, lambda.InvocationTypeEvent, aws.StringValue(input.InvocationType))
+	assert.Equal(t, lambda.LogTypeTail, aws.StringValue(input.LogType))
+	assert.Nil(t, input.Payload)
+
+	// Nil options invoke the function synchronously without a payload
+	input, err = getLambdaInvokeInput("my-function", nil)
+	require.NoError(t, err)
+	assert.Equal(t, lambda.InvocationTypeRequestResponse, aws.StringValue(input.InvocationType))
+	assert.Nil(t, input.LogType)
+	assert.Nil(t, input.Payload)
+}
@@ -0,0 +1,222 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/gruntwork-io/terratest/modules/collections"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// The error codes AWS APIs use to signal that the caller is not allowed to perform an action. Different services use
+// different codes, so we check against all of the ones we know about.
+var accessDeniedErrorCodes = []string{
+	"AccessDenied",
+	"AccessDeniedException",
+	"AuthorizationError",
+	"UnauthorizedOperation",
+	"Forbidden",
+}
+
+// GetOrganizationalUnitsForParent gets the Organizational Units that are direct children of the given parent, which
+// can be the ID of the organization root or of another Organizational Unit.
+func GetOrganizationalUnitsForParent(t testing.TB, parentID string) []*organizations.OrganizationalUnit {
+	units, err := GetOrganizationalUnitsForParentE(t, parentID)
+	require.NoError(t, err)
+	return units
+}
+
+// GetOrganizationalUnitsForParentE gets the Organizational Units that are direct children of the given parent, which
+// can be the ID of the organization root or of another Organizational Unit.
+func GetOrganizationalUnitsForParentE(t testing.TB, parentID string) ([]*organizations.OrganizationalUnit, error) {
+	logger.Logf(t, "Looking up Organizational Units under parent %s", parentID)
+
+	client, err := NewOrganizationsClientE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	units := []*organizations.OrganizationalUnit{}
+	input := &organizations.ListOrganizationalUnitsForParentInput{ParentId: aws.String(parentID)}
+	err = client.ListOrganizationalUnitsForParentPages(input, func(page *organizations.ListOrganizationalUnitsForParentOutput, lastPage bool) bool {
+		units = append(units, page.OrganizationalUnits...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return units, nil
+}
+
+// GetParentIdForAccount gets the ID of the organization root or Organizational Unit that directly contains the given
+// AWS account.
+func GetParentIdForAccount(t testing.TB, accountID string) string {
+	parentID, err := GetParentIdForAccountE(t, accountID)
+	require.NoError(t, err)
+	return parentID
+}
+
+// GetParentIdForAccountE gets the ID of the organization root or Organizational Unit that directly contains the given
+// AWS account.
+func GetParentIdForAccountE(t testing.TB, accountID string) (string, error) {
+	client, err := NewOrganizationsClientE(t)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.ListParents(&organizations.ListParentsInput{ChildId: aws.String(accountID)})
+	if err != nil {
+		return "", err
+	}
+
+	if len(output.Parents) == 0 {
+		return "", NewNotFoundError("Organization parent for account", accountID, defaultRegion)
+	}
+
+	return aws.StringValue(output.Parents[0].Id), nil
+}
+
+// GetTagsForAccount gets the tags attached to the given AWS account in the organization.
+func GetTagsForAccount(t testing.TB, accountID string) map[string]string {
+	tags, err := GetTagsForAccountE(t, accountID)
+	require.NoError(t, err)
+	return tags
+}
+
+// GetTagsForAccountE gets the tags attached to the given AWS account in the organization.
+func GetTagsForAccountE(t testing.TB, accountID string) (map[string]string, error) {
+	client, err := NewOrganizationsClientE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]string{}
+	input := &organizations.ListTagsForResourceInput{ResourceId: aws.String(accountID)}
+	err = client.ListTagsForResourcePages(input, func(page *organizations.ListTagsForResourceOutput, lastPage bool) bool {
+		for _, tag := range page.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// GetServiceControlPoliciesForTarget gets the Service Control Policies (SCPs) directly attached to the given target,
+// which can be the ID of the organization root, an Organizational Unit, or an AWS account.
+func GetServiceControlPoliciesForTarget(t testing.TB, targetID string) []*organizations.PolicySummary {
+	policies, err := GetServiceControlPoliciesForTargetE(t, targetID)
+	require.NoError(t, err)
+	return policies
+}
+
+// GetServiceControlPoliciesForTargetE gets the Service Control Policies (SCPs) directly attached to the given target,
+// which can be the ID of the organization root, an Organizational Unit, or an AWS account.
+func GetServiceControlPoliciesForTargetE(t testing.TB, targetID string) ([]*organizations.PolicySummary, error) {
+	logger.Logf(t, "Looking up Service Control Policies attached to %s", targetID)
+
+	client, err := NewOrganizationsClientE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := []*organizations.PolicySummary{}
+	input := &organizations.ListPoliciesForTargetInput{
+		TargetId: aws.String(targetID),
+		Filter:   aws.String(organizations.PolicyTypeServiceControlPolicy),
+	}
+	err = client.ListPoliciesForTargetPages(input, func(page *organizations.ListPoliciesForTargetOutput, lastPage bool) bool {
+		policies = append(policies, page.Policies...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// GetServiceControlPolicyContent gets the JSON policy document of the Service Control Policy with the given ID.
+func GetServiceControlPolicyContent(t testing.TB, policyID string) string {
+	content, err := GetServiceControlPolicyContentE(t, policyID)
+	require.NoError(t, err)
+	return content
+}
+
+// GetServiceControlPolicyContentE gets the JSON policy document of the Service Control Policy with the given ID.
+func GetServiceControlPolicyContentE(t testing.TB, policyID string) (string, error) {
+	client, err := NewOrganizationsClientE(t)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.DescribePolicy(&organizations.DescribePolicyInput{PolicyId: aws.String(policyID)})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(output.Policy.Content), nil
+}
+
+// AssertActionDeniedForRole assumes the IAM Role with the given ARN (typically a role in a member account of the
+// organization), runs the given action with a session for that role, and fails the test unless the action is rejected
+// with an access denied error. This is useful for checking that an SCP actually blocks an action, rather than just
+// checking that the SCP is attached.
+func AssertActionDeniedForRole(t testing.TB, awsRegion string, roleARN string, actionDescription string, action func(sess *session.Session) error) {
+	err := AssertActionDeniedForRoleE(t, awsRegion, roleARN, actionDescription, action)
+	require.NoError(t, err)
+}
+
+// AssertActionDeniedForRoleE assumes the IAM Role with the given ARN (typically a role in a member account of the
+// organization), runs the given action with a session for that role, and returns an error unless the action is
+// rejected with an access denied error.
+func AssertActionDeniedForRoleE(t testing.TB, awsRegion string, roleARN string, actionDescription string, action func(sess *session.Session) error) error {
+	logger.Logf(t, "Checking that '%s' is denied for role %s", actionDescription, roleARN)
+
+	sess, err := NewAuthenticatedSessionFromRole(awsRegion, roleARN)
+	if err != nil {
+		return err
+	}
+
+	actionErr := action(sess)
+	if isAccessDeniedError(actionErr) {
+		logger.Logf(t, "'%s' was denied for role %s as expected: %v", actionDescription, roleARN, actionErr)
+		return nil
+	}
+
+	return ActionNotDeniedError{ActionDescription: actionDescription, RoleArn: roleARN, UnderlyingErr: actionErr}
+}
+
+// isAccessDeniedError returns true if the given error is an AWS API error indicating the caller was not authorized.
+func isAccessDeniedError(err error) bool {
+	awsErr, isAwsErr := err.(awserr.Error)
+	if !isAwsErr {
+		return false
+	}
+	return collections.ListContains(accessDeniedErrorCodes, awsErr.Code())
+}
+
+// NewOrganizationsClient creates a new AWS Organizations client.
+func NewOrganizationsClient(t testing.TB) *organizations.Organizations {
+	client, err := NewOrganizationsClientE(t)
+	require.NoError(t, err)
+	return client
+}
+
+// NewOrganizationsClientE creates a new AWS Organizations client. Organizations is a global service whose API
+// endpoint lives in us-east-1, so no region needs to be specified.
+func NewOrganizationsClientE(t testing.TB) (*organizations.Organizations, error) {
+	sess, err := newTimedSession(t, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+	return organizations.New(sess), nil
+}
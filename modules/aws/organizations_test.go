@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAccessDeniedError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"NilError", nil, false},
+		{"NonAwsError", errors.New("AccessDenied"), false},
+		{"AccessDenied", awserr.New("AccessDenied", "explicit deny in a service control policy", nil), true},
+		{"AccessDeniedException", awserr.New("AccessDeniedException", "not authorized", nil), true},
+		{"UnauthorizedOperation", awserr.New("UnauthorizedOperation", "not authorized", nil), true},
+		{"OtherAwsError", awserr.New("ThrottlingException", "rate exceeded", nil), false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, isAccessDeniedError(testCase.err))
+		})
+	}
+}
@@ -3,15 +3,38 @@ package aws
 import (
 	"database/sql"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/ssh"
+	_ "github.com/lib/pq"
 )
 
+// RDS database engines that CheckRdsConnectionE knows how to connect to
+const (
+	RdsEngineMySql    = "mysql"
+	RdsEnginePostgres = "postgres"
+)
+
+// RdsConnectionOptions are the options for connecting to a database on an RDS instance.
+type RdsConnectionOptions struct {
+	Engine       string    // The database engine, either RdsEngineMySql (also for MariaDB and Aurora MySQL) or RdsEnginePostgres
+	Address      string    // The address of the RDS instance, e.g. from GetAddressOfRdsInstance
+	Port         int64     // The port of the RDS instance, e.g. from GetPortOfRdsInstance
+	Username     string    // The user name to connect with
+	Password     string    // The password to connect with
+	DatabaseName string    // The database to connect to. If empty, the default database of the user is used.
+	BastionHost  *ssh.Host // If set, the connection is made through an SSH tunnel via this host, for instances that aren't publicly accessible
+}
+
 // GetAddressOfRdsInstance gets the address of the given RDS Instance in the given region.
-func GetAddressOfRdsInstance(t *testing.T, dbInstanceID string, awsRegion string) string {
+func GetAddressOfRdsInstance(t testing.TB, dbInstanceID string, awsRegion string) string {
 	address, err := GetAddressOfRdsInstanceE(t, dbInstanceID, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -20,7 +43,7 @@ func GetAddressOfRdsInstance(t *testing.T, dbInstanceID string, awsRegion string
 }
 
 // GetAddressOfRdsInstanceE gets the address of the given RDS Instance in the given region.
-func GetAddressOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegion string) (string, error) {
+func GetAddressOfRdsInstanceE(t testing.TB, dbInstanceID string, awsRegion string) (string, error) {
 	dbInstance, err := GetRdsInstanceDetailsE(t, dbInstanceID, awsRegion)
 	if err != nil {
 		return "", err
@@ -30,7 +53,7 @@ func GetAddressOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegion strin
 }
 
 // GetPortOfRdsInstance gets the address of the given RDS Instance in the given region.
-func GetPortOfRdsInstance(t *testing.T, dbInstanceID string, awsRegion string) int64 {
+func GetPortOfRdsInstance(t testing.TB, dbInstanceID string, awsRegion string) int64 {
 	port, err := GetPortOfRdsInstanceE(t, dbInstanceID, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -39,7 +62,7 @@ func GetPortOfRdsInstance(t *testing.T, dbInstanceID string, awsRegion string) i
 }
 
 // GetPortOfRdsInstanceE gets the address of the given RDS Instance in the given region.
-func GetPortOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegion string) (int64, error) {
+func GetPortOfRdsInstanceE(t testing.TB, dbInstanceID string, awsRegion string) (int64, error) {
 	dbInstance, err := GetRdsInstanceDetailsE(t, dbInstanceID, awsRegion)
 	if err != nil {
 		return -1, err
@@ -48,8 +71,122 @@ func GetPortOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegion string)
 	return *dbInstance.Endpoint.Port, nil
 }
 
+// GetRdsEndpoint gets the endpoint of the given RDS Instance in the given region, in the form address:port.
+func GetRdsEndpoint(t testing.TB, dbInstanceID string, awsRegion string) string {
+	endpoint, err := GetRdsEndpointE(t, dbInstanceID, awsRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return endpoint
+}
+
+// GetRdsEndpointE gets the endpoint of the given RDS Instance in the given region, in the form address:port.
+func GetRdsEndpointE(t testing.TB, dbInstanceID string, awsRegion string) (string, error) {
+	dbInstance, err := GetRdsInstanceDetailsE(t, dbInstanceID, awsRegion)
+	if err != nil {
+		return "", err
+	}
+	if dbInstance.Endpoint == nil {
+		return "", NewNotFoundError("Endpoint of RDS instance", dbInstanceID, awsRegion)
+	}
+
+	return net.JoinHostPort(aws.StringValue(dbInstance.Endpoint.Address), strconv.FormatInt(aws.Int64Value(dbInstance.Endpoint.Port), 10)), nil
+}
+
+// AssertRdsEncrypted checks that the storage of the given RDS Instance in the given region is encrypted, and fails the
+// test if it is not.
+func AssertRdsEncrypted(t testing.TB, dbInstanceID string, awsRegion string) {
+	err := AssertRdsEncryptedE(t, dbInstanceID, awsRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertRdsEncryptedE checks that the storage of the given RDS Instance in the given region is encrypted, and returns
+// an error if it is not.
+func AssertRdsEncryptedE(t testing.TB, dbInstanceID string, awsRegion string) error {
+	dbInstance, err := GetRdsInstanceDetailsE(t, dbInstanceID, awsRegion)
+	if err != nil {
+		return err
+	}
+	if !aws.BoolValue(dbInstance.StorageEncrypted) {
+		return NewRdsNotEncryptedError(dbInstanceID, awsRegion)
+	}
+	return nil
+}
+
+// CheckRdsConnection connects to the database with the given options and runs a trivial query, to check that the
+// database actually accepts connections and the credentials work. This will fail the test if there is an error.
+func CheckRdsConnection(t testing.TB, options RdsConnectionOptions) {
+	err := CheckRdsConnectionE(t, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CheckRdsConnectionE connects to the database with the given options and runs a trivial query, to check that the
+// database actually accepts connections and the credentials work. If a bastion host is set, the connection goes
+// through an SSH tunnel via that host.
+func CheckRdsConnectionE(t testing.TB, options RdsConnectionOptions) error {
+	host := options.Address
+	port := options.Port
+
+	if options.BastionHost != nil {
+		tunnel := ssh.NewSshTunnel(*options.BastionHost, options.Address, int(options.Port), 0)
+		defer tunnel.Close()
+		if err := tunnel.ForwardPortE(t); err != nil {
+			return err
+		}
+		host = "localhost"
+		port = int64(tunnel.LocalPort())
+	}
+
+	driverName, connectionString, err := getRdsConnectionString(options, host, port)
+	if err != nil {
+		return err
+	}
+
+	logger.Logf(t, "Connecting to %s database %s on %s:%d as %s", options.Engine, options.DatabaseName, options.Address, options.Port, options.Username)
+
+	db, err := sql.Open(driverName, connectionString)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var result int
+	if err := db.QueryRow("SELECT 1").Scan(&result); err != nil {
+		return err
+	}
+
+	logger.Logf(t, "Successfully connected to %s database %s on %s:%d", options.Engine, options.DatabaseName, options.Address, options.Port)
+	return nil
+}
+
+// getRdsConnectionString returns the database/sql driver name and connection string to connect to the database with
+// the given options on the given host and port.
+func getRdsConnectionString(options RdsConnectionOptions, host string, port int64) (string, string, error) {
+	address := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+
+	switch options.Engine {
+	case RdsEngineMySql:
+		return "mysql", fmt.Sprintf("%s:%s@tcp(%s)/%s", options.Username, options.Password, address, options.DatabaseName), nil
+	case RdsEnginePostgres:
+		connectionUrl := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(options.Username, options.Password),
+			Host:     address,
+			Path:     "/" + options.DatabaseName,
+			RawQuery: "sslmode=require",
+		}
+		return "postgres", connectionUrl.String(), nil
+	default:
+		return "", "", UnsupportedRdsEngine(options.Engine)
+	}
+}
+
 // GetWhetherSchemaExistsInRdsMySqlInstance checks whether the specified schema/table name exists in the RDS instance
-func GetWhetherSchemaExistsInRdsMySqlInstance(t *testing.T, dbUrl string, dbPort int64, dbUsername string, dbPassword string, expectedSchemaName string) bool {
+func GetWhetherSchemaExistsInRdsMySqlInstance(t testing.TB, dbUrl string, dbPort int64, dbUsername string, dbPassword string, expectedSchemaName string) bool {
 	output, err := GetWhetherSchemaExistsInRdsMySqlInstanceE(t, dbUrl, dbPort, dbUsername, dbPassword, expectedSchemaName)
 	if err != nil {
 		t.Fatal(err)
@@ -58,7 +195,7 @@ func GetWhetherSchemaExistsInRdsMySqlInstance(t *testing.T, dbUrl string, dbPort
 }
 
 // GetWhetherSchemaExistsInRdsMySqlInstanceE checks whether the specified schema/table name exists in the RDS instance
-func GetWhetherSchemaExistsInRdsMySqlInstanceE(t *testing.T, dbUrl string, dbPort int64, dbUsername string, dbPassword string, expectedSchemaName string) (bool, error) {
+func GetWhetherSchemaExistsInRdsMySqlInstanceE(t testing.TB, dbUrl string, dbPort int64, dbUsername string, dbPassword string, expectedSchemaName string) (bool, error) {
 	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%d)/", dbUsername, dbPassword, dbUrl, dbPort)
 	db, connErr := sql.Open("mysql", connectionString)
 	if connErr != nil {
@@ -78,7 +215,7 @@ func GetWhetherSchemaExistsInRdsMySqlInstanceE(t *testing.T, dbUrl string, dbPor
 }
 
 // GetParameterValueForParameterOfRdsInstance gets the value of the parameter name specified for the RDS instance in the given region.
-func GetParameterValueForParameterOfRdsInstance(t *testing.T, parameterName string, dbInstanceID string, awsRegion string) string {
+func GetParameterValueForParameterOfRdsInstance(t testing.TB, parameterName string, dbInstanceID string, awsRegion string) string {
 	parameterValue, err := GetParameterValueForParameterOfRdsInstanceE(t, parameterName, dbInstanceID, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -87,7 +224,7 @@ func GetParameterValueForParameterOfRdsInstance(t *testing.T, parameterName stri
 }
 
 // GetParameterValueForParameterOfRdsInstanceE gets the value of the parameter name specified for the RDS instance in the given region.
-func GetParameterValueForParameterOfRdsInstanceE(t *testing.T, parameterName string, dbInstanceID string, awsRegion string) (string, error) {
+func GetParameterValueForParameterOfRdsInstanceE(t testing.TB, parameterName string, dbInstanceID string, awsRegion string) (string, error) {
 	output := GetAllParametersOfRdsInstance(t, dbInstanceID, awsRegion)
 	for _, parameter := range output {
 		if aws.StringValue(parameter.ParameterName) == parameterName {
@@ -98,7 +235,7 @@ func GetParameterValueForParameterOfRdsInstanceE(t *testing.T, parameterName str
 }
 
 // GetOptionSettingForOfRdsInstance gets the value of the option name in the option group specified for the RDS instance in the given region.
-func GetOptionSettingForOfRdsInstance(t *testing.T, optionName string, optionSettingName string, dbInstanceID, awsRegion string) string {
+func GetOptionSettingForOfRdsInstance(t testing.TB, optionName string, optionSettingName string, dbInstanceID, awsRegion string) string {
 	optionValue, err := GetOptionSettingForOfRdsInstanceE(t, optionName, optionSettingName, dbInstanceID, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -107,7 +244,7 @@ func GetOptionSettingForOfRdsInstance(t *testing.T, optionName string, optionSet
 }
 
 // GetOptionSettingForOfRdsInstanceE gets the value of the option name in the option group specified for the RDS instance in the given region.
-func GetOptionSettingForOfRdsInstanceE(t *testing.T, optionName string, optionSettingName string, dbInstanceID, awsRegion string) (string, error) {
+func GetOptionSettingForOfRdsInstanceE(t testing.TB, optionName string, optionSettingName string, dbInstanceID, awsRegion string) (string, error) {
 	optionGroupName := GetOptionGroupNameOfRdsInstance(t, dbInstanceID, awsRegion)
 	options := GetOptionsOfOptionGroup(t, optionGroupName, awsRegion)
 	for _, option := range options {
@@ -123,7 +260,7 @@ func GetOptionSettingForOfRdsInstanceE(t *testing.T, optionName string, optionSe
 }
 
 // GetOptionGroupNameOfRdsInstance gets the name of the option group associated with the RDS instance
-func GetOptionGroupNameOfRdsInstance(t *testing.T, dbInstanceID string, awsRegion string) string {
+func GetOptionGroupNameOfRdsInstance(t testing.TB, dbInstanceID string, awsRegion string) string {
 	dbInstance, err := GetOptionGroupNameOfRdsInstanceE(t, dbInstanceID, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -132,7 +269,7 @@ func GetOptionGroupNameOfRdsInstance(t *testing.T, dbInstanceID string, awsRegio
 }
 
 // GetOptionGroupNameOfRdsInstanceE gets the name of the option group associated with the RDS instance
-func GetOptionGroupNameOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegion string) (string, error) {
+func GetOptionGroupNameOfRdsInstanceE(t testing.TB, dbInstanceID string, awsRegion string) (string, error) {
 	dbInstance, err := GetRdsInstanceDetailsE(t, dbInstanceID, awsRegion)
 	if err != nil {
 		return "", err
@@ -141,7 +278,7 @@ func GetOptionGroupNameOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegi
 }
 
 // GetOptionsOfOptionGroup gets the options of the option group specified
-func GetOptionsOfOptionGroup(t *testing.T, optionGroupName string, awsRegion string) []*rds.Option {
+func GetOptionsOfOptionGroup(t testing.TB, optionGroupName string, awsRegion string) []*rds.Option {
 	output, err := GetOptionsOfOptionGroupE(t, optionGroupName, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -150,7 +287,7 @@ func GetOptionsOfOptionGroup(t *testing.T, optionGroupName string, awsRegion str
 }
 
 // GetOptionsOfOptionGroupE gets the options of the option group specified
-func GetOptionsOfOptionGroupE(t *testing.T, optionGroupName string, awsRegion string) ([]*rds.Option, error) {
+func GetOptionsOfOptionGroupE(t testing.TB, optionGroupName string, awsRegion string) ([]*rds.Option, error) {
 	rdsClient := NewRdsClient(t, awsRegion)
 	input := rds.DescribeOptionGroupsInput{OptionGroupName: aws.String(optionGroupName)}
 	output, err := rdsClient.DescribeOptionGroups(&input)
@@ -161,7 +298,7 @@ func GetOptionsOfOptionGroupE(t *testing.T, optionGroupName string, awsRegion st
 }
 
 // GetAllParametersOfRdsInstance gets all the parameters defined in the parameter group for the RDS instance in the given region.
-func GetAllParametersOfRdsInstance(t *testing.T, dbInstanceID string, awsRegion string) []*rds.Parameter {
+func GetAllParametersOfRdsInstance(t testing.TB, dbInstanceID string, awsRegion string) []*rds.Parameter {
 	parameters, err := GetAllParametersOfRdsInstanceE(t, dbInstanceID, awsRegion)
 	if err != nil {
 		t.Fatal(err)
@@ -170,7 +307,7 @@ func GetAllParametersOfRdsInstance(t *testing.T, dbInstanceID string, awsRegion
 }
 
 // GetAllParametersOfRdsInstanceE gets all the parameters defined in the parameter group for the RDS instance in the given region.
-func GetAllParametersOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegion string) ([]*rds.Parameter, error) {
+func GetAllParametersOfRdsInstanceE(t testing.TB, dbInstanceID string, awsRegion string) ([]*rds.Parameter, error) {
 	dbInstance, dbInstanceErr := GetRdsInstanceDetailsE(t, dbInstanceID, awsRegion)
 	if dbInstanceErr != nil {
 		return []*rds.Parameter{}, dbInstanceErr
@@ -187,19 +324,34 @@ func GetAllParametersOfRdsInstanceE(t *testing.T, dbInstanceID string, awsRegion
 	return output.Parameters, nil
 }
 
+// GetRdsInstanceDetails gets the details of a single DB instance whose identifier is passed.
+func GetRdsInstanceDetails(t testing.TB, dbInstanceID string, awsRegion string) *rds.DBInstance {
+	dbInstance, err := GetRdsInstanceDetailsE(t, dbInstanceID, awsRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dbInstance
+}
+
 // GetRdsInstanceDetailsE gets the details of a single DB instance whose identifier is passed.
-func GetRdsInstanceDetailsE(t *testing.T, dbInstanceID string, awsRegion string) (*rds.DBInstance, error) {
-	rdsClient := NewRdsClient(t, awsRegion)
+func GetRdsInstanceDetailsE(t testing.TB, dbInstanceID string, awsRegion string) (*rds.DBInstance, error) {
+	rdsClient, err := NewRdsClientE(t, awsRegion)
+	if err != nil {
+		return nil, err
+	}
 	input := rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(dbInstanceID)}
 	output, err := rdsClient.DescribeDBInstances(&input)
 	if err != nil {
 		return nil, err
 	}
+	if len(output.DBInstances) == 0 {
+		return nil, NewNotFoundError("RDS instance", dbInstanceID, awsRegion)
+	}
 	return output.DBInstances[0], nil
 }
 
 // NewRdsClient creates an RDS client.
-func NewRdsClient(t *testing.T, region string) *rds.RDS {
+func NewRdsClient(t testing.TB, region string) *rds.RDS {
 	client, err := NewRdsClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -208,8 +360,8 @@ func NewRdsClient(t *testing.T, region string) *rds.RDS {
 }
 
 // NewRdsClientE creates an RDS client.
-func NewRdsClientE(t *testing.T, region string) (*rds.RDS, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewRdsClientE(t testing.TB, region string) (*rds.RDS, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -239,3 +391,10 @@ type OptionGroupOptionSettingForDbInstanceNotFound struct {
 func (err OptionGroupOptionSettingForDbInstanceNotFound) Error() string {
 	return fmt.Sprintf("Could not find a option setting %s in option name %s of database %s in %s", err.OptionName, err.OptionSettingName, err.DbInstanceID, err.AwsRegion)
 }
+
+// UnsupportedRdsEngine is an error that occurs when asked to connect to an RDS database engine other than MySQL or Postgres
+type UnsupportedRdsEngine string
+
+func (engine UnsupportedRdsEngine) Error() string {
+	return fmt.Sprintf("Connecting to RDS engine %s is not supported, only %s and %s", string(engine), RdsEngineMySql, RdsEnginePostgres)
+}
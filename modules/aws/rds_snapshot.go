@@ -0,0 +1,143 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// CreateRdsSnapshot creates a manual snapshot with the given ID of the given RDS DB instance and waits until it is
+// available.
+func CreateRdsSnapshot(t testing.TB, dbInstanceID string, snapshotID string, awsRegion string) {
+	err := CreateRdsSnapshotE(t, dbInstanceID, snapshotID, awsRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CreateRdsSnapshotE creates a manual snapshot with the given ID of the given RDS DB instance and waits until it is
+// available.
+func CreateRdsSnapshotE(t testing.TB, dbInstanceID string, snapshotID string, awsRegion string) error {
+	if dryrun.Skip(t, "create snapshot %s of RDS DB instance %s in %s", snapshotID, dbInstanceID, awsRegion) {
+		return nil
+	}
+
+	logger.Logf(t, "Creating snapshot %s of RDS DB instance %s", snapshotID, dbInstanceID)
+
+	rdsClient, err := NewRdsClientE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	_, err = rdsClient.CreateDBSnapshot(&rds.CreateDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(dbInstanceID),
+		DBSnapshotIdentifier: aws.String(snapshotID),
+	})
+	if err != nil {
+		return err
+	}
+
+	return rdsClient.WaitUntilDBSnapshotAvailable(&rds.DescribeDBSnapshotsInput{DBSnapshotIdentifier: aws.String(snapshotID)})
+}
+
+// DeleteRdsSnapshot deletes the RDS DB snapshot with the given ID.
+func DeleteRdsSnapshot(t testing.TB, snapshotID string, awsRegion string) {
+	err := DeleteRdsSnapshotE(t, snapshotID, awsRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteRdsSnapshotE deletes the RDS DB snapshot with the given ID.
+func DeleteRdsSnapshotE(t testing.TB, snapshotID string, awsRegion string) error {
+	if dryrun.Skip(t, "delete RDS DB snapshot %s in %s", snapshotID, awsRegion) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting RDS DB snapshot %s", snapshotID)
+
+	rdsClient, err := NewRdsClientE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	_, err = rdsClient.DeleteDBSnapshot(&rds.DeleteDBSnapshotInput{DBSnapshotIdentifier: aws.String(snapshotID)})
+	return err
+}
+
+// RestoreRdsInstanceFromSnapshot restores the RDS DB snapshot with the given ID into a new DB instance with the given
+// ID and waits until the new instance is available. The new instance uses the default settings for everything that
+// is not stored in the snapshot, so set dbSubnetGroupName if the original instance is not in the default VPC.
+func RestoreRdsInstanceFromSnapshot(t testing.TB, snapshotID string, dbInstanceID string, dbSubnetGroupName string, awsRegion string) {
+	err := RestoreRdsInstanceFromSnapshotE(t, snapshotID, dbInstanceID, dbSubnetGroupName, awsRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// RestoreRdsInstanceFromSnapshotE restores the RDS DB snapshot with the given ID into a new DB instance with the given
+// ID and waits until the new instance is available. The new instance uses the default settings for everything that
+// is not stored in the snapshot, so set dbSubnetGroupName if the original instance is not in the default VPC.
+func RestoreRdsInstanceFromSnapshotE(t testing.TB, snapshotID string, dbInstanceID string, dbSubnetGroupName string, awsRegion string) error {
+	if dryrun.Skip(t, "restore RDS DB snapshot %s into DB instance %s in %s", snapshotID, dbInstanceID, awsRegion) {
+		return nil
+	}
+
+	logger.Logf(t, "Restoring RDS DB snapshot %s into new DB instance %s", snapshotID, dbInstanceID)
+
+	rdsClient, err := NewRdsClientE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	input := &rds.RestoreDBInstanceFromDBSnapshotInput{
+		DBSnapshotIdentifier: aws.String(snapshotID),
+		DBInstanceIdentifier: aws.String(dbInstanceID),
+	}
+	if dbSubnetGroupName != "" {
+		input.DBSubnetGroupName = aws.String(dbSubnetGroupName)
+	}
+
+	if _, err := rdsClient.RestoreDBInstanceFromDBSnapshot(input); err != nil {
+		return err
+	}
+
+	return rdsClient.WaitUntilDBInstanceAvailable(&rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(dbInstanceID)})
+}
+
+// DeleteRdsInstance deletes the RDS DB instance with the given ID, without taking a final snapshot, and waits until
+// it is gone. This is meant for cleaning up throwaway instances, e.g. ones restored from a snapshot by a test.
+func DeleteRdsInstance(t testing.TB, dbInstanceID string, awsRegion string) {
+	err := DeleteRdsInstanceE(t, dbInstanceID, awsRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteRdsInstanceE deletes the RDS DB instance with the given ID, without taking a final snapshot, and waits until
+// it is gone. This is meant for cleaning up throwaway instances, e.g. ones restored from a snapshot by a test.
+func DeleteRdsInstanceE(t testing.TB, dbInstanceID string, awsRegion string) error {
+	if dryrun.Skip(t, "delete RDS DB instance %s in %s", dbInstanceID, awsRegion) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting RDS DB instance %s", dbInstanceID)
+
+	rdsClient, err := NewRdsClientE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	_, err = rdsClient.DeleteDBInstance(&rds.DeleteDBInstanceInput{
+		DBInstanceIdentifier: aws.String(dbInstanceID),
+		SkipFinalSnapshot:    aws.Bool(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	return rdsClient.WaitUntilDBInstanceDeleted(&rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(dbInstanceID)})
+}
@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	dns_helper "github.com/gruntwork-io/terratest/modules/dns-helper"
+)
+
+// GetRoute53Record gets the record set with the given name and type (e.g. A or CNAME) in the given Route 53 hosted zone.
+func GetRoute53Record(t testing.TB, hostedZoneID string, recordName string, recordType string) *route53.ResourceRecordSet {
+	record, err := GetRoute53RecordE(t, hostedZoneID, recordName, recordType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+// GetRoute53RecordE gets the record set with the given name and type (e.g. A or CNAME) in the given Route 53 hosted zone.
+// For alias records, the target is in the AliasTarget of the record set rather than in its ResourceRecords.
+func GetRoute53RecordE(t testing.TB, hostedZoneID string, recordName string, recordType string) (*route53.ResourceRecordSet, error) {
+	route53Client, err := NewRoute53ClientE(t, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record sets are sorted by name and type, so the first one from this name and type on is the one we want, if it
+	// exists at all
+	output, err := route53Client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(recordName),
+		StartRecordType: aws.String(recordType),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range output.ResourceRecordSets {
+		if route53RecordNamesMatch(aws.StringValue(record.Name), recordName) && aws.StringValue(record.Type) == recordType {
+			return record, nil
+		}
+	}
+
+	return nil, NewNotFoundError(fmt.Sprintf("Route 53 %s record in hosted zone %s", recordType, hostedZoneID), recordName, defaultRegion)
+}
+
+// WaitUntilRecordResolves does a real DNS lookup of the record with the given name and type (A, AAAA, CNAME, TXT, MX,
+// or NS) and retries until the results contain all of expectedValues. This verifies that a record is actually being
+// served, not just that it exists in the Route 53 API.
+func WaitUntilRecordResolves(t testing.TB, recordName string, recordType string, expectedValues []string, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilRecordResolvesE(t, recordName, recordType, expectedValues, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilRecordResolvesE does a real DNS lookup of the record with the given name and type (A, AAAA, CNAME, TXT, MX,
+// or NS) and retries until the results contain all of expectedValues. This verifies that a record is actually being
+// served, not just that it exists in the Route 53 API. Note that the system resolver caches negative answers, so looking
+// up a record before it is created can delay the lookups that follow by up to the SOA TTL of the zone.
+func WaitUntilRecordResolvesE(t testing.TB, recordName string, recordType string, expectedValues []string, maxRetries int, sleepBetweenRetries time.Duration) error {
+	return dns_helper.WaitUntilRecordResolvesE(t, recordName, recordType, expectedValues, maxRetries, sleepBetweenRetries)
+}
+
+// route53RecordNamesMatch returns true if the given record name returned by Route 53 is the given record name. Route 53
+// returns names as lowercase FQDNs, with a wildcard escaped as \052.
+func route53RecordNamesMatch(route53Name string, recordName string) bool {
+	return normalizeRoute53RecordName(strings.Replace(route53Name, `\052`, "*", -1)) == normalizeRoute53RecordName(recordName)
+}
+
+// normalizeRoute53RecordName lowercases the given record name and strips its trailing dot.
+func normalizeRoute53RecordName(recordName string) string {
+	return strings.ToLower(strings.TrimSuffix(recordName, "."))
+}
+
+// NewRoute53Client creates a new Route 53 client.
+func NewRoute53Client(t testing.TB, region string) *route53.Route53 {
+	client, err := NewRoute53ClientE(t, region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// NewRoute53ClientE creates a new Route 53 client.
+func NewRoute53ClientE(t testing.TB, region string) (*route53.Route53, error) {
+	sess, err := newTimedSession(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return route53.New(sess), nil
+}
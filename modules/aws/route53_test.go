@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoute53RecordNamesMatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		route53Name string
+		recordName  string
+		expected    bool
+	}{
+		{"www.example.com.", "www.example.com", true},
+		{"www.example.com.", "www.example.com.", true},
+		{"www.example.com.", "WWW.Example.com", true},
+		{`\052.example.com.`, "*.example.com", true},
+		{"api.example.com.", "www.example.com", false},
+		{"www.example.com.", "www.example.co", false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.route53Name+" "+testCase.recordName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, route53RecordNamesMatch(testCase.route53Name, testCase.recordName))
+		})
+	}
+}
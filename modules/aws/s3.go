@@ -2,18 +2,25 @@ package aws
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/stretchr/testify/require"
 )
 
 // FindS3BucketWithTag finds the name of the S3 bucket in the given region with the given tag key=value.
-func FindS3BucketWithTag(t *testing.T, awsRegion string, key string, value string) string {
+func FindS3BucketWithTag(t testing.TB, awsRegion string, key string, value string) string {
 	bucket, err := FindS3BucketWithTagE(t, awsRegion, key, value)
 	require.NoError(t, err)
 
@@ -21,7 +28,7 @@ func FindS3BucketWithTag(t *testing.T, awsRegion string, key string, value strin
 }
 
 // FindS3BucketWithTagE finds the name of the S3 bucket in the given region with the given tag key=value.
-func FindS3BucketWithTagE(t *testing.T, awsRegion string, key string, value string) (string, error) {
+func FindS3BucketWithTagE(t testing.TB, awsRegion string, key string, value string) (string, error) {
 	s3Client, err := NewS3ClientE(t, awsRegion)
 	if err != nil {
 		return "", err
@@ -55,7 +62,7 @@ func FindS3BucketWithTagE(t *testing.T, awsRegion string, key string, value stri
 }
 
 // GetS3ObjectContents fetches the contents of the object in the given bucket with the given key and return it as a string.
-func GetS3ObjectContents(t *testing.T, awsRegion string, bucket string, key string) string {
+func GetS3ObjectContents(t testing.TB, awsRegion string, bucket string, key string) string {
 	contents, err := GetS3ObjectContentsE(t, awsRegion, bucket, key)
 	require.NoError(t, err)
 
@@ -63,7 +70,7 @@ func GetS3ObjectContents(t *testing.T, awsRegion string, bucket string, key stri
 }
 
 // GetS3ObjectContentsE fetches the contents of the object in the given bucket with the given key and return it as a string.
-func GetS3ObjectContentsE(t *testing.T, awsRegion string, bucket string, key string) (string, error) {
+func GetS3ObjectContentsE(t testing.TB, awsRegion string, bucket string, key string) (string, error) {
 	s3Client, err := NewS3ClientE(t, awsRegion)
 	if err != nil {
 		return "", err
@@ -75,7 +82,7 @@ func GetS3ObjectContentsE(t *testing.T, awsRegion string, bucket string, key str
 	})
 
 	if err != nil {
-		return "", err
+		return "", wrapAwsError(err, "S3 object", fmt.Sprintf("%s/%s", bucket, key), awsRegion)
 	}
 
 	buf := new(bytes.Buffer)
@@ -90,14 +97,137 @@ func GetS3ObjectContentsE(t *testing.T, awsRegion string, bucket string, key str
 	return contents, nil
 }
 
+// PutS3ObjectContents uploads the given contents to the object in the given bucket with the given key.
+func PutS3ObjectContents(t testing.TB, awsRegion string, bucket string, key string, body io.Reader) {
+	err := PutS3ObjectContentsE(t, awsRegion, bucket, key, body)
+	require.NoError(t, err)
+}
+
+// PutS3ObjectContentsE uploads the given contents to the object in the given bucket with the given key.
+func PutS3ObjectContentsE(t testing.TB, awsRegion string, bucket string, key string, body io.Reader) error {
+	if dryrun.Skip(t, "write s3://%s/%s", bucket, key) {
+		return nil
+	}
+
+	uploader, err := NewS3UploaderE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	_, err = uploader.Upload(&s3manager.UploadInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   body,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Logf(t, "Wrote contents to s3://%s/%s", bucket, key)
+	return nil
+}
+
+// ListS3ObjectKeys returns the keys of the objects in the given bucket that start with the given prefix.
+func ListS3ObjectKeys(t testing.TB, awsRegion string, bucket string, prefix string) []string {
+	keys, err := ListS3ObjectKeysE(t, awsRegion, bucket, prefix)
+	require.NoError(t, err)
+
+	return keys
+}
+
+// ListS3ObjectKeysE returns the keys of the objects in the given bucket that start with the given prefix.
+func ListS3ObjectKeysE(t testing.TB, awsRegion string, bucket string, prefix string) ([]string, error) {
+	s3Client, err := NewS3ClientE(t, awsRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	err = s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: &bucket,
+		Prefix: &prefix,
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// DeleteS3Object deletes the object in the given bucket with the given key.
+func DeleteS3Object(t testing.TB, awsRegion string, bucket string, key string) {
+	err := DeleteS3ObjectE(t, awsRegion, bucket, key)
+	require.NoError(t, err)
+}
+
+// DeleteS3ObjectE deletes the object in the given bucket with the given key.
+func DeleteS3ObjectE(t testing.TB, awsRegion string, bucket string, key string) error {
+	if dryrun.Skip(t, "delete s3://%s/%s", bucket, key) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting s3://%s/%s", bucket, key)
+
+	s3Client, err := NewS3ClientE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+	})
+	return err
+}
+
+// GetS3ObjectPresignedUrl returns a URL that grants anyone who has it access to the object in the given bucket with
+// the given key for the given HTTP method (GET or PUT) until it expires.
+func GetS3ObjectPresignedUrl(t testing.TB, awsRegion string, bucket string, key string, method string, expiry time.Duration) string {
+	url, err := GetS3ObjectPresignedUrlE(t, awsRegion, bucket, key, method, expiry)
+	require.NoError(t, err)
+
+	return url
+}
+
+// GetS3ObjectPresignedUrlE returns a URL that grants anyone who has it access to the object in the given bucket with
+// the given key for the given HTTP method (GET or PUT) until it expires.
+func GetS3ObjectPresignedUrlE(t testing.TB, awsRegion string, bucket string, key string, method string, expiry time.Duration) (string, error) {
+	s3Client, err := NewS3ClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	var req *request.Request
+	switch strings.ToUpper(method) {
+	case "GET":
+		req, _ = s3Client.GetObjectRequest(&s3.GetObjectInput{Bucket: &bucket, Key: &key})
+	case "PUT":
+		req, _ = s3Client.PutObjectRequest(&s3.PutObjectInput{Bucket: &bucket, Key: &key})
+	default:
+		return "", UnsupportedPresignMethod(method)
+	}
+
+	logger.Logf(t, "Presigning %s URL for s3://%s/%s, valid for %s", method, bucket, key, expiry)
+	return req.Presign(expiry)
+}
+
 // CreateS3Bucket creates an S3 bucket in the given region with the given name. Note that S3 bucket names must be globally unique.
-func CreateS3Bucket(t *testing.T, region string, name string) {
+func CreateS3Bucket(t testing.TB, region string, name string) {
 	err := CreateS3BucketE(t, region, name)
 	require.NoError(t, err)
 }
 
 // CreateS3BucketE creates an S3 bucket in the given region with the given name. Note that S3 bucket names must be globally unique.
-func CreateS3BucketE(t *testing.T, region string, name string) error {
+func CreateS3BucketE(t testing.TB, region string, name string) error {
+	if dryrun.Skip(t, "create S3 bucket %s in %s", name, region) {
+		return nil
+	}
+
 	logger.Logf(t, "Creating bucket %s in %s", name, region)
 
 	s3Client, err := NewS3ClientE(t, region)
@@ -113,13 +243,17 @@ func CreateS3BucketE(t *testing.T, region string, name string) error {
 }
 
 // PutS3BucketPolicy applies an IAM resource policy to a given S3 bucket to create it's bucket policy
-func PutS3BucketPolicy(t *testing.T, region string, bucketName string, policyJSONString string) {
+func PutS3BucketPolicy(t testing.TB, region string, bucketName string, policyJSONString string) {
 	err := PutS3BucketPolicyE(t, region, bucketName, policyJSONString)
 	require.NoError(t, err)
 }
 
 // PutS3BucketPolicyE applies an IAM resource policy to a given S3 bucket to create it's bucket policy
-func PutS3BucketPolicyE(t *testing.T, region string, bucketName string, policyJSONString string) error {
+func PutS3BucketPolicyE(t testing.TB, region string, bucketName string, policyJSONString string) error {
+	if dryrun.Skip(t, "put policy on S3 bucket %s", bucketName) {
+		return nil
+	}
+
 	logger.Logf(t, "Applying bucket policy for bucket %s in %s", bucketName, region)
 
 	s3Client, err := NewS3ClientE(t, region)
@@ -137,13 +271,17 @@ func PutS3BucketPolicyE(t *testing.T, region string, bucketName string, policyJS
 }
 
 // PutS3BucketVersioning creates an S3 bucket versioning configuration in the given region against the given bucket name, WITHOUT requiring MFA to remove versioning.
-func PutS3BucketVersioning(t *testing.T, region string, bucketName string) {
+func PutS3BucketVersioning(t testing.TB, region string, bucketName string) {
 	err := PutS3BucketVersioningE(t, region, bucketName)
 	require.NoError(t, err)
 }
 
 // PutS3BucketVersioningE creates an S3 bucket versioning configuration in the given region against the given bucket name, WITHOUT requiring MFA to remove versioning.
-func PutS3BucketVersioningE(t *testing.T, region string, bucketName string) error {
+func PutS3BucketVersioningE(t testing.TB, region string, bucketName string) error {
+	if dryrun.Skip(t, "enable versioning on S3 bucket %s", bucketName) {
+		return nil
+	}
+
 	logger.Logf(t, "Creating bucket versioning configuration for bucket %s in %s", bucketName, region)
 
 	s3Client, err := NewS3ClientE(t, region)
@@ -164,13 +302,17 @@ func PutS3BucketVersioningE(t *testing.T, region string, bucketName string) erro
 }
 
 // DeleteS3Bucket destroys the S3 bucket in the given region with the given name.
-func DeleteS3Bucket(t *testing.T, region string, name string) {
+func DeleteS3Bucket(t testing.TB, region string, name string) {
 	err := DeleteS3BucketE(t, region, name)
 	require.NoError(t, err)
 }
 
 // DeleteS3BucketE destroys the S3 bucket in the given region with the given name.
-func DeleteS3BucketE(t *testing.T, region string, name string) error {
+func DeleteS3BucketE(t testing.TB, region string, name string) error {
+	if dryrun.Skip(t, "delete S3 bucket %s in %s", name, region) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting bucket %s in %s", region, name)
 
 	s3Client, err := NewS3ClientE(t, region)
@@ -186,13 +328,17 @@ func DeleteS3BucketE(t *testing.T, region string, name string) error {
 }
 
 // EmptyS3BucketE removes the contents of an S3 bucket in the given region with the given name.
-func EmptyS3Bucket(t *testing.T, region string, name string) {
+func EmptyS3Bucket(t testing.TB, region string, name string) {
 	err := EmptyS3BucketE(t, region, name)
 	require.NoError(t, err)
 }
 
 // EmptyS3BucketE removes the contents of an S3 bucket in the given region with the given name.
-func EmptyS3BucketE(t *testing.T, region string, name string) error {
+func EmptyS3BucketE(t testing.TB, region string, name string) error {
+	if dryrun.Skip(t, "empty S3 bucket %s in %s", name, region) {
+		return nil
+	}
+
 	logger.Logf(t, "Emptying bucket %s in %s", name, region)
 
 	s3Client, err := NewS3ClientE(t, region)
@@ -205,45 +351,44 @@ func EmptyS3BucketE(t *testing.T, region string, name string) error {
 	}
 
 	for {
-		// Requesting a batch of objects from s3 bucket
+		// Requesting a batch of object versions and delete markers from s3 bucket
 		bucketObjects, err := s3Client.ListObjectVersions(params)
 		if err != nil {
 			return err
 		}
 
+		objectsToDelete := getObjectIdentifiersToDelete(bucketObjects)
+
 		//Checks if the bucket is already empty
-		if len((*bucketObjects).Versions) == 0 {
+		if len(objectsToDelete) == 0 && !aws.BoolValue(bucketObjects.IsTruncated) {
 			logger.Logf(t, "Bucket %s is already empty", name)
 			return nil
 		}
 
-		//creating an array of pointers of ObjectIdentifier
-		objectsToDelete := make([]*s3.ObjectIdentifier, 0, 1000)
-		for _, object := range (*bucketObjects).Versions {
-			obj := s3.ObjectIdentifier{
-				Key:       object.Key,
-				VersionId: object.VersionId,
+		if len(objectsToDelete) > 0 {
+			//Creating JSON payload for bulk delete
+			deleteParams := &s3.DeleteObjectsInput{
+				Bucket: aws.String(name),
+				Delete: &s3.Delete{Objects: objectsToDelete, Quiet: aws.Bool(true)},
 			}
-			objectsToDelete = append(objectsToDelete, &obj)
-		}
 
-		//Creating JSON payload for bulk delete
-		deleteArray := s3.Delete{Objects: objectsToDelete}
-		deleteParams := &s3.DeleteObjectsInput{
-			Bucket: aws.String(name),
-			Delete: &deleteArray,
-		}
+			//Running the Bulk delete job (limit 1000)
+			deleteOutput, err := s3Client.DeleteObjects(deleteParams)
+			if err != nil {
+				return err
+			}
 
-		//Running the Bulk delete job (limit 1000)
-		_, err = s3Client.DeleteObjects(deleteParams)
-		if err != nil {
-			return err
+			// DeleteObjects succeeds even if some of the objects could not be deleted, so check each of them
+			if len(deleteOutput.Errors) > 0 {
+				firstErr := deleteOutput.Errors[0]
+				return fmt.Errorf("Failed to delete %d objects from bucket %s, e.g. %s: %s", len(deleteOutput.Errors), name, aws.StringValue(firstErr.Key), aws.StringValue(firstErr.Message))
+			}
 		}
 
-		if *(*bucketObjects).IsTruncated { //if there are more objects in the bucket, IsTruncated = true
-			// params.Marker = (*deleteParams).Delete.Objects[len((*deleteParams).Delete.Objects)-1].Key
+		if aws.BoolValue(bucketObjects.IsTruncated) { //if there are more objects in the bucket, IsTruncated = true
 			params.KeyMarker = bucketObjects.NextKeyMarker
-			logger.Logf(t, "Requesting next batch | %s", *(params.KeyMarker))
+			params.VersionIdMarker = bucketObjects.NextVersionIdMarker
+			logger.Logf(t, "Requesting next batch | %s", aws.StringValue(params.KeyMarker))
 		} else { //if all objects in the bucket have been cleaned up.
 			break
 		}
@@ -252,8 +397,21 @@ func EmptyS3BucketE(t *testing.T, region string, name string) error {
 	return err
 }
 
+// getObjectIdentifiersToDelete returns the identifiers of all the object versions and delete markers in the given
+// page of ListObjectVersions results. Versioned buckets can only be deleted once both are gone.
+func getObjectIdentifiersToDelete(bucketObjects *s3.ListObjectVersionsOutput) []*s3.ObjectIdentifier {
+	objectsToDelete := make([]*s3.ObjectIdentifier, 0, len(bucketObjects.Versions)+len(bucketObjects.DeleteMarkers))
+	for _, object := range bucketObjects.Versions {
+		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{Key: object.Key, VersionId: object.VersionId})
+	}
+	for _, marker := range bucketObjects.DeleteMarkers {
+		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
+	}
+	return objectsToDelete
+}
+
 // GetS3BucketVersioning fetches the given bucket's versioning configuration status and returns it as a string
-func GetS3BucketVersioning(t *testing.T, awsRegion string, bucket string) string {
+func GetS3BucketVersioning(t testing.TB, awsRegion string, bucket string) string {
 	versioningStatus, err := GetS3BucketVersioningE(t, awsRegion, bucket)
 	require.NoError(t, err)
 
@@ -261,7 +419,7 @@ func GetS3BucketVersioning(t *testing.T, awsRegion string, bucket string) string
 }
 
 // GetS3BucketVersioningE fetches the given bucket's versioning configuration status and returns it as a string
-func GetS3BucketVersioningE(t *testing.T, awsRegion string, bucket string) (string, error) {
+func GetS3BucketVersioningE(t testing.TB, awsRegion string, bucket string) (string, error) {
 	s3Client, err := NewS3ClientE(t, awsRegion)
 	if err != nil {
 		return "", err
@@ -278,7 +436,7 @@ func GetS3BucketVersioningE(t *testing.T, awsRegion string, bucket string) (stri
 }
 
 // GetS3BucketPolicy fetches the given bucket's resource policy and returns it as a string
-func GetS3BucketPolicy(t *testing.T, awsRegion string, bucket string) string {
+func GetS3BucketPolicy(t testing.TB, awsRegion string, bucket string) string {
 	bucketPolicy, err := GetS3BucketPolicyE(t, awsRegion, bucket)
 	require.NoError(t, err)
 
@@ -286,7 +444,7 @@ func GetS3BucketPolicy(t *testing.T, awsRegion string, bucket string) string {
 }
 
 // GetS3BucketPolicyE fetches the given bucket's resource policy and returns it as a string
-func GetS3BucketPolicyE(t *testing.T, awsRegion string, bucket string) (string, error) {
+func GetS3BucketPolicyE(t testing.TB, awsRegion string, bucket string) (string, error) {
 	s3Client, err := NewS3ClientE(t, awsRegion)
 	if err != nil {
 		return "", err
@@ -303,13 +461,13 @@ func GetS3BucketPolicyE(t *testing.T, awsRegion string, bucket string) (string,
 }
 
 // AssertS3BucketExists checks if the given S3 bucket exists in the given region and fail the test if it does not.
-func AssertS3BucketExists(t *testing.T, region string, name string) {
+func AssertS3BucketExists(t testing.TB, region string, name string) {
 	err := AssertS3BucketExistsE(t, region, name)
 	require.NoError(t, err)
 }
 
 // AssertS3BucketExistsE checks if the given S3 bucket exists in the given region and return an error if it does not.
-func AssertS3BucketExistsE(t *testing.T, region string, name string) error {
+func AssertS3BucketExistsE(t testing.TB, region string, name string) error {
 	s3Client, err := NewS3ClientE(t, region)
 	if err != nil {
 		return err
@@ -323,13 +481,13 @@ func AssertS3BucketExistsE(t *testing.T, region string, name string) error {
 }
 
 // AssertS3BucketVersioningExists checks if the given S3 bucket has a versioning configuration enabled and returns an error if it does not.
-func AssertS3BucketVersioningExists(t *testing.T, region string, bucketName string) {
+func AssertS3BucketVersioningExists(t testing.TB, region string, bucketName string) {
 	err := AssertS3BucketVersioningExistsE(t, region, bucketName)
 	require.NoError(t, err)
 }
 
 // AssertS3BucketVersioningExistsE checks if the given S3 bucket has a versioning configuration enabled and returns an error if it does not.
-func AssertS3BucketVersioningExistsE(t *testing.T, region string, bucketName string) error {
+func AssertS3BucketVersioningExistsE(t testing.TB, region string, bucketName string) error {
 	status, err := GetS3BucketVersioningE(t, region, bucketName)
 	if err != nil {
 		return err
@@ -343,13 +501,13 @@ func AssertS3BucketVersioningExistsE(t *testing.T, region string, bucketName str
 }
 
 // AssertS3BucketPolicyExists checks if the given S3 bucket has a resource policy attached and returns an error if it does not
-func AssertS3BucketPolicyExists(t *testing.T, region string, bucketName string) {
+func AssertS3BucketPolicyExists(t testing.TB, region string, bucketName string) {
 	err := AssertS3BucketPolicyExistsE(t, region, bucketName)
 	require.NoError(t, err)
 }
 
 // AssertS3BucketPolicyExistsE checks if the given S3 bucket has a resource policy attached and returns an error if it does not
-func AssertS3BucketPolicyExistsE(t *testing.T, region string, bucketName string) error {
+func AssertS3BucketPolicyExistsE(t testing.TB, region string, bucketName string) error {
 	policy, err := GetS3BucketPolicyE(t, region, bucketName)
 	if err != nil {
 		return err
@@ -362,8 +520,52 @@ func AssertS3BucketPolicyExistsE(t *testing.T, region string, bucketName string)
 	}
 }
 
+// AssertS3BucketPolicy checks if the given S3 bucket has the expected resource policy attached and fails the test if
+// it does not.
+func AssertS3BucketPolicy(t testing.TB, region string, bucketName string, expectedPolicyJSONString string) {
+	err := AssertS3BucketPolicyE(t, region, bucketName, expectedPolicyJSONString)
+	require.NoError(t, err)
+}
+
+// AssertS3BucketPolicyE checks if the given S3 bucket has the expected resource policy attached and returns an error
+// if it does not. The policies are compared as JSON documents, so formatting and the order of keys don't matter, but
+// note that S3 may rewrite some values when it stores a policy (e.g. a Principal of "*" becomes {"AWS":"*"}).
+func AssertS3BucketPolicyE(t testing.TB, region string, bucketName string, expectedPolicyJSONString string) error {
+	policy, err := GetS3BucketPolicyE(t, region, bucketName)
+	if err != nil {
+		return err
+	}
+
+	if policy == "" {
+		return NewNoBucketPolicyError(bucketName, region, policy)
+	}
+
+	equal, err := jsonDocumentsAreEqual(expectedPolicyJSONString, policy)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		return NewBucketPolicyMismatchError(bucketName, region, expectedPolicyJSONString, policy)
+	}
+
+	return nil
+}
+
+// jsonDocumentsAreEqual returns true if the given strings contain the same JSON document, regardless of formatting
+// and the order of keys.
+func jsonDocumentsAreEqual(expected string, actual string) (bool, error) {
+	var expectedDocument, actualDocument interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedDocument); err != nil {
+		return false, fmt.Errorf("Failed to parse expected JSON document: %v", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &actualDocument); err != nil {
+		return false, fmt.Errorf("Failed to parse actual JSON document: %v", err)
+	}
+	return reflect.DeepEqual(expectedDocument, actualDocument), nil
+}
+
 // NewS3Client creates an S3 client.
-func NewS3Client(t *testing.T, region string) *s3.S3 {
+func NewS3Client(t testing.TB, region string) *s3.S3 {
 	client, err := NewS3ClientE(t, region)
 	require.NoError(t, err)
 
@@ -371,8 +573,8 @@ func NewS3Client(t *testing.T, region string) *s3.S3 {
 }
 
 // NewS3ClientE creates an S3 client.
-func NewS3ClientE(t *testing.T, region string) (*s3.S3, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewS3ClientE(t testing.TB, region string) (*s3.S3, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -381,15 +583,15 @@ func NewS3ClientE(t *testing.T, region string) (*s3.S3, error) {
 }
 
 // NewS3Uploader creates an S3 Uploader.
-func NewS3Uploader(t *testing.T, region string) *s3manager.Uploader {
+func NewS3Uploader(t testing.TB, region string) *s3manager.Uploader {
 	uploader, err := NewS3UploaderE(t, region)
 	require.NoError(t, err)
 	return uploader
 }
 
 // NewS3UploaderE creates an S3 Uploader.
-func NewS3UploaderE(t *testing.T, region string) (*s3manager.Uploader, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewS3UploaderE(t testing.TB, region string) (*s3manager.Uploader, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -146,6 +147,60 @@ func TestAssertS3BucketPolicyExists(t *testing.T) {
 
 }
 
+func TestGetObjectIdentifiersToDelete(t *testing.T) {
+	t.Parallel()
+
+	bucketObjects := &s3.ListObjectVersionsOutput{
+		Versions: []*s3.ObjectVersion{
+			{Key: aws.String("a"), VersionId: aws.String("a1")},
+			{Key: aws.String("a"), VersionId: aws.String("a2")},
+		},
+		DeleteMarkers: []*s3.DeleteMarkerEntry{
+			{Key: aws.String("b"), VersionId: aws.String("b1")},
+		},
+	}
+
+	expected := []*s3.ObjectIdentifier{
+		{Key: aws.String("a"), VersionId: aws.String("a1")},
+		{Key: aws.String("a"), VersionId: aws.String("a2")},
+		{Key: aws.String("b"), VersionId: aws.String("b1")},
+	}
+	assert.Equal(t, expected, getObjectIdentifiersToDelete(bucketObjects))
+	assert.Empty(t, getObjectIdentifiersToDelete(&s3.ListObjectVersionsOutput{}))
+}
+
+func TestJsonDocumentsAreEqual(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		expected string
+		actual   string
+		equal    bool
+	}{
+		{"identical", `{"Version":"2012-10-17"}`, `{"Version":"2012-10-17"}`, true},
+		{"formatting and key order", `{"Version": "2012-10-17", "Id": "x"}`, "{\n  \"Id\":\"x\",\n  \"Version\":\"2012-10-17\"\n}", true},
+		{"different value", `{"Version":"2012-10-17"}`, `{"Version":"2008-10-17"}`, false},
+		{"statement order", `{"Statement":[{"Sid":"a"},{"Sid":"b"}]}`, `{"Statement":[{"Sid":"b"},{"Sid":"a"}]}`, false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			equal, err := jsonDocumentsAreEqual(testCase.expected, testCase.actual)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.equal, equal)
+		})
+	}
+
+	_, err := jsonDocumentsAreEqual(`{"Version":`, `{}`)
+	assert.Error(t, err)
+}
+
 func testEmptyBucket(t *testing.T, s3Client *s3.S3, region string, s3BucketName string) {
 	expectedFileCount := rand.Intn(10000)
 
@@ -0,0 +1,196 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/stretchr/testify/require"
+)
+
+// CreateSecret creates a Secrets Manager secret with the given name and string value, e.g. to seed a fixture that the
+// code under test reads, and returns its ARN.
+func CreateSecret(t testing.TB, awsRegion string, name string, value string) string {
+	arn, err := CreateSecretE(t, awsRegion, name, value)
+	require.NoError(t, err)
+	return arn
+}
+
+// CreateSecretE creates a Secrets Manager secret with the given name and string value, e.g. to seed a fixture that the
+// code under test reads, and returns its ARN. You should defer a call to DeleteSecretE right away.
+func CreateSecretE(t testing.TB, awsRegion string, name string, value string) (string, error) {
+	if dryrun.Skip(t, "create secret %s in %s", name, awsRegion) {
+		return fmt.Sprintf("arn:aws:secretsmanager:%s:000000000000:secret:%s", awsRegion, dryrun.Id(name+"-")), nil
+	}
+
+	logger.Logf(t, "Creating secret %s", name)
+
+	client, err := NewSecretsManagerClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(value),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(resp.ARN), nil
+}
+
+// DeleteSecret deletes the given Secrets Manager secret. If forceDeleteWithoutRecovery is true, the secret is deleted
+// right away, so its name can be reused; otherwise it can still be restored during the default recovery window.
+func DeleteSecret(t testing.TB, awsRegion string, secretID string, forceDeleteWithoutRecovery bool) {
+	err := DeleteSecretE(t, awsRegion, secretID, forceDeleteWithoutRecovery)
+	require.NoError(t, err)
+}
+
+// DeleteSecretE deletes the given Secrets Manager secret. If forceDeleteWithoutRecovery is true, the secret is deleted
+// right away, so its name can be reused; otherwise it can still be restored during the default recovery window.
+func DeleteSecretE(t testing.TB, awsRegion string, secretID string, forceDeleteWithoutRecovery bool) error {
+	if dryrun.Skip(t, "delete secret %s in %s", secretID, awsRegion) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting secret %s", secretID)
+
+	client, err := NewSecretsManagerClientE(t, awsRegion)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(secretID),
+		ForceDeleteWithoutRecovery: aws.Bool(forceDeleteWithoutRecovery),
+	})
+	return err
+}
+
+// GetSecretValue gets the current value (the AWSCURRENT version) of the given Secrets Manager secret.
+func GetSecretValue(t testing.TB, awsRegion string, secretID string) string {
+	value, err := GetSecretValueE(t, awsRegion, secretID)
+	require.NoError(t, err)
+	return value
+}
+
+// GetSecretValueE gets the current value (the AWSCURRENT version) of the given Secrets Manager secret.
+func GetSecretValueE(t testing.TB, awsRegion string, secretID string) (string, error) {
+	logger.Logf(t, "Getting value of secret %s", secretID)
+
+	client, err := NewSecretsManagerClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.GetSecretValue(&secretsmanager.GetSecretValueInput{SecretId: aws.String(secretID)})
+	if err != nil {
+		return "", wrapAwsError(err, "Secrets Manager secret", secretID, awsRegion)
+	}
+
+	return aws.StringValue(resp.SecretString), nil
+}
+
+// GetCurrentSecretVersionId gets the ID of the current version (the one labeled AWSCURRENT) of the given Secrets
+// Manager secret.
+func GetCurrentSecretVersionId(t testing.TB, awsRegion string, secretID string) string {
+	versionID, err := GetCurrentSecretVersionIdE(t, awsRegion, secretID)
+	require.NoError(t, err)
+	return versionID
+}
+
+// GetCurrentSecretVersionIdE gets the ID of the current version (the one labeled AWSCURRENT) of the given Secrets
+// Manager secret.
+func GetCurrentSecretVersionIdE(t testing.TB, awsRegion string, secretID string) (string, error) {
+	client, err := NewSecretsManagerClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.DescribeSecret(&secretsmanager.DescribeSecretInput{SecretId: aws.String(secretID)})
+	if err != nil {
+		return "", err
+	}
+
+	for versionID, stages := range resp.VersionIdsToStages {
+		for _, stage := range stages {
+			if aws.StringValue(stage) == "AWSCURRENT" {
+				return versionID, nil
+			}
+		}
+	}
+
+	return "", NewNotFoundError("AWSCURRENT version of secret", secretID, awsRegion)
+}
+
+// RotateSecret triggers the rotation Lambda function of the given Secrets Manager secret and waits, retrying up to
+// maxRetries times, until the rotation has finished and a new version is current. It returns the new value.
+func RotateSecret(t testing.TB, awsRegion string, secretID string, maxRetries int, sleepBetweenRetries time.Duration) string {
+	value, err := RotateSecretE(t, awsRegion, secretID, maxRetries, sleepBetweenRetries)
+	require.NoError(t, err)
+	return value
+}
+
+// RotateSecretE triggers the rotation Lambda function of the given Secrets Manager secret and waits, retrying up to
+// maxRetries times, until the rotation has finished and a new version is current. It returns the new value.
+func RotateSecretE(t testing.TB, awsRegion string, secretID string, maxRetries int, sleepBetweenRetries time.Duration) (string, error) {
+	if dryrun.Skip(t, "rotate secret %s in %s", secretID, awsRegion) {
+		return dryrun.Id(""), nil
+	}
+
+	previousVersionID, err := GetCurrentSecretVersionIdE(t, awsRegion, secretID)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := NewSecretsManagerClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	logger.Logf(t, "Rotating secret %s", secretID)
+	if _, err := client.RotateSecret(&secretsmanager.RotateSecretInput{SecretId: aws.String(secretID)}); err != nil {
+		return "", err
+	}
+
+	description := fmt.Sprintf("Waiting for rotation of secret %s to finish", secretID)
+	_, err = retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		versionID, err := GetCurrentSecretVersionIdE(t, awsRegion, secretID)
+		if err != nil {
+			return "", err
+		}
+		if versionID == previousVersionID {
+			return "", fmt.Errorf("Version %s of secret %s is still current", versionID, secretID)
+		}
+		return versionID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return GetSecretValueE(t, awsRegion, secretID)
+}
+
+// NewSecretsManagerClient creates a Secrets Manager client.
+func NewSecretsManagerClient(t testing.TB, region string) *secretsmanager.SecretsManager {
+	client, err := NewSecretsManagerClientE(t, region)
+	require.NoError(t, err)
+	return client
+}
+
+// NewSecretsManagerClientE creates a Secrets Manager client.
+func NewSecretsManagerClientE(t testing.TB, region string) (*secretsmanager.SecretsManager, error) {
+	sess, err := newTimedSession(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return secretsmanager.New(sess), nil
+}
@@ -0,0 +1,29 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsManagerMethods(t *testing.T) {
+	t.Parallel()
+
+	region := GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+	name := fmt.Sprintf("terratest-secret-%s", uniqueID)
+	value := fmt.Sprintf("test-secret-value-%s", uniqueID)
+
+	arn := CreateSecret(t, region, name, value)
+	defer DeleteSecret(t, region, arn, true)
+
+	assert.Regexp(t, fmt.Sprintf("^arn:aws:secretsmanager:%s:[0-9]{12}:secret:%s-.+$", region, name), arn)
+	assert.Equal(t, value, GetSecretValue(t, region, name))
+
+	versionID, err := GetCurrentSecretVersionIdE(t, region, arn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, versionID)
+}
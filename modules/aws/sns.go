@@ -1,15 +1,17 @@
 package aws
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 // CreateSnsTopic creates an SNS Topic and return the ARN.
-func CreateSnsTopic(t *testing.T, region string, snsTopicName string) string {
+func CreateSnsTopic(t testing.TB, region string, snsTopicName string) string {
 	out, err := CreateSnsTopicE(t, region, snsTopicName)
 	if err != nil {
 		t.Fatal(err)
@@ -18,7 +20,11 @@ func CreateSnsTopic(t *testing.T, region string, snsTopicName string) string {
 }
 
 // CreateSnsTopicE creates an SNS Topic and return the ARN.
-func CreateSnsTopicE(t *testing.T, region string, snsTopicName string) (string, error) {
+func CreateSnsTopicE(t testing.TB, region string, snsTopicName string) (string, error) {
+	if dryrun.Skip(t, "create SNS topic %s in %s", snsTopicName, region) {
+		return fmt.Sprintf("arn:aws:sns:%s:000000000000:%s", region, snsTopicName), nil
+	}
+
 	logger.Logf(t, "Creating SNS topic %s in %s", snsTopicName, region)
 
 	snsClient, err := NewSnsClientE(t, region)
@@ -39,7 +45,7 @@ func CreateSnsTopicE(t *testing.T, region string, snsTopicName string) (string,
 }
 
 // DeleteSNSTopic deletes an SNS Topic.
-func DeleteSNSTopic(t *testing.T, region string, snsTopicArn string) {
+func DeleteSNSTopic(t testing.TB, region string, snsTopicArn string) {
 	err := DeleteSNSTopicE(t, region, snsTopicArn)
 	if err != nil {
 		t.Fatal(err)
@@ -47,7 +53,11 @@ func DeleteSNSTopic(t *testing.T, region string, snsTopicArn string) {
 }
 
 // DeleteSNSTopicE deletes an SNS Topic.
-func DeleteSNSTopicE(t *testing.T, region string, snsTopicArn string) error {
+func DeleteSNSTopicE(t testing.TB, region string, snsTopicArn string) error {
+	if dryrun.Skip(t, "delete SNS topic %s in %s", snsTopicArn, region) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting SNS topic %s in %s", snsTopicArn, region)
 
 	snsClient, err := NewSnsClientE(t, region)
@@ -63,8 +73,80 @@ func DeleteSNSTopicE(t *testing.T, region string, snsTopicArn string) error {
 	return err
 }
 
+// GetSnsTopicSubscriptions returns all the subscriptions of the given SNS Topic.
+func GetSnsTopicSubscriptions(t testing.TB, region string, snsTopicArn string) []*sns.Subscription {
+	out, err := GetSnsTopicSubscriptionsE(t, region, snsTopicArn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// GetSnsTopicSubscriptionsE returns all the subscriptions of the given SNS Topic.
+func GetSnsTopicSubscriptionsE(t testing.TB, region string, snsTopicArn string) ([]*sns.Subscription, error) {
+	snsClient, err := NewSnsClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := []*sns.Subscription{}
+	input := &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(snsTopicArn)}
+	err = snsClient.ListSubscriptionsByTopicPages(input, func(page *sns.ListSubscriptionsByTopicOutput, lastPage bool) bool {
+		subscriptions = append(subscriptions, page.Subscriptions...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// AssertSnsTopicSubscription checks that the given SNS Topic has a confirmed subscription with the given protocol (e.g.
+// sqs or lambda) and endpoint (e.g. the ARN of the queue or function), and fails the test if it does not.
+func AssertSnsTopicSubscription(t testing.TB, region string, snsTopicArn string, protocol string, endpoint string) {
+	err := AssertSnsTopicSubscriptionE(t, region, snsTopicArn, protocol, endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertSnsTopicSubscriptionE checks that the given SNS Topic has a confirmed subscription with the given protocol (e.g.
+// sqs or lambda) and endpoint (e.g. the ARN of the queue or function), and returns an error if it does not.
+// Subscriptions that are still pending confirmation, such as email subscriptions nobody has clicked through, don't count.
+func AssertSnsTopicSubscriptionE(t testing.TB, region string, snsTopicArn string, protocol string, endpoint string) error {
+	subscriptions, err := GetSnsTopicSubscriptionsE(t, region, snsTopicArn)
+	if err != nil {
+		return err
+	}
+
+	return checkSnsTopicSubscription(snsTopicArn, subscriptions, protocol, endpoint)
+}
+
+// checkSnsTopicSubscription returns an error if none of the given subscriptions of the given SNS Topic is a confirmed
+// subscription with the given protocol and endpoint.
+func checkSnsTopicSubscription(snsTopicArn string, subscriptions []*sns.Subscription, protocol string, endpoint string) error {
+	pendingConfirmation := false
+
+	for _, subscription := range subscriptions {
+		if aws.StringValue(subscription.Protocol) != protocol || aws.StringValue(subscription.Endpoint) != endpoint {
+			continue
+		}
+		if aws.StringValue(subscription.SubscriptionArn) == snsPendingConfirmation {
+			pendingConfirmation = true
+			continue
+		}
+		return nil
+	}
+
+	return NewSnsSubscriptionNotFoundError(snsTopicArn, protocol, endpoint, pendingConfirmation)
+}
+
+// The SubscriptionArn that SNS reports for subscriptions that haven't been confirmed yet
+const snsPendingConfirmation = "PendingConfirmation"
+
 // NewSnsClient creates a new SNS client.
-func NewSnsClient(t *testing.T, region string) *sns.SNS {
+func NewSnsClient(t testing.TB, region string) *sns.SNS {
 	client, err := NewSnsClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -73,8 +155,8 @@ func NewSnsClient(t *testing.T, region string) *sns.SNS {
 }
 
 // NewSnsClientE creates a new SNS client.
-func NewSnsClientE(t *testing.T, region string) (*sns.SNS, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewSnsClientE(t testing.TB, region string) (*sns.SNS, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
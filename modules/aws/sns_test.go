@@ -43,3 +43,44 @@ func deleteTopic(t *testing.T, region string, arn string) {
 	DeleteSNSTopic(t, region, arn)
 	assert.False(t, snsTopicExists(t, region, arn))
 }
+
+func TestCheckSnsTopicSubscription(t *testing.T) {
+	t.Parallel()
+
+	topicArn := "arn:aws:sns:us-east-1:000000000000:topic"
+	subscriptions := []*sns.Subscription{
+		{
+			Protocol:        aws.String("sqs"),
+			Endpoint:        aws.String("arn:aws:sqs:us-east-1:000000000000:queue"),
+			SubscriptionArn: aws.String(topicArn + ":0d7a0fa5"),
+		},
+		{
+			Protocol:        aws.String("email"),
+			Endpoint:        aws.String("ops@example.com"),
+			SubscriptionArn: aws.String("PendingConfirmation"),
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		protocol string
+		endpoint string
+		expected error
+	}{
+		{"confirmed", "sqs", "arn:aws:sqs:us-east-1:000000000000:queue", nil},
+		{"pending confirmation", "email", "ops@example.com", NewSnsSubscriptionNotFoundError(topicArn, "email", "ops@example.com", true)},
+		{"wrong protocol", "lambda", "arn:aws:sqs:us-east-1:000000000000:queue", NewSnsSubscriptionNotFoundError(topicArn, "lambda", "arn:aws:sqs:us-east-1:000000000000:queue", false)},
+		{"wrong endpoint", "sqs", "arn:aws:sqs:us-east-1:000000000000:other", NewSnsSubscriptionNotFoundError(topicArn, "sqs", "arn:aws:sqs:us-east-1:000000000000:other", false)},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, checkSnsTopicSubscription(topicArn, subscriptions, testCase.protocol, testCase.endpoint))
+		})
+	}
+}
@@ -9,11 +9,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/google/uuid"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 // CreateRandomQueue creates a new SQS queue with a random name that starts with the given prefix and return the queue URL.
-func CreateRandomQueue(t *testing.T, awsRegion string, prefix string) string {
+func CreateRandomQueue(t testing.TB, awsRegion string, prefix string) string {
 	url, err := CreateRandomQueueE(t, awsRegion, prefix)
 	if err != nil {
 		t.Fatal(err)
@@ -22,7 +23,11 @@ func CreateRandomQueue(t *testing.T, awsRegion string, prefix string) string {
 }
 
 // CreateRandomQueueE creates a new SQS queue with a random name that starts with the given prefix and return the queue URL.
-func CreateRandomQueueE(t *testing.T, awsRegion string, prefix string) (string, error) {
+func CreateRandomQueueE(t testing.TB, awsRegion string, prefix string) (string, error) {
+	if dryrun.Skip(t, "create SQS queue with prefix %s in %s", prefix, awsRegion) {
+		return fmt.Sprintf("https://sqs.%s.amazonaws.com/000000000000/%s", awsRegion, dryrun.Id(prefix+"-")), nil
+	}
+
 	logger.Logf(t, "Creating randomly named SQS queue with prefix %s", prefix)
 
 	sqsClient, err := NewSqsClientE(t, awsRegion)
@@ -49,7 +54,7 @@ func CreateRandomQueueE(t *testing.T, awsRegion string, prefix string) (string,
 }
 
 // DeleteQueue deletes the SQS queue with the given URL.
-func DeleteQueue(t *testing.T, awsRegion string, queueURL string) {
+func DeleteQueue(t testing.TB, awsRegion string, queueURL string) {
 	err := DeleteQueueE(t, awsRegion, queueURL)
 	if err != nil {
 		t.Fatal(err)
@@ -57,7 +62,11 @@ func DeleteQueue(t *testing.T, awsRegion string, queueURL string) {
 }
 
 // DeleteQueueE deletes the SQS queue with the given URL.
-func DeleteQueueE(t *testing.T, awsRegion string, queueURL string) error {
+func DeleteQueueE(t testing.TB, awsRegion string, queueURL string) error {
+	if dryrun.Skip(t, "delete SQS queue %s", queueURL) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting SQS Queue %s", queueURL)
 
 	sqsClient, err := NewSqsClientE(t, awsRegion)
@@ -73,7 +82,7 @@ func DeleteQueueE(t *testing.T, awsRegion string, queueURL string) error {
 }
 
 // DeleteMessageFromQueue deletes the message with the given receipt from the SQS queue with the given URL.
-func DeleteMessageFromQueue(t *testing.T, awsRegion string, queueURL string, receipt string) {
+func DeleteMessageFromQueue(t testing.TB, awsRegion string, queueURL string, receipt string) {
 	err := DeleteMessageFromQueueE(t, awsRegion, queueURL, receipt)
 	if err != nil {
 		t.Fatal(err)
@@ -81,7 +90,11 @@ func DeleteMessageFromQueue(t *testing.T, awsRegion string, queueURL string, rec
 }
 
 // DeleteMessageFromQueueE deletes the message with the given receipt from the SQS queue with the given URL.
-func DeleteMessageFromQueueE(t *testing.T, awsRegion string, queueURL string, receipt string) error {
+func DeleteMessageFromQueueE(t testing.TB, awsRegion string, queueURL string, receipt string) error {
+	if dryrun.Skip(t, "delete message from SQS queue %s", queueURL) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting message from queue %s (%s)", queueURL, receipt)
 
 	sqsClient, err := NewSqsClientE(t, awsRegion)
@@ -98,7 +111,7 @@ func DeleteMessageFromQueueE(t *testing.T, awsRegion string, queueURL string, re
 }
 
 // SendMessageToQueue sends the given message to the SQS queue with the given URL.
-func SendMessageToQueue(t *testing.T, awsRegion string, queueURL string, message string) {
+func SendMessageToQueue(t testing.TB, awsRegion string, queueURL string, message string) {
 	err := SendMessageToQueueE(t, awsRegion, queueURL, message)
 	if err != nil {
 		t.Fatal(err)
@@ -106,7 +119,11 @@ func SendMessageToQueue(t *testing.T, awsRegion string, queueURL string, message
 }
 
 // SendMessageToQueueE sends the given message to the SQS queue with the given URL.
-func SendMessageToQueueE(t *testing.T, awsRegion string, queueURL string, message string) error {
+func SendMessageToQueueE(t testing.TB, awsRegion string, queueURL string, message string) error {
+	if dryrun.Skip(t, "send message %s to queue %s", message, queueURL) {
+		return nil
+	}
+
 	logger.Logf(t, "Sending message %s to queue %s", message, queueURL)
 
 	sqsClient, err := NewSqsClientE(t, awsRegion)
@@ -121,7 +138,7 @@ func SendMessageToQueueE(t *testing.T, awsRegion string, queueURL string, messag
 
 	if err != nil {
 		if strings.Contains(err.Error(), "AWS.SimpleQueueService.NonExistentQueue") {
-			logger.Logf(t, fmt.Sprintf("WARN: Client has stopped listening on queue %s", queueURL))
+			logger.Logf(t, "WARN: Client has stopped listening on queue %s", queueURL)
 			return nil
 		}
 		return err
@@ -140,12 +157,24 @@ type QueueMessageResponse struct {
 }
 
 // WaitForQueueMessage waits to receive a message from on the queueURL. Since the API only allows us to wait a max 20 seconds for a new
-// message to arrive, we must loop TIMEOUT/20 number of times to be able to wait for a total of TIMEOUT seconds
-func WaitForQueueMessage(t *testing.T, awsRegion string, queueURL string, timeout int) QueueMessageResponse {
-	sqsClient, err := NewSqsClientE(t, awsRegion)
+// message to arrive, we must loop TIMEOUT/20 number of times to be able to wait for a total of TIMEOUT seconds. Any error is returned
+// in the Error field of the response rather than failing the test.
+func WaitForQueueMessage(t testing.TB, awsRegion string, queueURL string, timeout int) QueueMessageResponse {
+	response, err := WaitForQueueMessageE(t, awsRegion, queueURL, timeout)
 	if err != nil {
 		return QueueMessageResponse{Error: err}
 	}
+	return response
+}
+
+// WaitForQueueMessageE waits to receive a message from on the queueURL for up to the given number of seconds, and returns a
+// ReceiveMessageTimeout error if no message arrives in time. The message is not deleted from the queue, so call
+// DeleteMessageFromQueueE with its receipt handle once it has been processed.
+func WaitForQueueMessageE(t testing.TB, awsRegion string, queueURL string, timeout int) (QueueMessageResponse, error) {
+	sqsClient, err := NewSqsClientE(t, awsRegion)
+	if err != nil {
+		return QueueMessageResponse{}, err
+	}
 
 	cycles := timeout
 	cycleLength := 1
@@ -165,20 +194,20 @@ func WaitForQueueMessage(t *testing.T, awsRegion string, queueURL string, timeou
 		})
 
 		if err != nil {
-			return QueueMessageResponse{Error: err}
+			return QueueMessageResponse{}, err
 		}
 
 		if len(result.Messages) > 0 {
 			logger.Logf(t, "Message %s received on %s", *result.Messages[0].MessageId, queueURL)
-			return QueueMessageResponse{ReceiptHandle: *result.Messages[0].ReceiptHandle, MessageBody: *result.Messages[0].Body}
+			return QueueMessageResponse{ReceiptHandle: *result.Messages[0].ReceiptHandle, MessageBody: *result.Messages[0].Body}, nil
 		}
 	}
 
-	return QueueMessageResponse{Error: ReceiveMessageTimeout{QueueUrl: queueURL, TimeoutSec: timeout}}
+	return QueueMessageResponse{}, ReceiveMessageTimeout{QueueUrl: queueURL, TimeoutSec: timeout}
 }
 
 // NewSqsClient creates a new SQS client.
-func NewSqsClient(t *testing.T, region string) *sqs.SQS {
+func NewSqsClient(t testing.TB, region string) *sqs.SQS {
 	client, err := NewSqsClientE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -187,8 +216,8 @@ func NewSqsClient(t *testing.T, region string) *sqs.SQS {
 }
 
 // NewSqsClientE creates a new SQS client.
-func NewSqsClientE(t *testing.T, region string) (*sqs.SQS, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewSqsClientE(t testing.TB, region string) (*sqs.SQS, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/stretchr/testify/assert"
 )
@@ -57,3 +58,13 @@ func deleteQueue(t *testing.T, region string, url string) {
 	DeleteQueue(t, region, url)
 	assert.False(t, queueExists(t, region, url))
 }
+
+// This test does not call t.Parallel, as it enables dry run for the whole process.
+func TestSendMessageToQueueSkippedInDryRun(t *testing.T) {
+	restore := dryrun.Enable(t)
+	defer restore()
+
+	// The queue doesn't exist, so a message that is actually sent fails
+	queueURL := "https://sqs.us-east-1.amazonaws.com/000000000000/terratest-queue-that-does-not-exist"
+	assert.NoError(t, SendMessageToQueueE(t, "us-east-1", queueURL, "test-message"))
+}
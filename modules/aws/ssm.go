@@ -1,22 +1,27 @@
 package aws
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
 	"github.com/stretchr/testify/require"
 )
 
 // GetParameter retrieves the latest version of SSM Parameter at keyName with decryption.
-func GetParameter(t *testing.T, awsRegion string, keyName string) string {
+func GetParameter(t testing.TB, awsRegion string, keyName string) string {
 	keyValue, err := GetParameterE(t, awsRegion, keyName)
 	require.NoError(t, err)
 	return keyValue
 }
 
 // GetParameterE retrieves the latest version of SSM Parameter at keyName with decryption.
-func GetParameterE(t *testing.T, awsRegion string, keyName string) (string, error) {
+func GetParameterE(t testing.TB, awsRegion string, keyName string) (string, error) {
 	ssmClient, err := NewSsmClientE(t, awsRegion)
 	if err != nil {
 		return "", err
@@ -24,7 +29,7 @@ func GetParameterE(t *testing.T, awsRegion string, keyName string) (string, erro
 
 	resp, err := ssmClient.GetParameter(&ssm.GetParameterInput{Name: aws.String(keyName), WithDecryption: aws.Bool(true)})
 	if err != nil {
-		return "", err
+		return "", wrapAwsError(err, "SSM Parameter", keyName, awsRegion)
 	}
 
 	parameter := *resp.Parameter
@@ -32,14 +37,18 @@ func GetParameterE(t *testing.T, awsRegion string, keyName string) (string, erro
 }
 
 // PutParameter creates new version of SSM Parameter at keyName with keyValue as SecureString.
-func PutParameter(t *testing.T, awsRegion string, keyName string, keyDescription string, keyValue string) int64 {
+func PutParameter(t testing.TB, awsRegion string, keyName string, keyDescription string, keyValue string) int64 {
 	version, err := PutParameterE(t, awsRegion, keyName, keyDescription, keyValue)
 	require.NoError(t, err)
 	return version
 }
 
 // PutParameterE creates new version of SSM Parameter at keyName with keyValue as SecureString.
-func PutParameterE(t *testing.T, awsRegion string, keyName string, keyDescription string, keyValue string) (int64, error) {
+func PutParameterE(t testing.TB, awsRegion string, keyName string, keyDescription string, keyValue string) (int64, error) {
+	if dryrun.Skip(t, "put SSM parameter %s in %s", keyName, awsRegion) {
+		return 1, nil
+	}
+
 	ssmClient, err := NewSsmClientE(t, awsRegion)
 	if err != nil {
 		return 0, err
@@ -53,16 +62,108 @@ func PutParameterE(t *testing.T, awsRegion string, keyName string, keyDescriptio
 	return *resp.Version, nil
 }
 
+// CheckSsmCommand runs the given shell command on the given EC2 Instance using the SSM Agent (the AWS-RunShellScript
+// document), waits up to the given timeout for it to finish, and returns its stdout. This is an alternative to
+// ssh.CheckSshCommand for instances that are not reachable over SSH. The test fails if the command does not succeed.
+func CheckSsmCommand(t testing.TB, awsRegion string, instanceID string, command string, timeout time.Duration) string {
+	out, err := CheckSsmCommandE(t, awsRegion, instanceID, command, timeout)
+	require.NoError(t, err)
+	return out
+}
+
+// CheckSsmCommandE runs the given shell command on the given EC2 Instance using the SSM Agent (the AWS-RunShellScript
+// document), waits up to the given timeout for it to finish, and returns its stdout. This is an alternative to
+// ssh.CheckSshCommandE for instances that are not reachable over SSH. An SsmCommandFailed error is returned if the
+// command does not succeed.
+func CheckSsmCommandE(t testing.TB, awsRegion string, instanceID string, command string, timeout time.Duration) (string, error) {
+	return checkSsmCommandE(t, awsRegion, instanceID, "AWS-RunShellScript", command, timeout)
+}
+
+// CheckSsmPowerShellCommand runs the given PowerShell command on the given Windows EC2 Instance using the SSM Agent
+// (the AWS-RunPowerShellScript document), waits up to the given timeout for it to finish, and returns its stdout. The
+// test fails if the command does not succeed.
+func CheckSsmPowerShellCommand(t testing.TB, awsRegion string, instanceID string, command string, timeout time.Duration) string {
+	out, err := CheckSsmPowerShellCommandE(t, awsRegion, instanceID, command, timeout)
+	require.NoError(t, err)
+	return out
+}
+
+// CheckSsmPowerShellCommandE runs the given PowerShell command on the given Windows EC2 Instance using the SSM Agent
+// (the AWS-RunPowerShellScript document), waits up to the given timeout for it to finish, and returns its stdout. An
+// SsmCommandFailed error is returned if the command does not succeed.
+func CheckSsmPowerShellCommandE(t testing.TB, awsRegion string, instanceID string, command string, timeout time.Duration) (string, error) {
+	return checkSsmCommandE(t, awsRegion, instanceID, "AWS-RunPowerShellScript", command, timeout)
+}
+
+func checkSsmCommandE(t testing.TB, awsRegion string, instanceID string, documentName string, command string, timeout time.Duration) (string, error) {
+	logger.Logf(t, "Running command '%s' on EC2 Instance %s via SSM", command, instanceID)
+
+	ssmClient, err := NewSsmClientE(t, awsRegion)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := ssmClient.SendCommand(&ssm.SendCommandInput{
+		DocumentName: aws.String(documentName),
+		InstanceIds:  aws.StringSlice([]string{instanceID}),
+		Parameters:   map[string][]*string{"commands": aws.StringSlice([]string{command})},
+	})
+	if err != nil {
+		return "", err
+	}
+	commandID := aws.StringValue(resp.Command.CommandId)
+
+	sleepBetweenRetries := 2 * time.Second
+	maxRetries := int(timeout / sleepBetweenRetries)
+	description := fmt.Sprintf("Waiting for SSM command %s on EC2 Instance %s to finish", commandID, instanceID)
+
+	var invocation *ssm.GetCommandInvocationOutput
+	_, err = retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		// This returns an InvocationDoesNotExist error for a short while after the command is sent, so we retry
+		// on all errors
+		out, err := ssmClient.GetCommandInvocation(&ssm.GetCommandInvocationInput{
+			CommandId:  aws.String(commandID),
+			InstanceId: aws.String(instanceID),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		status := aws.StringValue(out.Status)
+		switch status {
+		case ssm.CommandInvocationStatusPending, ssm.CommandInvocationStatusInProgress, ssm.CommandInvocationStatusDelayed:
+			return "", fmt.Errorf("SSM command %s is %s", commandID, status)
+		}
+
+		invocation = out
+		return status, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if aws.StringValue(invocation.Status) != ssm.CommandInvocationStatusSuccess {
+		return aws.StringValue(invocation.StandardOutputContent), SsmCommandFailed{
+			InstanceId: instanceID,
+			CommandId:  commandID,
+			Status:     aws.StringValue(invocation.Status),
+			Stderr:     aws.StringValue(invocation.StandardErrorContent),
+		}
+	}
+
+	return aws.StringValue(invocation.StandardOutputContent), nil
+}
+
 // NewSsmClient creates a SSM client.
-func NewSsmClient(t *testing.T, region string) *ssm.SSM {
+func NewSsmClient(t testing.TB, region string) *ssm.SSM {
 	client, err := NewSsmClientE(t, region)
 	require.NoError(t, err)
 	return client
 }
 
 // NewSsmClientE creates an SSM client.
-func NewSsmClientE(t *testing.T, region string) (*ssm.SSM, error) {
-	sess, err := NewAuthenticatedSession(region)
+func NewSsmClientE(t testing.TB, region string) (*ssm.SSM, error) {
+	sess, err := newTimedSession(t, region)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +29,7 @@ var isDefaultFilterName = "isDefault"
 var isDefaultFilterValue = "true"
 
 // GetDefaultVpc fetches information about the default VPC in the given region.
-func GetDefaultVpc(t *testing.T, region string) *Vpc {
+func GetDefaultVpc(t testing.TB, region string) *Vpc {
 	vpc, err := GetDefaultVpcE(t, region)
 	if err != nil {
 		t.Fatal(err)
@@ -38,7 +38,7 @@ func GetDefaultVpc(t *testing.T, region string) *Vpc {
 }
 
 // GetDefaultVpcE fetches information about the default VPC in the given region.
-func GetDefaultVpcE(t *testing.T, region string) (*Vpc, error) {
+func GetDefaultVpcE(t testing.TB, region string) (*Vpc, error) {
 	client, err := NewEc2ClientE(t, region)
 	if err != nil {
 		return nil, err
@@ -82,7 +82,7 @@ func FindVpcName(vpc *ec2.Vpc) string {
 }
 
 // GetSubnetsForVpc gets the subnets in the specified VPC.
-func GetSubnetsForVpc(t *testing.T, vpcID string, region string) []Subnet {
+func GetSubnetsForVpc(t testing.TB, vpcID string, region string) []Subnet {
 	subnets, err := GetSubnetsForVpcE(t, vpcID, region)
 	if err != nil {
 		t.Fatal(err)
@@ -91,7 +91,7 @@ func GetSubnetsForVpc(t *testing.T, vpcID string, region string) []Subnet {
 }
 
 // GetSubnetsForVpcE gets the subnets in the specified VPC.
-func GetSubnetsForVpcE(t *testing.T, vpcID string, region string) ([]Subnet, error) {
+func GetSubnetsForVpcE(t testing.TB, vpcID string, region string) ([]Subnet, error) {
 	client, err := NewEc2ClientE(t, region)
 	if err != nil {
 		return nil, err
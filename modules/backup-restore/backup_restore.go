@@ -0,0 +1,85 @@
+// Package backup_restore contains a harness for proving that backups can actually be restored: it writes known data,
+// takes a backup using the mechanism of the module under test, restores it into a throwaway resource, and checks that
+// the data survived the round trip.
+package backup_restore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// Steps are the resource-specific steps of a backup and restore round trip. For example, for an RDS database Backup
+// could call aws.CreateRdsSnapshotE and Restore could call aws.RestoreRdsInstanceFromSnapshotE, while for a Persistent
+// Disk they could call gcp.CreateDiskSnapshotE and gcp.CreateDiskFromSnapshotE.
+type Steps struct {
+	// Writes known data to the resource under test and returns it, or a checksum of it, to compare against later
+	WriteData func() (string, error)
+
+	// Takes a backup of the resource under test and returns an ID for it, e.g. a snapshot ID. To test the module's
+	// own backup mechanism (e.g. a scheduled backup), wait for that backup to appear here instead of creating one.
+	Backup func() (string, error)
+
+	// Restores the backup with the given ID into a new, throwaway resource and returns an ID for that resource
+	Restore func(backupID string) (string, error)
+
+	// Reads the data back from the restored resource with the given ID, in the same form WriteData returned it
+	ReadData func(restoredID string) (string, error)
+
+	// Optional. Deletes the restored resource and the backup. Called even if reading the data back fails.
+	Cleanup func(backupID string, restoredID string) error
+}
+
+// VerifyBackupRestore runs a backup and restore round trip using the given steps, and fails the test if any step
+// fails or the data read from the restored resource does not match the data written before the backup.
+func VerifyBackupRestore(t testing.TB, steps Steps) {
+	err := VerifyBackupRestoreE(t, steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// VerifyBackupRestoreE runs a backup and restore round trip using the given steps, and returns an error if any step
+// fails or the data read from the restored resource does not match the data written before the backup.
+func VerifyBackupRestoreE(t testing.TB, steps Steps) (err error) {
+	logger.Log(t, "Writing known data before taking a backup")
+	written, err := steps.WriteData()
+	if err != nil {
+		return fmt.Errorf("Failed to write data before backup: %v", err)
+	}
+
+	logger.Log(t, "Taking a backup")
+	backupID, err := steps.Backup()
+	if err != nil {
+		return fmt.Errorf("Failed to take backup: %v", err)
+	}
+
+	logger.Logf(t, "Restoring backup %s", backupID)
+	restoredID, err := steps.Restore(backupID)
+	if err != nil {
+		return fmt.Errorf("Failed to restore backup %s: %v", backupID, err)
+	}
+
+	if steps.Cleanup != nil {
+		defer func() {
+			logger.Logf(t, "Cleaning up backup %s and restored resource %s", backupID, restoredID)
+			if cleanupErr := steps.Cleanup(backupID, restoredID); cleanupErr != nil && err == nil {
+				err = fmt.Errorf("Failed to clean up backup %s and restored resource %s: %v", backupID, restoredID, cleanupErr)
+			}
+		}()
+	}
+
+	logger.Logf(t, "Reading data back from restored resource %s", restoredID)
+	read, err := steps.ReadData(restoredID)
+	if err != nil {
+		return fmt.Errorf("Failed to read data from restored resource %s: %v", restoredID, err)
+	}
+
+	if read != written {
+		return fmt.Errorf("Data in restored resource %s does not match the data written before backup %s was taken. Expected '%s', but got '%s'", restoredID, backupID, written, read)
+	}
+
+	logger.Logf(t, "Data in restored resource %s matches the data written before backup %s was taken", restoredID, backupID)
+	return nil
+}
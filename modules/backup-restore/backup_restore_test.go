@@ -0,0 +1,78 @@
+package backup_restore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore is an in-memory resource whose backups are copies of its data
+type fakeStore struct {
+	data     string
+	backups  map[string]string
+	restored map[string]string
+	cleaned  bool
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{backups: map[string]string{}, restored: map[string]string{}}
+}
+
+func (store *fakeStore) steps(corrupt bool) Steps {
+	return Steps{
+		WriteData: func() (string, error) {
+			store.data = "canary-123"
+			return store.data, nil
+		},
+		Backup: func() (string, error) {
+			store.backups["backup-1"] = store.data
+			return "backup-1", nil
+		},
+		Restore: func(backupID string) (string, error) {
+			data := store.backups[backupID]
+			if corrupt {
+				data = "garbage"
+			}
+			store.restored["restored-1"] = data
+			return "restored-1", nil
+		},
+		ReadData: func(restoredID string) (string, error) {
+			return store.restored[restoredID], nil
+		},
+		Cleanup: func(backupID string, restoredID string) error {
+			store.cleaned = true
+			return nil
+		},
+	}
+}
+
+func TestVerifyBackupRestoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	VerifyBackupRestore(t, store.steps(false))
+	assert.True(t, store.cleaned)
+}
+
+func TestVerifyBackupRestoreDetectsCorruptData(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	err := VerifyBackupRestoreE(t, store.steps(true))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match")
+	assert.True(t, store.cleaned)
+}
+
+func TestVerifyBackupRestoreReportsCleanupErrors(t *testing.T) {
+	t.Parallel()
+
+	steps := newFakeStore().steps(false)
+	steps.Cleanup = func(backupID string, restoredID string) error { return errors.New("snapshot in use") }
+
+	err := VerifyBackupRestoreE(t, steps)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "snapshot in use")
+}
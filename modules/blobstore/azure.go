@@ -0,0 +1,264 @@
+package blobstore
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/vcr"
+)
+
+// The version of the Azure Blob Storage REST API to use
+const azureStorageApiVersion = "2018-03-28"
+
+// AzureStore is a Store backed by an Azure Blob Storage container. It calls the Blob Storage REST API directly, through
+// the active vcr recorder if there is one, and authenticates with a shared access signature (SAS) token, which must
+// allow reading, writing, listing, and deleting blobs in the container. Signing URLs for individual blobs also needs
+// the key of the storage account.
+type AzureStore struct {
+	Account    string
+	Container  string
+	SasToken   string // The SAS token, with or without the leading ?
+	Endpoint   string // The Blob Storage endpoint. Defaults to https://<Account>.blob.core.windows.net.
+	AccountKey string // The base64 encoded key of the storage account, which SignedUrlE signs URLs with. Optional.
+}
+
+// NewAzureStore returns a Store for the given Azure Blob Storage container that authenticates with the given SAS token.
+func NewAzureStore(account string, container string, sasToken string) *AzureStore {
+	return &AzureStore{Account: account, Container: container, SasToken: sasToken}
+}
+
+// PutE uploads the contents of body to the block blob with the given key.
+func (store *AzureStore) PutE(t testing.TB, key string, body io.Reader) error {
+	if dryrun.Skip(t, "write blob %s to container %s", key, store.Container) {
+		return nil
+	}
+
+	logger.Logf(t, "Writing blob %s to container %s", key, store.Container)
+
+	contents, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", store.blobUrl(key), bytes.NewReader(contents))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+
+	_, err = store.do(req, http.StatusCreated)
+	return err
+}
+
+// GetE returns the contents of the blob with the given key.
+func (store *AzureStore) GetE(t testing.TB, key string) (string, error) {
+	logger.Logf(t, "Reading blob %s from container %s", key, store.Container)
+
+	req, err := http.NewRequest("GET", store.blobUrl(key), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return store.do(req, http.StatusOK)
+}
+
+// ListE returns the keys of the blobs that start with the given prefix.
+func (store *AzureStore) ListE(t testing.TB, prefix string) ([]string, error) {
+	logger.Logf(t, "Listing blobs in container %s with prefix %s", store.Container, prefix)
+
+	keys := []string{}
+	marker := ""
+	for {
+		query := url.Values{}
+		query.Set("restype", "container")
+		query.Set("comp", "list")
+		query.Set("prefix", prefix)
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		req, err := http.NewRequest("GET", store.withSasToken(store.endpoint()+"/"+url.PathEscape(store.Container)+"?"+query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := store.do(req, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+
+		var results azureEnumerationResults
+		if err := xml.Unmarshal([]byte(body), &results); err != nil {
+			return nil, err
+		}
+
+		for _, blob := range results.Blobs {
+			keys = append(keys, blob.Name)
+		}
+
+		if results.NextMarker == "" {
+			return keys, nil
+		}
+		marker = results.NextMarker
+	}
+}
+
+// DeleteE deletes the blob with the given key.
+func (store *AzureStore) DeleteE(t testing.TB, key string) error {
+	if dryrun.Skip(t, "delete blob %s from container %s", key, store.Container) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting blob %s from container %s", key, store.Container)
+
+	req, err := http.NewRequest("DELETE", store.blobUrl(key), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.do(req, http.StatusAccepted)
+	return err
+}
+
+// SignedUrlE returns a URL that grants anyone who has it access to the blob with the given key for the given HTTP
+// method (GET or PUT) until it expires. The URL carries a service SAS signed with the AccountKey of the store, so this
+// returns an error if AccountKey is not set.
+func (store *AzureStore) SignedUrlE(t testing.TB, key string, method string, expiry time.Duration) (string, error) {
+	permissions, supported := azureSasPermissions[method]
+	if !supported {
+		return "", fmt.Errorf("Signed Azure Blob Storage URLs are only supported for GET and PUT, not %s", method)
+	}
+	if expiry <= 0 {
+		return "", fmt.Errorf("The expiry of a signed URL must be positive, got %s", expiry)
+	}
+	if store.AccountKey == "" {
+		return "", fmt.Errorf("Signing Azure Blob Storage URLs requires the AccountKey of storage account %s", store.Account)
+	}
+
+	accountKey, err := base64.StdEncoding.DecodeString(store.AccountKey)
+	if err != nil {
+		return "", fmt.Errorf("The AccountKey of storage account %s is not valid base64: %v", store.Account, err)
+	}
+
+	logger.Logf(t, "Signing URL to %s blob %s in container %s for %s", method, key, store.Container, expiry)
+
+	expiresAt := time.Now().UTC().Add(expiry).Format(time.RFC3339)
+	signature := signAzureServiceSas(accountKey, permissions, expiresAt, fmt.Sprintf("/blob/%s/%s/%s", store.Account, store.Container, key))
+
+	query := url.Values{}
+	query.Set("sv", azureStorageApiVersion)
+	query.Set("sr", "b")
+	query.Set("sp", permissions)
+	query.Set("se", expiresAt)
+	query.Set("sig", signature)
+
+	return store.blobPath(key) + "?" + query.Encode(), nil
+}
+
+// azureSasPermissions maps the HTTP methods SignedUrlE supports to the SAS permissions they need
+var azureSasPermissions = map[string]string{
+	"GET": "r",
+	"PUT": "cw",
+}
+
+// signAzureServiceSas returns the signature of a service SAS for the given canonicalized resource, with the given
+// permissions and expiry, in the format of the version of the REST API we use. See
+// https://docs.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+func signAzureServiceSas(accountKey []byte, permissions string, expiresAt string, canonicalizedResource string) string {
+	stringToSign := strings.Join([]string{
+		permissions,
+		"", // signed start
+		expiresAt,
+		canonicalizedResource,
+		"", // signed identifier
+		"", // signed IP
+		"", // signed protocol
+		azureStorageApiVersion,
+		"", // cache control
+		"", // content disposition
+		"", // content encoding
+		"", // content language
+		"", // content type
+	}, "\n")
+
+	mac := hmac.New(sha256.New, accountKey)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (store *AzureStore) endpoint() string {
+	if store.Endpoint != "" {
+		return strings.TrimSuffix(store.Endpoint, "/")
+	}
+	return fmt.Sprintf("https://%s.blob.core.windows.net", store.Account)
+}
+
+// blobUrl returns the URL of the blob with the given key, including the SAS token.
+func (store *AzureStore) blobUrl(key string) string {
+	return store.withSasToken(store.blobPath(key))
+}
+
+// blobPath returns the URL of the blob with the given key, without any query string.
+func (store *AzureStore) blobPath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return store.endpoint() + "/" + url.PathEscape(store.Container) + "/" + strings.Join(segments, "/")
+}
+
+func (store *AzureStore) withSasToken(rawUrl string) string {
+	token := strings.TrimPrefix(store.SasToken, "?")
+	if token == "" {
+		return rawUrl
+	}
+	if strings.Contains(rawUrl, "?") {
+		return rawUrl + "&" + token
+	}
+	return rawUrl + "?" + token
+}
+
+// do sends the given request and returns the response body, or an error if the response status is not the expected
+// one.
+func (store *AzureStore) do(req *http.Request, expectedStatus int) (string, error) {
+	req.Header.Set("x-ms-version", azureStorageApiVersion)
+
+	resp, err := vcr.HttpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return "", fmt.Errorf("%s %s returned status %d (expected %d): %s", req.Method, req.URL.Path, resp.StatusCode, expectedStatus, string(body))
+	}
+
+	return string(body), nil
+}
+
+// azureEnumerationResults is the part of the List Blobs response that we use
+type azureEnumerationResults struct {
+	Blobs []struct {
+		Name string `xml:"Name"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
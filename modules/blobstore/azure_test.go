@@ -0,0 +1,179 @@
+package blobstore
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAzureContainer implements just enough of the Blob Storage REST API to test AzureStore. It returns one blob per
+// page of List Blobs results so that paging is exercised. Requests are authorized by the SAS token "sig=secret", or by a
+// service SAS for the blob signed with accountKey.
+type fakeAzureContainer struct {
+	mutex      sync.Mutex
+	blobs      map[string]string
+	accountKey []byte
+}
+
+func (container *fakeAzureContainer) authorized(r *http.Request) bool {
+	query := r.URL.Query()
+	if query.Get("sig") == "secret" {
+		return true
+	}
+
+	permission := map[string]string{"GET": "r", "PUT": "w"}[r.Method]
+	if query.Get("sr") != "b" || permission == "" || !strings.Contains(query.Get("sp"), permission) {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, query.Get("se"))
+	if err != nil || time.Now().After(expiresAt) {
+		return false
+	}
+
+	expected := signAzureServiceSas(container.accountKey, query.Get("sp"), query.Get("se"), "/blob/account"+r.URL.Path)
+	return query.Get("sig") == expected
+}
+
+func (container *fakeAzureContainer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if !container.authorized(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/container/")
+	switch {
+	case r.Method == "GET" && r.URL.Query().Get("comp") == "list":
+		keys := []string{}
+		for name := range container.blobs {
+			if strings.HasPrefix(name, r.URL.Query().Get("prefix")) && name > r.URL.Query().Get("marker") {
+				keys = append(keys, name)
+			}
+		}
+		sort.Strings(keys)
+
+		fmt.Fprint(w, "<EnumerationResults><Blobs>")
+		if len(keys) > 0 {
+			fmt.Fprintf(w, "<Blob><Name>%s</Name></Blob>", keys[0])
+		}
+		fmt.Fprint(w, "</Blobs>")
+		if len(keys) > 1 {
+			fmt.Fprintf(w, "<NextMarker>%s</NextMarker>", keys[0])
+		}
+		fmt.Fprint(w, "</EnumerationResults>")
+	case r.Method == "PUT":
+		body, _ := ioutil.ReadAll(r.Body)
+		container.blobs[key] = string(body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == "GET":
+		contents, exists := container.blobs[key]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, contents)
+	case r.Method == "DELETE":
+		delete(container.blobs, key)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestAzureStore(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(&fakeAzureContainer{blobs: map[string]string{}, accountKey: []byte("account-key")})
+	defer server.Close()
+
+	store := NewAzureStore("account", "container", "?sig=secret")
+	store.Endpoint = server.URL
+	store.AccountKey = base64.StdEncoding.EncodeToString([]byte("account-key"))
+
+	Put(t, store, "fixtures/a.txt", "alpha")
+	Put(t, store, "fixtures/b.txt", "beta")
+	Put(t, store, "other/c.txt", "gamma")
+
+	assert.Equal(t, "beta", Get(t, store, "fixtures/b.txt"))
+	assert.Equal(t, []string{"fixtures/a.txt", "fixtures/b.txt"}, List(t, store, "fixtures/"))
+
+	Delete(t, store, "fixtures/a.txt")
+	assert.Equal(t, []string{"fixtures/b.txt"}, List(t, store, "fixtures/"))
+
+	_, err := store.GetE(t, "fixtures/a.txt")
+	require.Error(t, err)
+
+	url := SignedUrl(t, store, "fixtures/b.txt", "GET", time.Hour)
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "beta", string(body))
+
+	url = SignedUrl(t, store, "fixtures/d.txt", "PUT", time.Hour)
+	req, err := http.NewRequest("PUT", url, strings.NewReader("delta"))
+	require.NoError(t, err)
+	putResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	putResp.Body.Close()
+	assert.Equal(t, http.StatusCreated, putResp.StatusCode)
+	assert.Equal(t, "delta", Get(t, store, "fixtures/d.txt"))
+
+	// A URL signed for GET doesn't allow writing
+	url = SignedUrl(t, store, "fixtures/d.txt", "GET", time.Hour)
+	req, err = http.NewRequest("PUT", url, strings.NewReader("epsilon"))
+	require.NoError(t, err)
+	putResp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	putResp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, putResp.StatusCode)
+}
+
+func TestAzureSignedUrlRequiresAccountKey(t *testing.T) {
+	t.Parallel()
+
+	store := NewAzureStore("account", "container", "sv=2018-03-28&sig=abc")
+
+	_, err := store.SignedUrlE(t, "a.txt", "GET", time.Hour)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccountKey")
+}
+
+func TestSignAzureServiceSas(t *testing.T) {
+	t.Parallel()
+
+	signature := signAzureServiceSas([]byte("account-key"), "r", "2019-01-01T01:00:00Z", "/blob/account/container/dir/my file.txt")
+	assert.Equal(t, "WMkSIhTpXUFokS/dxtWUdmJRq3O/It13Ng6zcjCHNGE=", signature)
+}
+
+func TestAzureStoreRequiresSasToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(&fakeAzureContainer{blobs: map[string]string{}})
+	defer server.Close()
+
+	store := NewAzureStore("account", "container", "")
+	store.Endpoint = server.URL
+
+	err := store.PutE(t, "a.txt", strings.NewReader("alpha"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
+
+func TestAzureBlobUrl(t *testing.T) {
+	t.Parallel()
+
+	store := NewAzureStore("account", "container", "sv=2018-03-28&sig=abc")
+	assert.Equal(t, "https://account.blob.core.windows.net/container/dir/my%20file.txt?sv=2018-03-28&sig=abc", store.blobUrl("dir/my file.txt"))
+}
@@ -0,0 +1,69 @@
+// Package blobstore provides a provider agnostic interface to object storage (GCS, S3, and Azure Blob Storage), so
+// tests for multi-cloud modules can share the code that stages fixtures and checks the objects the module wrote.
+package blobstore
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Store is a bucket (or container) in an object storage service.
+type Store interface {
+	// PutE uploads the contents of body to the object with the given key.
+	PutE(t testing.TB, key string, body io.Reader) error
+	// GetE returns the contents of the object with the given key.
+	GetE(t testing.TB, key string) (string, error)
+	// ListE returns the keys of the objects that start with the given prefix.
+	ListE(t testing.TB, prefix string) ([]string, error)
+	// DeleteE deletes the object with the given key.
+	DeleteE(t testing.TB, key string) error
+	// SignedUrlE returns a URL that grants anyone who has it access to the object with the given key for the given
+	// HTTP method (GET or PUT) until it expires.
+	SignedUrlE(t testing.TB, key string, method string, expiry time.Duration) (string, error)
+}
+
+// Put uploads the given contents to the object with the given key, failing the test on error.
+func Put(t testing.TB, store Store, key string, contents string) {
+	err := store.PutE(t, key, strings.NewReader(contents))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Get returns the contents of the object with the given key, failing the test on error.
+func Get(t testing.TB, store Store, key string) string {
+	contents, err := store.GetE(t, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contents
+}
+
+// List returns the keys of the objects that start with the given prefix, failing the test on error.
+func List(t testing.TB, store Store, prefix string) []string {
+	keys, err := store.ListE(t, prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// Delete deletes the object with the given key, failing the test on error.
+func Delete(t testing.TB, store Store, key string) {
+	err := store.DeleteE(t, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// SignedUrl returns a URL that grants anyone who has it access to the object with the given key for the given HTTP
+// method (GET or PUT) until it expires, failing the test on error.
+func SignedUrl(t testing.TB, store Store, key string, method string, expiry time.Duration) string {
+	url, err := store.SignedUrlE(t, key, method, expiry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return url
+}
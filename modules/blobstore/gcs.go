@@ -0,0 +1,61 @@
+package blobstore
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/gcp"
+)
+
+// GcsStore is a Store backed by a Google Cloud Storage bucket. It uses the default Google credentials, and signing
+// URLs requires those to be a service account key.
+type GcsStore struct {
+	Bucket string
+}
+
+// NewGcsStore returns a Store for the given Google Cloud Storage bucket.
+func NewGcsStore(bucket string) *GcsStore {
+	return &GcsStore{Bucket: bucket}
+}
+
+// PutE uploads the contents of body to the object with the given key.
+func (store *GcsStore) PutE(t testing.TB, key string, body io.Reader) error {
+	_, err := gcp.WriteBucketObjectE(t, store.Bucket, key, body, "")
+	return err
+}
+
+// GetE returns the contents of the object with the given key.
+func (store *GcsStore) GetE(t testing.TB, key string) (string, error) {
+	reader, err := gcp.ReadBucketObjectE(t, store.Bucket, key)
+	if err != nil {
+		return "", err
+	}
+	if closer, isCloser := reader.(io.Closer); isCloser {
+		defer closer.Close()
+	}
+
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents), nil
+}
+
+// ListE returns the keys of the objects that start with the given prefix.
+func (store *GcsStore) ListE(t testing.TB, prefix string) ([]string, error) {
+	return gcp.ListBucketObjectsE(t, store.Bucket, prefix)
+}
+
+// DeleteE deletes the object with the given key.
+func (store *GcsStore) DeleteE(t testing.TB, key string) error {
+	return gcp.DeleteBucketObjectE(t, store.Bucket, key)
+}
+
+// SignedUrlE returns a URL that grants anyone who has it access to the object with the given key for the given HTTP
+// method until it expires.
+func (store *GcsStore) SignedUrlE(t testing.TB, key string, method string, expiry time.Duration) (string, error) {
+	return gcp.GetSignedBucketObjectUrlE(t, store.Bucket, key, method, expiry)
+}
@@ -0,0 +1,46 @@
+package blobstore
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+)
+
+// S3Store is a Store backed by an S3 bucket. It uses the default AWS credentials.
+type S3Store struct {
+	Region string
+	Bucket string
+}
+
+// NewS3Store returns a Store for the given S3 bucket in the given region.
+func NewS3Store(region string, bucket string) *S3Store {
+	return &S3Store{Region: region, Bucket: bucket}
+}
+
+// PutE uploads the contents of body to the object with the given key.
+func (store *S3Store) PutE(t testing.TB, key string, body io.Reader) error {
+	return aws.PutS3ObjectContentsE(t, store.Region, store.Bucket, key, body)
+}
+
+// GetE returns the contents of the object with the given key.
+func (store *S3Store) GetE(t testing.TB, key string) (string, error) {
+	return aws.GetS3ObjectContentsE(t, store.Region, store.Bucket, key)
+}
+
+// ListE returns the keys of the objects that start with the given prefix.
+func (store *S3Store) ListE(t testing.TB, prefix string) ([]string, error) {
+	return aws.ListS3ObjectKeysE(t, store.Region, store.Bucket, prefix)
+}
+
+// DeleteE deletes the object with the given key.
+func (store *S3Store) DeleteE(t testing.TB, key string) error {
+	return aws.DeleteS3ObjectE(t, store.Region, store.Bucket, key)
+}
+
+// SignedUrlE returns a URL that grants anyone who has it access to the object with the given key for the given HTTP
+// method (GET or PUT) until it expires.
+func (store *S3Store) SignedUrlE(t testing.TB, key string, method string, expiry time.Duration) (string, error) {
+	return aws.GetS3ObjectPresignedUrlE(t, store.Region, store.Bucket, key, method, expiry)
+}
@@ -0,0 +1,335 @@
+// Package budget guards a test run against runaway cloud bills by estimating the cost of the resources the tests
+// have created so far and aborting the run, after cleaning up, once a configured budget is exceeded.
+package budget
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/customerrors"
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// DefaultHourlyPrices is a static table of rough on-demand prices, in US dollars per hour, for commonly used resource
+// types. The prices are deliberately on the high side of what the providers charge so the estimate errs towards
+// stopping early. Pass your own table to NewGuard to cover other resource types or to use negotiated prices.
+var DefaultHourlyPrices = map[string]float64{
+	"aws:t2.micro":            0.0116,
+	"aws:t3.micro":            0.0104,
+	"aws:t3.medium":           0.0416,
+	"aws:m5.large":            0.096,
+	"aws:db.t3.micro":         0.018,
+	"aws:eks-cluster":         0.10,
+	"aws:nat-gateway":         0.045,
+	"aws:alb":                 0.0225,
+	"gcp:f1-micro":            0.0076,
+	"gcp:e2-micro":            0.0084,
+	"gcp:e2-medium":           0.0335,
+	"gcp:n1-standard-1":       0.0475,
+	"gcp:n1-standard-4":       0.19,
+	"gcp:gke-cluster":         0.10,
+	"gcp:cloud-sql-db-f1":     0.015,
+	"gcp:redis-basic-gb":      0.049,
+	"gcp:spanner-node":        0.90,
+	"gcp:pd-standard-gb":      0.00006,
+	"gcp:forwarding-rule":     0.025,
+	"gcp:static-external-ip":  0.01,
+	"gcp:filestore-basic-tb":  0.30,
+	"gcp:cloud-nat-gateway":   0.044,
+	"gcp:load-balancer-proxy": 0.025,
+}
+
+// Guard keeps track of the resources created during a test run and their estimated cost, and aborts the run once the
+// estimate exceeds the budget. A single Guard is typically shared by all the tests in a package, e.g. as a package
+// level variable created in TestMain.
+type Guard struct {
+	limit    float64
+	prices   map[string]float64
+	mutex    sync.Mutex
+	tracked  []trackedResource
+	cleanups []func() error
+	exceeded bool
+	now      func() time.Time
+}
+
+type trackedResource struct {
+	resourceType string
+	quantity     float64
+	since        time.Time
+	// When the resource was released, or the zero time if it still exists
+	until time.Time
+}
+
+// NewGuard creates a Guard that aborts the run once the estimated cost, in US dollars, exceeds the given limit. The
+// prices are per hour and per unit, keyed by resource type. If prices is nil, DefaultHourlyPrices is used.
+func NewGuard(t testing.TB, limit float64, prices map[string]float64) *Guard {
+	if prices == nil {
+		prices = DefaultHourlyPrices
+	}
+
+	logger.Logf(t, "Guarding test run with a budget of $%.2f", limit)
+	return &Guard{limit: limit, prices: prices, now: time.Now}
+}
+
+// Track records that the given quantity of the given resource type now exists, so its cost counts towards the budget
+// from now on. It fails the test if the resource type has no price or the budget has already been exceeded.
+func (guard *Guard) Track(t testing.TB, resourceType string, quantity float64) {
+	err := guard.TrackE(t, resourceType, quantity)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TrackE records that the given quantity of the given resource type now exists, so its cost counts towards the budget
+// from now on. It returns an UnknownResourceType error if the resource type has no price and a BudgetExceeded error if
+// the budget has already been exceeded, so no new resources should be created.
+func (guard *Guard) TrackE(t testing.TB, resourceType string, quantity float64) error {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	if guard.exceeded {
+		return BudgetExceeded{Limit: guard.limit, Estimate: guard.estimate()}
+	}
+
+	if _, hasPrice := guard.prices[resourceType]; !hasPrice {
+		return UnknownResourceType(resourceType)
+	}
+
+	guard.tracked = append(guard.tracked, trackedResource{resourceType: resourceType, quantity: quantity, since: guard.now()})
+	logger.Logf(t, "Tracking %v x %s against the budget", quantity, resourceType)
+	return nil
+}
+
+// Release records that the given quantity of the given resource type, tracked earlier with Track, has been destroyed,
+// so its cost stops counting towards the budget. The cost it has run up so far still counts. It fails the test if not
+// that much of the resource type is being tracked.
+func (guard *Guard) Release(t testing.TB, resourceType string, quantity float64) {
+	err := guard.ReleaseE(t, resourceType, quantity)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ReleaseE records that the given quantity of the given resource type, tracked earlier with TrackE, has been destroyed,
+// so its cost stops counting towards the budget. The cost it has run up so far still counts. It returns a NotTracked
+// error if not that much of the resource type is being tracked.
+func (guard *Guard) ReleaseE(t testing.TB, resourceType string, quantity float64) error {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	trackedQuantity := 0.0
+	for _, resource := range guard.tracked {
+		if resource.resourceType == resourceType && resource.until.IsZero() {
+			trackedQuantity += resource.quantity
+		}
+	}
+	if trackedQuantity < quantity {
+		return NotTracked{ResourceType: resourceType, Quantity: quantity, TrackedQuantity: trackedQuantity}
+	}
+
+	now := guard.now()
+	remaining := quantity
+	for i := len(guard.tracked) - 1; i >= 0 && remaining > 0; i-- {
+		resource := &guard.tracked[i]
+		if resource.resourceType != resourceType || !resource.until.IsZero() {
+			continue
+		}
+
+		if resource.quantity > remaining {
+			// Only part of this resource is released, so split off the part that still exists
+			guard.tracked = append(guard.tracked, trackedResource{resourceType: resourceType, quantity: resource.quantity - remaining, since: resource.since})
+			resource = &guard.tracked[i]
+			resource.quantity = remaining
+		}
+
+		resource.until = now
+		remaining -= resource.quantity
+	}
+
+	logger.Logf(t, "Released %v x %s from the budget", quantity, resourceType)
+	return nil
+}
+
+// AddCleanup registers a function that destroys resources to run when the budget is exceeded. Cleanup functions run
+// once, in the reverse order they were added, just like defer. As Watch may run them on a background goroutine, they
+// must not fail the test directly, so use the E variants of the helpers (e.g. terraform.DestroyE) and return the error.
+func (guard *Guard) AddCleanup(cleanup func() error) {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	guard.cleanups = append(guard.cleanups, cleanup)
+}
+
+// EstimatedCost returns the estimated cost, in US dollars, of all the tracked resources from the time they were
+// tracked until now.
+func (guard *Guard) EstimatedCost() float64 {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	return guard.estimate()
+}
+
+// Check fails the test, after running the registered cleanup functions, if the estimated cost exceeds the budget.
+// Call it between the steps of long running tests, or at the start of each test so that the rest of the run is
+// aborted once one test has used up the budget.
+func (guard *Guard) Check(t testing.TB) {
+	err := guard.CheckE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CheckE runs the registered cleanup functions and returns a BudgetExceeded error if the estimated cost exceeds the
+// budget. If any of the cleanup functions fail, it returns a MultiError with the BudgetExceeded error and their errors.
+func (guard *Guard) CheckE(t testing.TB) error {
+	exceededErr, cleanupErr := guard.check(t)
+	if cleanupErr != nil {
+		return customerrors.NewMultiError(exceededErr, cleanupErr)
+	}
+	return exceededErr
+}
+
+// check runs the registered cleanup functions if the estimated cost exceeds the budget, and returns a BudgetExceeded
+// error if it does, along with the errors of the cleanup functions, if any.
+func (guard *Guard) check(t testing.TB) (error, error) {
+	guard.mutex.Lock()
+	estimate := guard.estimate()
+	if !guard.exceeded && estimate <= guard.limit {
+		guard.mutex.Unlock()
+		return nil, nil
+	}
+
+	cleanups := []func() error{}
+	if !guard.exceeded {
+		guard.exceeded = true
+		cleanups = guard.cleanups
+		guard.cleanups = nil
+	}
+	guard.mutex.Unlock()
+
+	exceededErr := BudgetExceeded{Limit: guard.limit, Estimate: estimate}
+	if len(cleanups) == 0 {
+		return exceededErr, nil
+	}
+
+	logger.Logf(t, "%s. Running %d cleanup functions.", exceededErr.Error(), len(cleanups))
+	errorsOccurred := []error{}
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		errorsOccurred = append(errorsOccurred, cleanups[i]())
+	}
+
+	return exceededErr, customerrors.NewMultiError(errorsOccurred...)
+}
+
+// Watch checks the budget in the background every given interval, running the registered cleanup functions as soon as
+// it is exceeded, so the cleanup doesn't have to wait for the next call to Check. Errors from the cleanup functions are
+// reported with t.Error. Call the returned function, typically with defer, to stop watching; it waits for any running
+// cleanup to finish. Tests still need to call Check or Track to stop, as a background goroutine can't stop a test.
+func (guard *Guard) Watch(t testing.TB, interval time.Duration) func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer close(stopped)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				exceededErr, cleanupErr := guard.check(t)
+				if cleanupErr != nil {
+					t.Errorf("Cleanup after the budget was exceeded failed: %v", cleanupErr)
+				}
+				if exceededErr != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+		<-stopped
+	}
+}
+
+// estimate returns the estimated cost of the tracked resources. The caller must hold the mutex.
+func (guard *Guard) estimate() float64 {
+	now := guard.now()
+
+	total := 0.0
+	for _, resource := range guard.tracked {
+		total += guard.cost(resource, now)
+	}
+
+	return total
+}
+
+// cost returns the estimated cost of the given tracked resource from the time it was tracked until it was released,
+// or until now if it still exists.
+func (guard *Guard) cost(resource trackedResource, now time.Time) float64 {
+	until := now
+	if !resource.until.IsZero() {
+		until = resource.until
+	}
+	return guard.prices[resource.resourceType] * resource.quantity * until.Sub(resource.since).Hours()
+}
+
+// Summary returns a human readable breakdown of the tracked resources by type, useful for logging at the end of a run.
+func (guard *Guard) Summary() string {
+	guard.mutex.Lock()
+	defer guard.mutex.Unlock()
+
+	now := guard.now()
+	costs := map[string]float64{}
+	for _, resource := range guard.tracked {
+		costs[resource.resourceType] += guard.cost(resource, now)
+	}
+
+	resourceTypes := []string{}
+	for resourceType := range costs {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	summary := fmt.Sprintf("Estimated cost $%.4f of $%.2f budget", guard.estimate(), guard.limit)
+	for _, resourceType := range resourceTypes {
+		summary += fmt.Sprintf("\n  %s: $%.4f", resourceType, costs[resourceType])
+	}
+
+	return summary
+}
+
+// BudgetExceeded is returned when the estimated cost of the test run exceeds the budget.
+type BudgetExceeded struct {
+	Limit    float64
+	Estimate float64
+}
+
+func (err BudgetExceeded) Error() string {
+	return fmt.Sprintf("Estimated cost of the test run $%.4f exceeds the budget of $%.2f", err.Estimate, err.Limit)
+}
+
+// NotTracked is returned when releasing more of a resource type than is being tracked.
+type NotTracked struct {
+	ResourceType    string
+	Quantity        float64
+	TrackedQuantity float64
+}
+
+func (err NotTracked) Error() string {
+	return fmt.Sprintf("Can't release %v x %s, as only %v are being tracked", err.Quantity, err.ResourceType, err.TrackedQuantity)
+}
+
+// UnknownResourceType is returned when a resource type has no price in the Guard's price table.
+type UnknownResourceType string
+
+func (err UnknownResourceType) Error() string {
+	return fmt.Sprintf("No hourly price for resource type %s", string(err))
+}
@@ -0,0 +1,130 @@
+package budget
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGuard(t *testing.T, limit float64, clock *time.Time) *Guard {
+	guard := NewGuard(t, limit, map[string]float64{"vm": 1.0, "ip": 0.5})
+	guard.now = func() time.Time { return *clock }
+	return guard
+}
+
+func TestEstimatedCostGrowsWithTime(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	guard := newTestGuard(t, 10, &clock)
+
+	guard.Track(t, "vm", 2)
+	guard.Track(t, "ip", 1)
+	assert.Equal(t, 0.0, guard.EstimatedCost())
+
+	clock = clock.Add(2 * time.Hour)
+	assert.InDelta(t, 5.0, guard.EstimatedCost(), 0.0001)
+	assert.Contains(t, guard.Summary(), "vm: $4.0000")
+}
+
+func TestReleaseStopsCountingCost(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	guard := newTestGuard(t, 10, &clock)
+
+	guard.Track(t, "vm", 3)
+	clock = clock.Add(time.Hour)
+	guard.Release(t, "vm", 2)
+	clock = clock.Add(time.Hour)
+
+	// 3 VMs for the first hour, and the one that is left for the second
+	assert.InDelta(t, 4.0, guard.EstimatedCost(), 0.0001)
+
+	err := guard.ReleaseE(t, "vm", 2)
+	require.Error(t, err)
+	assert.IsType(t, NotTracked{}, err)
+
+	guard.Release(t, "vm", 1)
+	clock = clock.Add(time.Hour)
+	assert.InDelta(t, 4.0, guard.EstimatedCost(), 0.0001)
+}
+
+func TestTrackRejectsUnknownResourceType(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Now()
+	guard := newTestGuard(t, 10, &clock)
+
+	err := guard.TrackE(t, "gpu", 1)
+	require.Error(t, err)
+	assert.IsType(t, UnknownResourceType(""), err)
+}
+
+func TestCheckRunsCleanupsOnceInReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	guard := newTestGuard(t, 1, &clock)
+
+	order := []string{}
+	guard.AddCleanup(func() error { order = append(order, "first"); return nil })
+	guard.AddCleanup(func() error { order = append(order, "second"); return nil })
+	guard.Track(t, "vm", 1)
+
+	require.NoError(t, guard.CheckE(t))
+
+	clock = clock.Add(90 * time.Minute)
+	err := guard.CheckE(t)
+	require.Error(t, err)
+	assert.IsType(t, BudgetExceeded{}, err)
+	assert.Equal(t, []string{"second", "first"}, order)
+
+	// The run stays aborted: further checks fail without running the cleanups again, and no new resources are tracked
+	require.Error(t, guard.CheckE(t))
+	require.Error(t, guard.TrackE(t, "ip", 1))
+	assert.Equal(t, []string{"second", "first"}, order)
+}
+
+func TestCheckReturnsCleanupErrors(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	guard := newTestGuard(t, 1, &clock)
+
+	cleanedUp := false
+	guard.AddCleanup(func() error { cleanedUp = true; return nil })
+	guard.AddCleanup(func() error { return errors.New("destroy failed") })
+	guard.Track(t, "vm", 1)
+
+	clock = clock.Add(2 * time.Hour)
+	err := guard.CheckE(t)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the budget")
+	assert.Contains(t, err.Error(), "destroy failed")
+
+	// A failing cleanup doesn't stop the others from running
+	assert.True(t, cleanedUp)
+}
+
+func TestWatchRunsCleanupsInTheBackground(t *testing.T) {
+	t.Parallel()
+
+	guard := NewGuard(t, 0, map[string]float64{"vm": 1.0})
+	guard.Track(t, "vm", 1)
+
+	cleanedUp := make(chan struct{})
+	guard.AddCleanup(func() error { close(cleanedUp); return nil })
+
+	stop := guard.Watch(t, time.Millisecond)
+	defer stop()
+
+	select {
+	case <-cleanedUp:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for Watch to run the cleanup functions")
+	}
+}
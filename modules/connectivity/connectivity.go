@@ -0,0 +1,246 @@
+// Package connectivity contains functions for checking which hosts can reach which other hosts, e.g. to test that
+// firewall rules and network segmentation work as intended.
+package connectivity
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/ssh"
+)
+
+const (
+	reachableMarker   = "TERRATEST_REACHABLE"
+	unreachableMarker = "TERRATEST_UNREACHABLE"
+)
+
+// CommandRunner runs a shell command on a host and returns its stdout.
+type CommandRunner func(t testing.TB, command string) (string, error)
+
+// Host is a host that takes part in connectivity tests.
+type Host struct {
+	Name       string        // A name for the host to use in expectations and results, e.g. web-1
+	Address    string        // The address other hosts use to reach this host, typically its private IP
+	RunCommand CommandRunner // Runs probes from this host. Only needed for hosts that probes are run from.
+}
+
+// Probe is a reachability check from one host to another.
+type Probe struct {
+	Protocol string // Either icmp or tcp
+	Port     int    // The port to connect to. Ignored for icmp.
+}
+
+// Icmp returns a Probe that pings the target host.
+func Icmp() Probe {
+	return Probe{Protocol: "icmp"}
+}
+
+// Tcp returns a Probe that opens a TCP connection to the given port on the target host.
+func Tcp(port int) Probe {
+	return Probe{Protocol: "tcp", Port: port}
+}
+
+func (probe Probe) String() string {
+	if probe.Protocol == "icmp" {
+		return probe.Protocol
+	}
+	return fmt.Sprintf("%s/%d", probe.Protocol, probe.Port)
+}
+
+// Expectation is an entry in a connectivity policy: whether the From host should be able to reach the To host with
+// the given Probe.
+type Expectation struct {
+	From      string
+	To        string
+	Probe     Probe
+	Reachable bool
+}
+
+// Result is the outcome of checking an Expectation.
+type Result struct {
+	Expectation
+	ActualReachable bool  // Whether the probe succeeded
+	Err             error // Set if the probe could not be run at all, e.g. because SSH to the From host failed
+}
+
+// Passed returns true if the probe ran and its outcome matches the expectation.
+func (result Result) Passed() bool {
+	return result.Err == nil && result.ActualReachable == result.Reachable
+}
+
+// Matrix is the set of results of a connectivity test.
+type Matrix []Result
+
+// Failures returns the results that did not match their expectations.
+func (matrix Matrix) Failures() []Result {
+	failures := []Result{}
+	for _, result := range matrix {
+		if !result.Passed() {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// String formats the matrix as a table with one row per expectation.
+func (matrix Matrix) String() string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "FROM\tTO\tPROBE\tEXPECTED\tACTUAL\tRESULT")
+	for _, result := range matrix {
+		actual := reachability(result.ActualReachable)
+		if result.Err != nil {
+			actual = fmt.Sprintf("error: %v", result.Err)
+		}
+		status := "PASS"
+		if !result.Passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", result.From, result.To, result.Probe, reachability(result.Reachable), actual, status)
+	}
+
+	writer.Flush()
+	return buffer.String()
+}
+
+// SshCommandRunner returns a CommandRunner that runs commands on the given host over SSH.
+func SshCommandRunner(host ssh.Host) CommandRunner {
+	return func(t testing.TB, command string) (string, error) {
+		return ssh.CheckSshCommandE(t, host, command)
+	}
+}
+
+// SsmCommandRunner returns a CommandRunner that runs commands on the given EC2 Instance via the SSM Agent.
+func SsmCommandRunner(awsRegion string, instanceID string, timeout time.Duration) CommandRunner {
+	return func(t testing.TB, command string) (string, error) {
+		return aws.CheckSsmCommandE(t, awsRegion, instanceID, command, timeout)
+	}
+}
+
+// AssertConnectivity runs the probe for each of the given expectations and fails the test, printing the connectivity
+// matrix, if any host can reach a host it should not be able to reach or cannot reach a host it should be able to
+// reach.
+func AssertConnectivity(t testing.TB, hosts []Host, expectations []Expectation) {
+	err := AssertConnectivityE(t, hosts, expectations)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertConnectivityE runs the probe for each of the given expectations and returns an error, including the
+// connectivity matrix, if any host can reach a host it should not be able to reach or cannot reach a host it should be
+// able to reach.
+func AssertConnectivityE(t testing.TB, hosts []Host, expectations []Expectation) error {
+	matrix, err := CheckConnectivityE(t, hosts, expectations)
+	if err != nil {
+		return err
+	}
+
+	failures := matrix.Failures()
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d connectivity checks did not match the expected policy:\n%s", len(failures), len(matrix), matrix)
+	}
+
+	return nil
+}
+
+// CheckConnectivity runs the probe for each of the given expectations and returns the resulting connectivity matrix.
+func CheckConnectivity(t testing.TB, hosts []Host, expectations []Expectation) Matrix {
+	matrix, err := CheckConnectivityE(t, hosts, expectations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matrix
+}
+
+// CheckConnectivityE runs the probe for each of the given expectations and returns the resulting connectivity matrix.
+// An error is only returned if an expectation refers to an unknown host; probes that fail to run are recorded in the
+// Err field of their Result instead.
+func CheckConnectivityE(t testing.TB, hosts []Host, expectations []Expectation) (Matrix, error) {
+	hostsByName := map[string]Host{}
+	for _, host := range hosts {
+		hostsByName[host.Name] = host
+	}
+
+	for _, expectation := range expectations {
+		from, exists := hostsByName[expectation.From]
+		if !exists {
+			return nil, fmt.Errorf("Unknown host %s in connectivity expectation", expectation.From)
+		}
+		if from.RunCommand == nil {
+			return nil, fmt.Errorf("Host %s has no RunCommand set, so probes cannot be run from it", expectation.From)
+		}
+		if _, exists := hostsByName[expectation.To]; !exists {
+			return nil, fmt.Errorf("Unknown host %s in connectivity expectation", expectation.To)
+		}
+	}
+
+	matrix := Matrix{}
+	for _, expectation := range expectations {
+		from := hostsByName[expectation.From]
+		to := hostsByName[expectation.To]
+
+		logger.Logf(t, "Checking %s connectivity from %s to %s (%s)", expectation.Probe, from.Name, to.Name, to.Address)
+
+		result := Result{Expectation: expectation}
+		command, err := probeCommand(expectation.Probe, to.Address)
+		if err == nil {
+			var out string
+			out, err = from.RunCommand(t, command)
+			if err == nil {
+				result.ActualReachable, err = parseProbeOutput(out)
+			}
+		}
+		result.Err = err
+
+		matrix = append(matrix, result)
+	}
+
+	logger.Logf(t, "Connectivity matrix:\n%s", matrix)
+	return matrix, nil
+}
+
+// probeCommand returns a shell command that runs the given probe against the given address and prints whether it
+// succeeded. The command itself always exits successfully, so a non-zero exit code means the probe could not be run.
+func probeCommand(probe Probe, address string) (string, error) {
+	var check string
+	switch probe.Protocol {
+	case "icmp":
+		check = fmt.Sprintf("ping -c 1 -W 3 %s > /dev/null 2>&1", address)
+	case "tcp":
+		if probe.Port <= 0 {
+			return "", fmt.Errorf("Invalid port %d for tcp probe", probe.Port)
+		}
+		check = fmt.Sprintf("timeout 5 bash -c 'cat < /dev/null > /dev/tcp/%s/%d' > /dev/null 2>&1", address, probe.Port)
+	default:
+		return "", fmt.Errorf("Unsupported probe protocol %s. Must be icmp or tcp.", probe.Protocol)
+	}
+
+	return fmt.Sprintf("if %s; then echo %s; else echo %s; fi", check, reachableMarker, unreachableMarker), nil
+}
+
+// parseProbeOutput returns whether the output of a probe command says the probe succeeded.
+func parseProbeOutput(out string) (bool, error) {
+	switch {
+	case strings.Contains(out, unreachableMarker):
+		return false, nil
+	case strings.Contains(out, reachableMarker):
+		return true, nil
+	default:
+		return false, fmt.Errorf("Unexpected probe output: %s", out)
+	}
+}
+
+func reachability(reachable bool) string {
+	if reachable {
+		return "reachable"
+	}
+	return "unreachable"
+}
@@ -0,0 +1,98 @@
+package connectivity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner returns a CommandRunner that reports every probe to one of the given addresses as reachable
+func fakeRunner(reachableAddresses ...string) CommandRunner {
+	return func(t testing.TB, command string) (string, error) {
+		for _, address := range reachableAddresses {
+			if strings.Contains(command, address) {
+				return reachableMarker + "\n", nil
+			}
+		}
+		return unreachableMarker + "\n", nil
+	}
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	t.Parallel()
+
+	hosts := []Host{
+		{Name: "web", Address: "10.0.1.10", RunCommand: fakeRunner("10.0.2.10")},
+		{Name: "app", Address: "10.0.2.10", RunCommand: fakeRunner("10.0.1.10", "10.0.3.10")},
+		{Name: "db", Address: "10.0.3.10"},
+	}
+	expectations := []Expectation{
+		{From: "web", To: "app", Probe: Tcp(8080), Reachable: true},
+		{From: "web", To: "db", Probe: Tcp(5432), Reachable: false},
+		{From: "app", To: "db", Probe: Tcp(5432), Reachable: true},
+		{From: "app", To: "web", Probe: Icmp(), Reachable: false},
+	}
+
+	matrix := CheckConnectivity(t, hosts, expectations)
+	require.Len(t, matrix, 4)
+
+	failures := matrix.Failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "app", failures[0].From)
+	assert.Equal(t, "web", failures[0].To)
+	assert.True(t, failures[0].ActualReachable)
+
+	err := AssertConnectivityE(t, hosts, expectations)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 4 connectivity checks")
+}
+
+func TestCheckConnectivityRecordsRunnerErrors(t *testing.T) {
+	t.Parallel()
+
+	failingRunner := func(t testing.TB, command string) (string, error) {
+		return "", errors.New("ssh: handshake failed")
+	}
+	hosts := []Host{
+		{Name: "a", Address: "10.0.0.1", RunCommand: failingRunner},
+		{Name: "b", Address: "10.0.0.2"},
+	}
+
+	matrix := CheckConnectivity(t, hosts, []Expectation{{From: "a", To: "b", Probe: Icmp(), Reachable: false}})
+	require.Len(t, matrix, 1)
+	assert.Error(t, matrix[0].Err)
+	assert.False(t, matrix[0].Passed())
+}
+
+func TestCheckConnectivityUnknownHost(t *testing.T) {
+	t.Parallel()
+
+	hosts := []Host{{Name: "a", Address: "10.0.0.1", RunCommand: fakeRunner()}}
+
+	_, err := CheckConnectivityE(t, hosts, []Expectation{{From: "a", To: "missing", Probe: Icmp()}})
+	assert.Error(t, err)
+
+	_, err = CheckConnectivityE(t, append(hosts, Host{Name: "b"}), []Expectation{{From: "b", To: "a", Probe: Icmp()}})
+	assert.Error(t, err)
+}
+
+func TestProbeCommand(t *testing.T) {
+	t.Parallel()
+
+	command, err := probeCommand(Tcp(443), "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "if timeout 5 bash -c 'cat < /dev/null > /dev/tcp/10.0.0.1/443' > /dev/null 2>&1; then echo TERRATEST_REACHABLE; else echo TERRATEST_UNREACHABLE; fi", command)
+
+	command, err = probeCommand(Icmp(), "10.0.0.1")
+	require.NoError(t, err)
+	assert.Contains(t, command, "ping -c 1 -W 3 10.0.0.1")
+
+	_, err = probeCommand(Tcp(0), "10.0.0.1")
+	assert.Error(t, err)
+
+	_, err = probeCommand(Probe{Protocol: "udp", Port: 53}, "10.0.0.1")
+	assert.Error(t, err)
+}
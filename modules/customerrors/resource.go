@@ -0,0 +1,93 @@
+package customerrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceContext identifies the cloud resource an error is about, so that logs and test failures point at the
+// resource that failed.
+type ResourceContext struct {
+	// The kind of resource, such as "S3 object", "Compute Instance", or "Pod"
+	Kind string
+	// The name or ID of the resource
+	Name string
+	// The region or zone the resource is in. For Kubernetes resources, this is the namespace.
+	Region string
+}
+
+func (ctx ResourceContext) String() string {
+	description := strings.TrimSpace(fmt.Sprintf("%s %s", ctx.Kind, ctx.Name))
+	if ctx.Region != "" {
+		description = fmt.Sprintf("%s in %s", description, ctx.Region)
+	}
+	return description
+}
+
+// NotFoundError is returned when a cloud provider reports that a resource does not exist.
+type NotFoundError struct {
+	ResourceContext
+	Underlying error
+}
+
+func (err NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %v", err.ResourceContext, err.Underlying)
+}
+
+// ThrottledError is returned when a cloud provider rejects a request about a resource because of rate limiting. These
+// errors are usually worth retrying.
+type ThrottledError struct {
+	ResourceContext
+	Underlying error
+}
+
+func (err ThrottledError) Error() string {
+	return fmt.Sprintf("Request for %s was throttled: %v", err.ResourceContext, err.Underlying)
+}
+
+// AuthError is returned when a cloud provider rejects a request about a resource because the credentials are missing,
+// invalid, or not allowed to perform the action.
+type AuthError struct {
+	ResourceContext
+	Underlying error
+}
+
+func (err AuthError) Error() string {
+	return fmt.Sprintf("Not authorized to access %s: %v", err.ResourceContext, err.Underlying)
+}
+
+// IsNotFound returns true if the given error is a NotFoundError, or a MultiError that contains one.
+func IsNotFound(err error) bool {
+	return matches(err, func(err error) bool {
+		_, isNotFound := err.(NotFoundError)
+		return isNotFound
+	})
+}
+
+// IsThrottled returns true if the given error is a ThrottledError, or a MultiError that contains one.
+func IsThrottled(err error) bool {
+	return matches(err, func(err error) bool {
+		_, isThrottled := err.(ThrottledError)
+		return isThrottled
+	})
+}
+
+// IsAuth returns true if the given error is an AuthError, or a MultiError that contains one.
+func IsAuth(err error) bool {
+	return matches(err, func(err error) bool {
+		_, isAuth := err.(AuthError)
+		return isAuth
+	})
+}
+
+func matches(err error, predicate func(error) bool) bool {
+	if multiErr, isMultiErr := err.(MultiError); isMultiErr {
+		for _, item := range multiErr.Errors {
+			if matches(item, predicate) {
+				return true
+			}
+		}
+		return false
+	}
+	return err != nil && predicate(err)
+}
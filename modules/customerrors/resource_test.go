@@ -0,0 +1,56 @@
+package customerrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceErrorMessages(t *testing.T) {
+	t.Parallel()
+
+	underlying := errors.New("boom")
+	ctx := ResourceContext{Kind: "S3 object", Name: "my-bucket/my-key", Region: "us-east-1"}
+
+	assert.Equal(t, "S3 object my-bucket/my-key in us-east-1 not found: boom", NotFoundError{ctx, underlying}.Error())
+	assert.Equal(t, "Request for S3 object my-bucket/my-key in us-east-1 was throttled: boom", ThrottledError{ctx, underlying}.Error())
+	assert.Equal(t, "Not authorized to access S3 object my-bucket/my-key in us-east-1: boom", AuthError{ctx, underlying}.Error())
+	assert.Equal(t, "Pod my-pod", ResourceContext{Kind: "Pod", Name: "my-pod"}.String())
+}
+
+func TestErrorCategories(t *testing.T) {
+	t.Parallel()
+
+	notFound := NotFoundError{Underlying: errors.New("missing")}
+	throttled := ThrottledError{Underlying: errors.New("slow down")}
+	auth := AuthError{Underlying: errors.New("denied")}
+
+	testCases := []struct {
+		name              string
+		err               error
+		expectedNotFound  bool
+		expectedThrottled bool
+		expectedAuth      bool
+	}{
+		{"nil", nil, false, false, false},
+		{"plain", errors.New("plain"), false, false, false},
+		{"not found", notFound, true, false, false},
+		{"throttled", throttled, false, true, false},
+		{"auth", auth, false, false, true},
+		{"multi", NewMultiError(errors.New("plain"), throttled, auth), false, true, true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expectedNotFound, IsNotFound(testCase.err))
+			assert.Equal(t, testCase.expectedThrottled, IsThrottled(testCase.err))
+			assert.Equal(t, testCase.expectedAuth, IsAuth(testCase.err))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package dns_helper contains functions for testing DNS behavior, such as failover between primary and secondary
+// targets, against real DNS servers.
+package dns_helper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// FailoverOptions configures VerifyFailover.
+type FailoverOptions struct {
+	RecordName         string        // The DNS name to resolve, e.g. app.example.com
+	PrimaryValues      []string      // The IPs (or CNAME target) the record resolves to while the primary is healthy
+	SecondaryValues    []string      // The IPs (or CNAME target) the record should resolve to after failing over
+	FailoverWindow     time.Duration // How long failover may take, typically the health check interval times its failure threshold plus the record TTL
+	SleepBetweenChecks time.Duration // How long to wait between DNS lookups
+
+	// The DNS server to query, e.g. ns-123.awsdns-45.com:53 or ns-cloud-a1.googledomains.com:53. Querying the zone's
+	// authoritative name server avoids waiting for caching resolvers to expire their entries. Defaults to the system
+	// resolver.
+	Nameserver string
+}
+
+// VerifyFailover checks that the record in the given options resolves to the primary values, calls the given function
+// to take the primary out of service (e.g. stop its instance or make its health check fail), and then checks that the
+// record resolves to the secondary values within the failover window. It fails the test otherwise, and returns how
+// long the failover took. This is useful for validating Route 53 and Cloud DNS failover routing.
+func VerifyFailover(t testing.TB, options FailoverOptions, disablePrimary func() error) time.Duration {
+	elapsed, err := VerifyFailoverE(t, options, disablePrimary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return elapsed
+}
+
+// VerifyFailoverE checks that the record in the given options resolves to the primary values, calls the given
+// function to take the primary out of service (e.g. stop its instance or make its health check fail), and then checks
+// that the record resolves to the secondary values within the failover window. It returns how long the failover took,
+// or an error if it did not happen in time. This is useful for validating Route 53 and Cloud DNS failover routing.
+func VerifyFailoverE(t testing.TB, options FailoverOptions, disablePrimary func() error) (time.Duration, error) {
+	values, err := lookup(options.Nameserver, options.RecordName)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to resolve %s before failover: %v", options.RecordName, err)
+	}
+	if !sameValues(values, options.PrimaryValues) {
+		return 0, fmt.Errorf("Expected %s to resolve to the primary %v before failover, but got %v", options.RecordName, options.PrimaryValues, values)
+	}
+
+	logger.Logf(t, "%s resolves to the primary %v. Taking the primary out of service.", options.RecordName, values)
+	if err := disablePrimary(); err != nil {
+		return 0, fmt.Errorf("Failed to take the primary out of service: %v", err)
+	}
+
+	start := time.Now()
+	for {
+		values, err := lookup(options.Nameserver, options.RecordName)
+		elapsed := time.Since(start)
+
+		if err == nil && sameValues(values, options.SecondaryValues) {
+			logger.Logf(t, "%s failed over to the secondary %v after %s", options.RecordName, values, elapsed)
+			return elapsed, nil
+		}
+
+		if elapsed > options.FailoverWindow {
+			if err != nil {
+				return elapsed, fmt.Errorf("%s did not fail over to the secondary %v within %s. The last lookup failed: %v", options.RecordName, options.SecondaryValues, options.FailoverWindow, err)
+			}
+			return elapsed, fmt.Errorf("%s did not fail over to the secondary %v within %s. It still resolves to %v", options.RecordName, options.SecondaryValues, options.FailoverWindow, values)
+		}
+
+		logger.Logf(t, "%s resolves to %v (err: %v) after %s. Checking again in %s.", options.RecordName, values, err, elapsed, options.SleepBetweenChecks)
+		time.Sleep(options.SleepBetweenChecks)
+	}
+}
+
+// lookup resolves the given name to its IPs, or to its CNAME target if it has one, using the given name server or
+// the system resolver if the name server is empty.
+func lookup(nameserver string, name string) ([]string, error) {
+	resolver := newResolver(nameserver)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cname, err := resolver.LookupCNAME(ctx, name)
+	if err == nil && normalize(cname) != normalize(name) {
+		return []string{cname}, nil
+	}
+
+	return resolver.LookupHost(ctx, name)
+}
+
+// sameValues returns true if the given values and expected values are the same set, ignoring order, case, and
+// trailing dots.
+func sameValues(values []string, expectedValues []string) bool {
+	return strings.Join(normalizeAll(values), ",") == strings.Join(normalizeAll(expectedValues), ",")
+}
+
+func normalizeAll(values []string) []string {
+	normalized := map[string]bool{}
+	for _, value := range values {
+		normalized[normalize(value)] = true
+	}
+
+	out := []string{}
+	for value := range normalized {
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// normalize lowercases the given DNS value and strips any surrounding quotes and trailing dot.
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.Trim(value, `"`), "."))
+}
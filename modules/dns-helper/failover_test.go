@@ -0,0 +1,61 @@
+package dns_helper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSameValues(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, sameValues([]string{"10.0.0.2", "10.0.0.1"}, []string{"10.0.0.1", "10.0.0.2"}))
+	assert.True(t, sameValues([]string{"Backup.Example.com."}, []string{"backup.example.com"}))
+	assert.False(t, sameValues([]string{"10.0.0.1", "10.0.0.2"}, []string{"10.0.0.1"}))
+	assert.False(t, sameValues([]string{}, []string{"10.0.0.1"}))
+}
+
+func TestVerifyFailoverFailsIfNotOnPrimary(t *testing.T) {
+	t.Parallel()
+
+	options := FailoverOptions{
+		RecordName:      "localhost",
+		PrimaryValues:   []string{"192.0.2.1"},
+		SecondaryValues: []string{"127.0.0.1"},
+	}
+
+	called := false
+	_, err := VerifyFailoverE(t, options, func() error {
+		called = true
+		return nil
+	})
+
+	require.Error(t, err)
+	assert.False(t, called)
+}
+
+func TestVerifyFailoverReturnsDisableErrors(t *testing.T) {
+	t.Parallel()
+
+	values, err := lookup("", "localhost")
+	require.NoError(t, err)
+
+	options := FailoverOptions{
+		RecordName:         "localhost",
+		PrimaryValues:      values,
+		SecondaryValues:    []string{"192.0.2.1"},
+		FailoverWindow:     time.Second,
+		SleepBetweenChecks: 100 * time.Millisecond,
+	}
+
+	_, err = VerifyFailoverE(t, options, func() error { return errors.New("could not stop instance") })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not stop instance")
+
+	_, err = VerifyFailoverE(t, options, func() error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not fail over")
+}
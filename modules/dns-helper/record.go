@@ -0,0 +1,128 @@
+package dns_helper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
+)
+
+// WaitUntilRecordResolves does a real DNS lookup of the record with the given name and type (A, AAAA, CNAME, TXT, MX,
+// or NS) and retries until the results contain all of expectedValues. This will fail the test if the record does not
+// resolve to those values in time.
+func WaitUntilRecordResolves(t testing.TB, recordName string, recordType string, expectedValues []string, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilRecordResolvesE(t, recordName, recordType, expectedValues, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilRecordResolvesE does a real DNS lookup of the record with the given name and type (A, AAAA, CNAME, TXT, MX,
+// or NS) and retries until the results contain all of expectedValues. This verifies that a record is actually being
+// served, not just that it exists in the API of the DNS provider. Note that the system resolver caches negative
+// answers, so looking up a record before it is created can delay the lookups that follow by up to the SOA TTL of the
+// zone.
+func WaitUntilRecordResolvesE(t testing.TB, recordName string, recordType string, expectedValues []string, maxRetries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "dns_helper.WaitUntilRecordResolves", time.Now(), &err)
+
+	description := fmt.Sprintf("Waiting for %s record %s to resolve to %v", recordType, recordName, expectedValues)
+
+	msg, err := retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		values, err := lookupRecord(newResolver(""), recordName, recordType)
+		if err != nil {
+			return "", err
+		}
+
+		if !ContainsAllRecordValues(values, expectedValues) {
+			return "", fmt.Errorf("Expected %s record %s to resolve to %v, but got %v", recordType, recordName, expectedValues, values)
+		}
+
+		return fmt.Sprintf("%s record %s resolved to %v", recordType, recordName, values), nil
+	})
+	if err != nil {
+		logger.Logf(t, "Timed out waiting for %s record %s to resolve to %v: %s", recordType, recordName, expectedValues, err)
+		return err
+	}
+	logger.Log(t, msg)
+	return nil
+}
+
+// ContainsAllRecordValues returns true if every one of the expected values is in the given values. Values are compared
+// without regard to case, trailing dots, or the quotes DNS providers put around TXT records.
+func ContainsAllRecordValues(values []string, expectedValues []string) bool {
+	normalized := map[string]bool{}
+	for _, value := range values {
+		normalized[normalize(value)] = true
+	}
+
+	for _, expected := range expectedValues {
+		if !normalized[normalize(expected)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupRecord resolves the record with the given name and type using the given resolver.
+func lookupRecord(resolver *net.Resolver, recordName string, recordType string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA":
+		return resolver.LookupHost(ctx, recordName)
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, recordName)
+		if err != nil {
+			return nil, err
+		}
+		return []string{cname}, nil
+	case "TXT":
+		return resolver.LookupTXT(ctx, recordName)
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, recordName)
+		if err != nil {
+			return nil, err
+		}
+		values := []string{}
+		for _, mx := range mxs {
+			values = append(values, fmt.Sprintf("%d %s", mx.Pref, mx.Host))
+		}
+		return values, nil
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, recordName)
+		if err != nil {
+			return nil, err
+		}
+		values := []string{}
+		for _, ns := range nss {
+			values = append(values, ns.Host)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("Looking up DNS records of type %s is not supported", recordType)
+	}
+}
+
+// newResolver returns a resolver that queries the given name server, or the system resolver if the name server is
+// empty.
+func newResolver(nameserver string) *net.Resolver {
+	if nameserver == "" {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network string, address string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: 5 * time.Second}
+			return dialer.DialContext(ctx, network, nameserver)
+		},
+	}
+}
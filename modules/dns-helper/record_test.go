@@ -0,0 +1,37 @@
+package dns_helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsAllRecordValues(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		values         []string
+		expectedValues []string
+		expected       bool
+	}{
+		{"ExactMatch", []string{"10.0.0.1"}, []string{"10.0.0.1"}, true},
+		{"Subset", []string{"10.0.0.1", "10.0.0.2"}, []string{"10.0.0.2"}, true},
+		{"Missing", []string{"10.0.0.1"}, []string{"10.0.0.1", "10.0.0.2"}, false},
+		{"TrailingDot", []string{"www.example.com."}, []string{"WWW.example.com"}, true},
+		{"QuotedTxt", []string{`"v=spf1 -all"`}, []string{"v=spf1 -all"}, true},
+		{"MixedCaseCname", []string{"Target.Example.com."}, []string{"target.example.com"}, true},
+		{"NoExpectedValues", []string{}, []string{}, true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, ContainsAllRecordValues(testCase.values, testCase.expectedValues))
+		})
+	}
+}
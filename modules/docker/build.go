@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// BuildOptions defines options that can be passed to the 'docker build' command.
+type BuildOptions struct {
+	// Tags for the Docker image
+	Tags []string
+
+	// Build args to pass the 'docker build' command, in the form KEY=VALUE
+	BuildArgs []string
+
+	// The stage of a multi-stage Dockerfile to build
+	Target string
+
+	// The platform to build the image for (e.g. linux/amd64), if the server is multi-platform capable
+	Platform string
+
+	// Custom CLI options that will be passed as-is to the 'docker build' command. This is an "escape hatch" that
+	// allows Terratest to not have to support every single command-line option offered by the 'docker build' command,
+	// and solely focus on the most important ones.
+	OtherOptions []string
+
+	// The logger to log the command and its output with. Defaults to logger.Global.
+	Logger *logger.Logger
+}
+
+// Build runs the 'docker build' command at the given path with the given options and fails the test if there are any
+// errors.
+func Build(t testing.TB, path string, options *BuildOptions) {
+	require.NoError(t, BuildE(t, path, options))
+}
+
+// BuildE runs the 'docker build' command at the given path with the given options and returns any errors.
+func BuildE(t testing.TB, path string, options *BuildOptions) error {
+	options.Logger.Logf(t, "Running 'docker build' in %s", path)
+
+	cmd := shell.Command{
+		Command: "docker",
+		Args:    formatDockerBuildArgs(path, options),
+		Logger:  options.Logger,
+	}
+
+	_, err := shell.RunCommandAndGetOutputE(t, cmd)
+	return err
+}
+
+// formatDockerBuildArgs formats the arguments for the 'docker build' command.
+func formatDockerBuildArgs(path string, options *BuildOptions) []string {
+	args := []string{"build"}
+
+	for _, tag := range options.Tags {
+		args = append(args, "--tag", tag)
+	}
+
+	for _, arg := range options.BuildArgs {
+		args = append(args, "--build-arg", arg)
+	}
+
+	if options.Target != "" {
+		args = append(args, "--target", options.Target)
+	}
+
+	if options.Platform != "" {
+		args = append(args, "--platform", options.Platform)
+	}
+
+	args = append(args, options.OtherOptions...)
+
+	return append(args, path)
+}
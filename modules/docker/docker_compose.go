@@ -1,19 +1,33 @@
 package docker
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
 	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/gruntwork-io/terratest/modules/timing"
 )
 
 // Options are Docker options.
 type Options struct {
 	WorkingDir string
 	EnvVars    map[string]string
+
+	// The Docker Compose project name. Defaults to the name of the test, so that containers from multiple different
+	// tests using Docker Compose don't end up in the same project and conflict with each other.
+	ProjectName string
+
+	// The logger to log docker-compose commands and their output with. Defaults to logger.Global.
+	Logger *logger.Logger
 }
 
 // RunDockerCompose runs docker-compose with the given arguments and options and return stdout/stderr.
-func RunDockerCompose(t *testing.T, options *Options, args ...string) string {
+func RunDockerCompose(t testing.TB, options *Options, args ...string) string {
 	out, err := RunDockerComposeE(t, options, args...)
 	if err != nil {
 		t.Fatal(err)
@@ -22,15 +36,127 @@ func RunDockerCompose(t *testing.T, options *Options, args ...string) string {
 }
 
 // RunDockerComposeE runs docker-compose with the given arguments and options and return stdout/stderr.
-func RunDockerComposeE(t *testing.T, options *Options, args ...string) (string, error) {
+func RunDockerComposeE(t testing.TB, options *Options, args ...string) (string, error) {
 	cmd := shell.Command{
 		Command: "docker-compose",
 		// We append --project-name to ensure containers from multiple different tests using Docker Compose don't end
 		// up in the same project and end up conflicting with each other.
-		Args:       append([]string{"--project-name", t.Name()}, args...),
+		Args:       append([]string{"--project-name", getDockerComposeProjectName(t, options)}, args...),
 		WorkingDir: options.WorkingDir,
 		Env:        options.EnvVars,
+		Logger:     options.Logger,
 	}
 
 	return shell.RunCommandAndGetOutputE(t, cmd)
 }
+
+// WaitUntilServiceHealthy waits until the container of the given Docker Compose service reports that it's healthy,
+// retrying the check for the specified amount of times, sleeping for the provided duration between each try. This
+// will fail the test if the service does not become healthy in time.
+func WaitUntilServiceHealthy(t testing.TB, options *Options, service string, retries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilServiceHealthyE(t, options, service, retries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilServiceHealthyE waits until the container of the given Docker Compose service reports that it's healthy,
+// retrying the check for the specified amount of times, sleeping for the provided duration between each try. This
+// relies on the healthcheck defined for the service in the Compose file (or in its image), and returns an error
+// right away if there is none, or if the container exits.
+func WaitUntilServiceHealthyE(t testing.TB, options *Options, service string, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "docker.WaitUntilServiceHealthy", time.Now(), &err)
+
+	statusMsg := fmt.Sprintf("Wait for Docker Compose service %s to be healthy", service)
+	message, err := retry.DoWithRetryE(
+		t,
+		statusMsg,
+		retries,
+		sleepBetweenRetries,
+		func() (string, error) {
+			id, err := getDockerComposeServiceContainerIDE(t, options, service)
+			if err != nil {
+				return "", err
+			}
+
+			container, err := InspectE(t, id)
+			if err != nil {
+				return "", err
+			}
+
+			return checkContainerHealth(service, container)
+		},
+	)
+	if err != nil {
+		options.Logger.Logf(t, "Timedout waiting for Docker Compose service %s to be healthy: %s", service, err)
+		return err
+	}
+	options.Logger.Logf(t, "%s", message)
+	return nil
+}
+
+// GetDockerComposeServiceLogs returns the logs of the containers of the given Docker Compose service. This will fail
+// the test if there is an error.
+func GetDockerComposeServiceLogs(t testing.TB, options *Options, service string) string {
+	logs, err := GetDockerComposeServiceLogsE(t, options, service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logs
+}
+
+// GetDockerComposeServiceLogsE returns the logs of the containers of the given Docker Compose service. This is useful
+// to capture the logs of each service before the project is torn down, e.g. to attach them to a failed test.
+func GetDockerComposeServiceLogsE(t testing.TB, options *Options, service string) (string, error) {
+	// Don't log the command output, as the logs of a chatty service would otherwise end up in the test output twice
+	logsOptions := *options
+	logsOptions.Logger = logger.Discard
+
+	return RunDockerComposeE(t, &logsOptions, "logs", "--no-color", service)
+}
+
+// getDockerComposeServiceContainerIDE returns the ID of the container of the given Docker Compose service.
+func getDockerComposeServiceContainerIDE(t testing.TB, options *Options, service string) (string, error) {
+	out, err := RunDockerComposeE(t, options, "ps", "-q", service)
+	if err != nil {
+		return "", err
+	}
+
+	id := strings.TrimSpace(out)
+	if id == "" {
+		return "", fmt.Errorf("No container found for Docker Compose service %s", service)
+	}
+	if strings.Contains(id, "\n") {
+		return "", retry.FatalError{Underlying: fmt.Errorf("Docker Compose service %s has more than one container: %s", service, id)}
+	}
+	return id, nil
+}
+
+// checkContainerHealth returns an error if the given container of a Docker Compose service isn't healthy yet. Errors
+// that waiting won't resolve are wrapped in a retry.FatalError.
+func checkContainerHealth(service string, container *ContainerInspect) (string, error) {
+	if !container.Running {
+		return "", retry.FatalError{Underlying: fmt.Errorf("Docker Compose service %s exited with code %d", service, container.ExitCode)}
+	}
+	if container.HealthStatus == "" {
+		return "", retry.FatalError{Underlying: fmt.Errorf("Docker Compose service %s does not define a healthcheck", service)}
+	}
+	if container.HealthStatus != "healthy" {
+		return "", fmt.Errorf("Docker Compose service %s is %s", service, container.HealthStatus)
+	}
+	return fmt.Sprintf("Docker Compose service %s is now healthy", service), nil
+}
+
+// invalidProjectNameChars matches the characters that aren't allowed in a Docker Compose project name.
+var invalidProjectNameChars = regexp.MustCompile("[^a-z0-9_-]+")
+
+// getDockerComposeProjectName returns the Docker Compose project name to use for the given options, which defaults to
+// the name of the test. Compose only allows lowercase letters, digits, dashes, and underscores in project names, so
+// other characters (e.g. the slashes in the names of subtests) are replaced with dashes.
+func getDockerComposeProjectName(t testing.TB, options *Options) string {
+	projectName := options.ProjectName
+	if projectName == "" {
+		projectName = t.Name()
+	}
+	return invalidProjectNameChars.ReplaceAllString(strings.ToLower(projectName), "-")
+}
@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDockerComposeProjectName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		projectName string
+		expected    string
+	}{
+		{"explicit", "my_project-1", "my_project-1"},
+		{"upper case", "MyProject", "myproject"},
+		{"invalid characters", "my project/with.dots", "my-project-with-dots"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, getDockerComposeProjectName(t, &Options{ProjectName: testCase.projectName}))
+		})
+	}
+}
+
+func TestGetDockerComposeProjectNameDefaultsToTestName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Sub Test", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "testgetdockercomposeprojectnamedefaultstotestname-sub_test", getDockerComposeProjectName(t, &Options{}))
+	})
+}
+
+func TestCheckContainerHealth(t *testing.T) {
+	t.Parallel()
+
+	_, err := checkContainerHealth("db", &ContainerInspect{Running: true, HealthStatus: "healthy"})
+	assert.NoError(t, err)
+
+	_, err = checkContainerHealth("db", &ContainerInspect{Running: true, HealthStatus: "starting"})
+	require.Error(t, err)
+	_, isFatal := err.(retry.FatalError)
+	assert.False(t, isFatal)
+
+	_, err = checkContainerHealth("db", &ContainerInspect{Running: true})
+	require.Error(t, err)
+	_, isFatal = err.(retry.FatalError)
+	assert.True(t, isFatal)
+
+	_, err = checkContainerHealth("db", &ContainerInspect{Running: false, ExitCode: 1, HealthStatus: "unhealthy"})
+	require.Error(t, err)
+	_, isFatal = err.(retry.FatalError)
+	assert.True(t, isFatal)
+}
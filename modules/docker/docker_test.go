@@ -0,0 +1,161 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatDockerBuildArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		options  *BuildOptions
+		expected []string
+	}{
+		{"no options", &BuildOptions{}, []string{"build", "."}},
+		{"tags", &BuildOptions{Tags: []string{"app:latest", "app:1.0"}}, []string{"build", "--tag", "app:latest", "--tag", "app:1.0", "."}},
+		{
+			"all",
+			&BuildOptions{
+				Tags:         []string{"app:latest"},
+				BuildArgs:    []string{"VERSION=1.0", "DEBUG=false"},
+				Target:       "release",
+				Platform:     "linux/arm64",
+				OtherOptions: []string{"--no-cache"},
+			},
+			[]string{"build", "--tag", "app:latest", "--build-arg", "VERSION=1.0", "--build-arg", "DEBUG=false", "--target", "release", "--platform", "linux/arm64", "--no-cache", "."},
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, formatDockerBuildArgs(".", testCase.options))
+		})
+	}
+}
+
+func TestFormatDockerRunArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		options  *RunOptions
+		expected []string
+	}{
+		{"no options", &RunOptions{}, []string{"run", "app:latest"}},
+		{"command", &RunOptions{Command: []string{"echo", "hello"}, Remove: true}, []string{"run", "--rm", "app:latest", "echo", "hello"}},
+		{
+			"all",
+			&RunOptions{
+				Command:              []string{"serve"},
+				Detach:               true,
+				Entrypoint:           "/bin/app",
+				EnvironmentVariables: []string{"PORT=8080"},
+				Labels:               []string{"test=true"},
+				Name:                 "app",
+				Ports:                []string{"8080:80"},
+				Remove:               true,
+				Volumes:              []string{"/tmp/data:/data"},
+				OtherOptions:         []string{"--init"},
+			},
+			[]string{"run", "--detach", "--entrypoint", "/bin/app", "--env", "PORT=8080", "--label", "test=true", "--name", "app", "--publish", "8080:80", "--rm", "--volume", "/tmp/data:/data", "--init", "app:latest", "serve"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, formatDockerRunArgs("app:latest", testCase.options))
+		})
+	}
+}
+
+func TestFormatDockerStopArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"stop", "a", "b"}, formatDockerStopArgs([]string{"a", "b"}, &StopOptions{}))
+	assert.Equal(t, []string{"stop", "--time", "5", "a"}, formatDockerStopArgs([]string{"a"}, &StopOptions{Time: 5}))
+}
+
+func TestGetContainerIDFromRunOutput(t *testing.T) {
+	t.Parallel()
+
+	id, err := getContainerIDFromRunOutput("abc123\n")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", id)
+
+	id, err = getContainerIDFromRunOutput("Unable to find image 'app:latest' locally\nlatest: Pulling from library/app\nStatus: Downloaded newer image for app:latest\nabc123")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", id)
+
+	_, err = getContainerIDFromRunOutput("")
+	assert.Error(t, err)
+}
+
+const exampleContainerInspectOutput = `[
+  {
+    "Id": "abc123",
+    "Created": "2019-03-01T10:00:00.123456789Z",
+    "Name": "/app",
+    "State": {"Status": "exited", "Running": false, "ExitCode": 3, "Health": {"Status": "unhealthy"}},
+    "Config": {"Image": "app:latest", "Env": ["PORT=8080", "PATH=/usr/bin"], "Labels": {"maintainer": "terratest"}},
+    "NetworkSettings": {
+      "Ports": {
+        "8080/tcp": [{"HostIp": "0.0.0.0", "HostPort": "32768"}],
+        "53/udp": [{"HostIp": "0.0.0.0", "HostPort": "5353"}],
+        "9090/tcp": null
+      }
+    },
+    "HostConfig": {"Binds": ["/tmp/data:/data"]}
+  }
+]`
+
+func TestParseContainerInspect(t *testing.T) {
+	t.Parallel()
+
+	container, err := parseContainerInspect("abc123", exampleContainerInspectOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc123", container.ID)
+	assert.Equal(t, "app", container.Name)
+	assert.Equal(t, "app:latest", container.Image)
+	assert.Equal(t, 2019, container.Created.Year())
+	assert.Equal(t, "exited", container.Status)
+	assert.False(t, container.Running)
+	assert.Equal(t, 3, container.ExitCode)
+	assert.Equal(t, map[string]string{"maintainer": "terratest"}, container.Labels)
+	assert.Equal(t, []string{"PORT=8080", "PATH=/usr/bin"}, container.Env)
+	assert.Equal(t, []Port{{ContainerPort: 53, HostPort: 5353, Protocol: "udp"}, {ContainerPort: 8080, HostPort: 32768, Protocol: "tcp"}}, container.Ports)
+	assert.Equal(t, []string{"/tmp/data:/data"}, container.Binds)
+	assert.Equal(t, "unhealthy", container.HealthStatus)
+}
+
+func TestParseContainerInspectNoContainers(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseContainerInspect("abc123", "[]")
+	assert.Error(t, err)
+}
+
+// This test does not call t.Parallel, as it enables dry run for the whole process.
+func TestPushSkippedInDryRun(t *testing.T) {
+	restore := dryrun.Enable(t)
+	defer restore()
+
+	// The registry doesn't exist, so a push that is actually run fails
+	require.NoError(t, PushE(t, logger.Default, "terratest-registry-that-does-not-exist.invalid/app:latest"))
+}
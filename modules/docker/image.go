@@ -0,0 +1,226 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// ImageInspect defines the output of the 'docker image inspect' command that tests most commonly assert on.
+type ImageInspect struct {
+	// ID of the image
+	ID string
+
+	// Tags of the image
+	RepoTags []string
+
+	// The user the image runs as, in the form USER[:GROUP], or empty if it runs as root by default
+	User string
+
+	// Labels set on the image
+	Labels map[string]string
+
+	// Environment variables of the image, in the form KEY=VALUE
+	Env []string
+
+	// Ports exposed by the image, in the form <port>/<protocol>, sorted
+	ExposedPorts []string
+
+	// Digests of the layers of the image, from the base layer up
+	Layers []string
+}
+
+// imageInspectOutput is the subset of the JSON 'docker image inspect' writes that is decoded into an ImageInspect.
+type imageInspectOutput struct {
+	Id       string
+	RepoTags []string
+	Config   struct {
+		User         string
+		Labels       map[string]string
+		Env          []string
+		ExposedPorts map[string]struct{}
+	}
+	RootFS struct {
+		Layers []string
+	}
+}
+
+// InspectImage runs the 'docker image inspect' command for the given image and returns the parsed output. This will
+// fail the test if there is an error.
+func InspectImage(t testing.TB, image string) *ImageInspect {
+	inspect, err := InspectImageE(t, image)
+	require.NoError(t, err)
+	return inspect
+}
+
+// InspectImageE runs the 'docker image inspect' command for the given image and returns the parsed output.
+func InspectImageE(t testing.TB, image string) (*ImageInspect, error) {
+	logger.Logf(t, "Running 'docker image inspect' on image '%s'", image)
+
+	cmd := shell.Command{
+		Command: "docker",
+		Args:    []string{"image", "inspect", image},
+		// Don't log the output, which can contain secrets baked into the image as environment variables
+		Logger: logger.Discard,
+	}
+
+	// Only parse stdout, as docker writes warnings (e.g. about the daemon config) to stderr
+	out, err := shell.RunCommandAndGetStdOutE(t, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseImageInspect(image, out)
+}
+
+// GetImageLayers returns the digests of the layers of the given image, from the base layer up. This will fail the
+// test if there is an error.
+func GetImageLayers(t testing.TB, image string) []string {
+	layers, err := GetImageLayersE(t, image)
+	require.NoError(t, err)
+	return layers
+}
+
+// GetImageLayersE returns the digests of the layers of the given image, from the base layer up. This is useful to
+// check the number of layers, or that an image shares its base layers with an approved base image.
+func GetImageLayersE(t testing.TB, image string) ([]string, error) {
+	inspect, err := InspectImageE(t, image)
+	if err != nil {
+		return nil, err
+	}
+	return inspect.Layers, nil
+}
+
+// AssertImageHasLabel checks that the given image has the given label set to the expected value, and fails the test
+// if it does not.
+func AssertImageHasLabel(t testing.TB, image string, key string, expectedValue string) {
+	require.NoError(t, AssertImageHasLabelE(t, image, key, expectedValue))
+}
+
+// AssertImageHasLabelE checks that the given image has the given label set to the expected value, and returns an
+// error if it does not.
+func AssertImageHasLabelE(t testing.TB, image string, key string, expectedValue string) error {
+	inspect, err := InspectImageE(t, image)
+	if err != nil {
+		return err
+	}
+	return checkImageLabel(image, inspect, key, expectedValue)
+}
+
+// AssertImageUser checks that the given image runs as the expected user, and fails the test if it does not.
+func AssertImageUser(t testing.TB, image string, expectedUser string) {
+	require.NoError(t, AssertImageUserE(t, image, expectedUser))
+}
+
+// AssertImageUserE checks that the given image runs as the expected user, and returns an error if it does not. The
+// expected user is compared with the USER instruction of the image as written, e.g. app, 1000, or 1000:1000.
+func AssertImageUserE(t testing.TB, image string, expectedUser string) error {
+	inspect, err := InspectImageE(t, image)
+	if err != nil {
+		return err
+	}
+	if inspect.User != expectedUser {
+		return fmt.Errorf("Expected image %s to run as user '%s', but it runs as '%s'", image, expectedUser, inspect.User)
+	}
+	return nil
+}
+
+// AssertImageNonRootUser checks that the given image does not run as root by default, and fails the test if it does.
+func AssertImageNonRootUser(t testing.TB, image string) {
+	require.NoError(t, AssertImageNonRootUserE(t, image))
+}
+
+// AssertImageNonRootUserE checks that the given image does not run as root by default, and returns an error if it
+// does. Images without a USER instruction run as root.
+func AssertImageNonRootUserE(t testing.TB, image string) error {
+	inspect, err := InspectImageE(t, image)
+	if err != nil {
+		return err
+	}
+	if isRootUser(inspect.User) {
+		return fmt.Errorf("Expected image %s to run as a non-root user, but it runs as '%s'", image, inspect.User)
+	}
+	return nil
+}
+
+// AssertImageExposesPort checks that the given image exposes the given port, and fails the test if it does not.
+func AssertImageExposesPort(t testing.TB, image string, port string) {
+	require.NoError(t, AssertImageExposesPortE(t, image, port))
+}
+
+// AssertImageExposesPortE checks that the given image exposes the given port, and returns an error if it does not. The
+// port is in the form <port>/<protocol>, e.g. 53/udp, or just <port> for a TCP port.
+func AssertImageExposesPortE(t testing.TB, image string, port string) error {
+	inspect, err := InspectImageE(t, image)
+	if err != nil {
+		return err
+	}
+	return checkImageExposesPort(image, inspect, port)
+}
+
+// parseImageInspect parses the JSON output of 'docker image inspect' for a single image.
+func parseImageInspect(image string, out string) (*ImageInspect, error) {
+	var images []imageInspectOutput
+	if err := json.Unmarshal([]byte(out), &images); err != nil {
+		return nil, err
+	}
+	if len(images) != 1 {
+		return nil, fmt.Errorf("Expected 'docker image inspect' to return 1 image for %s, but got %d", image, len(images))
+	}
+	output := images[0]
+
+	exposedPorts := []string{}
+	for port := range output.Config.ExposedPorts {
+		exposedPorts = append(exposedPorts, port)
+	}
+	sort.Strings(exposedPorts)
+
+	return &ImageInspect{
+		ID:           output.Id,
+		RepoTags:     output.RepoTags,
+		User:         output.Config.User,
+		Labels:       output.Config.Labels,
+		Env:          output.Config.Env,
+		ExposedPorts: exposedPorts,
+		Layers:       output.RootFS.Layers,
+	}, nil
+}
+
+// checkImageLabel returns an error if the given image does not have the given label set to the expected value.
+func checkImageLabel(image string, inspect *ImageInspect, key string, expectedValue string) error {
+	value, hasLabel := inspect.Labels[key]
+	if !hasLabel {
+		return fmt.Errorf("Expected image %s to have label %s, but it does not", image, key)
+	}
+	if value != expectedValue {
+		return fmt.Errorf("Expected label %s of image %s to be '%s', but it is '%s'", key, image, expectedValue, value)
+	}
+	return nil
+}
+
+// checkImageExposesPort returns an error if the given image does not expose the given port. Ports without a protocol
+// are TCP ports.
+func checkImageExposesPort(image string, inspect *ImageInspect, port string) error {
+	if !strings.Contains(port, "/") {
+		port = port + "/tcp"
+	}
+	for _, exposedPort := range inspect.ExposedPorts {
+		if exposedPort == port {
+			return nil
+		}
+	}
+	return fmt.Errorf("Expected image %s to expose port %s, but it only exposes %v", image, port, inspect.ExposedPorts)
+}
+
+// isRootUser returns true if the given USER of an image, in the form USER[:GROUP], is root. Images without a USER run
+// as root.
+func isRootUser(user string) bool {
+	userName := strings.SplitN(user, ":", 2)[0]
+	return userName == "" || userName == "root" || userName == "0"
+}
@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleImageInspectOutput = `[
+  {
+    "Id": "sha256:abc123",
+    "RepoTags": ["app:latest"],
+    "Config": {
+      "User": "app:app",
+      "Labels": {"org.opencontainers.image.source": "https://github.com/gruntwork-io/terratest"},
+      "Env": ["PATH=/usr/bin"],
+      "ExposedPorts": {"8080/tcp": {}, "53/udp": {}}
+    },
+    "RootFS": {"Type": "layers", "Layers": ["sha256:base", "sha256:app"]}
+  }
+]`
+
+func TestParseImageInspect(t *testing.T) {
+	t.Parallel()
+
+	inspect, err := parseImageInspect("app:latest", exampleImageInspectOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sha256:abc123", inspect.ID)
+	assert.Equal(t, []string{"app:latest"}, inspect.RepoTags)
+	assert.Equal(t, "app:app", inspect.User)
+	assert.Equal(t, map[string]string{"org.opencontainers.image.source": "https://github.com/gruntwork-io/terratest"}, inspect.Labels)
+	assert.Equal(t, []string{"PATH=/usr/bin"}, inspect.Env)
+	assert.Equal(t, []string{"53/udp", "8080/tcp"}, inspect.ExposedPorts)
+	assert.Equal(t, []string{"sha256:base", "sha256:app"}, inspect.Layers)
+
+	_, err = parseImageInspect("app:latest", "[]")
+	assert.Error(t, err)
+}
+
+func TestCheckImageLabel(t *testing.T) {
+	t.Parallel()
+
+	inspect := &ImageInspect{Labels: map[string]string{"team": "platform"}}
+
+	assert.NoError(t, checkImageLabel("app", inspect, "team", "platform"))
+	assert.Error(t, checkImageLabel("app", inspect, "team", "data"))
+	assert.Error(t, checkImageLabel("app", inspect, "owner", "platform"))
+}
+
+func TestCheckImageExposesPort(t *testing.T) {
+	t.Parallel()
+
+	inspect := &ImageInspect{ExposedPorts: []string{"53/udp", "8080/tcp"}}
+
+	testCases := []struct {
+		port     string
+		expected bool
+	}{
+		{"8080", true},
+		{"8080/tcp", true},
+		{"53/udp", true},
+		{"53", false},
+		{"9090", false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.port, func(t *testing.T) {
+			t.Parallel()
+			err := checkImageExposesPort("app", inspect, testCase.port)
+			assert.Equal(t, testCase.expected, err == nil)
+		})
+	}
+}
+
+func TestIsRootUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		user     string
+		expected bool
+	}{
+		{"", true},
+		{"root", true},
+		{"0", true},
+		{"0:0", true},
+		{"root:app", true},
+		{"app", false},
+		{"1000", false},
+		{"1000:0", false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.user, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, isRootUser(testCase.user))
+		})
+	}
+}
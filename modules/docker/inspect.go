@@ -0,0 +1,191 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// ContainerInspect defines the output of the 'docker container inspect' command that tests most commonly assert on.
+type ContainerInspect struct {
+	// ID of the container
+	ID string
+
+	// Name of the container, without the leading slash Docker adds
+	Name string
+
+	// The image the container was created from
+	Image string
+
+	// Time the container was created
+	Created time.Time
+
+	// Status of the container (e.g. running, exited)
+	Status string
+
+	// Whether the container is running
+	Running bool
+
+	// Exit code of the container, if it has exited
+	ExitCode int
+
+	// Labels set on the container, including the ones inherited from its image
+	Labels map[string]string
+
+	// Environment variables of the container, in the form KEY=VALUE
+	Env []string
+
+	// Container ports published to the host
+	Ports []Port
+
+	// Volumes mounted into the container, in the form HOST_PATH:CONTAINER_PATH
+	Binds []string
+
+	// Status of the container's healthcheck, or empty if the image does not define one
+	HealthStatus string
+}
+
+// Port represents a container port published to the host.
+type Port struct {
+	ContainerPort uint16
+	HostPort      uint16
+	Protocol      string
+}
+
+// inspectOutput is the subset of the JSON 'docker container inspect' writes that is decoded into a ContainerInspect.
+type inspectOutput struct {
+	Id      string
+	Created time.Time
+	Name    string
+	State   struct {
+		Status   string
+		Running  bool
+		ExitCode int
+		Health   *struct {
+			Status string
+		}
+	}
+	Config struct {
+		Image  string
+		Env    []string
+		Labels map[string]string
+	}
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostIp   string
+			HostPort string
+		}
+	}
+	HostConfig struct {
+		Binds []string
+	}
+}
+
+// Inspect runs the 'docker container inspect' command for the given container and returns the parsed output. This
+// will fail the test if there is an error.
+func Inspect(t testing.TB, id string) *ContainerInspect {
+	container, err := InspectE(t, id)
+	require.NoError(t, err)
+	return container
+}
+
+// InspectE runs the 'docker container inspect' command for the given container and returns the parsed output.
+func InspectE(t testing.TB, id string) (*ContainerInspect, error) {
+	logger.Logf(t, "Running 'docker container inspect' on container '%s'", id)
+
+	cmd := shell.Command{
+		Command: "docker",
+		Args:    []string{"container", "inspect", id},
+		// Don't log the output, which can contain secrets passed in as environment variables
+		Logger: logger.Discard,
+	}
+
+	// Only parse stdout, as docker writes warnings (e.g. about the daemon config) to stderr
+	out, err := shell.RunCommandAndGetStdOutE(t, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseContainerInspect(id, out)
+}
+
+// parseContainerInspect parses the JSON output of 'docker container inspect' for a single container.
+func parseContainerInspect(id string, out string) (*ContainerInspect, error) {
+	var containers []inspectOutput
+	if err := json.Unmarshal([]byte(out), &containers); err != nil {
+		return nil, err
+	}
+	if len(containers) != 1 {
+		return nil, fmt.Errorf("Expected 'docker container inspect' to return 1 container for %s, but got %d", id, len(containers))
+	}
+	container := containers[0]
+
+	ports, err := transformPorts(container)
+	if err != nil {
+		return nil, err
+	}
+
+	healthStatus := ""
+	if container.State.Health != nil {
+		healthStatus = container.State.Health.Status
+	}
+
+	return &ContainerInspect{
+		ID:           container.Id,
+		Name:         strings.TrimPrefix(container.Name, "/"),
+		Image:        container.Config.Image,
+		Created:      container.Created,
+		Status:       container.State.Status,
+		Running:      container.State.Running,
+		ExitCode:     container.State.ExitCode,
+		Labels:       container.Config.Labels,
+		Env:          container.Config.Env,
+		Ports:        ports,
+		Binds:        container.HostConfig.Binds,
+		HealthStatus: healthStatus,
+	}, nil
+}
+
+// transformPorts converts the port bindings of a container, which are keyed by "<port>/<protocol>", into Ports.
+// Container ports that are exposed but not published to the host are skipped.
+func transformPorts(container inspectOutput) ([]Port, error) {
+	ports := []Port{}
+
+	for key, bindings := range container.NetworkSettings.Ports {
+		keyParts := strings.SplitN(key, "/", 2)
+		containerPort, err := strconv.ParseUint(keyParts[0], 10, 16)
+		if err != nil {
+			return nil, err
+		}
+		protocol := "tcp"
+		if len(keyParts) == 2 {
+			protocol = keyParts[1]
+		}
+
+		for _, binding := range bindings {
+			hostPort, err := strconv.ParseUint(binding.HostPort, 10, 16)
+			if err != nil {
+				return nil, err
+			}
+			ports = append(ports, Port{ContainerPort: uint16(containerPort), HostPort: uint16(hostPort), Protocol: protocol})
+		}
+	}
+
+	// Map iteration order is random, so sort the ports to return them in a stable order
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].ContainerPort != ports[j].ContainerPort {
+			return ports[i].ContainerPort < ports[j].ContainerPort
+		}
+		return ports[i].HostPort < ports[j].HostPort
+	})
+
+	return ports, nil
+}
@@ -0,0 +1,171 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// The user name to log in to ACR with a refresh token from the ACR token exchange
+const acrUsername = "00000000-0000-0000-0000-000000000000"
+
+// The scope of the Azure AD tokens that ACR accepts in its token exchange
+const azureManagementScope = "https://management.azure.com/.default"
+
+// The Azure AD endpoint to request tokens from
+const azureLoginEndpoint = "https://login.microsoftonline.com"
+
+// LoginOptions defines options that can be passed to the 'docker login' command.
+type LoginOptions struct {
+	// The registry to log in to, e.g. us-docker.pkg.dev. If empty, Docker Hub is used.
+	Registry string
+
+	// The user name to log in with
+	Username string
+
+	// The password or token to log in with. It's passed to docker on stdin, so it doesn't show up in the logs.
+	Password string
+
+	// The logger to log the command and its output with. Defaults to logger.Global.
+	Logger *logger.Logger
+}
+
+// Login runs the 'docker login' command with the given options, so that subsequent pushes and pulls can use the
+// registry. This will fail the test if there is an error.
+func Login(t testing.TB, options *LoginOptions) {
+	require.NoError(t, LoginE(t, options))
+}
+
+// LoginE runs the 'docker login' command with the given options, so that subsequent pushes and pulls can use the
+// registry.
+func LoginE(t testing.TB, options *LoginOptions) error {
+	options.Logger.Logf(t, "Logging in to Docker registry %s as %s", options.Registry, options.Username)
+
+	// Make sure the password doesn't show up in the logs, e.g. if the command echoes it back in an error message
+	logger.RegisterSecret(options.Password)
+
+	cmd := shell.Command{
+		Command: "docker",
+		Args:    formatDockerLoginArgs(options),
+		Stdin:   strings.NewReader(options.Password),
+		Logger:  options.Logger,
+	}
+
+	_, err := shell.RunCommandAndGetOutputE(t, cmd)
+	return err
+}
+
+// LoginToAcr logs in to the given Azure Container Registry, e.g. myregistry.azurecr.io, by exchanging an Azure AD
+// token for an ACR refresh token. The Azure AD token is requested for the service principal in the AZURE_TENANT_ID,
+// AZURE_CLIENT_ID, and AZURE_CLIENT_SECRET environment variables. This will fail the test if there is an error.
+func LoginToAcr(t testing.TB, registry string, log *logger.Logger) {
+	require.NoError(t, LoginToAcrE(t, registry, log))
+}
+
+// LoginToAcrE logs in to the given Azure Container Registry, e.g. myregistry.azurecr.io, by exchanging an Azure AD
+// token for an ACR refresh token. The Azure AD token is requested for the service principal in the AZURE_TENANT_ID,
+// AZURE_CLIENT_ID, and AZURE_CLIENT_SECRET environment variables.
+func LoginToAcrE(t testing.TB, registry string, log *logger.Logger) error {
+	tenantID := os.Getenv("AZURE_TENANT_ID")
+	clientID := os.Getenv("AZURE_CLIENT_ID")
+	clientSecret := os.Getenv("AZURE_CLIENT_SECRET")
+	if tenantID == "" || clientID == "" || clientSecret == "" {
+		return fmt.Errorf("AZURE_TENANT_ID, AZURE_CLIENT_ID, and AZURE_CLIENT_SECRET must be set to log in to ACR %s", registry)
+	}
+
+	aadToken, err := getAzureAccessTokenE(azureLoginEndpoint, tenantID, clientID, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	refreshToken, err := exchangeAcrRefreshTokenE(fmt.Sprintf("https://%s/oauth2/exchange", registry), registry, tenantID, aadToken)
+	if err != nil {
+		return err
+	}
+
+	return LoginE(t, &LoginOptions{
+		Registry: registry,
+		Username: acrUsername,
+		Password: refreshToken,
+		Logger:   log,
+	})
+}
+
+// getAzureAccessTokenE requests an Azure AD access token for the Azure management API with the client credentials of
+// a service principal.
+func getAzureAccessTokenE(loginEndpoint string, tenantID string, clientID string, clientSecret string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {azureManagementScope},
+	}
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+	}
+	tokenUrl := fmt.Sprintf("%s/%s/oauth2/v2.0/token", loginEndpoint, tenantID)
+	if err := postFormForJSON(tokenUrl, form, &response); err != nil {
+		return "", fmt.Errorf("Failed to get an Azure AD token for client %s: %v", clientID, err)
+	}
+
+	return response.AccessToken, nil
+}
+
+// exchangeAcrRefreshTokenE exchanges an Azure AD access token for a refresh token of the given ACR registry, which
+// can be used as the password for 'docker login'.
+func exchangeAcrRefreshTokenE(exchangeUrl string, registry string, tenantID string, aadToken string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {registry},
+		"tenant":       {tenantID},
+		"access_token": {aadToken},
+	}
+
+	var response struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := postFormForJSON(exchangeUrl, form, &response); err != nil {
+		return "", fmt.Errorf("Failed to exchange an Azure AD token for an ACR refresh token for %s: %v", registry, err)
+	}
+
+	return response.RefreshToken, nil
+}
+
+// postFormForJSON posts the given form to the given URL and decodes the JSON response into the given value. The
+// request doesn't go through vcr, so no credentials end up in cassettes.
+func postFormForJSON(postUrl string, form url.Values, value interface{}) error {
+	resp, err := http.PostForm(postUrl, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("got status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, value)
+}
+
+// formatDockerLoginArgs formats the arguments for the 'docker login' command. The password is read from stdin.
+func formatDockerLoginArgs(options *LoginOptions) []string {
+	args := []string{"login", "--username", options.Username, "--password-stdin"}
+	if options.Registry != "" {
+		args = append(args, options.Registry)
+	}
+	return args
+}
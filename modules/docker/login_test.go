@@ -0,0 +1,64 @@
+package docker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatDockerLoginArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"login", "--username", "user", "--password-stdin"}, formatDockerLoginArgs(&LoginOptions{Username: "user", Password: "secret"}))
+	assert.Equal(
+		t,
+		[]string{"login", "--username", "oauth2accesstoken", "--password-stdin", "us-docker.pkg.dev"},
+		formatDockerLoginArgs(&LoginOptions{Registry: "us-docker.pkg.dev", Username: "oauth2accesstoken", Password: "token"}),
+	)
+}
+
+func TestGetAzureAccessToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my-tenant/oauth2/v2.0/token" || r.PostFormValue("client_secret") != "my-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client"}`)
+			return
+		}
+		assert.Equal(t, "client_credentials", r.PostFormValue("grant_type"))
+		assert.Equal(t, "my-client", r.PostFormValue("client_id"))
+		assert.Equal(t, azureManagementScope, r.PostFormValue("scope"))
+		fmt.Fprint(w, `{"token_type": "Bearer", "access_token": "aad-token"}`)
+	}))
+	defer server.Close()
+
+	token, err := getAzureAccessTokenE(server.URL, "my-tenant", "my-client", "my-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "aad-token", token)
+
+	_, err = getAzureAccessTokenE(server.URL, "my-tenant", "my-client", "wrong-secret")
+	assert.Error(t, err)
+}
+
+func TestExchangeAcrRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth2/exchange", r.URL.Path)
+		assert.Equal(t, "access_token", r.PostFormValue("grant_type"))
+		assert.Equal(t, "myregistry.azurecr.io", r.PostFormValue("service"))
+		assert.Equal(t, "my-tenant", r.PostFormValue("tenant"))
+		assert.Equal(t, "aad-token", r.PostFormValue("access_token"))
+		fmt.Fprint(w, `{"refresh_token": "acr-refresh-token"}`)
+	}))
+	defer server.Close()
+
+	token, err := exchangeAcrRefreshTokenE(server.URL+"/oauth2/exchange", "myregistry.azurecr.io", "my-tenant", "aad-token")
+	require.NoError(t, err)
+	assert.Equal(t, "acr-refresh-token", token)
+}
@@ -0,0 +1,35 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// Push runs the 'docker push' command to push the given tag to its registry. This will fail the test if there is an
+// error.
+func Push(t testing.TB, log *logger.Logger, tag string) {
+	require.NoError(t, PushE(t, log, tag))
+}
+
+// PushE runs the 'docker push' command to push the given tag to its registry. You must be logged in to the registry
+// already.
+func PushE(t testing.TB, log *logger.Logger, tag string) error {
+	if dryrun.Skip(t, "push Docker image %s", tag) {
+		return nil
+	}
+
+	log.Logf(t, "Running 'docker push' for tag %s", tag)
+
+	cmd := shell.Command{
+		Command: "docker",
+		Args:    []string{"push", tag},
+		Logger:  log,
+	}
+
+	_, err := shell.RunCommandAndGetOutputE(t, cmd)
+	return err
+}
@@ -0,0 +1,151 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// RunOptions defines options that can be passed to the 'docker run' command.
+type RunOptions struct {
+	// Override the default COMMAND of the Docker image
+	Command []string
+
+	// If set to true, pass the --detach flag to 'docker run' to run the container in the background
+	Detach bool
+
+	// Override the default ENTRYPOINT of the Docker image
+	Entrypoint string
+
+	// Set environment variables, in the form KEY=VALUE
+	EnvironmentVariables []string
+
+	// Labels to set on the container, in the form KEY=VALUE
+	Labels []string
+
+	// Assign a name to the container
+	Name string
+
+	// Publish container ports to the host, in the form HOST_PORT:CONTAINER_PORT
+	Ports []string
+
+	// If set to true, pass the --rm flag to 'docker run' to remove the container when it exits
+	Remove bool
+
+	// Mount volumes, in the form HOST_PATH:CONTAINER_PATH
+	Volumes []string
+
+	// Custom CLI options that will be passed as-is to the 'docker run' command. This is an "escape hatch" that allows
+	// Terratest to not have to support every single command-line option offered by the 'docker run' command, and
+	// solely focus on the most important ones.
+	OtherOptions []string
+
+	// The logger to log the command and its output with. Defaults to logger.Global.
+	Logger *logger.Logger
+}
+
+// Run runs the 'docker run' command on the given image with the given options and returns stdout/stderr. This method
+// fails the test if there are any errors.
+func Run(t testing.TB, image string, options *RunOptions) string {
+	out, err := RunE(t, image, options)
+	require.NoError(t, err)
+	return out
+}
+
+// RunE runs the 'docker run' command on the given image with the given options and returns stdout/stderr, or any
+// error. If the container exits with a non-zero exit code, use shell.GetExitCodeForRunCommandError on the error to
+// get that exit code.
+func RunE(t testing.TB, image string, options *RunOptions) (string, error) {
+	options.Logger.Logf(t, "Running 'docker run' on image '%s'", image)
+
+	cmd := shell.Command{
+		Command: "docker",
+		Args:    formatDockerRunArgs(image, options),
+		Logger:  options.Logger,
+	}
+
+	return shell.RunCommandAndGetOutputE(t, cmd)
+}
+
+// RunAndGetID runs the 'docker run' command on the given image with the given options in the background and returns
+// the ID of the container. This method fails the test if there are any errors. You should defer a call to Stop to
+// clean the container up when the test is done.
+func RunAndGetID(t testing.TB, image string, options *RunOptions) string {
+	id, err := RunAndGetIDE(t, image, options)
+	require.NoError(t, err)
+	return id
+}
+
+// RunAndGetIDE runs the 'docker run' command on the given image with the given options in the background and returns
+// the ID of the container, or any error. You should defer a call to StopE to clean the container up when the test is
+// done.
+func RunAndGetIDE(t testing.TB, image string, options *RunOptions) (string, error) {
+	// Copy the options, so that we don't modify the ones the caller passed in
+	detachedOptions := *options
+	detachedOptions.Detach = true
+
+	out, err := RunE(t, image, &detachedOptions)
+	if err != nil {
+		return "", err
+	}
+
+	return getContainerIDFromRunOutput(out)
+}
+
+// getContainerIDFromRunOutput returns the container ID from the output of 'docker run --detach', which ends with the
+// ID, but may be preceded by the output of pulling the image.
+func getContainerIDFromRunOutput(out string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	id := strings.TrimSpace(lines[len(lines)-1])
+	if id == "" {
+		return "", fmt.Errorf("Could not find the container ID in the output of 'docker run': %s", out)
+	}
+	return id, nil
+}
+
+// formatDockerRunArgs formats the arguments for the 'docker run' command.
+func formatDockerRunArgs(image string, options *RunOptions) []string {
+	args := []string{"run"}
+
+	if options.Detach {
+		args = append(args, "--detach")
+	}
+
+	if options.Entrypoint != "" {
+		args = append(args, "--entrypoint", options.Entrypoint)
+	}
+
+	for _, envVar := range options.EnvironmentVariables {
+		args = append(args, "--env", envVar)
+	}
+
+	for _, label := range options.Labels {
+		args = append(args, "--label", label)
+	}
+
+	if options.Name != "" {
+		args = append(args, "--name", options.Name)
+	}
+
+	for _, port := range options.Ports {
+		args = append(args, "--publish", port)
+	}
+
+	if options.Remove {
+		args = append(args, "--rm")
+	}
+
+	for _, volume := range options.Volumes {
+		args = append(args, "--volume", volume)
+	}
+
+	args = append(args, options.OtherOptions...)
+
+	args = append(args, image)
+
+	return append(args, options.Command...)
+}
@@ -0,0 +1,51 @@
+package docker
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// StopOptions defines options that can be passed to the 'docker stop' command.
+type StopOptions struct {
+	// Seconds to wait for the container to stop before killing it. If zero, Docker's default is used.
+	Time int
+
+	// The logger to log the command and its output with. Defaults to logger.Global.
+	Logger *logger.Logger
+}
+
+// Stop runs the 'docker stop' command for the given containers and returns stdout/stderr. This method fails the test
+// if there are any errors.
+func Stop(t testing.TB, containers []string, options *StopOptions) string {
+	out, err := StopE(t, containers, options)
+	require.NoError(t, err)
+	return out
+}
+
+// StopE runs the 'docker stop' command for the given containers and returns stdout/stderr, or any error.
+func StopE(t testing.TB, containers []string, options *StopOptions) (string, error) {
+	options.Logger.Logf(t, "Running 'docker stop' on containers '%s'", containers)
+
+	cmd := shell.Command{
+		Command: "docker",
+		Args:    formatDockerStopArgs(containers, options),
+		Logger:  options.Logger,
+	}
+
+	return shell.RunCommandAndGetOutputE(t, cmd)
+}
+
+// formatDockerStopArgs formats the arguments for the 'docker stop' command.
+func formatDockerStopArgs(containers []string, options *StopOptions) []string {
+	args := []string{"stop"}
+
+	if options.Time != 0 {
+		args = append(args, "--time", strconv.Itoa(options.Time))
+	}
+
+	return append(args, containers...)
+}
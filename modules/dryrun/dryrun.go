@@ -0,0 +1,69 @@
+// Package dryrun lets tests run without provisioning anything. When dry run is enabled, the helpers in terratest that
+// create, change, or delete cloud resources (e.g. aws.CreateS3BucketE or gcp.DeleteDiskE) log what they would have
+// done and return synthesized results instead of calling the cloud APIs, so you can check the orchestration of a test
+// (the order of steps, the names it generates, its cleanup) quickly and for free.
+//
+// The same goes for the terraform, kubectl, and helm commands that change resources (e.g. terraform apply, kubectl
+// delete, or helm install), which are logged and skipped. Read-only helpers and commands still run, so they may fail
+// for resources that were never created. Use terraform plan (e.g. terraform.InitAndPlanAndShowWithStruct) to check
+// what terraform would do.
+package dryrun
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/random"
+)
+
+// EnvVar is the environment variable that enables dry run when set to true (or 1).
+const EnvVar = "TERRATEST_DRY_RUN"
+
+// Enabled returns true if dry run is enabled.
+func Enabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(EnvVar))
+	return err == nil && enabled
+}
+
+// Enable turns on dry run for every test in the process, until the returned function is called. Note that this sets
+// an environment variable, so it is not safe to use in tests that run in parallel with tests that should not be dry
+// runs.
+func Enable(t testing.TB) func() {
+	previous, wasSet := os.LookupEnv(EnvVar)
+
+	logger.Logf(t, "Enabling dry run: no cloud resources will be created, changed, or deleted")
+	os.Setenv(EnvVar, "true")
+
+	return func() {
+		logger.Logf(t, "Disabling dry run")
+		if wasSet {
+			os.Setenv(EnvVar, previous)
+		} else {
+			os.Unsetenv(EnvVar)
+		}
+	}
+}
+
+// Skip returns true, after logging the action described by the given format and args, if dry run is enabled. Mutating
+// helpers call it first thing and return a synthesized result when it returns true:
+//
+//	if dryrun.Skip(t, "Create S3 bucket %s in %s", name, region) {
+//		return nil
+//	}
+func Skip(t testing.TB, format string, args ...interface{}) bool {
+	if !Enabled() {
+		return false
+	}
+
+	logger.Logf(t, "[dry run] Would %s", fmt.Sprintf(format, args...))
+	return true
+}
+
+// Id returns a unique, recognizable ID for a resource that a dry run pretended to create, e.g. "sg-dryrun-a1b2c3".
+func Id(prefix string) string {
+	return prefix + "dryrun-" + strings.ToLower(random.UniqueId())
+}
@@ -0,0 +1,52 @@
+package dryrun
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The tests in this file do not call t.Parallel, as they change the dry run environment variable.
+
+func TestEnableAndRestore(t *testing.T) {
+	os.Unsetenv(EnvVar)
+	assert.False(t, Enabled())
+	assert.False(t, Skip(t, "create %s", "something"))
+
+	restore := Enable(t)
+	assert.True(t, Enabled())
+	assert.True(t, Skip(t, "create %s", "something"))
+
+	restore()
+	assert.False(t, Enabled())
+	_, isSet := os.LookupEnv(EnvVar)
+	assert.False(t, isSet)
+}
+
+func TestEnabledParsesEnvVar(t *testing.T) {
+	defer os.Unsetenv(EnvVar)
+
+	testCases := []struct {
+		value    string
+		expected bool
+	}{
+		{"true", true},
+		{"1", true},
+		{"false", false},
+		{"yes-please", false},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		os.Setenv(EnvVar, testCase.value)
+		assert.Equal(t, testCase.expected, Enabled(), "Value %q", testCase.value)
+	}
+}
+
+func TestId(t *testing.T) {
+	id := Id("sg-")
+	assert.True(t, strings.HasPrefix(id, "sg-dryrun-"), id)
+	assert.NotEqual(t, id, Id("sg-"))
+}
@@ -0,0 +1,238 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/docker"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// The user name to log in to Artifact Registry (and Container Registry) with an OAuth2 access token
+const artifactRegistryUsername = "oauth2accesstoken"
+
+// The manifest media types we accept when looking up an image, so that the registry returns the digest of the image
+// as it was pushed, rather than converting it to an older schema.
+var acceptedManifestTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// The functions in this file talk to the Docker Registry HTTP API V2, which is served both by Google Container Registry
+// (e.g. gcr.io/my-project/my-image) and by Artifact Registry (e.g. us-docker.pkg.dev/my-project/my-repo/my-image). The
+// repository argument of each function is the full name of the image without a tag, in either of those formats. The
+// functions are named after container images to tell them apart from the Compute Image helpers in image.go.
+
+// AssertContainerImageExists checks that the given tag of the container image in the given repository exists, and
+// fails the test if it does not.
+func AssertContainerImageExists(t testing.TB, repository string, tag string) {
+	err := AssertContainerImageExistsE(t, repository, tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertContainerImageExistsE checks that the given tag of the container image in the given repository exists, and
+// returns an error if it does not.
+func AssertContainerImageExistsE(t testing.TB, repository string, tag string) error {
+	_, err := GetContainerImageDigestE(t, repository, tag)
+	return err
+}
+
+// GetContainerImageDigest gets the digest (e.g. sha256:abc123...) of the given tag of the container image in the given
+// repository.
+func GetContainerImageDigest(t testing.TB, repository string, tag string) string {
+	digest, err := GetContainerImageDigestE(t, repository, tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return digest
+}
+
+// GetContainerImageDigestE gets the digest (e.g. sha256:abc123...) of the given tag of the container image in the given
+// repository.
+func GetContainerImageDigestE(t testing.TB, repository string, tag string) (string, error) {
+	logger.Logf(t, "Getting digest of image %s:%s", repository, tag)
+
+	url, err := registryUrl(repository, "manifests/"+tag)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := doRegistryRequest(http.MethodHead, url)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Image %s:%s not found: %s returned status %d", repository, tag, url, response.StatusCode)
+	}
+
+	digest := response.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("%s did not return a Docker-Content-Digest header", url)
+	}
+
+	return digest, nil
+}
+
+// ListContainerImageTags lists the tags of the container image in the given repository.
+func ListContainerImageTags(t testing.TB, repository string) []string {
+	tags, err := ListContainerImageTagsE(t, repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tags
+}
+
+// ListContainerImageTagsE lists the tags of the container image in the given repository.
+func ListContainerImageTagsE(t testing.TB, repository string) ([]string, error) {
+	logger.Logf(t, "Listing tags of image %s", repository)
+
+	url, err := registryUrl(repository, "tags/list")
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := doRegistryRequest(http.MethodGet, url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d: %s", url, response.StatusCode, string(body))
+	}
+
+	var tagList struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal(body, &tagList); err != nil {
+		return nil, fmt.Errorf("Failed to parse tag list from %s: %v", url, err)
+	}
+
+	return tagList.Tags, nil
+}
+
+// DeleteContainerImage deletes the given tag of the container image in the given repository, along with the image it
+// points to.
+func DeleteContainerImage(t testing.TB, repository string, tag string) {
+	err := DeleteContainerImageE(t, repository, tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteContainerImageE deletes the given tag of the container image in the given repository, along with the image it
+// points to. The image itself can only be deleted if no other tags point to it, in which case this returns an error
+// after the tag is deleted.
+func DeleteContainerImageE(t testing.TB, repository string, tag string) error {
+	if dryrun.Skip(t, "delete container image %s:%s", repository, tag) {
+		return nil
+	}
+
+	digest, err := GetContainerImageDigestE(t, repository, tag)
+	if err != nil {
+		return err
+	}
+
+	logger.Logf(t, "Deleting container image %s:%s (%s)", repository, tag, digest)
+
+	// The registry refuses to delete an image that is still tagged, so first delete the tag, then the image
+	for _, reference := range []string{tag, digest} {
+		url, err := registryUrl(repository, "manifests/"+reference)
+		if err != nil {
+			return err
+		}
+
+		response, err := doRegistryRequest(http.MethodDelete, url)
+		if err != nil {
+			return err
+		}
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusAccepted {
+			return fmt.Errorf("Failed to delete %s: %s returned status %d", reference, url, response.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// LoginToArtifactRegistry logs in to the given Artifact Registry (or Container Registry) host, e.g. us-docker.pkg.dev,
+// with 'docker login', using an access token for the default Google credentials (see GetAccessToken). This will fail
+// the test if there is an error.
+func LoginToArtifactRegistry(t testing.TB, registry string, log *logger.Logger) {
+	err := LoginToArtifactRegistryE(t, registry, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// LoginToArtifactRegistryE logs in to the given Artifact Registry (or Container Registry) host, e.g. us-docker.pkg.dev,
+// with 'docker login', using an access token for the default Google credentials (see GetAccessToken).
+func LoginToArtifactRegistryE(t testing.TB, registry string, log *logger.Logger) error {
+	accessToken, err := GetAccessTokenE(t)
+	if err != nil {
+		return err
+	}
+
+	return docker.LoginE(t, &docker.LoginOptions{
+		Registry: registry,
+		Username: artifactRegistryUsername,
+		Password: accessToken,
+		Logger:   log,
+	})
+}
+
+// registryUrl returns the Docker Registry HTTP API V2 URL for the given path (e.g. tags/list) of the given repository.
+// For example, the tags/list path of gcr.io/my-project/my-image is https://gcr.io/v2/my-project/my-image/tags/list.
+func registryUrl(repository string, path string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(repository, "https://"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("Invalid image repository %s: expected a name like gcr.io/my-project/my-image", repository)
+	}
+
+	host := parts[0]
+	name := strings.TrimSuffix(parts[1], "/")
+
+	return fmt.Sprintf("https://%s/v2/%s/%s", host, name, path), nil
+}
+
+// doRegistryRequest makes a request to the given registry URL, authenticated with the default Google credentials.
+func doRegistryRequest(method string, url string) (*http.Response, error) {
+	ctx := context.Background()
+
+	tokenSource, err := newTokenSourceE(ctx, cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default token source: %v", err)
+	}
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get OAuth2 token: %v", err)
+	}
+
+	request, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	request.Header.Set("Accept", strings.Join(acceptedManifestTypes, ","))
+
+	return http.DefaultClient.Do(request)
+}
@@ -0,0 +1,42 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryUrl(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		repository  string
+		expectedUrl string
+	}{
+		{"gcr.io/my-project/my-image", "https://gcr.io/v2/my-project/my-image/tags/list"},
+		{"eu.gcr.io/my-project/team/my-image/", "https://eu.gcr.io/v2/my-project/team/my-image/tags/list"},
+		{"us-docker.pkg.dev/my-project/my-repo/my-image", "https://us-docker.pkg.dev/v2/my-project/my-repo/my-image/tags/list"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.repository, func(t *testing.T) {
+			t.Parallel()
+
+			url, err := registryUrl(testCase.repository, "tags/list")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedUrl, url)
+		})
+	}
+}
+
+func TestRegistryUrlInvalidRepository(t *testing.T) {
+	t.Parallel()
+
+	_, err := registryUrl("my-image", "tags/list")
+	assert.Error(t, err)
+}
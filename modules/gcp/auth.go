@@ -0,0 +1,171 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/timing"
+	"github.com/gruntwork-io/terratest/modules/vcr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+)
+
+// ImpersonateServiceAccountEnvVar is the environment variable that, when set to the email of a Service Account, makes
+// all the helpers in this package impersonate that Service Account rather than use the default credentials directly.
+// It is the same environment variable the Terraform Google provider uses, so setting it makes terraform impersonate
+// the Service Account as well.
+const ImpersonateServiceAccountEnvVar = "GOOGLE_IMPERSONATE_SERVICE_ACCOUNT"
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// ImpersonateServiceAccount makes all the helpers in this package (and terraform) impersonate the Service Account
+// with the given email, so a test can check that resources are reachable with the exact identity a workload will
+// use, rather than the broad credentials of the CI runner. The default credentials must have the Service Account
+// Token Creator role on the Service Account. Note that this sets an environment variable, so it applies to every test
+// in the process. Call the returned function to stop impersonating.
+func ImpersonateServiceAccount(t testing.TB, email string) func() {
+	previous, wasSet := os.LookupEnv(ImpersonateServiceAccountEnvVar)
+
+	logger.Logf(t, "Impersonating Service Account %s", email)
+	os.Setenv(ImpersonateServiceAccountEnvVar, email)
+
+	return func() {
+		logger.Logf(t, "No longer impersonating Service Account %s", email)
+		if wasSet {
+			os.Setenv(ImpersonateServiceAccountEnvVar, previous)
+		} else {
+			os.Unsetenv(ImpersonateServiceAccountEnvVar)
+		}
+	}
+}
+
+// newTokenSourceE returns a source of OAuth2 tokens with the given scopes for the default credentials, or for the
+// Service Account in ImpersonateServiceAccountEnvVar if it is set.
+func newTokenSourceE(ctx context.Context, scopes ...string) (oauth2.TokenSource, error) {
+	// Replaying a cassette doesn't need real credentials
+	if vcr.Replaying() {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "vcr-replay"}), nil
+	}
+
+	email := os.Getenv(ImpersonateServiceAccountEnvVar)
+	if email == "" {
+		return google.DefaultTokenSource(ctx, scopes...)
+	}
+
+	base, err := google.DefaultTokenSource(ctx, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth2.ReuseTokenSource(nil, &impersonatedTokenSource{ctx: ctx, base: base, email: email, scopes: scopes}), nil
+}
+
+// GetAccessToken returns an OAuth2 access token with the cloud-platform scope for the default credentials, or for
+// the Service Account in ImpersonateServiceAccountEnvVar if it is set. This is useful to authenticate to services
+// that accept Google access tokens but have no Go client, such as Artifact Registry. This will fail the test if there
+// is an error.
+func GetAccessToken(t testing.TB) string {
+	token, err := GetAccessTokenE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// GetAccessTokenE returns an OAuth2 access token with the cloud-platform scope for the default credentials, or for
+// the Service Account in ImpersonateServiceAccountEnvVar if it is set. This is useful to authenticate to services
+// that accept Google access tokens but have no Go client, such as Artifact Registry.
+func GetAccessTokenE(t testing.TB) (string, error) {
+	tokenSource, err := newTokenSourceE(context.Background(), cloudPlatformScope)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("Failed to get an access token: %v", err)
+	}
+
+	return token.AccessToken, nil
+}
+
+// newGoogleClientE returns an HTTP client that authenticates its requests with tokens from newTokenSourceE. Requests
+// go through the active vcr recorder, if there is one, but requests to fetch tokens do not, so no credentials end up
+// in cassettes. The time each request takes is recorded in the timing summary of the given test.
+func newGoogleClientE(t testing.TB, ctx context.Context, scopes ...string) (*http.Client, error) {
+	tokenSource, err := newTokenSourceE(ctx, scopes...)
+	if err != nil {
+		return nil, err
+	}
+	base := &timedTransport{t: t, base: vcr.WrapTransport(http.DefaultTransport)}
+	return &http.Client{Transport: &oauth2.Transport{Source: oauth2.ReuseTokenSource(nil, tokenSource), Base: base}}, nil
+}
+
+// newStorageClientE returns a Cloud Storage client that authenticates with tokens from newTokenSourceE.
+func newStorageClientE(t testing.TB, ctx context.Context) (*storage.Client, error) {
+	client, err := newGoogleClientE(t, ctx, storage.ScopeFullControl)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewClient(ctx, option.WithHTTPClient(client))
+}
+
+// timedTransport records how long each request to a Google API takes in the timing summary of a test (see the timing
+// package), under the host of the API and the HTTP method, e.g. "gcp compute.googleapis.com GET". The path is left out
+// of the name, as it holds the names of the resources.
+type timedTransport struct {
+	t    testing.TB
+	base http.RoundTripper
+}
+
+func (transport *timedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := transport.base.RoundTrip(request)
+
+	recordedErr := err
+	if err == nil && response.StatusCode >= http.StatusBadRequest {
+		recordedErr = fmt.Errorf("%s returned status %d", request.URL.Host, response.StatusCode)
+	}
+	timing.Record(transport.t, fmt.Sprintf("gcp %s %s", request.URL.Host, request.Method), time.Since(start), recordedErr)
+
+	return response, err
+}
+
+// impersonatedTokenSource gets tokens for a Service Account from the IAM Credentials API, using the base token
+// source to authenticate.
+type impersonatedTokenSource struct {
+	ctx    context.Context
+	base   oauth2.TokenSource
+	email  string
+	scopes []string
+}
+
+func (source *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	service, err := iamcredentials.New(oauth2.NewClient(source.ctx, source.base))
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("projects/-/serviceAccounts/%s", source.email)
+	request := &iamcredentials.GenerateAccessTokenRequest{Scope: source.scopes, Lifetime: "3600s"}
+
+	response, err := service.Projects.ServiceAccounts.GenerateAccessToken(name, request).Context(source.ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to impersonate Service Account %s: %v", source.email, err)
+	}
+
+	expiry, err := time.Parse(time.RFC3339, response.ExpireTime)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse expiry time %s of token for Service Account %s: %v", response.ExpireTime, source.email, err)
+	}
+
+	return &oauth2.Token{AccessToken: response.AccessToken, TokenType: "Bearer", Expiry: expiry}, nil
+}
@@ -0,0 +1,74 @@
+package gcp
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/timing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iam/v1"
+)
+
+// Note that this test modifies an environment variable, so it must not run in parallel with tests that read it
+func TestImpersonateServiceAccountRestoresEnvVar(t *testing.T) {
+	os.Setenv(ImpersonateServiceAccountEnvVar, "original@example.iam.gserviceaccount.com")
+	defer os.Unsetenv(ImpersonateServiceAccountEnvVar)
+
+	stop := ImpersonateServiceAccount(t, "workload@example.iam.gserviceaccount.com")
+	assert.Equal(t, "workload@example.iam.gserviceaccount.com", os.Getenv(ImpersonateServiceAccountEnvVar))
+
+	stop()
+	assert.Equal(t, "original@example.iam.gserviceaccount.com", os.Getenv(ImpersonateServiceAccountEnvVar))
+
+	os.Unsetenv(ImpersonateServiceAccountEnvVar)
+	stop = ImpersonateServiceAccount(t, "workload@example.iam.gserviceaccount.com")
+	stop()
+	_, isSet := os.LookupEnv(ImpersonateServiceAccountEnvVar)
+	assert.False(t, isSet)
+}
+
+func TestGetServiceAccountKeyJson(t *testing.T) {
+	t.Parallel()
+
+	key := &iam.ServiceAccountKey{Name: "key", PrivateKeyData: base64.StdEncoding.EncodeToString([]byte(`{"type": "service_account"}`))}
+	assert.Equal(t, `{"type": "service_account"}`, GetServiceAccountKeyJson(t, key))
+
+	_, err := GetServiceAccountKeyJsonE(t, &iam.ServiceAccountKey{Name: "key", PrivateKeyData: "not base64!"})
+	assert.Error(t, err)
+}
+
+func TestTimedTransportRecordsHostAndMethod(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	recorder := timing.Start(t)
+	defer timing.PrintSummary(t)
+
+	client := &http.Client{Transport: &timedTransport{t: t, base: http.DefaultTransport}}
+	for _, path := range []string{"/projects/p/instances/a", "/missing"} {
+		response, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		response.Body.Close()
+	}
+
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	entries := recorder.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "gcp "+serverUrl.Host+" GET", entries[0].Name)
+	assert.False(t, entries[0].Failed)
+	assert.Equal(t, "gcp "+serverUrl.Host+" GET", entries[1].Name)
+	assert.True(t, entries[1].Failed)
+}
@@ -0,0 +1,273 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/iterator"
+)
+
+// TestRunLabelKey is the key of the label TagResourcesForTest uses to mark the resources created by a test run.
+const TestRunLabelKey = "terratest-run"
+
+// TagResourcesForTest returns a set of labels containing a unique test run ID under TestRunLabelKey. Apply these
+// labels to every resource the test creates (e.g. by passing them to your Terraform code as a variable) and defer a
+// call to CleanupTestResources with the same ID, so that the resources are removed even if the test fails before it
+// can run terraform destroy.
+func TagResourcesForTest(t testing.TB) map[string]string {
+	// Label values may only contain lowercase letters, numbers, underscores, and dashes
+	runID := strings.ToLower(random.UniqueId())
+	logger.Logf(t, "Labeling resources for this test run with %s=%s", TestRunLabelKey, runID)
+
+	return map[string]string{TestRunLabelKey: runID}
+}
+
+// CleanupTestResources deletes all the Storage Buckets, Compute Instances, Disks, and Addresses in the given Project
+// that have the TestRunLabelKey label set to the given test run ID, and fails the test if any of them could not be
+// deleted.
+func CleanupTestResources(t testing.TB, projectID string, runID string) {
+	err := CleanupTestResourcesE(t, projectID, runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CleanupTestResourcesE deletes all the Storage Buckets, Compute Instances, Disks, and Addresses in the given Project
+// that have the TestRunLabelKey label set to the given test run ID. It carries on past resources it fails to delete
+// and returns an error listing all of them at the end.
+func CleanupTestResourcesE(t testing.TB, projectID string, runID string) error {
+	if dryrun.Skip(t, "delete resources in Project %s labeled with %s=%s", projectID, TestRunLabelKey, runID) {
+		return nil
+	}
+
+	logger.Logf(t, "Cleaning up resources in Project %s labeled with %s=%s", projectID, TestRunLabelKey, runID)
+
+	errs := []string{}
+	addErr := func(err error) {
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	addErr(cleanupStorageBucketsE(t, projectID, runID))
+
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	// Instances have to be gone before the Disks and Addresses they use can be deleted
+	addErr(cleanupInstancesE(t, service, projectID, runID))
+	addErr(cleanupDisksE(t, service, projectID, runID))
+	addErr(cleanupAddressesE(t, service, projectID, runID))
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to clean up all resources labeled with %s=%s in Project %s:\n%s", TestRunLabelKey, runID, projectID, strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func cleanupStorageBucketsE(t testing.TB, projectID string, runID string) error {
+	ctx := context.Background()
+
+	client, err := newStorageClientE(t, ctx)
+	if err != nil {
+		return err
+	}
+
+	bucketNames := []string{}
+	it := client.Buckets(ctx, projectID)
+	for {
+		bucketAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to list Storage Buckets in Project %s: %v", projectID, err)
+		}
+		if bucketAttrs.Labels[TestRunLabelKey] == runID {
+			bucketNames = append(bucketNames, bucketAttrs.Name)
+		}
+	}
+
+	errs := []string{}
+	for _, name := range bucketNames {
+		if err := EmptyStorageBucketE(t, name); err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to empty Storage Bucket %s: %v", name, err))
+			continue
+		}
+		if err := DeleteStorageBucketE(t, name); err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to delete Storage Bucket %s: %v", name, err))
+		}
+	}
+
+	return joinCleanupErrors(errs)
+}
+
+func cleanupInstancesE(t testing.TB, service *compute.Service, projectID string, runID string) error {
+	ctx := context.Background()
+
+	instances := []*compute.Instance{}
+	err := service.Instances.AggregatedList(projectID).Filter(testRunLabelFilter(runID)).Pages(ctx, func(page *compute.InstanceAggregatedList) error {
+		for _, scopedList := range page.Items {
+			instances = append(instances, scopedList.Instances...)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to list Compute Instances in Project %s: %v", projectID, err)
+	}
+
+	errs := []string{}
+	operations := map[string]*compute.Operation{}
+	for _, instance := range instances {
+		zone := ZoneUrlToZone(instance.Zone)
+		logger.Logf(t, "Deleting Compute Instance %s in %s", instance.Name, zone)
+
+		op, err := service.Instances.Delete(projectID, zone, instance.Name).Context(ctx).Do()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to delete Compute Instance %s: %v", instance.Name, err))
+			continue
+		}
+		operations[instance.Name] = op
+	}
+
+	for name, op := range operations {
+		if err := waitForZoneOperationE(t, service, projectID, ZoneUrlToZone(op.Zone), op.Name); err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to delete Compute Instance %s: %v", name, err))
+		}
+	}
+
+	return joinCleanupErrors(errs)
+}
+
+func cleanupDisksE(t testing.TB, service *compute.Service, projectID string, runID string) error {
+	ctx := context.Background()
+
+	disks := []*compute.Disk{}
+	err := service.Disks.AggregatedList(projectID).Filter(testRunLabelFilter(runID)).Pages(ctx, func(page *compute.DiskAggregatedList) error {
+		for _, scopedList := range page.Items {
+			disks = append(disks, scopedList.Disks...)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to list Compute Disks in Project %s: %v", projectID, err)
+	}
+
+	errs := []string{}
+	for _, disk := range disks {
+		var err error
+		if disk.Zone != "" {
+			logger.Logf(t, "Deleting Compute Disk %s in %s", disk.Name, ZoneUrlToZone(disk.Zone))
+			_, err = service.Disks.Delete(projectID, ZoneUrlToZone(disk.Zone), disk.Name).Context(ctx).Do()
+		} else {
+			logger.Logf(t, "Deleting Compute Disk %s in %s", disk.Name, RegionUrlToRegion(disk.Region))
+			_, err = service.RegionDisks.Delete(projectID, RegionUrlToRegion(disk.Region), disk.Name).Context(ctx).Do()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to delete Compute Disk %s: %v", disk.Name, err))
+		}
+	}
+
+	return joinCleanupErrors(errs)
+}
+
+func cleanupAddressesE(t testing.TB, service *compute.Service, projectID string, runID string) error {
+	ctx := context.Background()
+
+	addresses := []*compute.Address{}
+	err := service.Addresses.AggregatedList(projectID).Filter(testRunLabelFilter(runID)).Pages(ctx, func(page *compute.AddressAggregatedList) error {
+		for _, scopedList := range page.Items {
+			addresses = append(addresses, scopedList.Addresses...)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to list Compute Addresses in Project %s: %v", projectID, err)
+	}
+
+	errs := []string{}
+	for _, address := range addresses {
+		var err error
+		if address.Region != "" {
+			logger.Logf(t, "Deleting Compute Address %s in %s", address.Name, RegionUrlToRegion(address.Region))
+			_, err = service.Addresses.Delete(projectID, RegionUrlToRegion(address.Region), address.Name).Context(ctx).Do()
+		} else {
+			logger.Logf(t, "Deleting global Compute Address %s", address.Name)
+			_, err = service.GlobalAddresses.Delete(projectID, address.Name).Context(ctx).Do()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Failed to delete Compute Address %s: %v", address.Name, err))
+		}
+	}
+
+	return joinCleanupErrors(errs)
+}
+
+// operationPollPolicy is how long-running GCP operations are polled: quickly at first, as many finish within seconds,
+// and then every 30 seconds, for about 5 minutes in total. A poll that hangs is cancelled after 30 seconds.
+var operationPollPolicy = retry.ExponentialPolicy(15, time.Second, 30*time.Second).WithJitter(0.2).WithAttemptTimeout(30 * time.Second)
+
+// waitForZoneOperationE waits until the given zonal Compute operation is done, returning an error if it failed.
+func waitForZoneOperationE(t testing.TB, service *compute.Service, projectID string, zone string, operationName string) error {
+	description := fmt.Sprintf("Waiting for Compute operation %s to finish", operationName)
+
+	_, err := retry.DoWithPolicyContextE(t, description, operationPollPolicy, func(ctx context.Context) (string, error) {
+		op, err := service.ZoneOperations.Get(projectID, zone, operationName).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+		if op.Status != "DONE" {
+			return "", fmt.Errorf("Compute operation %s is %s", operationName, op.Status)
+		}
+		if op.Error != nil && len(op.Error.Errors) > 0 {
+			return "", retry.FatalError{Underlying: fmt.Errorf("Compute operation %s failed: %s", operationName, op.Error.Errors[0].Message)}
+		}
+		return "", nil
+	})
+
+	return err
+}
+
+// waitForGlobalOperationE waits until the given global Compute operation is done, returning an error if it failed.
+func waitForGlobalOperationE(t testing.TB, service *compute.Service, projectID string, operationName string) error {
+	description := fmt.Sprintf("Waiting for Compute operation %s to finish", operationName)
+
+	_, err := retry.DoWithPolicyContextE(t, description, operationPollPolicy, func(ctx context.Context) (string, error) {
+		op, err := service.GlobalOperations.Get(projectID, operationName).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+		if op.Status != "DONE" {
+			return "", fmt.Errorf("Compute operation %s is %s", operationName, op.Status)
+		}
+		if op.Error != nil && len(op.Error.Errors) > 0 {
+			return "", retry.FatalError{Underlying: fmt.Errorf("Compute operation %s failed: %s", operationName, op.Error.Errors[0].Message)}
+		}
+		return "", nil
+	})
+
+	return err
+}
+
+// testRunLabelFilter returns a Compute API list filter that matches resources labeled with the given test run ID.
+func testRunLabelFilter(runID string) string {
+	return fmt.Sprintf("labels.%s = %s", TestRunLabelKey, runID)
+}
+
+func joinCleanupErrors(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "\n"))
+}
@@ -0,0 +1,25 @@
+package gcp
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagResourcesForTestReturnsValidUniqueLabel(t *testing.T) {
+	t.Parallel()
+
+	labels := TagResourcesForTest(t)
+	require.Contains(t, labels, TestRunLabelKey)
+	assert.Regexp(t, regexp.MustCompile(`^[a-z0-9_-]{1,63}$`), labels[TestRunLabelKey])
+
+	assert.NotEqual(t, labels[TestRunLabelKey], TagResourcesForTest(t)[TestRunLabelKey])
+}
+
+func TestTestRunLabelFilter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "labels.terratest-run = abc123", testRunLabelFilter("abc123"))
+}
@@ -9,11 +9,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gruntwork-io/terratest/modules/customerrors"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/retry"
 
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/random"
-	"golang.org/x/oauth2/google"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -42,12 +43,12 @@ type RegionalInstanceGroup struct {
 }
 
 type InstanceGroup interface {
-	GetInstanceIds(t *testing.T) []string
-	GetInstanceIdsE(t *testing.T) ([]string, error)
+	GetInstanceIds(t testing.TB) []string
+	GetInstanceIdsE(t testing.TB) ([]string, error)
 }
 
 // FetchInstance queries GCP to return an instance of the (GCP Compute) Instance type
-func FetchInstance(t *testing.T, projectID string, name string) *Instance {
+func FetchInstance(t testing.TB, projectID string, name string) *Instance {
 	instance, err := FetchInstanceE(t, projectID, name)
 	if err != nil {
 		t.Fatal(err)
@@ -57,7 +58,7 @@ func FetchInstance(t *testing.T, projectID string, name string) *Instance {
 }
 
 // FetchInstance queries GCP to return an instance of the (GCP Compute) Instance type
-func FetchInstanceE(t *testing.T, projectID string, name string) (*Instance, error) {
+func FetchInstanceE(t testing.TB, projectID string, name string) (*Instance, error) {
 	logger.Logf(t, "Getting Compute Instance %s", name)
 
 	ctx := context.Background()
@@ -70,6 +71,9 @@ func FetchInstanceE(t *testing.T, projectID string, name string) (*Instance, err
 	// and match on name.
 	instanceAggregatedList, err := service.Instances.AggregatedList(projectID).Context(ctx).Do()
 	if err != nil {
+		if wrappedErr := wrapGcpError(err, "Compute Instance", name, ""); isCategorizedError(wrappedErr) {
+			return nil, wrappedErr
+		}
 		return nil, fmt.Errorf("Instances.AggregatedList(%s) got error: %v", projectID, err)
 	}
 
@@ -81,11 +85,14 @@ func FetchInstanceE(t *testing.T, projectID string, name string) (*Instance, err
 		}
 	}
 
-	return nil, fmt.Errorf("Compute Instance %s could not be found in project %s", name, projectID)
+	return nil, customerrors.NotFoundError{
+		ResourceContext: customerrors.ResourceContext{Kind: "Compute Instance", Name: name},
+		Underlying:      fmt.Errorf("no instance with that name in project %s", projectID),
+	}
 }
 
 // FetchImage queries GCP to return a new instance of the (GCP Compute) Image type
-func FetchImage(t *testing.T, projectID string, name string) *Image {
+func FetchImage(t testing.TB, projectID string, name string) *Image {
 	image, err := FetchImageE(t, projectID, name)
 	if err != nil {
 		t.Fatal(err)
@@ -95,7 +102,7 @@ func FetchImage(t *testing.T, projectID string, name string) *Image {
 }
 
 // FetchImage queries GCP to return a new instance of the (GCP Compute) Image type
-func FetchImageE(t *testing.T, projectID string, name string) (*Image, error) {
+func FetchImageE(t testing.TB, projectID string, name string) (*Image, error) {
 	logger.Logf(t, "Getting Image %s", name)
 
 	ctx := context.Background()
@@ -114,7 +121,7 @@ func FetchImageE(t *testing.T, projectID string, name string) (*Image, error) {
 }
 
 // FetchRegionalInstanceGroup queries GCP to return a new instance of the Regional Instance Group type
-func FetchRegionalInstanceGroup(t *testing.T, projectID string, region string, name string) *RegionalInstanceGroup {
+func FetchRegionalInstanceGroup(t testing.TB, projectID string, region string, name string) *RegionalInstanceGroup {
 	instanceGroup, err := FetchRegionalInstanceGroupE(t, projectID, region, name)
 	if err != nil {
 		t.Fatal(err)
@@ -124,7 +131,7 @@ func FetchRegionalInstanceGroup(t *testing.T, projectID string, region string, n
 }
 
 // FetchRegionalInstanceGroup queries GCP to return a new instance of the Regional Instance Group type
-func FetchRegionalInstanceGroupE(t *testing.T, projectID string, region string, name string) (*RegionalInstanceGroup, error) {
+func FetchRegionalInstanceGroupE(t testing.TB, projectID string, region string, name string) (*RegionalInstanceGroup, error) {
 	logger.Logf(t, "Getting Regional Instance Group %s", name)
 
 	ctx := context.Background()
@@ -143,7 +150,7 @@ func FetchRegionalInstanceGroupE(t *testing.T, projectID string, region string,
 }
 
 // FetchZonalInstanceGroup queries GCP to return a new instance of the Regional Instance Group type
-func FetchZonalInstanceGroup(t *testing.T, projectID string, zone string, name string) *ZonalInstanceGroup {
+func FetchZonalInstanceGroup(t testing.TB, projectID string, zone string, name string) *ZonalInstanceGroup {
 	instanceGroup, err := FetchZonalInstanceGroupE(t, projectID, zone, name)
 	if err != nil {
 		t.Fatal(err)
@@ -153,7 +160,7 @@ func FetchZonalInstanceGroup(t *testing.T, projectID string, zone string, name s
 }
 
 // FetchZonalInstanceGroup queries GCP to return a new instance of the Regional Instance Group type
-func FetchZonalInstanceGroupE(t *testing.T, projectID string, zone string, name string) (*ZonalInstanceGroup, error) {
+func FetchZonalInstanceGroupE(t testing.TB, projectID string, zone string, name string) (*ZonalInstanceGroup, error) {
 	logger.Logf(t, "Getting Zonal Instance Group %s", name)
 
 	ctx := context.Background()
@@ -172,7 +179,7 @@ func FetchZonalInstanceGroupE(t *testing.T, projectID string, zone string, name
 }
 
 // GetPublicIP gets the public IP address of the given Compute Instance.
-func (i *Instance) GetPublicIp(t *testing.T) string {
+func (i *Instance) GetPublicIp(t testing.TB) string {
 	ip, err := i.GetPublicIpE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -181,7 +188,7 @@ func (i *Instance) GetPublicIp(t *testing.T) string {
 }
 
 // GetPublicIpE gets the public IP address of the given Compute Instance.
-func (i *Instance) GetPublicIpE(t *testing.T) (string, error) {
+func (i *Instance) GetPublicIpE(t testing.TB) (string, error) {
 	// If there are no accessConfigs specified, then this instance will have no external internet access:
 	// https://cloud.google.com/compute/docs/reference/rest/v1/instances.
 	if len(i.NetworkInterfaces[0].AccessConfigs) == 0 {
@@ -194,17 +201,17 @@ func (i *Instance) GetPublicIpE(t *testing.T) (string, error) {
 }
 
 // GetLabels returns all the tags for the given Compute Instance.
-func (i *Instance) GetLabels(t *testing.T) map[string]string {
+func (i *Instance) GetLabels(t testing.TB) map[string]string {
 	return i.Labels
 }
 
 // GetZone returns the Zone in which the Compute Instance is located.
-func (i *Instance) GetZone(t *testing.T) string {
+func (i *Instance) GetZone(t testing.TB) string {
 	return ZoneUrlToZone(i.Zone)
 }
 
 // SetLabels adds the tags to the given Compute Instance.
-func (i *Instance) SetLabels(t *testing.T, labels map[string]string) {
+func (i *Instance) SetLabels(t testing.TB, labels map[string]string) {
 	err := i.SetLabelsE(t, labels)
 	if err != nil {
 		t.Fatal(err)
@@ -212,7 +219,11 @@ func (i *Instance) SetLabels(t *testing.T, labels map[string]string) {
 }
 
 // SetLabelsE adds the tags to the given Compute Instance.
-func (i *Instance) SetLabelsE(t *testing.T, labels map[string]string) error {
+func (i *Instance) SetLabelsE(t testing.TB, labels map[string]string) error {
+	if dryrun.Skip(t, "set labels %v on Compute Instance %s", labels, i.Name) {
+		return nil
+	}
+
 	logger.Logf(t, "Adding labels to instance %s in zone %s", i.Name, i.Zone)
 
 	ctx := context.Background()
@@ -230,12 +241,12 @@ func (i *Instance) SetLabelsE(t *testing.T, labels map[string]string) error {
 }
 
 // GetMetadata gets the given Compute Instance's metadata
-func (i *Instance) GetMetadata(t *testing.T) []*compute.MetadataItems {
+func (i *Instance) GetMetadata(t testing.TB) []*compute.MetadataItems {
 	return i.Metadata.Items
 }
 
 // SetMetadata sets the given Compute Instance's metadata
-func (i *Instance) SetMetadata(t *testing.T, metadata map[string]string) {
+func (i *Instance) SetMetadata(t testing.TB, metadata map[string]string) {
 	err := i.SetMetadataE(t, metadata)
 	if err != nil {
 		t.Fatal(err)
@@ -243,7 +254,11 @@ func (i *Instance) SetMetadata(t *testing.T, metadata map[string]string) {
 }
 
 // SetLabelsE adds the given metadata map to the existing metadata of the given Compute Instance.
-func (i *Instance) SetMetadataE(t *testing.T, metadata map[string]string) error {
+func (i *Instance) SetMetadataE(t testing.TB, metadata map[string]string) error {
+	if dryrun.Skip(t, "set metadata on Compute Instance %s", i.Name) {
+		return nil
+	}
+
 	logger.Logf(t, "Adding metadata to instance %s in zone %s", i.Name, i.Zone)
 
 	ctx := context.Background()
@@ -263,7 +278,7 @@ func (i *Instance) SetMetadataE(t *testing.T, metadata map[string]string) error
 
 // newMetadata takes in a Compute Instance's existing metadata plus a new set of key-value pairs and returns an updated
 // metadata object.
-func newMetadata(t *testing.T, oldMetadata *compute.Metadata, kvs map[string]string) *compute.Metadata {
+func newMetadata(t testing.TB, oldMetadata *compute.Metadata, kvs map[string]string) *compute.Metadata {
 	items := []*compute.MetadataItems{}
 
 	for key, val := range kvs {
@@ -284,7 +299,7 @@ func newMetadata(t *testing.T, oldMetadata *compute.Metadata, kvs map[string]str
 }
 
 // Add the given public SSH key to the Compute Instance. Users can SSH in with the given username.
-func (i *Instance) AddSshKey(t *testing.T, username string, publicKey string) {
+func (i *Instance) AddSshKey(t testing.TB, username string, publicKey string) {
 	err := i.AddSshKeyE(t, username, publicKey)
 	if err != nil {
 		t.Fatal(err)
@@ -292,7 +307,11 @@ func (i *Instance) AddSshKey(t *testing.T, username string, publicKey string) {
 }
 
 // Add the given public SSH key to the Compute Instance. Users can SSH in with the given username.
-func (i *Instance) AddSshKeyE(t *testing.T, username string, publicKey string) error {
+func (i *Instance) AddSshKeyE(t testing.TB, username string, publicKey string) error {
+	if dryrun.Skip(t, "add SSH key for user %s to Compute Instance %s", username, i.Name) {
+		return nil
+	}
+
 	logger.Logf(t, "Adding SSH Key to Compute Instance %s for username %s\n", i.Name, username)
 
 	// We represent the key in the format required per GCP docs (https://cloud.google.com/compute/docs/instances/adding-removing-ssh-keys)
@@ -311,16 +330,20 @@ func (i *Instance) AddSshKeyE(t *testing.T, username string, publicKey string) e
 	return nil
 }
 
-// DeleteImage deletes the given Compute Image.
-func (i *Image) DeleteImage(t *testing.T) {
+// DeleteImage deletes the given Compute Image and waits until it is gone.
+func (i *Image) DeleteImage(t testing.TB) {
 	err := i.DeleteImageE(t)
 	if err != nil {
 		t.Fatal(err)
 	}
 }
 
-// DeleteImageE deletes the given Compute Image.
-func (i *Image) DeleteImageE(t *testing.T) error {
+// DeleteImageE deletes the given Compute Image and waits until it is gone.
+func (i *Image) DeleteImageE(t testing.TB) error {
+	if dryrun.Skip(t, "delete Image %s", i.Name) {
+		return nil
+	}
+
 	logger.Logf(t, "Destroying Image %s", i.Name)
 
 	ctx := context.Background()
@@ -329,15 +352,16 @@ func (i *Image) DeleteImageE(t *testing.T) error {
 		return err
 	}
 
-	if _, err := service.Images.Delete(i.projectID, i.Name).Context(ctx).Do(); err != nil {
+	op, err := service.Images.Delete(i.projectID, i.Name).Context(ctx).Do()
+	if err != nil {
 		return fmt.Errorf("Images.Delete(%s) got error: %v", i.Name, err)
 	}
 
-	return nil
+	return waitForGlobalOperationE(t, service, i.projectID, op.Name)
 }
 
 // GetInstanceIds gets the IDs of Instances in the given Instance Group.
-func (ig *ZonalInstanceGroup) GetInstanceIds(t *testing.T) []string {
+func (ig *ZonalInstanceGroup) GetInstanceIds(t testing.TB) []string {
 	ids, err := ig.GetInstanceIdsE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -346,7 +370,7 @@ func (ig *ZonalInstanceGroup) GetInstanceIds(t *testing.T) []string {
 }
 
 // GetInstanceIdsE gets the IDs of Instances in the given Zonal Instance Group.
-func (ig *ZonalInstanceGroup) GetInstanceIdsE(t *testing.T) ([]string, error) {
+func (ig *ZonalInstanceGroup) GetInstanceIdsE(t testing.TB) ([]string, error) {
 	logger.Logf(t, "Get instances for Zonal Instance Group %s", ig.Name)
 
 	ctx := context.Background()
@@ -382,7 +406,7 @@ func (ig *ZonalInstanceGroup) GetInstanceIdsE(t *testing.T) ([]string, error) {
 }
 
 // GetInstanceIds gets the IDs of Instances in the given Regional Instance Group.
-func (ig *RegionalInstanceGroup) GetInstanceIds(t *testing.T) []string {
+func (ig *RegionalInstanceGroup) GetInstanceIds(t testing.TB) []string {
 	ids, err := ig.GetInstanceIdsE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -391,7 +415,7 @@ func (ig *RegionalInstanceGroup) GetInstanceIds(t *testing.T) []string {
 }
 
 // GetInstanceIdsE gets the IDs of Instances in the given Regional Instance Group.
-func (ig *RegionalInstanceGroup) GetInstanceIdsE(t *testing.T) ([]string, error) {
+func (ig *RegionalInstanceGroup) GetInstanceIdsE(t testing.TB) ([]string, error) {
 	logger.Logf(t, "Get instances for Regional Instance Group %s", ig.Name)
 
 	ctx := context.Background()
@@ -428,27 +452,27 @@ func (ig *RegionalInstanceGroup) GetInstanceIdsE(t *testing.T) ([]string, error)
 }
 
 // Return a collection of Instance structs from the given Instance Group
-func (ig *ZonalInstanceGroup) GetInstances(t *testing.T, projectId string) []*Instance {
+func (ig *ZonalInstanceGroup) GetInstances(t testing.TB, projectId string) []*Instance {
 	return getInstances(t, ig, projectId)
 }
 
 // Return a collection of Instance structs from the given Instance Group
-func (ig *ZonalInstanceGroup) GetInstancesE(t *testing.T, projectId string) ([]*Instance, error) {
+func (ig *ZonalInstanceGroup) GetInstancesE(t testing.TB, projectId string) ([]*Instance, error) {
 	return getInstancesE(t, ig, projectId)
 }
 
 // Return a collection of Instance structs from the given Instance Group
-func (ig *RegionalInstanceGroup) GetInstances(t *testing.T, projectId string) []*Instance {
+func (ig *RegionalInstanceGroup) GetInstances(t testing.TB, projectId string) []*Instance {
 	return getInstances(t, ig, projectId)
 }
 
 // Return a collection of Instance structs from the given Instance Group
-func (ig *RegionalInstanceGroup) GetInstancesE(t *testing.T, projectId string) ([]*Instance, error) {
+func (ig *RegionalInstanceGroup) GetInstancesE(t testing.TB, projectId string) ([]*Instance, error) {
 	return getInstancesE(t, ig, projectId)
 }
 
 // getInstancesE returns a collection of Instance structs from the given Instance Group
-func getInstances(t *testing.T, ig InstanceGroup, projectId string) []*Instance {
+func getInstances(t testing.TB, ig InstanceGroup, projectId string) []*Instance {
 	instances, err := getInstancesE(t, ig, projectId)
 	if err != nil {
 		t.Fatal(err)
@@ -458,7 +482,7 @@ func getInstances(t *testing.T, ig InstanceGroup, projectId string) []*Instance
 }
 
 // getInstancesE returns a collection of Instance structs from the given Instance Group
-func getInstancesE(t *testing.T, ig InstanceGroup, projectId string) ([]*Instance, error) {
+func getInstancesE(t testing.TB, ig InstanceGroup, projectId string) ([]*Instance, error) {
 	instanceIds, err := ig.GetInstanceIdsE(t)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get Instance Group IDs: %s", err)
@@ -479,27 +503,27 @@ func getInstancesE(t *testing.T, ig InstanceGroup, projectId string) ([]*Instanc
 }
 
 // GetPublicIps returns a slice of the public IPs from the given Instance Group
-func (ig *ZonalInstanceGroup) GetPublicIps(t *testing.T, projectId string) []string {
+func (ig *ZonalInstanceGroup) GetPublicIps(t testing.TB, projectId string) []string {
 	return getPublicIps(t, ig, projectId)
 }
 
 // GetPublicIpsE returns a slice of the public IPs from the given Instance Group
-func (ig *ZonalInstanceGroup) GetPublicIpsE(t *testing.T, projectId string) ([]string, error) {
+func (ig *ZonalInstanceGroup) GetPublicIpsE(t testing.TB, projectId string) ([]string, error) {
 	return getPublicIpsE(t, ig, projectId)
 }
 
 // GetPublicIps returns a slice of the public IPs from the given Instance Group
-func (ig *RegionalInstanceGroup) GetPublicIps(t *testing.T, projectId string) []string {
+func (ig *RegionalInstanceGroup) GetPublicIps(t testing.TB, projectId string) []string {
 	return getPublicIps(t, ig, projectId)
 }
 
 // GetPublicIpsE returns a slice of the public IPs from the given Instance Group
-func (ig *RegionalInstanceGroup) GetPublicIpsE(t *testing.T, projectId string) ([]string, error) {
+func (ig *RegionalInstanceGroup) GetPublicIpsE(t testing.TB, projectId string) ([]string, error) {
 	return getPublicIpsE(t, ig, projectId)
 }
 
 // getPublicIps a slice of the public IPs from the given Instance Group
-func getPublicIps(t *testing.T, ig InstanceGroup, projectId string) []string {
+func getPublicIps(t testing.TB, ig InstanceGroup, projectId string) []string {
 	ips, err := getPublicIpsE(t, ig, projectId)
 	if err != nil {
 		t.Fatal(err)
@@ -509,7 +533,7 @@ func getPublicIps(t *testing.T, ig InstanceGroup, projectId string) []string {
 }
 
 // getPublicIpsE a slice of the public IPs from the given Instance Group
-func getPublicIpsE(t *testing.T, ig InstanceGroup, projectId string) ([]string, error) {
+func getPublicIpsE(t testing.TB, ig InstanceGroup, projectId string) ([]string, error) {
 	instances, err := getInstancesE(t, ig, projectId)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get Compute Instances from Instance Group: %s", err)
@@ -526,26 +550,26 @@ func getPublicIpsE(t *testing.T, ig InstanceGroup, projectId string) ([]string,
 }
 
 // getRandomInstance returns a randomly selected Instance from the Regional Instance Group
-func (ig *ZonalInstanceGroup) GetRandomInstance(t *testing.T) *Instance {
+func (ig *ZonalInstanceGroup) GetRandomInstance(t testing.TB) *Instance {
 	return getRandomInstance(t, ig, ig.Name, ig.Region, ig.Size, ig.projectID)
 }
 
 // getRandomInstanceE returns a randomly selected Instance from the Regional Instance Group
-func (ig *ZonalInstanceGroup) GetRandomInstanceE(t *testing.T) (*Instance, error) {
+func (ig *ZonalInstanceGroup) GetRandomInstanceE(t testing.TB) (*Instance, error) {
 	return getRandomInstanceE(t, ig, ig.Name, ig.Region, ig.Size, ig.projectID)
 }
 
 // getRandomInstance returns a randomly selected Instance from the Regional Instance Group
-func (ig *RegionalInstanceGroup) GetRandomInstance(t *testing.T) *Instance {
+func (ig *RegionalInstanceGroup) GetRandomInstance(t testing.TB) *Instance {
 	return getRandomInstance(t, ig, ig.Name, ig.Region, ig.Size, ig.projectID)
 }
 
 // getRandomInstanceE returns a randomly selected Instance from the Regional Instance Group
-func (ig *RegionalInstanceGroup) GetRandomInstanceE(t *testing.T) (*Instance, error) {
+func (ig *RegionalInstanceGroup) GetRandomInstanceE(t testing.TB) (*Instance, error) {
 	return getRandomInstanceE(t, ig, ig.Name, ig.Region, ig.Size, ig.projectID)
 }
 
-func getRandomInstance(t *testing.T, ig InstanceGroup, name string, region string, size int64, projectID string) *Instance {
+func getRandomInstance(t testing.TB, ig InstanceGroup, name string, region string, size int64, projectID string) *Instance {
 	instance, err := getRandomInstanceE(t, ig, name, region, size, projectID)
 	if err != nil {
 		t.Fatal(err)
@@ -554,7 +578,7 @@ func getRandomInstance(t *testing.T, ig InstanceGroup, name string, region strin
 	return instance
 }
 
-func getRandomInstanceE(t *testing.T, ig InstanceGroup, name string, region string, size int64, projectID string) (*Instance, error) {
+func getRandomInstanceE(t testing.TB, ig InstanceGroup, name string, region string, size int64, projectID string) (*Instance, error) {
 	instanceIDs := ig.GetInstanceIds(t)
 	if len(instanceIDs) == 0 {
 		return nil, fmt.Errorf("Could not find any instances in Regional Instance Group %s in Region %s", name, region)
@@ -573,7 +597,7 @@ func getRandomInstanceE(t *testing.T, ig InstanceGroup, name string, region stri
 }
 
 // NewComputeService creates a new Compute service, which is used to make GCE API calls.
-func NewComputeService(t *testing.T) *compute.Service {
+func NewComputeService(t testing.TB) *compute.Service {
 	client, err := NewComputeServiceE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -582,7 +606,7 @@ func NewComputeService(t *testing.T) *compute.Service {
 }
 
 // NewComputeServiceE creates a new Compute service, which is used to make GCE API calls.
-func NewComputeServiceE(t *testing.T) (*compute.Service, error) {
+func NewComputeServiceE(t testing.TB) (*compute.Service, error) {
 	ctx := context.Background()
 
 	// Retrieve the Google OAuth token using a retry loop as it can sometimes return an error.
@@ -597,7 +621,7 @@ func NewComputeServiceE(t *testing.T) (*compute.Service, error) {
 
 	_, retryErr := retry.DoWithRetryE(t, description, maxRetries, timeBetweenRetries, func() (string, error) {
 		var clientErr error
-		client, clientErr = google.DefaultClient(ctx, compute.CloudPlatformScope)
+		client, clientErr = newGoogleClientE(t, ctx, compute.CloudPlatformScope)
 		return "", clientErr
 	})
 
@@ -614,7 +638,7 @@ func NewComputeServiceE(t *testing.T) (*compute.Service, error) {
 }
 
 // NewInstancesService creates a new InstancesService service, which is used to make a subset of GCE API calls.
-func NewInstancesService(t *testing.T) *compute.InstancesService {
+func NewInstancesService(t testing.TB) *compute.InstancesService {
 	client, err := NewInstancesServiceE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -623,7 +647,7 @@ func NewInstancesService(t *testing.T) *compute.InstancesService {
 }
 
 // NewInstancesServiceE creates a new InstancesService service, which is used to make a subset of GCE API calls.
-func NewInstancesServiceE(t *testing.T) (*compute.InstancesService, error) {
+func NewInstancesServiceE(t testing.TB) (*compute.InstancesService, error) {
 	service, err := NewComputeServiceE(t)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get new Instances Service\n")
@@ -0,0 +1,161 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/file/v1beta1"
+)
+
+// GetDisk gets the Persistent Disk with the given name in the given zone.
+func GetDisk(t testing.TB, projectID string, zone string, diskName string) *compute.Disk {
+	disk, err := GetDiskE(t, projectID, zone, diskName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return disk
+}
+
+// GetDiskE gets the Persistent Disk with the given name in the given zone.
+func GetDiskE(t testing.TB, projectID string, zone string, diskName string) (*compute.Disk, error) {
+	logger.Logf(t, "Getting Disk %s in %s", diskName, zone)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := service.Disks.Get(projectID, zone, diskName).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Disks.Get(%s) got error: %v", diskName, err)
+	}
+
+	return disk, nil
+}
+
+// AssertDiskTypeAndSize checks that the given Persistent Disk has the expected type (e.g. pd-ssd) and size in GB, and
+// fails the test if it does not.
+func AssertDiskTypeAndSize(t testing.TB, projectID string, zone string, diskName string, expectedType string, expectedSizeGb int64) {
+	err := AssertDiskTypeAndSizeE(t, projectID, zone, diskName, expectedType, expectedSizeGb)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertDiskTypeAndSizeE checks that the given Persistent Disk has the expected type (e.g. pd-ssd) and size in GB,
+// and returns an error if it does not.
+func AssertDiskTypeAndSizeE(t testing.TB, projectID string, zone string, diskName string, expectedType string, expectedSizeGb int64) error {
+	disk, err := GetDiskE(t, projectID, zone, diskName)
+	if err != nil {
+		return err
+	}
+
+	// The disk type is a URL such as https://www.googleapis.com/compute/v1/projects/my-project/zones/us-east1-b/diskTypes/pd-ssd
+	diskType := ZoneUrlToZone(disk.Type)
+	if diskType != expectedType {
+		return fmt.Errorf("Expected Disk %s to be of type %s, but found %s", diskName, expectedType, diskType)
+	}
+
+	if disk.SizeGb != expectedSizeGb {
+		return fmt.Errorf("Expected Disk %s to be %d GB, but found %d GB", diskName, expectedSizeGb, disk.SizeGb)
+	}
+
+	return nil
+}
+
+// AssertDiskEncryptedWithCMEK checks that the given Persistent Disk is encrypted with the given customer-managed
+// Cloud KMS key (e.g. projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key), and fails the test
+// if it is not.
+func AssertDiskEncryptedWithCMEK(t testing.TB, projectID string, zone string, diskName string, expectedKmsKeyName string) {
+	err := AssertDiskEncryptedWithCMEKE(t, projectID, zone, diskName, expectedKmsKeyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertDiskEncryptedWithCMEKE checks that the given Persistent Disk is encrypted with the given customer-managed
+// Cloud KMS key (e.g. projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key), and returns an
+// error if it is not.
+func AssertDiskEncryptedWithCMEKE(t testing.TB, projectID string, zone string, diskName string, expectedKmsKeyName string) error {
+	disk, err := GetDiskE(t, projectID, zone, diskName)
+	if err != nil {
+		return err
+	}
+
+	if disk.DiskEncryptionKey == nil || disk.DiskEncryptionKey.KmsKeyName == "" {
+		return fmt.Errorf("Expected Disk %s to be encrypted with KMS key %s, but it is encrypted with a Google-managed key", diskName, expectedKmsKeyName)
+	}
+
+	if !isSameKmsKey(disk.DiskEncryptionKey.KmsKeyName, expectedKmsKeyName) {
+		return fmt.Errorf("Expected Disk %s to be encrypted with KMS key %s, but it is encrypted with %s", diskName, expectedKmsKeyName, disk.DiskEncryptionKey.KmsKeyName)
+	}
+
+	return nil
+}
+
+// isSameKmsKey returns true if the given KMS key name refers to the expected key. The API reports the key version
+// used (e.g. .../cryptoKeys/my-key/cryptoKeyVersions/1), so any version of the expected key matches.
+func isSameKmsKey(kmsKeyName string, expectedKmsKeyName string) bool {
+	keyName := strings.SplitN(kmsKeyName, "/cryptoKeyVersions/", 2)[0]
+	return keyName == strings.TrimSuffix(expectedKmsKeyName, "/")
+}
+
+// GetFilestoreInstance gets the Filestore instance with the given name in the given zone.
+func GetFilestoreInstance(t testing.TB, projectID string, zone string, instanceName string) *file.Instance {
+	instance, err := GetFilestoreInstanceE(t, projectID, zone, instanceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instance
+}
+
+// GetFilestoreInstanceE gets the Filestore instance with the given name in the given zone. The capacity, tier, and IP
+// addresses of the instance are in its FileShares, Tier, and Networks fields.
+func GetFilestoreInstanceE(t testing.TB, projectID string, zone string, instanceName string) (*file.Instance, error) {
+	logger.Logf(t, "Getting Filestore instance %s in %s", instanceName, zone)
+
+	ctx := context.Background()
+	service, err := NewFilestoreServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("projects/%s/locations/%s/instances/%s", projectID, zone, instanceName)
+	instance, err := service.Projects.Locations.Instances.Get(name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Instances.Get(%s) got error: %v", instanceName, err)
+	}
+
+	return instance, nil
+}
+
+// NewFilestoreService creates a new Filestore service, which is used to make Filestore API calls.
+func NewFilestoreService(t testing.TB) *file.Service {
+	service, err := NewFilestoreServiceE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// NewFilestoreServiceE creates a new Filestore service, which is used to make Filestore API calls.
+func NewFilestoreServiceE(t testing.TB) (*file.Service, error) {
+	ctx := context.Background()
+
+	client, err := newGoogleClientE(t, ctx, file.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default client: %v", err)
+	}
+
+	service, err := file.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
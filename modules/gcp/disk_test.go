@@ -0,0 +1,35 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSameKmsKey(t *testing.T) {
+	t.Parallel()
+
+	key := "projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key"
+
+	testCases := []struct {
+		name       string
+		kmsKeyName string
+		expected   bool
+	}{
+		{"Exact", key, true},
+		{"WithVersion", key + "/cryptoKeyVersions/3", true},
+		{"OtherKey", "projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/other-key", false},
+		{"KeyWithSamePrefix", key + "-2/cryptoKeyVersions/1", false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, isSameKmsKey(testCase.kmsKeyName, key))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	dns_helper "github.com/gruntwork-io/terratest/modules/dns-helper"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"google.golang.org/api/dns/v1"
+)
+
+// GetManagedZone gets the Cloud DNS Managed Zone with the given name in the given project.
+func GetManagedZone(t testing.TB, projectID string, zoneName string) *dns.ManagedZone {
+	zone, err := GetManagedZoneE(t, projectID, zoneName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zone
+}
+
+// GetManagedZoneE gets the Cloud DNS Managed Zone with the given name in the given project.
+func GetManagedZoneE(t testing.TB, projectID string, zoneName string) (*dns.ManagedZone, error) {
+	logger.Logf(t, "Getting Cloud DNS Managed Zone %s", zoneName)
+
+	ctx := context.Background()
+	service, err := NewDNSServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := service.ManagedZones.Get(projectID, zoneName).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("ManagedZones.Get(%s) got error: %v", zoneName, err)
+	}
+
+	return zone, nil
+}
+
+// ListRecordSets lists all the record sets in the Cloud DNS Managed Zone with the given name.
+func ListRecordSets(t testing.TB, projectID string, zoneName string) []*dns.ResourceRecordSet {
+	recordSets, err := ListRecordSetsE(t, projectID, zoneName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return recordSets
+}
+
+// ListRecordSetsE lists all the record sets in the Cloud DNS Managed Zone with the given name.
+func ListRecordSetsE(t testing.TB, projectID string, zoneName string) ([]*dns.ResourceRecordSet, error) {
+	logger.Logf(t, "Listing record sets in Cloud DNS Managed Zone %s", zoneName)
+
+	ctx := context.Background()
+	service, err := NewDNSServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	recordSets := []*dns.ResourceRecordSet{}
+	err = service.ResourceRecordSets.List(projectID, zoneName).Pages(ctx, func(page *dns.ResourceRecordSetsListResponse) error {
+		recordSets = append(recordSets, page.Rrsets...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ResourceRecordSets.List(%s) got error: %v", zoneName, err)
+	}
+
+	return recordSets, nil
+}
+
+// AssertRecordExists checks that the Cloud DNS Managed Zone with the given name has a record set with the given name
+// and type (e.g. A, CNAME, TXT) that contains all of expectedValues, and fails the test if it does not.
+func AssertRecordExists(t testing.TB, projectID string, zoneName string, recordName string, recordType string, expectedValues []string) {
+	err := AssertRecordExistsE(t, projectID, zoneName, recordName, recordType, expectedValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertRecordExistsE checks that the Cloud DNS Managed Zone with the given name has a record set with the given name
+// and type (e.g. A, CNAME, TXT) that contains all of expectedValues, and returns an error if it does not.
+func AssertRecordExistsE(t testing.TB, projectID string, zoneName string, recordName string, recordType string, expectedValues []string) error {
+	logger.Logf(t, "Checking that Cloud DNS Managed Zone %s has a %s record for %s", zoneName, recordType, recordName)
+
+	ctx := context.Background()
+	service, err := NewDNSServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	// Cloud DNS always stores record names as fully qualified domain names with a trailing dot
+	fqdn := toFqdn(recordName)
+
+	response, err := service.ResourceRecordSets.List(projectID, zoneName).Name(fqdn).Type(recordType).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("ResourceRecordSets.List(%s) got error: %v", zoneName, err)
+	}
+
+	if len(response.Rrsets) == 0 {
+		return fmt.Errorf("Cloud DNS Managed Zone %s has no %s record for %s", zoneName, recordType, fqdn)
+	}
+
+	values := response.Rrsets[0].Rrdatas
+	if !dns_helper.ContainsAllRecordValues(values, expectedValues) {
+		return fmt.Errorf("Expected %s record for %s to contain %v, but found %v", recordType, fqdn, expectedValues, values)
+	}
+
+	return nil
+}
+
+// WaitUntilRecordResolves does a real DNS lookup of the record with the given name and type (A, AAAA, CNAME, TXT, MX,
+// or NS) and retries until the results contain all of expectedValues. This verifies that a record is actually being
+// served, not just that it exists in the Cloud DNS API.
+func WaitUntilRecordResolves(t testing.TB, recordName string, recordType string, expectedValues []string, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilRecordResolvesE(t, recordName, recordType, expectedValues, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilRecordResolvesE does a real DNS lookup of the record with the given name and type (A, AAAA, CNAME, TXT, MX,
+// or NS) and retries until the results contain all of expectedValues. This verifies that a record is actually being
+// served, not just that it exists in the Cloud DNS API.
+func WaitUntilRecordResolvesE(t testing.TB, recordName string, recordType string, expectedValues []string, maxRetries int, sleepBetweenRetries time.Duration) error {
+	return dns_helper.WaitUntilRecordResolvesE(t, recordName, recordType, expectedValues, maxRetries, sleepBetweenRetries)
+}
+
+// toFqdn adds a trailing dot to the given DNS name if it doesn't already have one.
+func toFqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// NewDNSService creates a new Cloud DNS service, which is used to make Cloud DNS API calls.
+func NewDNSService(t testing.TB) *dns.Service {
+	service, err := NewDNSServiceE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// NewDNSServiceE creates a new Cloud DNS service, which is used to make Cloud DNS API calls.
+func NewDNSServiceE(t testing.TB) (*dns.Service, error) {
+	ctx := context.Background()
+
+	client, err := newGoogleClientE(t, ctx, dns.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default client: %v", err)
+	}
+
+	service, err := dns.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
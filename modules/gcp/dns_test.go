@@ -0,0 +1,14 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFqdn(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "example.com.", toFqdn("example.com"))
+	assert.Equal(t, "example.com.", toFqdn("example.com."))
+}
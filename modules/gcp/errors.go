@@ -0,0 +1,48 @@
+package gcp
+
+import (
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+
+	"github.com/gruntwork-io/terratest/modules/customerrors"
+)
+
+// wrapGcpError converts an error returned by a GCP API about the given resource into a customerrors.NotFoundError,
+// customerrors.ThrottledError, or customerrors.AuthError, so test code can branch on the category of the error. Any
+// other error is returned unchanged.
+func wrapGcpError(err error, kind string, name string, region string) error {
+	ctx := customerrors.ResourceContext{Kind: kind, Name: name, Region: region}
+
+	if err == storage.ErrBucketNotExist || err == storage.ErrObjectNotExist {
+		return customerrors.NotFoundError{ResourceContext: ctx, Underlying: err}
+	}
+
+	apiErr, isApiErr := err.(*googleapi.Error)
+	if !isApiErr {
+		return err
+	}
+
+	switch apiErr.Code {
+	case http.StatusNotFound:
+		return customerrors.NotFoundError{ResourceContext: ctx, Underlying: err}
+	case http.StatusTooManyRequests:
+		return customerrors.ThrottledError{ResourceContext: ctx, Underlying: err}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// GCP also uses 403 for exceeded rate limits, with a reason that says so
+		for _, item := range apiErr.Errors {
+			if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+				return customerrors.ThrottledError{ResourceContext: ctx, Underlying: err}
+			}
+		}
+		return customerrors.AuthError{ResourceContext: ctx, Underlying: err}
+	default:
+		return err
+	}
+}
+
+// isCategorizedError returns true if the given error is one of the error categories wrapGcpError converts to.
+func isCategorizedError(err error) bool {
+	return customerrors.IsNotFound(err) || customerrors.IsThrottled(err) || customerrors.IsAuth(err)
+}
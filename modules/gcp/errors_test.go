@@ -0,0 +1,45 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+
+	"github.com/gruntwork-io/terratest/modules/customerrors"
+)
+
+func TestWrapGcpError(t *testing.T) {
+	t.Parallel()
+
+	plainErr := errors.New("boom")
+	conflictErr := &googleapi.Error{Code: 409}
+
+	testCases := []struct {
+		name         string
+		err          error
+		expectedType interface{}
+	}{
+		{"object not exist", storage.ErrObjectNotExist, customerrors.NotFoundError{}},
+		{"404", &googleapi.Error{Code: 404}, customerrors.NotFoundError{}},
+		{"429", &googleapi.Error{Code: 429}, customerrors.ThrottledError{}},
+		{"403 rate limit", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, customerrors.ThrottledError{}},
+		{"403", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}, customerrors.AuthError{}},
+		{"401", &googleapi.Error{Code: 401}, customerrors.AuthError{}},
+		{"409", conflictErr, conflictErr},
+		{"not an api error", plainErr, plainErr},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.IsType(t, testCase.expectedType, wrapGcpError(testCase.err, "Compute Instance", "my-instance", "us-east1-b"))
+		})
+	}
+}
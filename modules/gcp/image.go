@@ -0,0 +1,147 @@
+package gcp
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// GetImage gets the Compute Image with the given name, e.g. to check the image that a Packer build produced. This will
+// fail the test if there is an error.
+func GetImage(t testing.TB, projectID string, name string) *Image {
+	image, err := GetImageE(t, projectID, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return image
+}
+
+// GetImageE gets the Compute Image with the given name, e.g. to check the image that a Packer build produced.
+func GetImageE(t testing.TB, projectID string, name string) (*Image, error) {
+	return FetchImageE(t, projectID, name)
+}
+
+// DeleteImage deletes the Compute Image with the given name and waits until it is gone. This will fail the test if
+// there is an error.
+func DeleteImage(t testing.TB, projectID string, name string) {
+	err := DeleteImageE(t, projectID, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteImageE deletes the Compute Image with the given name and waits until it is gone, e.g. to clean up the image
+// that a Packer build produced.
+func DeleteImageE(t testing.TB, projectID string, name string) error {
+	image := &Image{projectID: projectID, Image: &compute.Image{Name: name}}
+	return image.DeleteImageE(t)
+}
+
+// AssertImageFamily checks that the Compute Image with the given name is in the expected image family, and fails the
+// test if it is not.
+func AssertImageFamily(t testing.TB, projectID string, name string, expectedFamily string) {
+	err := AssertImageFamilyE(t, projectID, name, expectedFamily)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertImageFamilyE checks that the Compute Image with the given name is in the expected image family, and returns
+// an error if it is not. This is useful to check that a Packer build published its image to the family that instance
+// templates launch from.
+func AssertImageFamilyE(t testing.TB, projectID string, name string, expectedFamily string) error {
+	image, err := FetchImageE(t, projectID, name)
+	if err != nil {
+		return err
+	}
+	return checkImageFamily(image.Image, expectedFamily)
+}
+
+// AssertImageLabels checks that the Compute Image with the given name has all the expected labels, and fails the
+// test if it does not.
+func AssertImageLabels(t testing.TB, projectID string, name string, expectedLabels map[string]string) {
+	err := AssertImageLabelsE(t, projectID, name, expectedLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertImageLabelsE checks that the Compute Image with the given name has all the expected labels, and returns an
+// error if it does not. The image may have other labels as well.
+func AssertImageLabelsE(t testing.TB, projectID string, name string, expectedLabels map[string]string) error {
+	image, err := FetchImageE(t, projectID, name)
+	if err != nil {
+		return err
+	}
+	return checkImageLabels(image.Image, expectedLabels)
+}
+
+// AssertImageLicenses checks that the Compute Image with the given name has all the expected licenses, and fails the
+// test if it does not.
+func AssertImageLicenses(t testing.TB, projectID string, name string, expectedLicenses []string) {
+	err := AssertImageLicensesE(t, projectID, name, expectedLicenses)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertImageLicensesE checks that the Compute Image with the given name has all the expected licenses, and returns
+// an error if it does not. Licenses are matched by name (e.g. debian-11-bullseye), so they may be in any project.
+func AssertImageLicensesE(t testing.TB, projectID string, name string, expectedLicenses []string) error {
+	image, err := FetchImageE(t, projectID, name)
+	if err != nil {
+		return err
+	}
+	return checkImageLicenses(image.Image, expectedLicenses)
+}
+
+// checkImageFamily returns an error if the given image is not in the expected family.
+func checkImageFamily(image *compute.Image, expectedFamily string) error {
+	if image.Family != expectedFamily {
+		return fmt.Errorf("Expected Image %s to be in family '%s', but it is in family '%s'", image.Name, expectedFamily, image.Family)
+	}
+	return nil
+}
+
+// checkImageLabels returns an error if the given image is missing any of the expected labels, or has a different
+// value for any of them.
+func checkImageLabels(image *compute.Image, expectedLabels map[string]string) error {
+	// Sort the keys, so the error always reports the same label first
+	keys := []string{}
+	for key := range expectedLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, hasLabel := image.Labels[key]
+		if !hasLabel {
+			return fmt.Errorf("Expected Image %s to have label %s, but it does not", image.Name, key)
+		}
+		if value != expectedLabels[key] {
+			return fmt.Errorf("Expected label %s of Image %s to be '%s', but it is '%s'", key, image.Name, expectedLabels[key], value)
+		}
+	}
+
+	return nil
+}
+
+// checkImageLicenses returns an error if the given image is missing any of the expected licenses. The image lists its
+// licenses as URLs, which end with the license name.
+func checkImageLicenses(image *compute.Image, expectedLicenses []string) error {
+	licenseNames := map[string]bool{}
+	for _, licenseUrl := range image.Licenses {
+		licenseNames[path.Base(licenseUrl)] = true
+	}
+
+	for _, license := range expectedLicenses {
+		if !licenseNames[path.Base(license)] {
+			return fmt.Errorf("Expected Image %s to have license %s, but it only has %v", image.Name, license, image.Licenses)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestCheckImageFamily(t *testing.T) {
+	t.Parallel()
+
+	image := &compute.Image{Name: "app-20190301", Family: "app"}
+
+	assert.NoError(t, checkImageFamily(image, "app"))
+	assert.Error(t, checkImageFamily(image, "app-canary"))
+}
+
+func TestCheckImageLabels(t *testing.T) {
+	t.Parallel()
+
+	image := &compute.Image{Name: "app-20190301", Labels: map[string]string{"team": "platform", "build": "42"}}
+
+	testCases := []struct {
+		name           string
+		expectedLabels map[string]string
+		expectErr      bool
+	}{
+		{"no labels", map[string]string{}, false},
+		{"subset", map[string]string{"team": "platform"}, false},
+		{"all", map[string]string{"team": "platform", "build": "42"}, false},
+		{"wrong value", map[string]string{"team": "data"}, true},
+		{"missing", map[string]string{"owner": "platform"}, true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkImageLabels(image, testCase.expectedLabels)
+			assert.Equal(t, testCase.expectErr, err != nil)
+		})
+	}
+}
+
+func TestCheckImageLicenses(t *testing.T) {
+	t.Parallel()
+
+	image := &compute.Image{
+		Name:     "app-20190301",
+		Licenses: []string{"https://www.googleapis.com/compute/v1/projects/debian-cloud/global/licenses/debian-9-stretch"},
+	}
+
+	assert.NoError(t, checkImageLicenses(image, []string{"debian-9-stretch"}))
+	assert.NoError(t, checkImageLicenses(image, []string{"projects/debian-cloud/global/licenses/debian-9-stretch"}))
+	assert.Error(t, checkImageLicenses(image, []string{"windows-server-2016-dc"}))
+}
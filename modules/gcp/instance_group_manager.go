@@ -0,0 +1,148 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
+	"google.golang.org/api/compute/v1"
+)
+
+// GetManagedInstanceGroup gets the Managed Instance Group with the given name. The location can be either a zone
+// (e.g. us-central1-a) for a zonal Managed Instance Group or a region (e.g. us-central1) for a regional one.
+func GetManagedInstanceGroup(t testing.TB, projectID string, location string, name string) *compute.InstanceGroupManager {
+	instanceGroupManager, err := GetManagedInstanceGroupE(t, projectID, location, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instanceGroupManager
+}
+
+// GetManagedInstanceGroupE gets the Managed Instance Group with the given name. The location can be either a zone
+// (e.g. us-central1-a) for a zonal Managed Instance Group or a region (e.g. us-central1) for a regional one.
+func GetManagedInstanceGroupE(t testing.TB, projectID string, location string, name string) (*compute.InstanceGroupManager, error) {
+	logger.Logf(t, "Getting Managed Instance Group %s in %s", name, location)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var instanceGroupManager *compute.InstanceGroupManager
+	if isZone(location) {
+		instanceGroupManager, err = service.InstanceGroupManagers.Get(projectID, location, name).Context(ctx).Do()
+	} else {
+		instanceGroupManager, err = service.RegionInstanceGroupManagers.Get(projectID, location, name).Context(ctx).Do()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("InstanceGroupManagers.Get(%s) got error: %v", name, err)
+	}
+
+	return instanceGroupManager, nil
+}
+
+// WaitUntilInstanceGroupStable waits until the Managed Instance Group with the given name is stable, which means all
+// of its instances are running and no rollout, recreation, or other action is in progress.
+func WaitUntilInstanceGroupStable(t testing.TB, projectID string, location string, name string, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilInstanceGroupStableE(t, projectID, location, name, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilInstanceGroupStableE waits until the Managed Instance Group with the given name is stable, which means all
+// of its instances are running and no rollout, recreation, or other action is in progress.
+func WaitUntilInstanceGroupStableE(t testing.TB, projectID string, location string, name string, maxRetries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "gcp.WaitUntilInstanceGroupStable", time.Now(), &err)
+
+	description := fmt.Sprintf("Waiting for Managed Instance Group %s to become stable", name)
+
+	msg, err := retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		instanceGroupManager, err := GetManagedInstanceGroupE(t, projectID, location, name)
+		if err != nil {
+			return "", err
+		}
+
+		if instanceGroupManager.Status == nil || !instanceGroupManager.Status.IsStable {
+			return "", fmt.Errorf("Managed Instance Group %s is not yet stable", name)
+		}
+
+		return fmt.Sprintf("Managed Instance Group %s is now stable", name), nil
+	})
+	logger.Log(t, msg)
+	return err
+}
+
+// AssertInstanceGroupSize checks that the target size of the Managed Instance Group with the given name is
+// expectedSize and fails the test if it is not.
+func AssertInstanceGroupSize(t testing.TB, projectID string, location string, name string, expectedSize int64) {
+	err := AssertInstanceGroupSizeE(t, projectID, location, name, expectedSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertInstanceGroupSizeE checks that the target size of the Managed Instance Group with the given name is
+// expectedSize and returns an error if it is not.
+func AssertInstanceGroupSizeE(t testing.TB, projectID string, location string, name string, expectedSize int64) error {
+	instanceGroupManager, err := GetManagedInstanceGroupE(t, projectID, location, name)
+	if err != nil {
+		return err
+	}
+
+	if instanceGroupManager.TargetSize != expectedSize {
+		return fmt.Errorf("Expected Managed Instance Group %s to have target size %d, but found %d", name, expectedSize, instanceGroupManager.TargetSize)
+	}
+
+	return nil
+}
+
+// GetAutoscalerPolicy gets the autoscaling policy (min and max replicas, cooldown period, and utilization targets) of
+// the Autoscaler with the given name. The location can be either a zone or a region, as with GetManagedInstanceGroup.
+func GetAutoscalerPolicy(t testing.TB, projectID string, location string, name string) *compute.AutoscalingPolicy {
+	policy, err := GetAutoscalerPolicyE(t, projectID, location, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return policy
+}
+
+// GetAutoscalerPolicyE gets the autoscaling policy (min and max replicas, cooldown period, and utilization targets) of
+// the Autoscaler with the given name. The location can be either a zone or a region, as with GetManagedInstanceGroup.
+func GetAutoscalerPolicyE(t testing.TB, projectID string, location string, name string) (*compute.AutoscalingPolicy, error) {
+	logger.Logf(t, "Getting Autoscaler %s in %s", name, location)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var autoscaler *compute.Autoscaler
+	if isZone(location) {
+		autoscaler, err = service.Autoscalers.Get(projectID, location, name).Context(ctx).Do()
+	} else {
+		autoscaler, err = service.RegionAutoscalers.Get(projectID, location, name).Context(ctx).Do()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Autoscalers.Get(%s) got error: %v", name, err)
+	}
+
+	if autoscaler.AutoscalingPolicy == nil {
+		return nil, fmt.Errorf("Autoscaler %s has no autoscaling policy", name)
+	}
+
+	return autoscaler.AutoscalingPolicy, nil
+}
+
+// isZone returns true if the given location is a GCP zone rather than a region. Zones are named after their region
+// with a single letter suffix (e.g. us-central1-a is a zone in the us-central1 region).
+func isZone(location string) bool {
+	return strings.Count(location, "-") >= 2
+}
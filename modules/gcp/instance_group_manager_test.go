@@ -0,0 +1,32 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsZone(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		location string
+		expected bool
+	}{
+		{"us-central1", false},
+		{"us-central1-a", true},
+		{"northamerica-northeast1", false},
+		{"northamerica-northeast1-b", true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.location, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, isZone(testCase.location))
+		})
+	}
+}
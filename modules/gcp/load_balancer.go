@@ -0,0 +1,247 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	http_helper "github.com/gruntwork-io/terratest/modules/http-helper"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/timing"
+	"google.golang.org/api/compute/v1"
+)
+
+// The location to pass to the load balancer functions in this file to look up global, rather than regional, resources.
+const GlobalLocation = "global"
+
+// GetForwardingRule gets the Forwarding Rule with the given name. Set location to GlobalLocation for a global
+// Forwarding Rule (e.g. for an HTTP(S) Load Balancer) or to a region for a regional one.
+func GetForwardingRule(t testing.TB, projectID string, location string, name string) *compute.ForwardingRule {
+	rule, err := GetForwardingRuleE(t, projectID, location, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
+
+// GetForwardingRuleE gets the Forwarding Rule with the given name. Set location to GlobalLocation for a global
+// Forwarding Rule (e.g. for an HTTP(S) Load Balancer) or to a region for a regional one.
+func GetForwardingRuleE(t testing.TB, projectID string, location string, name string) (*compute.ForwardingRule, error) {
+	logger.Logf(t, "Getting Forwarding Rule %s in %s", name, location)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule *compute.ForwardingRule
+	if location == GlobalLocation {
+		rule, err = service.GlobalForwardingRules.Get(projectID, name).Context(ctx).Do()
+	} else {
+		rule, err = service.ForwardingRules.Get(projectID, location, name).Context(ctx).Do()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ForwardingRules.Get(%s) got error: %v", name, err)
+	}
+
+	return rule, nil
+}
+
+// GetBackendService gets the Backend Service with the given name. Set location to GlobalLocation for a global Backend
+// Service or to a region for a regional one (e.g. for an Internal Load Balancer).
+func GetBackendService(t testing.TB, projectID string, location string, name string) *compute.BackendService {
+	backendService, err := GetBackendServiceE(t, projectID, location, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backendService
+}
+
+// GetBackendServiceE gets the Backend Service with the given name. Set location to GlobalLocation for a global Backend
+// Service or to a region for a regional one (e.g. for an Internal Load Balancer).
+func GetBackendServiceE(t testing.TB, projectID string, location string, name string) (*compute.BackendService, error) {
+	logger.Logf(t, "Getting Backend Service %s in %s", name, location)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var backendService *compute.BackendService
+	if location == GlobalLocation {
+		backendService, err = service.BackendServices.Get(projectID, name).Context(ctx).Do()
+	} else {
+		backendService, err = service.RegionBackendServices.Get(projectID, location, name).Context(ctx).Do()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("BackendServices.Get(%s) got error: %v", name, err)
+	}
+
+	return backendService, nil
+}
+
+// GetHealthCheck gets the global Health Check with the given name.
+func GetHealthCheck(t testing.TB, projectID string, name string) *compute.HealthCheck {
+	healthCheck, err := GetHealthCheckE(t, projectID, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return healthCheck
+}
+
+// GetHealthCheckE gets the global Health Check with the given name.
+func GetHealthCheckE(t testing.TB, projectID string, name string) (*compute.HealthCheck, error) {
+	logger.Logf(t, "Getting Health Check %s", name)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	healthCheck, err := service.HealthChecks.Get(projectID, name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("HealthChecks.Get(%s) got error: %v", name, err)
+	}
+
+	return healthCheck, nil
+}
+
+// GetUrlMap gets the URL Map with the given name.
+func GetUrlMap(t testing.TB, projectID string, name string) *compute.UrlMap {
+	urlMap, err := GetUrlMapE(t, projectID, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return urlMap
+}
+
+// GetUrlMapE gets the URL Map with the given name.
+func GetUrlMapE(t testing.TB, projectID string, name string) (*compute.UrlMap, error) {
+	logger.Logf(t, "Getting URL Map %s", name)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	urlMap, err := service.UrlMaps.Get(projectID, name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("UrlMaps.Get(%s) got error: %v", name, err)
+	}
+
+	return urlMap, nil
+}
+
+// AssertBackendHasHealthyInstances checks that every backend (Instance Group) of the given Backend Service has at least
+// one instance that is passing its health checks, and fails the test if not.
+func AssertBackendHasHealthyInstances(t testing.TB, projectID string, location string, backendServiceName string) {
+	err := AssertBackendHasHealthyInstancesE(t, projectID, location, backendServiceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertBackendHasHealthyInstancesE checks that every backend (Instance Group) of the given Backend Service has at
+// least one instance that is passing its health checks, and returns an error if not.
+func AssertBackendHasHealthyInstancesE(t testing.TB, projectID string, location string, backendServiceName string) error {
+	backendService, err := GetBackendServiceE(t, projectID, location, backendServiceName)
+	if err != nil {
+		return err
+	}
+
+	if len(backendService.Backends) == 0 {
+		return fmt.Errorf("Backend Service %s has no backends", backendServiceName)
+	}
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	for _, backend := range backendService.Backends {
+		ref := &compute.ResourceGroupReference{Group: backend.Group}
+
+		var health *compute.BackendServiceGroupHealth
+		if location == GlobalLocation {
+			health, err = service.BackendServices.GetHealth(projectID, backendServiceName, ref).Context(ctx).Do()
+		} else {
+			health, err = service.RegionBackendServices.GetHealth(projectID, location, backendServiceName, ref).Context(ctx).Do()
+		}
+		if err != nil {
+			return fmt.Errorf("BackendServices.GetHealth(%s) got error: %v", backendServiceName, err)
+		}
+
+		healthy := 0
+		for _, status := range health.HealthStatus {
+			if status.HealthState == "HEALTHY" {
+				healthy++
+			}
+		}
+
+		logger.Logf(t, "Backend %s of Backend Service %s has %d of %d instances healthy", backend.Group, backendServiceName, healthy, len(health.HealthStatus))
+
+		if healthy == 0 {
+			return fmt.Errorf("Backend %s of Backend Service %s has no healthy instances", backend.Group, backendServiceName)
+		}
+	}
+
+	return nil
+}
+
+// WaitUntilLoadBalancerServes makes HTTP GET requests to the IP address and port of the given Forwarding Rule until
+// it responds at the given path with the expected status code. This only works for Forwarding Rules that serve plain
+// HTTP; for HTTPS, use the http_helper package with the domain name of the certificate.
+func WaitUntilLoadBalancerServes(t testing.TB, projectID string, location string, forwardingRuleName string, path string, expectedStatus int, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilLoadBalancerServesE(t, projectID, location, forwardingRuleName, path, expectedStatus, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilLoadBalancerServesE makes HTTP GET requests to the IP address and port of the given Forwarding Rule until
+// it responds at the given path with the expected status code. This only works for Forwarding Rules that serve plain
+// HTTP; for HTTPS, use the http_helper package with the domain name of the certificate.
+func WaitUntilLoadBalancerServesE(t testing.TB, projectID string, location string, forwardingRuleName string, path string, expectedStatus int, maxRetries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "gcp.WaitUntilLoadBalancerServes", time.Now(), &err)
+
+	rule, err := GetForwardingRuleE(t, projectID, location, forwardingRuleName)
+	if err != nil {
+		return err
+	}
+
+	url, err := forwardingRuleUrl(rule, path)
+	if err != nil {
+		return err
+	}
+
+	return http_helper.HttpGetWithRetryWithCustomValidationE(t, url, nil, maxRetries, sleepBetweenRetries, func(status int, body string) bool {
+		return status == expectedStatus
+	})
+}
+
+// forwardingRuleUrl returns the HTTP URL for the given path on the IP address and first port of the given Forwarding
+// Rule. The port range of a Forwarding Rule is a single port (e.g. "80") or a range (e.g. "80-80").
+func forwardingRuleUrl(rule *compute.ForwardingRule, path string) (string, error) {
+	if rule.IPAddress == "" {
+		return "", fmt.Errorf("Forwarding Rule %s has no IP address", rule.Name)
+	}
+
+	port := 80
+	if rule.PortRange != "" {
+		parsedPort, err := strconv.Atoi(strings.SplitN(rule.PortRange, "-", 2)[0])
+		if err != nil {
+			return "", fmt.Errorf("Forwarding Rule %s has invalid port range %s: %v", rule.Name, rule.PortRange, err)
+		}
+		port = parsedPort
+	}
+
+	return fmt.Sprintf("http://%s:%d/%s", rule.IPAddress, port, strings.TrimPrefix(path, "/")), nil
+}
@@ -0,0 +1,45 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestForwardingRuleUrl(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		portRange   string
+		path        string
+		expectedUrl string
+	}{
+		{"NoPortRange", "", "/", "http://35.1.2.3:80/"},
+		{"SinglePort", "8080", "health", "http://35.1.2.3:8080/health"},
+		{"PortRange", "80-80", "/index.html", "http://35.1.2.3:80/index.html"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			url, err := forwardingRuleUrl(&compute.ForwardingRule{IPAddress: "35.1.2.3", PortRange: testCase.portRange}, testCase.path)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedUrl, url)
+		})
+	}
+}
+
+func TestForwardingRuleUrlNoIpAddress(t *testing.T) {
+	t.Parallel()
+
+	_, err := forwardingRuleUrl(&compute.ForwardingRule{Name: "lb"}, "/")
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
+	"google.golang.org/api/monitoring/v3"
+)
+
+// GetMetricTimeSeries gets the time series matching the given Cloud Monitoring filter (e.g.
+// metric.type="compute.googleapis.com/instance/cpu/utilization" AND resource.labels.instance_id="1234") over the last
+// window of time.
+func GetMetricTimeSeries(t testing.TB, projectID string, filter string, window time.Duration) []*monitoring.TimeSeries {
+	timeSeries, err := GetMetricTimeSeriesE(t, projectID, filter, window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return timeSeries
+}
+
+// GetMetricTimeSeriesE gets the time series matching the given Cloud Monitoring filter (e.g.
+// metric.type="compute.googleapis.com/instance/cpu/utilization" AND resource.labels.instance_id="1234") over the last
+// window of time.
+func GetMetricTimeSeriesE(t testing.TB, projectID string, filter string, window time.Duration) ([]*monitoring.TimeSeries, error) {
+	logger.Logf(t, "Getting time series for %s over the last %s", filter, window)
+
+	ctx := context.Background()
+	service, err := NewMonitoringServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-window)
+
+	timeSeries := []*monitoring.TimeSeries{}
+	req := service.Projects.TimeSeries.List("projects/" + projectID).
+		Filter(filter).
+		IntervalStartTime(start.Format(time.RFC3339)).
+		IntervalEndTime(end.Format(time.RFC3339))
+	err = req.Pages(ctx, func(page *monitoring.ListTimeSeriesResponse) error {
+		timeSeries = append(timeSeries, page.TimeSeries...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TimeSeries.List(%s) got error: %v", filter, err)
+	}
+
+	return timeSeries, nil
+}
+
+// AssertMetricAboveThreshold checks that at least one data point of the time series matching the given filter over the
+// last window of time is above the given threshold, and fails the test if not.
+func AssertMetricAboveThreshold(t testing.TB, projectID string, filter string, window time.Duration, threshold float64) {
+	err := AssertMetricAboveThresholdE(t, projectID, filter, window, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertMetricAboveThresholdE checks that at least one data point of the time series matching the given filter over
+// the last window of time is above the given threshold, and returns an error if not.
+func AssertMetricAboveThresholdE(t testing.TB, projectID string, filter string, window time.Duration, threshold float64) error {
+	timeSeries, err := GetMetricTimeSeriesE(t, projectID, filter, window)
+	if err != nil {
+		return err
+	}
+
+	max, found := maxPointValue(timeSeries)
+	if !found {
+		return fmt.Errorf("No numeric data points found for %s over the last %s", filter, window)
+	}
+
+	if max <= threshold {
+		return fmt.Errorf("Expected a data point for %s above %f over the last %s, but the highest was %f", filter, threshold, window, max)
+	}
+
+	return nil
+}
+
+// WaitUntilMetricAboveThreshold retries AssertMetricAboveThreshold until it passes or maxRetries is exceeded. This is
+// useful right after deploying a service, as it can take a few minutes for metrics to be reported.
+func WaitUntilMetricAboveThreshold(t testing.TB, projectID string, filter string, window time.Duration, threshold float64, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitUntilMetricAboveThresholdE(t, projectID, filter, window, threshold, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitUntilMetricAboveThresholdE retries AssertMetricAboveThresholdE until it passes or maxRetries is exceeded. This
+// is useful right after deploying a service, as it can take a few minutes for metrics to be reported.
+func WaitUntilMetricAboveThresholdE(t testing.TB, projectID string, filter string, window time.Duration, threshold float64, maxRetries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "gcp.WaitUntilMetricAboveThreshold", time.Now(), &err)
+
+	description := fmt.Sprintf("Waiting for %s to go above %f", filter, threshold)
+
+	_, err = retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		return "", AssertMetricAboveThresholdE(t, projectID, filter, window, threshold)
+	})
+	return err
+}
+
+// AssertAlertPolicyExists checks that the given project has an Alert Policy with the given display name, and fails the
+// test if not.
+func AssertAlertPolicyExists(t testing.TB, projectID string, displayName string) {
+	err := AssertAlertPolicyExistsE(t, projectID, displayName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertAlertPolicyExistsE checks that the given project has an Alert Policy with the given display name, and returns
+// an error if not.
+func AssertAlertPolicyExistsE(t testing.TB, projectID string, displayName string) error {
+	logger.Logf(t, "Looking for Alert Policy %s", displayName)
+
+	ctx := context.Background()
+	service, err := NewMonitoringServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	err = service.Projects.AlertPolicies.List("projects/"+projectID).Pages(ctx, func(page *monitoring.ListAlertPoliciesResponse) error {
+		for _, policy := range page.AlertPolicies {
+			if policy.DisplayName == displayName {
+				found = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("AlertPolicies.List(%s) got error: %v", projectID, err)
+	}
+
+	if !found {
+		return fmt.Errorf("No Alert Policy named %s found in Project %s", displayName, projectID)
+	}
+
+	return nil
+}
+
+// maxPointValue returns the highest numeric value of all the data points in the given time series. The second return
+// value is false if there are no numeric data points.
+func maxPointValue(timeSeries []*monitoring.TimeSeries) (float64, bool) {
+	max := 0.0
+	found := false
+
+	for _, series := range timeSeries {
+		for _, point := range series.Points {
+			if point.Value == nil {
+				continue
+			}
+
+			var value float64
+			switch {
+			case point.Value.DoubleValue != nil:
+				value = *point.Value.DoubleValue
+			case point.Value.Int64Value != nil:
+				value = float64(*point.Value.Int64Value)
+			default:
+				continue
+			}
+
+			if !found || value > max {
+				max = value
+				found = true
+			}
+		}
+	}
+
+	return max, found
+}
+
+// NewMonitoringService creates a new Cloud Monitoring service, which is used to make Cloud Monitoring API calls.
+func NewMonitoringService(t testing.TB) *monitoring.Service {
+	service, err := NewMonitoringServiceE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// NewMonitoringServiceE creates a new Cloud Monitoring service, which is used to make Cloud Monitoring API calls.
+func NewMonitoringServiceE(t testing.TB) (*monitoring.Service, error) {
+	ctx := context.Background()
+
+	client, err := newGoogleClientE(t, ctx, monitoring.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default client: %v", err)
+	}
+
+	service, err := monitoring.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
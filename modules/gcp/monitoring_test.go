@@ -0,0 +1,35 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/monitoring/v3"
+)
+
+func TestMaxPointValue(t *testing.T) {
+	t.Parallel()
+
+	double := func(value float64) *monitoring.Point {
+		return &monitoring.Point{Value: &monitoring.TypedValue{DoubleValue: &value}}
+	}
+	integer := func(value int64) *monitoring.Point {
+		return &monitoring.Point{Value: &monitoring.TypedValue{Int64Value: &value}}
+	}
+
+	timeSeries := []*monitoring.TimeSeries{
+		{Points: []*monitoring.Point{double(0.25), double(0.75)}},
+		{Points: []*monitoring.Point{integer(-3), {Value: nil}}},
+	}
+
+	max, found := maxPointValue(timeSeries)
+	assert.True(t, found)
+	assert.Equal(t, 0.75, max)
+
+	max, found = maxPointValue([]*monitoring.TimeSeries{{Points: []*monitoring.Point{integer(-3)}}})
+	assert.True(t, found)
+	assert.Equal(t, -3.0, max)
+
+	_, found = maxPointValue([]*monitoring.TimeSeries{{}})
+	assert.False(t, found)
+}
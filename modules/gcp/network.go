@@ -0,0 +1,212 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"google.golang.org/api/compute/v1"
+)
+
+// GetNetwork gets the VPC Network with the given name in the given project.
+func GetNetwork(t testing.TB, projectID string, name string) *compute.Network {
+	network, err := GetNetworkE(t, projectID, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return network
+}
+
+// GetNetworkE gets the VPC Network with the given name in the given project.
+func GetNetworkE(t testing.TB, projectID string, name string) (*compute.Network, error) {
+	logger.Logf(t, "Getting VPC Network %s", name)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := service.Networks.Get(projectID, name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Networks.Get(%s) got error: %v", name, err)
+	}
+
+	return network, nil
+}
+
+// GetSubnetwork gets the Subnetwork with the given name in the given project and region.
+func GetSubnetwork(t testing.TB, projectID string, region string, name string) *compute.Subnetwork {
+	subnetwork, err := GetSubnetworkE(t, projectID, region, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return subnetwork
+}
+
+// GetSubnetworkE gets the Subnetwork with the given name in the given project and region.
+func GetSubnetworkE(t testing.TB, projectID string, region string, name string) (*compute.Subnetwork, error) {
+	logger.Logf(t, "Getting Subnetwork %s in Region %s", name, region)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetwork, err := service.Subnetworks.Get(projectID, region, name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Subnetworks.Get(%s) got error: %v", name, err)
+	}
+
+	return subnetwork, nil
+}
+
+// AssertSubnetCIDR checks that the primary IP range of the given Subnetwork matches expectedCIDR and fails the test if
+// it does not.
+func AssertSubnetCIDR(t testing.TB, projectID string, region string, name string, expectedCIDR string) {
+	err := AssertSubnetCIDRE(t, projectID, region, name, expectedCIDR)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertSubnetCIDRE checks that the primary IP range of the given Subnetwork matches expectedCIDR and returns an error
+// if it does not.
+func AssertSubnetCIDRE(t testing.TB, projectID string, region string, name string, expectedCIDR string) error {
+	subnetwork, err := GetSubnetworkE(t, projectID, region, name)
+	if err != nil {
+		return err
+	}
+
+	if subnetwork.IpCidrRange != expectedCIDR {
+		return fmt.Errorf("Expected Subnetwork %s to have CIDR block %s, but found %s", name, expectedCIDR, subnetwork.IpCidrRange)
+	}
+
+	return nil
+}
+
+// ListFirewallRules lists all the Firewall Rules that apply to the VPC Network with the given name.
+func ListFirewallRules(t testing.TB, projectID string, networkName string) []*compute.Firewall {
+	rules, err := ListFirewallRulesE(t, projectID, networkName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rules
+}
+
+// ListFirewallRulesE lists all the Firewall Rules that apply to the VPC Network with the given name.
+func ListFirewallRulesE(t testing.TB, projectID string, networkName string) ([]*compute.Firewall, error) {
+	logger.Logf(t, "Listing Firewall Rules for VPC Network %s", networkName)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*compute.Firewall{}
+	err = service.Firewalls.List(projectID).Pages(ctx, func(page *compute.FirewallList) error {
+		for _, rule := range page.Items {
+			// The network of a Firewall Rule is a URL ending in the network name, e.g.
+			// https://www.googleapis.com/compute/v1/projects/project-123456/global/networks/default
+			if path.Base(rule.Network) == networkName {
+				rules = append(rules, rule)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Firewalls.List(%s) got error: %v", projectID, err)
+	}
+
+	return rules, nil
+}
+
+// AssertFirewallRuleAllows checks that the Firewall Rule with the given name has the given direction (INGRESS or
+// EGRESS) and allows traffic over the given protocol and port, and fails the test if it does not.
+func AssertFirewallRuleAllows(t testing.TB, projectID string, ruleName string, direction string, protocol string, port int) {
+	err := AssertFirewallRuleAllowsE(t, projectID, ruleName, direction, protocol, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertFirewallRuleAllowsE checks that the Firewall Rule with the given name has the given direction (INGRESS or
+// EGRESS) and allows traffic over the given protocol and port, and returns an error if it does not.
+func AssertFirewallRuleAllowsE(t testing.TB, projectID string, ruleName string, direction string, protocol string, port int) error {
+	logger.Logf(t, "Checking that Firewall Rule %s allows %s %s traffic on port %d", ruleName, direction, protocol, port)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	rule, err := service.Firewalls.Get(projectID, ruleName).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("Firewalls.Get(%s) got error: %v", ruleName, err)
+	}
+
+	if rule.Disabled {
+		return fmt.Errorf("Firewall Rule %s is disabled", ruleName)
+	}
+
+	if !strings.EqualFold(rule.Direction, direction) {
+		return fmt.Errorf("Expected Firewall Rule %s to have direction %s, but found %s", ruleName, direction, rule.Direction)
+	}
+
+	if !firewallAllowsProtocolAndPort(rule, protocol, port) {
+		return fmt.Errorf("Firewall Rule %s does not allow %s traffic on port %d", ruleName, protocol, port)
+	}
+
+	return nil
+}
+
+// firewallAllowsProtocolAndPort returns true if any of the allow entries of the given Firewall Rule matches the given
+// protocol and port. An entry with the protocol "all" matches any protocol, and an entry with no ports matches any
+// port.
+func firewallAllowsProtocolAndPort(rule *compute.Firewall, protocol string, port int) bool {
+	for _, allowed := range rule.Allowed {
+		if allowed.IPProtocol != "all" && !strings.EqualFold(allowed.IPProtocol, protocol) {
+			continue
+		}
+
+		if len(allowed.Ports) == 0 {
+			return true
+		}
+
+		for _, portSpec := range allowed.Ports {
+			if portSpecContains(portSpec, port) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// portSpecContains returns true if the given port spec, which can be a single port (e.g. "22") or a range
+// (e.g. "8000-9000"), contains the given port.
+func portSpecContains(portSpec string, port int) bool {
+	bounds := strings.SplitN(portSpec, "-", 2)
+
+	low, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return false
+	}
+
+	high := low
+	if len(bounds) == 2 {
+		high, err = strconv.Atoi(bounds[1])
+		if err != nil {
+			return false
+		}
+	}
+
+	return low <= port && port <= high
+}
@@ -0,0 +1,55 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestFirewallAllowsProtocolAndPort(t *testing.T) {
+	t.Parallel()
+
+	rule := &compute.Firewall{
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "tcp", Ports: []string{"22", "8000-9000"}},
+			{IPProtocol: "udp"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		protocol string
+		port     int
+		expected bool
+	}{
+		{"SinglePort", "tcp", 22, true},
+		{"PortInRange", "tcp", 8080, true},
+		{"PortAtEndOfRange", "tcp", 9000, true},
+		{"PortNotAllowed", "tcp", 80, false},
+		{"AnyPortForProtocol", "udp", 53, true},
+		{"ProtocolNotAllowed", "icmp", 0, false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, firewallAllowsProtocolAndPort(rule, testCase.protocol, testCase.port))
+		})
+	}
+}
+
+func TestFirewallAllowsAllProtocols(t *testing.T) {
+	t.Parallel()
+
+	rule := &compute.Firewall{
+		Allowed: []*compute.FirewallAllowed{{IPProtocol: "all"}},
+	}
+
+	assert.True(t, firewallAllowsProtocolAndPort(rule, "tcp", 443))
+	assert.True(t, firewallAllowsProtocolAndPort(rule, "icmp", 0))
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/stretchr/testify/require"
-	"golang.org/x/oauth2/google"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/oslogin/v1"
 )
@@ -16,14 +16,18 @@ import (
 // The `user` parameter should be the email address of the user.
 // The `key` parameter should be the public key of the SSH key being uploaded.
 // This will fail the test if there is an error.
-func ImportSSHKey(t *testing.T, user, key string) {
+func ImportSSHKey(t testing.TB, user, key string) {
 	require.NoErrorf(t, ImportSSHKeyE(t, user, key), "Could not add SSH Key to user %s", user)
 }
 
 // ImportSSHKeyE will import an SSH key to GCP under the provided user identity.
 // The `user` parameter should be the email address of the user.
 // The `key` parameter should be the public key of the SSH key being uploaded.
-func ImportSSHKeyE(t *testing.T, user, key string) error {
+func ImportSSHKeyE(t testing.TB, user, key string) error {
+	if dryrun.Skip(t, "import SSH key for user %s", user) {
+		return nil
+	}
+
 	logger.Logf(t, "Importing SSH key for user %s", user)
 
 	ctx := context.Background()
@@ -50,14 +54,18 @@ func ImportSSHKeyE(t *testing.T, user, key string) error {
 // The `user` parameter should be the email address of the user.
 // The `key` parameter should be the public key of the SSH key that was uploaded.
 // This will fail the test if there is an error.
-func DeleteSSHKey(t *testing.T, user, key string) {
+func DeleteSSHKey(t testing.TB, user, key string) {
 	require.NoErrorf(t, DeleteSSHKeyE(t, user, key), "Could not delete SSH Key for user %s", user)
 }
 
 // DeleteSSHKeyE will delete an SSH key attached to the provided user identity.
 // The `user` parameter should be the email address of the user.
 // The `key` parameter should be the public key of the SSH key that was uploaded.
-func DeleteSSHKeyE(t *testing.T, user, key string) error {
+func DeleteSSHKeyE(t testing.TB, user, key string) error {
+	if dryrun.Skip(t, "delete SSH key for user %s", user) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting SSH key for user %s", user)
 
 	ctx := context.Background()
@@ -87,7 +95,7 @@ func DeleteSSHKeyE(t *testing.T, user, key string) error {
 // accounts the user will appear as. Generally, this will only be the OS Login key + account, but `gcloud compute ssh` could create temporary keys and profiles.
 // The `user` parameter should be the email address of the user.
 // This will fail the test if there is an error.
-func GetLoginProfile(t *testing.T, user string) *oslogin.LoginProfile {
+func GetLoginProfile(t testing.TB, user string) *oslogin.LoginProfile {
 	profile, err := GetLoginProfileE(t, user)
 	require.NoErrorf(t, err, "Could not get login profile for user %s", user)
 
@@ -97,7 +105,7 @@ func GetLoginProfile(t *testing.T, user string) *oslogin.LoginProfile {
 // GetLoginProfileE will retrieve the login profile for a user's Google identity. The login profile is a combination of OS Login + gcloud SSH keys and POSIX
 // accounts the user will appear as. Generally, this will only be the OS Login key + account, but `gcloud compute ssh` could create temporary keys and profiles.
 // The `user` parameter should be the email address of the user.
-func GetLoginProfileE(t *testing.T, user string) (*oslogin.LoginProfile, error) {
+func GetLoginProfileE(t testing.TB, user string) (*oslogin.LoginProfile, error) {
 	logger.Logf(t, "Getting login profile for user %s", user)
 
 	ctx := context.Background()
@@ -117,10 +125,10 @@ func GetLoginProfileE(t *testing.T, user string) (*oslogin.LoginProfile, error)
 }
 
 // NewOSLoginServiceE creates a new OS Login service, which is used to make OS Login API calls.
-func NewOSLoginServiceE(t *testing.T) (*oslogin.Service, error) {
+func NewOSLoginServiceE(t testing.TB) (*oslogin.Service, error) {
 	ctx := context.Background()
 
-	client, err := google.DefaultClient(ctx, compute.CloudPlatformScope)
+	client, err := newGoogleClientE(t, ctx, compute.CloudPlatformScope)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get default client: %v", err)
 	}
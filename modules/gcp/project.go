@@ -0,0 +1,173 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+// GetProject gets the GCP Project with the given ID.
+func GetProject(t testing.TB, projectID string) *cloudresourcemanager.Project {
+	project, err := GetProjectE(t, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return project
+}
+
+// GetProjectE gets the GCP Project with the given ID.
+func GetProjectE(t testing.TB, projectID string) (*cloudresourcemanager.Project, error) {
+	logger.Logf(t, "Getting Project %s", projectID)
+
+	ctx := context.Background()
+	service, err := NewResourceManagerServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := service.Projects.Get(projectID).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Projects.Get(%s) got error: %v", projectID, err)
+	}
+
+	return project, nil
+}
+
+// AssertProjectLabels checks that the GCP Project with the given ID has all of the expected labels with the expected
+// values, and fails the test if it does not. The Project may have other labels as well.
+func AssertProjectLabels(t testing.TB, projectID string, expectedLabels map[string]string) {
+	err := AssertProjectLabelsE(t, projectID, expectedLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertProjectLabelsE checks that the GCP Project with the given ID has all of the expected labels with the expected
+// values, and returns an error if it does not. The Project may have other labels as well.
+func AssertProjectLabelsE(t testing.TB, projectID string, expectedLabels map[string]string) error {
+	project, err := GetProjectE(t, projectID)
+	if err != nil {
+		return err
+	}
+
+	for key, expectedValue := range expectedLabels {
+		value, exists := project.Labels[key]
+		if !exists {
+			return fmt.Errorf("Expected Project %s to have label %s, but it does not", projectID, key)
+		}
+		if value != expectedValue {
+			return fmt.Errorf("Expected label %s of Project %s to be %s, but found %s", key, projectID, expectedValue, value)
+		}
+	}
+
+	return nil
+}
+
+// GetEffectiveOrgPolicy gets the Organization Policy for the given constraint (e.g.
+// constraints/compute.vmExternalIpAccess) that is in effect for the given Project, taking into account the policies
+// inherited from its folders and organization.
+func GetEffectiveOrgPolicy(t testing.TB, projectID string, constraint string) *cloudresourcemanager.OrgPolicy {
+	policy, err := GetEffectiveOrgPolicyE(t, projectID, constraint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return policy
+}
+
+// GetEffectiveOrgPolicyE gets the Organization Policy for the given constraint (e.g.
+// constraints/compute.vmExternalIpAccess) that is in effect for the given Project, taking into account the policies
+// inherited from its folders and organization.
+func GetEffectiveOrgPolicyE(t testing.TB, projectID string, constraint string) (*cloudresourcemanager.OrgPolicy, error) {
+	logger.Logf(t, "Getting effective Organization Policy %s for Project %s", constraint, projectID)
+
+	ctx := context.Background()
+	service, err := NewResourceManagerServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cloudresourcemanager.GetEffectiveOrgPolicyRequest{Constraint: constraint}
+	policy, err := service.Projects.GetEffectiveOrgPolicy("projects/"+projectID, request).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Projects.GetEffectiveOrgPolicy(%s) got error: %v", constraint, err)
+	}
+
+	return policy, nil
+}
+
+// AssertOrgPolicyEnforced checks that the Organization Policy for the given constraint is enforced for the given
+// Project, and fails the test if it is not. See isOrgPolicyEnforced for what counts as enforced.
+func AssertOrgPolicyEnforced(t testing.TB, projectID string, constraint string) {
+	err := AssertOrgPolicyEnforcedE(t, projectID, constraint)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertOrgPolicyEnforcedE checks that the Organization Policy for the given constraint is enforced for the given
+// Project, and returns an error if it is not. See isOrgPolicyEnforced for what counts as enforced.
+func AssertOrgPolicyEnforcedE(t testing.TB, projectID string, constraint string) error {
+	policy, err := GetEffectiveOrgPolicyE(t, projectID, constraint)
+	if err != nil {
+		return err
+	}
+
+	if !isOrgPolicyEnforced(policy) {
+		return fmt.Errorf("Expected Organization Policy %s to be enforced for Project %s, but it is not", constraint, projectID)
+	}
+
+	return nil
+}
+
+// isOrgPolicyEnforced returns true if the given Organization Policy restricts something: a boolean constraint (e.g.
+// constraints/compute.requireOsLogin) is enforced, or a list constraint (e.g. constraints/compute.vmExternalIpAccess)
+// denies all values or allows only a specific set of values.
+func isOrgPolicyEnforced(policy *cloudresourcemanager.OrgPolicy) bool {
+	if policy.BooleanPolicy != nil {
+		return policy.BooleanPolicy.Enforced
+	}
+
+	if policy.ListPolicy != nil {
+		listPolicy := policy.ListPolicy
+		if listPolicy.AllValues == "DENY" {
+			return true
+		}
+		if listPolicy.AllValues == "ALLOW" {
+			return false
+		}
+		return len(listPolicy.AllowedValues) > 0 || len(listPolicy.DeniedValues) > 0
+	}
+
+	return false
+}
+
+// NewResourceManagerService creates a new Cloud Resource Manager service, which is used to make Project and
+// Organization Policy API calls.
+func NewResourceManagerService(t testing.TB) *cloudresourcemanager.Service {
+	service, err := NewResourceManagerServiceE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// NewResourceManagerServiceE creates a new Cloud Resource Manager service, which is used to make Project and
+// Organization Policy API calls.
+func NewResourceManagerServiceE(t testing.TB) (*cloudresourcemanager.Service, error) {
+	ctx := context.Background()
+
+	client, err := newGoogleClientE(t, ctx, cloudresourcemanager.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default client: %v", err)
+	}
+
+	service, err := cloudresourcemanager.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
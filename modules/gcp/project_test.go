@@ -0,0 +1,37 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestIsOrgPolicyEnforced(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		policy   *cloudresourcemanager.OrgPolicy
+		expected bool
+	}{
+		{"NoPolicy", &cloudresourcemanager.OrgPolicy{}, false},
+		{"BooleanEnforced", &cloudresourcemanager.OrgPolicy{BooleanPolicy: &cloudresourcemanager.BooleanPolicy{Enforced: true}}, true},
+		{"BooleanNotEnforced", &cloudresourcemanager.OrgPolicy{BooleanPolicy: &cloudresourcemanager.BooleanPolicy{}}, false},
+		{"ListDenyAll", &cloudresourcemanager.OrgPolicy{ListPolicy: &cloudresourcemanager.ListPolicy{AllValues: "DENY"}}, true},
+		{"ListAllowAll", &cloudresourcemanager.OrgPolicy{ListPolicy: &cloudresourcemanager.ListPolicy{AllValues: "ALLOW"}}, false},
+		{"ListAllowedValues", &cloudresourcemanager.OrgPolicy{ListPolicy: &cloudresourcemanager.ListPolicy{AllowedValues: []string{"projects/foo/zones/us-east1-b/instances/bastion"}}}, true},
+		{"ListEmpty", &cloudresourcemanager.OrgPolicy{ListPolicy: &cloudresourcemanager.ListPolicy{}}, false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, isOrgPolicyEnforced(testCase.policy))
+		})
+	}
+}
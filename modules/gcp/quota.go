@@ -0,0 +1,96 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"google.golang.org/api/compute/v1"
+)
+
+// GetComputeQuota gets the Compute Engine quota for the given metric (e.g. CPUS, IN_USE_ADDRESSES, or
+// SSD_TOTAL_GB). Set location to a region to get a regional quota, or to GlobalLocation to get a project-wide quota
+// (e.g. NETWORKS or FIREWALLS).
+func GetComputeQuota(t testing.TB, projectID string, location string, metric string) *compute.Quota {
+	quota, err := GetComputeQuotaE(t, projectID, location, metric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return quota
+}
+
+// GetComputeQuotaE gets the Compute Engine quota for the given metric (e.g. CPUS, IN_USE_ADDRESSES, or
+// SSD_TOTAL_GB). Set location to a region to get a regional quota, or to GlobalLocation to get a project-wide quota
+// (e.g. NETWORKS or FIREWALLS).
+func GetComputeQuotaE(t testing.TB, projectID string, location string, metric string) (*compute.Quota, error) {
+	logger.Logf(t, "Getting quota %s in %s for Project %s", metric, location, projectID)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var quotas []*compute.Quota
+	if location == GlobalLocation {
+		project, err := service.Projects.Get(projectID).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("Projects.Get(%s) got error: %v", projectID, err)
+		}
+		quotas = project.Quotas
+	} else {
+		region, err := service.Regions.Get(projectID, location).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("Regions.Get(%s) got error: %v", location, err)
+		}
+		quotas = region.Quotas
+	}
+
+	quota := findQuota(quotas, metric)
+	if quota == nil {
+		return nil, fmt.Errorf("Quota %s not found in %s for Project %s", metric, location, projectID)
+	}
+
+	return quota, nil
+}
+
+// CheckQuotaAvailable checks that at least the given amount of the Compute Engine quota for the given metric (e.g.
+// CPUS) is still available, and fails the test if it is not. Call this before provisioning resources to fail fast
+// with a clear message rather than time out in the middle of an apply when the quota is exhausted. Set location to a
+// region for a regional quota, or to GlobalLocation for a project-wide quota.
+func CheckQuotaAvailable(t testing.TB, projectID string, location string, metric string, required float64) {
+	err := CheckQuotaAvailableE(t, projectID, location, metric, required)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CheckQuotaAvailableE checks that at least the given amount of the Compute Engine quota for the given metric (e.g.
+// CPUS) is still available, and returns an error if it is not. Call this before provisioning resources to fail fast
+// with a clear message rather than time out in the middle of an apply when the quota is exhausted. Set location to a
+// region for a regional quota, or to GlobalLocation for a project-wide quota.
+func CheckQuotaAvailableE(t testing.TB, projectID string, location string, metric string, required float64) error {
+	quota, err := GetComputeQuotaE(t, projectID, location, metric)
+	if err != nil {
+		return err
+	}
+
+	available := quota.Limit - quota.Usage
+	if available < required {
+		return fmt.Errorf("Not enough %s quota in %s for Project %s: need %v, but only %v of %v is available (%v in use)", metric, location, projectID, required, available, quota.Limit, quota.Usage)
+	}
+
+	logger.Logf(t, "Quota %s in %s for Project %s has %v of %v available, which covers the %v required", metric, location, projectID, available, quota.Limit, required)
+	return nil
+}
+
+// findQuota returns the quota for the given metric, or nil if there is no such quota.
+func findQuota(quotas []*compute.Quota, metric string) *compute.Quota {
+	for _, quota := range quotas {
+		if quota.Metric == metric {
+			return quota
+		}
+	}
+	return nil
+}
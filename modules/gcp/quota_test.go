@@ -0,0 +1,20 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestFindQuota(t *testing.T) {
+	t.Parallel()
+
+	quotas := []*compute.Quota{
+		{Metric: "CPUS", Limit: 24, Usage: 8},
+		{Metric: "IN_USE_ADDRESSES", Limit: 8, Usage: 8},
+	}
+
+	assert.Equal(t, quotas[1], findQuota(quotas, "IN_USE_ADDRESSES"))
+	assert.Nil(t, findQuota(quotas, "SSD_TOTAL_GB"))
+}
@@ -0,0 +1,171 @@
+package gcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/ssh"
+	"google.golang.org/api/redis/v1"
+)
+
+// GetRedisInstance gets the Memorystore for Redis instance with the given name in the given region.
+func GetRedisInstance(t testing.TB, projectID string, region string, instanceName string) *redis.Instance {
+	instance, err := GetRedisInstanceE(t, projectID, region, instanceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instance
+}
+
+// GetRedisInstanceE gets the Memorystore for Redis instance with the given name in the given region. The address to
+// connect to is in the Host and Port fields of the instance.
+func GetRedisInstanceE(t testing.TB, projectID string, region string, instanceName string) (*redis.Instance, error) {
+	logger.Logf(t, "Getting Redis instance %s in %s", instanceName, region)
+
+	ctx := context.Background()
+	service, err := NewRedisServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("projects/%s/locations/%s/instances/%s", projectID, region, instanceName)
+	instance, err := service.Projects.Locations.Instances.Get(name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Instances.Get(%s) got error: %v", instanceName, err)
+	}
+
+	return instance, nil
+}
+
+// AssertRedisTierAndSize checks that the given Memorystore for Redis instance has the expected tier (BASIC or
+// STANDARD_HA) and memory size in GB, and fails the test if it does not.
+func AssertRedisTierAndSize(t testing.TB, projectID string, region string, instanceName string, expectedTier string, expectedMemorySizeGb int64) {
+	err := AssertRedisTierAndSizeE(t, projectID, region, instanceName, expectedTier, expectedMemorySizeGb)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertRedisTierAndSizeE checks that the given Memorystore for Redis instance has the expected tier (BASIC or
+// STANDARD_HA) and memory size in GB, and returns an error if it does not.
+func AssertRedisTierAndSizeE(t testing.TB, projectID string, region string, instanceName string, expectedTier string, expectedMemorySizeGb int64) error {
+	instance, err := GetRedisInstanceE(t, projectID, region, instanceName)
+	if err != nil {
+		return err
+	}
+
+	if instance.Tier != expectedTier {
+		return fmt.Errorf("Expected Redis instance %s to be of tier %s, but found %s", instanceName, expectedTier, instance.Tier)
+	}
+
+	if instance.MemorySizeGb != expectedMemorySizeGb {
+		return fmt.Errorf("Expected Redis instance %s to have %d GB of memory, but found %d GB", instanceName, expectedMemorySizeGb, instance.MemorySizeGb)
+	}
+
+	return nil
+}
+
+// PingRedis sends a PING to the Redis server at the given host and port and fails the test if it does not reply with
+// PONG. Memorystore instances only have private IPs, so use this when the test runs inside the VPC (e.g. through a
+// VPC connector), or use PingRedisViaSsh otherwise.
+func PingRedis(t testing.TB, host string, port int64) {
+	err := PingRedisE(t, host, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// PingRedisE sends a PING to the Redis server at the given host and port and returns an error if it does not reply
+// with PONG. Memorystore instances only have private IPs, so use this when the test runs inside the VPC (e.g. through
+// a VPC connector), or use PingRedisViaSshE otherwise.
+func PingRedisE(t testing.TB, host string, port int64) error {
+	address := net.JoinHostPort(host, fmt.Sprintf("%d", port))
+	logger.Logf(t, "Sending PING to Redis at %s", address)
+
+	conn, err := net.DialTimeout("tcp", address, 10*time.Second)
+	if err != nil {
+		return fmt.Errorf("Failed to connect to Redis at %s: %v", address, err)
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	if _, err := fmt.Fprint(conn, "PING\r\n"); err != nil {
+		return fmt.Errorf("Failed to send PING to Redis at %s: %v", address, err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("Failed to read reply to PING from Redis at %s: %v", address, err)
+	}
+
+	return checkRedisPong(address, reply)
+}
+
+// PingRedisViaSsh connects to the given host (e.g. a bastion in the same VPC) over SSH, sends a PING from there to
+// the Redis server at the given host and port, and fails the test if it does not reply with PONG. The SSH host needs
+// bash and the timeout command, but not redis-cli.
+func PingRedisViaSsh(t testing.TB, sshHost ssh.Host, redisHost string, redisPort int64) {
+	err := PingRedisViaSshE(t, sshHost, redisHost, redisPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// PingRedisViaSshE connects to the given host (e.g. a bastion in the same VPC) over SSH, sends a PING from there to
+// the Redis server at the given host and port, and returns an error if it does not reply with PONG. The SSH host
+// needs bash and the timeout command, but not redis-cli.
+func PingRedisViaSshE(t testing.TB, sshHost ssh.Host, redisHost string, redisPort int64) error {
+	address := net.JoinHostPort(redisHost, fmt.Sprintf("%d", redisPort))
+	logger.Logf(t, "Sending PING to Redis at %s from %s", address, sshHost.Hostname)
+
+	reply, err := ssh.CheckSshCommandE(t, sshHost, redisPingCommand(redisHost, redisPort))
+	if err != nil {
+		return fmt.Errorf("Failed to send PING to Redis at %s from %s: %v", address, sshHost.Hostname, err)
+	}
+
+	return checkRedisPong(address, reply)
+}
+
+// redisPingCommand returns a shell command that sends a PING to the given Redis server and prints the reply.
+func redisPingCommand(host string, port int64) string {
+	return fmt.Sprintf(`timeout 10 bash -c 'exec 3<>/dev/tcp/%s/%d && printf "PING\r\n" >&3 && head -n 1 <&3'`, host, port)
+}
+
+func checkRedisPong(address string, reply string) error {
+	if strings.TrimSpace(reply) != "+PONG" {
+		return fmt.Errorf("Expected Redis at %s to reply to PING with +PONG, but got %q", address, reply)
+	}
+	return nil
+}
+
+// NewRedisService creates a new Memorystore for Redis service, which is used to make Redis API calls.
+func NewRedisService(t testing.TB) *redis.Service {
+	service, err := NewRedisServiceE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// NewRedisServiceE creates a new Memorystore for Redis service, which is used to make Redis API calls.
+func NewRedisServiceE(t testing.TB) (*redis.Service, error) {
+	ctx := context.Background()
+
+	client, err := newGoogleClientE(t, ctx, redis.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default client: %v", err)
+	}
+
+	service, err := redis.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
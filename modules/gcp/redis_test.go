@@ -0,0 +1,59 @@
+package gcp
+
+import (
+	"bufio"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFakeRedis runs a server that replies to every line it receives with the given reply
+func runFakeRedis(t *testing.T, reply string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				if _, err := bufio.NewReader(conn).ReadString('\n'); err == nil {
+					conn.Write([]byte(reply))
+				}
+			}(conn)
+		}
+	}()
+
+	return listener
+}
+
+func TestPingRedis(t *testing.T) {
+	t.Parallel()
+
+	listener := runFakeRedis(t, "+PONG\r\n")
+	defer listener.Close()
+
+	port := int64(listener.Addr().(*net.TCPAddr).Port)
+	assert.NoError(t, PingRedisE(t, "127.0.0.1", port))
+}
+
+func TestPingRedisRejectsOtherReplies(t *testing.T) {
+	t.Parallel()
+
+	listener := runFakeRedis(t, "-NOAUTH Authentication required.\r\n")
+	defer listener.Close()
+
+	port := int64(listener.Addr().(*net.TCPAddr).Port)
+	assert.Error(t, PingRedisE(t, "127.0.0.1", port))
+}
+
+func TestRedisPingCommand(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `timeout 10 bash -c 'exec 3<>/dev/tcp/10.0.0.3/6379 && printf "PING\r\n" >&3 && head -n 1 <&3'`, redisPingCommand("10.0.0.3", 6379))
+}
@@ -2,6 +2,7 @@ package gcp
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -30,10 +31,59 @@ const defaultRegion = "us-west1"
 // this Zone as a default.
 const defaultZone = "us-west1-b"
 
+// Reference for launch dates: https://cloud.google.com/about/locations/
+var stableRegions = []string{
+	"us-central1",          // Launched 2013
+	"us-east1",             // Launched 2015
+	"us-east4",             // Launched 2017
+	"us-west1",             // Launched 2016
+	"europe-west1",         // Launched 2013
+	"europe-west2",         // Launched 2017
+	"europe-west3",         // Launched 2017
+	"asia-east1",           // Launched 2014
+	"asia-northeast1",      // Launched 2016
+	"asia-south1",          // Launched 2017
+	"asia-southeast1",      // Launched 2017
+	"australia-southeast1", // Launched 2017
+	"southamerica-east1",   // Launched 2017
+}
+
+// GetRandomStableRegion gets a randomly chosen GCP Region that is considered stable. Like GetRandomRegion, you can
+// further restrict the stable Region list using approvedRegions and forbiddenRegions. We consider stable Regions to be
+// those that have been around for at least 1 year, which avoids newly launched Regions that are short on capacity or
+// don't yet support all services.
+// Note that Regions in the approvedRegions list that are not considered stable are ignored.
+func GetRandomStableRegion(t testing.TB, projectID string, approvedRegions []string, forbiddenRegions []string) string {
+	region, err := GetRandomStableRegionE(t, projectID, approvedRegions, forbiddenRegions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return region
+}
+
+// GetRandomStableRegionE gets a randomly chosen GCP Region that is considered stable. Like GetRandomRegionE, you can
+// further restrict the stable Region list using approvedRegions and forbiddenRegions. We consider stable Regions to be
+// those that have been around for at least 1 year, which avoids newly launched Regions that are short on capacity or
+// don't yet support all services.
+// Note that Regions in the approvedRegions list that are not considered stable are ignored.
+func GetRandomStableRegionE(t testing.TB, projectID string, approvedRegions []string, forbiddenRegions []string) (string, error) {
+	regionsToPickFrom := stableRegions
+	if len(approvedRegions) > 0 {
+		regionsToPickFrom = collections.ListIntersection(regionsToPickFrom, approvedRegions)
+	}
+	if len(forbiddenRegions) > 0 {
+		regionsToPickFrom = collections.ListSubtract(regionsToPickFrom, forbiddenRegions)
+	}
+	if len(regionsToPickFrom) == 0 {
+		return "", fmt.Errorf("None of the stable GCP Regions %v are in the approved Regions %v and not in the forbidden Regions %v", stableRegions, approvedRegions, forbiddenRegions)
+	}
+	return GetRandomRegionE(t, projectID, regionsToPickFrom, nil)
+}
+
 // GetRandomRegion gets a randomly chosen GCP Region. If approvedRegions is not empty, this will be a Region from the approvedRegions
 // list; otherwise, this method will fetch the latest list of regions from the GCP APIs and pick one of those. If
 // forbiddenRegions is not empty, this method will make sure the returned Region is not in the forbiddenRegions list.
-func GetRandomRegion(t *testing.T, projectID string, approvedRegions []string, forbiddenRegions []string) string {
+func GetRandomRegion(t testing.TB, projectID string, approvedRegions []string, forbiddenRegions []string) string {
 	region, err := GetRandomRegionE(t, projectID, approvedRegions, forbiddenRegions)
 	if err != nil {
 		t.Fatal(err)
@@ -44,7 +94,7 @@ func GetRandomRegion(t *testing.T, projectID string, approvedRegions []string, f
 // GetRandomRegionE gets a randomly chosen GCP Region. If approvedRegions is not empty, this will be a Region from the approvedRegions
 // list; otherwise, this method will fetch the latest list of regions from the GCP APIs and pick one of those. If
 // forbiddenRegions is not empty, this method will make sure the returned Region is not in the forbiddenRegions list.
-func GetRandomRegionE(t *testing.T, projectID string, approvedRegions []string, forbiddenRegions []string) (string, error) {
+func GetRandomRegionE(t testing.TB, projectID string, approvedRegions []string, forbiddenRegions []string) (string, error) {
 	regionFromEnvVar := os.Getenv(regionOverrideEnvVarName)
 	if regionFromEnvVar != "" {
 		logger.Logf(t, "Using GCP Region %s from environment variable %s", regionFromEnvVar, regionOverrideEnvVarName)
@@ -71,7 +121,7 @@ func GetRandomRegionE(t *testing.T, projectID string, approvedRegions []string,
 // GetRandomZone gets a randomly chosen GCP Zone. If approvedRegions is not empty, this will be a Zone from the approvedZones
 // list; otherwise, this method will fetch the latest list of Zones from the GCP APIs and pick one of those. If
 // forbiddenZones is not empty, this method will make sure the returned Region is not in the forbiddenZones list.
-func GetRandomZone(t *testing.T, projectID string, approvedZones []string, forbiddenZones []string, forbiddenRegions []string) string {
+func GetRandomZone(t testing.TB, projectID string, approvedZones []string, forbiddenZones []string, forbiddenRegions []string) string {
 	zone, err := GetRandomZoneE(t, projectID, approvedZones, forbiddenZones, forbiddenRegions)
 	if err != nil {
 		t.Fatal(err)
@@ -82,7 +132,7 @@ func GetRandomZone(t *testing.T, projectID string, approvedZones []string, forbi
 // GetRandomZoneE gets a randomly chosen GCP Zone. If approvedRegions is not empty, this will be a Zone from the approvedZones
 // list; otherwise, this method will fetch the latest list of Zones from the GCP APIs and pick one of those. If
 // forbiddenZones is not empty, this method will make sure the returned Region is not in the forbiddenZones list.
-func GetRandomZoneE(t *testing.T, projectID string, approvedZones []string, forbiddenZones []string, forbiddenRegions []string) (string, error) {
+func GetRandomZoneE(t testing.TB, projectID string, approvedZones []string, forbiddenZones []string, forbiddenRegions []string) (string, error) {
 	zoneFromEnvVar := os.Getenv(zoneOverrideEnvVarName)
 	if zoneFromEnvVar != "" {
 		logger.Logf(t, "Using GCP Zone %s from environment variable %s", zoneFromEnvVar, zoneOverrideEnvVarName)
@@ -114,7 +164,7 @@ func GetRandomZoneE(t *testing.T, projectID string, approvedZones []string, forb
 }
 
 // GetRandomZoneForRegion gets a randomly chosen GCP Zone in the given Region.
-func GetRandomZoneForRegion(t *testing.T, projectID string, region string) string {
+func GetRandomZoneForRegion(t testing.TB, projectID string, region string) string {
 	zone, err := GetRandomZoneForRegionE(t, projectID, region)
 	if err != nil {
 		t.Fatal(err)
@@ -123,7 +173,7 @@ func GetRandomZoneForRegion(t *testing.T, projectID string, region string) strin
 }
 
 // GetRandomZoneForRegionE gets a randomly chosen GCP Zone in the given Region.
-func GetRandomZoneForRegionE(t *testing.T, projectID string, region string) (string, error) {
+func GetRandomZoneForRegionE(t testing.TB, projectID string, region string) (string, error) {
 	zoneFromEnvVar := os.Getenv(zoneOverrideEnvVarName)
 	if zoneFromEnvVar != "" {
 		logger.Logf(t, "Using GCP Zone %s from environment variable %s", zoneFromEnvVar, zoneOverrideEnvVarName)
@@ -150,7 +200,7 @@ func GetRandomZoneForRegionE(t *testing.T, projectID string, region string) (str
 }
 
 // GetAllGcpRegions gets the list of GCP regions available in this account.
-func GetAllGcpRegions(t *testing.T, projectID string) []string {
+func GetAllGcpRegions(t testing.TB, projectID string) []string {
 	out, err := GetAllGcpRegionsE(t, projectID)
 	if err != nil {
 		t.Fatal(err)
@@ -159,7 +209,7 @@ func GetAllGcpRegions(t *testing.T, projectID string) []string {
 }
 
 // GetAllGcpRegionsE gets the list of GCP regions available in this account.
-func GetAllGcpRegionsE(t *testing.T, projectID string) ([]string, error) {
+func GetAllGcpRegionsE(t testing.TB, projectID string) ([]string, error) {
 	logger.Log(t, "Looking up all GCP regions available in this account")
 
 	// Note that NewComputeServiceE creates a context, but it appears to be empty so we keep the code simpler by
@@ -188,7 +238,7 @@ func GetAllGcpRegionsE(t *testing.T, projectID string) ([]string, error) {
 }
 
 // GetAllGcpZones gets the list of GCP Zones available in this account.
-func GetAllGcpZones(t *testing.T, projectID string) []string {
+func GetAllGcpZones(t testing.TB, projectID string) []string {
 	out, err := GetAllGcpZonesE(t, projectID)
 	if err != nil {
 		t.Fatal(err)
@@ -197,7 +247,7 @@ func GetAllGcpZones(t *testing.T, projectID string) []string {
 }
 
 // GetAllGcpZonesE gets the list of GCP Zones available in this account.
-func GetAllGcpZonesE(t *testing.T, projectID string) ([]string, error) {
+func GetAllGcpZonesE(t testing.TB, projectID string) ([]string, error) {
 	// Note that NewComputeServiceE creates a context, but it appears to be empty so we keep the code simpler by
 	// creating a new one here
 	ctx := context.Background()
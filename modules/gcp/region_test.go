@@ -165,3 +165,17 @@ func assertLooksLikeRegionName(t *testing.T, regionName string) {
 func assertLooksLikeZoneName(t *testing.T, zoneName string) {
 	assert.Regexp(t, "[a-z]+-[a-z]+[[:digit:]]+-[a-z]{1}", zoneName)
 }
+
+func TestGetRandomStableRegionOnlyReturnsStableRegions(t *testing.T) {
+	t.Parallel()
+
+	projectID := GetGoogleProjectIDFromEnvVar(t)
+
+	approvedRegions := []string{"us-east1", "us-west2", "europe-west1", "europe-north1"}
+	forbiddenRegions := []string{"europe-west1"}
+
+	for i := 0; i < 1000; i++ {
+		randomRegion := GetRandomStableRegion(t, projectID, approvedRegions, forbiddenRegions)
+		assert.Equal(t, "us-east1", randomRegion)
+	}
+}
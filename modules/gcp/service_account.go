@@ -0,0 +1,125 @@
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"google.golang.org/api/iam/v1"
+)
+
+// CreateServiceAccountKey creates a new key for the Service Account with the given email. The returned key's
+// PrivateKeyData field holds the base64-encoded JSON key file, which you can decode with GetServiceAccountKeyJson.
+// Make sure to delete the key with DeleteServiceAccountKey when you're done!
+func CreateServiceAccountKey(t testing.TB, email string) *iam.ServiceAccountKey {
+	key, err := CreateServiceAccountKeyE(t, email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// CreateServiceAccountKeyE creates a new key for the Service Account with the given email. The returned key's
+// PrivateKeyData field holds the base64-encoded JSON key file, which you can decode with GetServiceAccountKeyJson.
+// Make sure to delete the key with DeleteServiceAccountKeyE when you're done!
+func CreateServiceAccountKeyE(t testing.TB, email string) (*iam.ServiceAccountKey, error) {
+	if dryrun.Skip(t, "create key for Service Account %s", email) {
+		return &iam.ServiceAccountKey{Name: fmt.Sprintf("projects/-/serviceAccounts/%s/keys/%s", email, dryrun.Id(""))}, nil
+	}
+
+	logger.Logf(t, "Creating key for Service Account %s", email)
+
+	ctx := context.Background()
+	service, err := NewIamServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("projects/-/serviceAccounts/%s", email)
+	key, err := service.Projects.ServiceAccounts.Keys.Create(name, &iam.CreateServiceAccountKeyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("ServiceAccounts.Keys.Create(%s) got error: %v", email, err)
+	}
+
+	return key, nil
+}
+
+// DeleteServiceAccountKey deletes the Service Account key with the given name, as found in the Name field of the key
+// returned by CreateServiceAccountKey.
+func DeleteServiceAccountKey(t testing.TB, keyName string) {
+	err := DeleteServiceAccountKeyE(t, keyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteServiceAccountKeyE deletes the Service Account key with the given name, as found in the Name field of the
+// key returned by CreateServiceAccountKeyE.
+func DeleteServiceAccountKeyE(t testing.TB, keyName string) error {
+	if dryrun.Skip(t, "delete Service Account key %s", keyName) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting Service Account key %s", keyName)
+
+	ctx := context.Background()
+	service, err := NewIamServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	if _, err := service.Projects.ServiceAccounts.Keys.Delete(keyName).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("ServiceAccounts.Keys.Delete(%s) got error: %v", keyName, err)
+	}
+
+	return nil
+}
+
+// GetServiceAccountKeyJson returns the JSON key file of the given Service Account key, which you can e.g. write to a
+// file and point GOOGLE_APPLICATION_CREDENTIALS at to run a workload with that identity.
+func GetServiceAccountKeyJson(t testing.TB, key *iam.ServiceAccountKey) string {
+	keyJSON, err := GetServiceAccountKeyJsonE(t, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyJSON
+}
+
+// GetServiceAccountKeyJsonE returns the JSON key file of the given Service Account key, which you can e.g. write to a
+// file and point GOOGLE_APPLICATION_CREDENTIALS at to run a workload with that identity.
+func GetServiceAccountKeyJsonE(t testing.TB, key *iam.ServiceAccountKey) (string, error) {
+	keyJSON, err := base64.StdEncoding.DecodeString(key.PrivateKeyData)
+	if err != nil {
+		return "", fmt.Errorf("Failed to decode private key data of Service Account key %s: %v", key.Name, err)
+	}
+	return string(keyJSON), nil
+}
+
+// NewIamService creates a new IAM service, which is used to make IAM API calls.
+func NewIamService(t testing.TB) *iam.Service {
+	service, err := NewIamServiceE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// NewIamServiceE creates a new IAM service, which is used to make IAM API calls.
+func NewIamServiceE(t testing.TB) (*iam.Service, error) {
+	ctx := context.Background()
+
+	client, err := newGoogleClientE(t, ctx, iam.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default client: %v", err)
+	}
+
+	service, err := iam.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
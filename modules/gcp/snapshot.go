@@ -0,0 +1,136 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"google.golang.org/api/compute/v1"
+)
+
+// CreateDiskSnapshot creates a snapshot with the given name of the given Persistent Disk and waits until it is done.
+func CreateDiskSnapshot(t testing.TB, projectID string, zone string, diskName string, snapshotName string) {
+	err := CreateDiskSnapshotE(t, projectID, zone, diskName, snapshotName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CreateDiskSnapshotE creates a snapshot with the given name of the given Persistent Disk and waits until it is done.
+func CreateDiskSnapshotE(t testing.TB, projectID string, zone string, diskName string, snapshotName string) error {
+	if dryrun.Skip(t, "create snapshot %s of Disk %s in %s", snapshotName, diskName, zone) {
+		return nil
+	}
+
+	logger.Logf(t, "Creating snapshot %s of Disk %s", snapshotName, diskName)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	op, err := service.Disks.CreateSnapshot(projectID, zone, diskName, &compute.Snapshot{Name: snapshotName}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("Disks.CreateSnapshot(%s) got error: %v", diskName, err)
+	}
+
+	return waitForZoneOperationE(t, service, projectID, zone, op.Name)
+}
+
+// CreateDiskFromSnapshot creates a new Persistent Disk with the given name from the given snapshot and waits until it
+// is ready, e.g. to check that a backup can actually be restored.
+func CreateDiskFromSnapshot(t testing.TB, projectID string, zone string, snapshotName string, diskName string) {
+	err := CreateDiskFromSnapshotE(t, projectID, zone, snapshotName, diskName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CreateDiskFromSnapshotE creates a new Persistent Disk with the given name from the given snapshot and waits until
+// it is ready, e.g. to check that a backup can actually be restored.
+func CreateDiskFromSnapshotE(t testing.TB, projectID string, zone string, snapshotName string, diskName string) error {
+	if dryrun.Skip(t, "create Disk %s in %s from snapshot %s", diskName, zone, snapshotName) {
+		return nil
+	}
+
+	logger.Logf(t, "Creating Disk %s from snapshot %s", diskName, snapshotName)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	disk := &compute.Disk{
+		Name:           diskName,
+		SourceSnapshot: fmt.Sprintf("projects/%s/global/snapshots/%s", projectID, snapshotName),
+	}
+	op, err := service.Disks.Insert(projectID, zone, disk).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("Disks.Insert(%s) got error: %v", diskName, err)
+	}
+
+	return waitForZoneOperationE(t, service, projectID, zone, op.Name)
+}
+
+// DeleteDisk deletes the given Persistent Disk and waits until it is gone.
+func DeleteDisk(t testing.TB, projectID string, zone string, diskName string) {
+	err := DeleteDiskE(t, projectID, zone, diskName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteDiskE deletes the given Persistent Disk and waits until it is gone.
+func DeleteDiskE(t testing.TB, projectID string, zone string, diskName string) error {
+	if dryrun.Skip(t, "delete Disk %s in %s", diskName, zone) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting Disk %s", diskName)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	op, err := service.Disks.Delete(projectID, zone, diskName).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("Disks.Delete(%s) got error: %v", diskName, err)
+	}
+
+	return waitForZoneOperationE(t, service, projectID, zone, op.Name)
+}
+
+// DeleteSnapshot deletes the given Disk snapshot.
+func DeleteSnapshot(t testing.TB, projectID string, snapshotName string) {
+	err := DeleteSnapshotE(t, projectID, snapshotName)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteSnapshotE deletes the given Disk snapshot.
+func DeleteSnapshotE(t testing.TB, projectID string, snapshotName string) error {
+	if dryrun.Skip(t, "delete snapshot %s", snapshotName) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting snapshot %s", snapshotName)
+
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	if _, err := service.Snapshots.Delete(projectID, snapshotName).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("Snapshots.Delete(%s) got error: %v", snapshotName, err)
+	}
+
+	return nil
+}
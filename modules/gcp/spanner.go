@@ -0,0 +1,309 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"google.golang.org/api/spanner/v1"
+)
+
+// GetSpannerInstance gets the Cloud Spanner Instance with the given ID.
+func GetSpannerInstance(t testing.TB, projectID string, instanceID string) *spanner.Instance {
+	instance, err := GetSpannerInstanceE(t, projectID, instanceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instance
+}
+
+// GetSpannerInstanceE gets the Cloud Spanner Instance with the given ID.
+func GetSpannerInstanceE(t testing.TB, projectID string, instanceID string) (*spanner.Instance, error) {
+	logger.Logf(t, "Getting Spanner Instance %s", instanceID)
+
+	ctx := context.Background()
+	service, err := NewSpannerServiceE(t)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := service.Projects.Instances.Get(spannerInstanceName(projectID, instanceID)).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Instances.Get(%s) got error: %v", instanceID, err)
+	}
+
+	return instance, nil
+}
+
+// AssertInstanceNodeCount checks that the Cloud Spanner Instance with the given ID has the expected number of nodes,
+// and fails the test if it does not.
+func AssertInstanceNodeCount(t testing.TB, projectID string, instanceID string, expectedNodeCount int64) {
+	err := AssertInstanceNodeCountE(t, projectID, instanceID, expectedNodeCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertInstanceNodeCountE checks that the Cloud Spanner Instance with the given ID has the expected number of
+// nodes, and returns an error if it does not.
+func AssertInstanceNodeCountE(t testing.TB, projectID string, instanceID string, expectedNodeCount int64) error {
+	instance, err := GetSpannerInstanceE(t, projectID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	if instance.NodeCount != expectedNodeCount {
+		return fmt.Errorf("Expected Spanner Instance %s to have %d nodes, but found %d", instanceID, expectedNodeCount, instance.NodeCount)
+	}
+
+	return nil
+}
+
+// CreateDatabase creates a database with the given ID in the given Cloud Spanner Instance, runs the given DDL
+// statements (e.g. CREATE TABLE) against it, and waits for the operation to complete.
+func CreateDatabase(t testing.TB, projectID string, instanceID string, databaseID string, ddlStatements []string) {
+	err := CreateDatabaseE(t, projectID, instanceID, databaseID, ddlStatements)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CreateDatabaseE creates a database with the given ID in the given Cloud Spanner Instance, runs the given DDL
+// statements (e.g. CREATE TABLE) against it, and waits for the operation to complete.
+func CreateDatabaseE(t testing.TB, projectID string, instanceID string, databaseID string, ddlStatements []string) error {
+	if dryrun.Skip(t, "create Spanner database %s in instance %s", databaseID, instanceID) {
+		return nil
+	}
+
+	logger.Logf(t, "Creating Spanner database %s in Instance %s", databaseID, instanceID)
+
+	ctx := context.Background()
+	service, err := NewSpannerServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	request := &spanner.CreateDatabaseRequest{
+		CreateStatement: fmt.Sprintf("CREATE DATABASE `%s`", databaseID),
+		ExtraStatements: ddlStatements,
+	}
+	op, err := service.Projects.Instances.Databases.Create(spannerInstanceName(projectID, instanceID), request).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("Databases.Create(%s) got error: %v", databaseID, err)
+	}
+
+	description := fmt.Sprintf("Waiting for Spanner database %s to be created", databaseID)
+	_, err = retry.DoWithPolicyContextE(t, description, operationPollPolicy, func(ctx context.Context) (string, error) {
+		current, err := service.Projects.Instances.Databases.Operations.Get(op.Name).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+		if !current.Done {
+			return "", fmt.Errorf("Operation %s is not done yet", op.Name)
+		}
+		if current.Error != nil {
+			return "", retry.FatalError{Underlying: fmt.Errorf("Failed to create Spanner database %s: %s", databaseID, current.Error.Message)}
+		}
+		return "", nil
+	})
+
+	return err
+}
+
+// DeleteDatabase drops the database with the given ID from the given Cloud Spanner Instance.
+func DeleteDatabase(t testing.TB, projectID string, instanceID string, databaseID string) {
+	err := DeleteDatabaseE(t, projectID, instanceID, databaseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteDatabaseE drops the database with the given ID from the given Cloud Spanner Instance.
+func DeleteDatabaseE(t testing.TB, projectID string, instanceID string, databaseID string) error {
+	if dryrun.Skip(t, "delete Spanner database %s in instance %s", databaseID, instanceID) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting Spanner database %s in Instance %s", databaseID, instanceID)
+
+	ctx := context.Background()
+	service, err := NewSpannerServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.Projects.Instances.Databases.DropDatabase(spannerDatabaseName(projectID, instanceID, databaseID)).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("Databases.DropDatabase(%s) got error: %v", databaseID, err)
+	}
+
+	return nil
+}
+
+// ExecuteDML runs the given DML statement (INSERT, UPDATE, or DELETE) against the given Cloud Spanner database in a
+// read-write transaction and returns the number of rows it modified.
+func ExecuteDML(t testing.TB, projectID string, instanceID string, databaseID string, sql string) int64 {
+	rowCount, err := ExecuteDMLE(t, projectID, instanceID, databaseID, sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rowCount
+}
+
+// ExecuteDMLE runs the given DML statement (INSERT, UPDATE, or DELETE) against the given Cloud Spanner database in a
+// read-write transaction and returns the number of rows it modified.
+func ExecuteDMLE(t testing.TB, projectID string, instanceID string, databaseID string, sql string) (int64, error) {
+	if dryrun.Skip(t, "execute DML against Spanner database %s: %s", databaseID, sql) {
+		return 0, nil
+	}
+
+	logger.Logf(t, "Executing DML against Spanner database %s: %s", databaseID, sql)
+
+	var rowCount int64
+	err := withSpannerSessionE(t, projectID, instanceID, databaseID, func(ctx context.Context, service *spanner.Service, session string) error {
+		request := &spanner.ExecuteSqlRequest{
+			Sql:         sql,
+			Seqno:       1,
+			Transaction: &spanner.TransactionSelector{Begin: &spanner.TransactionOptions{ReadWrite: &spanner.ReadWrite{}}},
+		}
+		resultSet, err := service.Projects.Instances.Databases.Sessions.ExecuteSql(session, request).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("Sessions.ExecuteSql got error: %v", err)
+		}
+		if resultSet.Stats != nil {
+			rowCount = resultSet.Stats.RowCountExact
+		}
+
+		transactionID, err := transactionIDFromResultSet(resultSet)
+		if err != nil {
+			return err
+		}
+
+		commit := &spanner.CommitRequest{TransactionId: transactionID}
+		if _, err := service.Projects.Instances.Databases.Sessions.Commit(session, commit).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("Sessions.Commit got error: %v", err)
+		}
+
+		return nil
+	})
+
+	return rowCount, err
+}
+
+// QueryRows runs the given SQL query against the given Cloud Spanner database and returns the resulting rows as maps
+// from column name to value. Values are decoded from JSON as Spanner returns them, so e.g. INT64 columns are strings
+// and FLOAT64 columns are float64s.
+func QueryRows(t testing.TB, projectID string, instanceID string, databaseID string, sql string) []map[string]interface{} {
+	rows, err := QueryRowsE(t, projectID, instanceID, databaseID, sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+// QueryRowsE runs the given SQL query against the given Cloud Spanner database and returns the resulting rows as
+// maps from column name to value. Values are decoded from JSON as Spanner returns them, so e.g. INT64 columns are
+// strings and FLOAT64 columns are float64s.
+func QueryRowsE(t testing.TB, projectID string, instanceID string, databaseID string, sql string) ([]map[string]interface{}, error) {
+	logger.Logf(t, "Querying Spanner database %s: %s", databaseID, sql)
+
+	var rows []map[string]interface{}
+	err := withSpannerSessionE(t, projectID, instanceID, databaseID, func(ctx context.Context, service *spanner.Service, session string) error {
+		resultSet, err := service.Projects.Instances.Databases.Sessions.ExecuteSql(session, &spanner.ExecuteSqlRequest{Sql: sql}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("Sessions.ExecuteSql got error: %v", err)
+		}
+		rows = resultSetToRows(resultSet)
+		return nil
+	})
+
+	return rows, err
+}
+
+// withSpannerSessionE creates a session on the given database, calls the given function with it, and then deletes the
+// session again.
+func withSpannerSessionE(t testing.TB, projectID string, instanceID string, databaseID string, action func(ctx context.Context, service *spanner.Service, session string) error) error {
+	ctx := context.Background()
+	service, err := NewSpannerServiceE(t)
+	if err != nil {
+		return err
+	}
+
+	database := spannerDatabaseName(projectID, instanceID, databaseID)
+	session, err := service.Projects.Instances.Databases.Sessions.Create(database, &spanner.CreateSessionRequest{}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("Sessions.Create(%s) got error: %v", databaseID, err)
+	}
+	defer service.Projects.Instances.Databases.Sessions.Delete(session.Name).Context(ctx).Do()
+
+	return action(ctx, service, session.Name)
+}
+
+// resultSetToRows converts the rows of a Spanner ResultSet into maps from column name to value.
+func resultSetToRows(resultSet *spanner.ResultSet) []map[string]interface{} {
+	columns := []string{}
+	if resultSet.Metadata != nil && resultSet.Metadata.RowType != nil {
+		for _, field := range resultSet.Metadata.RowType.Fields {
+			columns = append(columns, field.Name)
+		}
+	}
+
+	rows := []map[string]interface{}{}
+	for _, values := range resultSet.Rows {
+		row := map[string]interface{}{}
+		for i, value := range values {
+			if i < len(columns) {
+				row[columns[i]] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// transactionIDFromResultSet returns the ID of the transaction that was begun by the statement that returned the given
+// result set.
+func transactionIDFromResultSet(resultSet *spanner.ResultSet) (string, error) {
+	if resultSet.Metadata == nil || resultSet.Metadata.Transaction == nil {
+		return "", fmt.Errorf("Sessions.ExecuteSql did not return the transaction it began, so the DML cannot be committed")
+	}
+	return resultSet.Metadata.Transaction.Id, nil
+}
+
+func spannerInstanceName(projectID string, instanceID string) string {
+	return fmt.Sprintf("projects/%s/instances/%s", projectID, instanceID)
+}
+
+func spannerDatabaseName(projectID string, instanceID string, databaseID string) string {
+	return fmt.Sprintf("%s/databases/%s", spannerInstanceName(projectID, instanceID), databaseID)
+}
+
+// NewSpannerService creates a new Cloud Spanner service, which is used to make Spanner API calls.
+func NewSpannerService(t testing.TB) *spanner.Service {
+	service, err := NewSpannerServiceE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// NewSpannerServiceE creates a new Cloud Spanner service, which is used to make Spanner API calls.
+func NewSpannerServiceE(t testing.TB) (*spanner.Service, error) {
+	ctx := context.Background()
+
+	client, err := newGoogleClientE(t, ctx, spanner.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get default client: %v", err)
+	}
+
+	service, err := spanner.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,61 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/spanner/v1"
+)
+
+func TestResultSetToRows(t *testing.T) {
+	t.Parallel()
+
+	resultSet := &spanner.ResultSet{
+		Metadata: &spanner.ResultSetMetadata{
+			RowType: &spanner.StructType{Fields: []*spanner.Field{{Name: "id"}, {Name: "name"}}},
+		},
+		Rows: [][]interface{}{{"1", "alice"}, {"2", nil}},
+	}
+
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "1", "name": "alice"},
+		{"id": "2", "name": nil},
+	}, resultSetToRows(resultSet))
+
+	assert.Equal(t, []map[string]interface{}{}, resultSetToRows(&spanner.ResultSet{}))
+}
+
+func TestTransactionIDFromResultSet(t *testing.T) {
+	t.Parallel()
+
+	transactionID, err := transactionIDFromResultSet(&spanner.ResultSet{
+		Metadata: &spanner.ResultSetMetadata{Transaction: &spanner.Transaction{Id: "dHhuLTE="}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dHhuLTE=", transactionID)
+
+	_, err = transactionIDFromResultSet(&spanner.ResultSet{})
+	assert.Error(t, err)
+
+	_, err = transactionIDFromResultSet(&spanner.ResultSet{Metadata: &spanner.ResultSetMetadata{}})
+	assert.Error(t, err)
+}
+
+func TestSpannerDatabaseName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "projects/my-project/instances/my-instance/databases/my-db", spannerDatabaseName("my-project", "my-instance", "my-db"))
+}
+
+// This test does not call t.Parallel, as it enables dry run for the whole process.
+func TestExecuteDMLSkippedInDryRun(t *testing.T) {
+	restore := dryrun.Enable(t)
+	defer restore()
+
+	// The database doesn't exist, so a statement that is actually executed fails
+	rowCount, err := ExecuteDMLE(t, "terratest-project-that-does-not-exist", "instance", "database", "DELETE FROM users WHERE true")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rowCount)
+}
@@ -0,0 +1,112 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+)
+
+var startupScriptExitStatusRegexp = regexp.MustCompile(`startup-script exit status (\d+)`)
+
+const startupScriptsFinishedMessage = "Finished running startup scripts"
+
+// GetSerialPortOutput gets the output of the first serial port of the given Compute Instance, which is where the
+// boot log and the output of startup scripts end up.
+func GetSerialPortOutput(t testing.TB, projectID string, zone string, instanceName string) string {
+	out, err := GetSerialPortOutputE(t, projectID, zone, instanceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// GetSerialPortOutputE gets the output of the first serial port of the given Compute Instance, which is where the
+// boot log and the output of startup scripts end up.
+func GetSerialPortOutputE(t testing.TB, projectID string, zone string, instanceName string) (string, error) {
+	ctx := context.Background()
+	service, err := NewComputeServiceE(t)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := service.Instances.GetSerialPortOutput(projectID, zone, instanceName).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("Instances.GetSerialPortOutput(%s) got error: %v", instanceName, err)
+	}
+
+	return output.Contents, nil
+}
+
+// WaitForStartupScript waits until the startup script of the given Compute Instance has finished, by watching the
+// serial port output of the Instance, retrying up to maxRetries times. The test fails, showing the startup script's
+// output, if the script exits with a non-zero status. Use this instead of a fixed sleep before running assertions
+// against a new Instance.
+func WaitForStartupScript(t testing.TB, projectID string, zone string, instanceName string, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitForStartupScriptE(t, projectID, zone, instanceName, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitForStartupScriptE waits until the startup script of the given Compute Instance has finished, by watching the
+// serial port output of the Instance, retrying up to maxRetries times. An error, including the startup script's
+// output, is returned if the script exits with a non-zero status. Use this instead of a fixed sleep before running
+// assertions against a new Instance.
+func WaitForStartupScriptE(t testing.TB, projectID string, zone string, instanceName string, maxRetries int, sleepBetweenRetries time.Duration) error {
+	description := fmt.Sprintf("Waiting for the startup script of Compute Instance %s to finish", instanceName)
+
+	_, err := retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		out, err := GetSerialPortOutputE(t, projectID, zone, instanceName)
+		if err != nil {
+			return "", err
+		}
+
+		finished, exitStatus := parseStartupScriptStatus(out)
+		if !finished {
+			return "", fmt.Errorf("The startup script of Compute Instance %s is still running", instanceName)
+		}
+		if exitStatus != "0" {
+			return "", retry.FatalError{Underlying: fmt.Errorf("The startup script of Compute Instance %s exited with status %s. Output:\n%s", instanceName, exitStatus, startupScriptLines(out))}
+		}
+		return "", nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Logf(t, "The startup script of Compute Instance %s has finished", instanceName)
+	return nil
+}
+
+// parseStartupScriptStatus returns whether the startup scripts have finished according to the given serial port
+// output and, if so, the exit status of the last one. Instances without a startup script still log that the startup
+// scripts have finished, in which case the exit status is "0".
+func parseStartupScriptStatus(serialPortOutput string) (bool, string) {
+	if !strings.Contains(serialPortOutput, startupScriptsFinishedMessage) {
+		return false, ""
+	}
+
+	matches := startupScriptExitStatusRegexp.FindAllStringSubmatch(serialPortOutput, -1)
+	if len(matches) == 0 {
+		return true, "0"
+	}
+
+	return true, matches[len(matches)-1][1]
+}
+
+// startupScriptLines returns the lines of the given serial port output that were logged by the startup script.
+func startupScriptLines(serialPortOutput string) string {
+	lines := []string{}
+	for _, line := range strings.Split(serialPortOutput, "\n") {
+		if strings.Contains(line, "startup-script") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
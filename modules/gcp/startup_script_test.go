@@ -0,0 +1,44 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStartupScriptStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		output           string
+		expectedFinished bool
+		expectedStatus   string
+	}{
+		{"Running", "google_metadata_script_runner: startup-script: installing nginx\n", false, ""},
+		{"Succeeded", "startup-script: done\nstartup-script exit status 0\nFinished running startup scripts.\n", true, "0"},
+		{"Failed", "startup-script: apt-get failed\nstartup-script exit status 100\nFinished running startup scripts.\n", true, "100"},
+		{"NoScript", "No startup scripts to run.\nFinished running startup scripts.\n", true, "0"},
+		{"Rebooted", "startup-script exit status 1\nFinished running startup scripts.\nstartup-script exit status 0\nFinished running startup scripts.\n", true, "0"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			finished, status := parseStartupScriptStatus(testCase.output)
+			assert.Equal(t, testCase.expectedFinished, finished)
+			assert.Equal(t, testCase.expectedStatus, status)
+		})
+	}
+}
+
+func TestStartupScriptLines(t *testing.T) {
+	t.Parallel()
+
+	output := "kernel: booting\nstartup-script: hello\nsystemd: started\nstartup-script exit status 0\n"
+	assert.Equal(t, "startup-script: hello\nstartup-script exit status 0", startupScriptLines(output))
+}
@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
+	"golang.org/x/oauth2/google"
 	"google.golang.org/api/iterator"
 )
 
 // CreateStorageBucket creates a Google Cloud bucket with the given BucketAttrs. Note that Google Storage bucket names must be globally unique.
-func CreateStorageBucket(t *testing.T, projectID string, name string, attr *storage.BucketAttrs) {
+func CreateStorageBucket(t testing.TB, projectID string, name string, attr *storage.BucketAttrs) {
 	err := CreateStorageBucketE(t, projectID, name, attr)
 	if err != nil {
 		t.Fatal(err)
@@ -20,13 +24,17 @@ func CreateStorageBucket(t *testing.T, projectID string, name string, attr *stor
 }
 
 // CreateStorageBucketE creates a Google Cloud bucket with the given BucketAttrs. Note that Google Storage bucket names must be globally unique.
-func CreateStorageBucketE(t *testing.T, projectID string, name string, attr *storage.BucketAttrs) error {
+func CreateStorageBucketE(t testing.TB, projectID string, name string, attr *storage.BucketAttrs) error {
+	if dryrun.Skip(t, "create storage bucket %s in Project %s", name, projectID) {
+		return nil
+	}
+
 	logger.Logf(t, "Creating bucket %s", name)
 
 	ctx := context.Background()
 
 	// Creates a client.
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return err
 	}
@@ -39,7 +47,7 @@ func CreateStorageBucketE(t *testing.T, projectID string, name string, attr *sto
 }
 
 // DeleteStorageBucket destroys the Google Storage bucket.
-func DeleteStorageBucket(t *testing.T, name string) {
+func DeleteStorageBucket(t testing.TB, name string) {
 	err := DeleteStorageBucketE(t, name)
 	if err != nil {
 		t.Fatal(err)
@@ -47,12 +55,16 @@ func DeleteStorageBucket(t *testing.T, name string) {
 }
 
 // DeleteStorageBucketE destroys the S3 bucket in the given region with the given name.
-func DeleteStorageBucketE(t *testing.T, name string) error {
+func DeleteStorageBucketE(t testing.TB, name string) error {
+	if dryrun.Skip(t, "delete storage bucket %s", name) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting bucket %s", name)
 
 	ctx := context.Background()
 
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return err
 	}
@@ -61,7 +73,7 @@ func DeleteStorageBucketE(t *testing.T, name string) error {
 }
 
 // ReadBucketObject reads an object from the given Storage Bucket and returns its contents.
-func ReadBucketObject(t *testing.T, bucketName string, filePath string) io.Reader {
+func ReadBucketObject(t testing.TB, bucketName string, filePath string) io.Reader {
 	out, err := ReadBucketObjectE(t, bucketName, filePath)
 	if err != nil {
 		t.Fatal(err)
@@ -70,12 +82,12 @@ func ReadBucketObject(t *testing.T, bucketName string, filePath string) io.Reade
 }
 
 // ReadBucketObjectE reads an object from the given Storage Bucket and returns its contents.
-func ReadBucketObjectE(t *testing.T, bucketName string, filePath string) (io.Reader, error) {
+func ReadBucketObjectE(t testing.TB, bucketName string, filePath string) (io.Reader, error) {
 	logger.Logf(t, "Reading object from bucket %s using path %s", bucketName, filePath)
 
 	ctx := context.Background()
 
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -83,14 +95,14 @@ func ReadBucketObjectE(t *testing.T, bucketName string, filePath string) (io.Rea
 	bucket := client.Bucket(bucketName)
 	r, err := bucket.Object(filePath).NewReader(ctx)
 	if err != nil {
-		return nil, err
+		return nil, wrapGcpError(err, "Storage object", fmt.Sprintf("%s/%s", bucketName, filePath), "")
 	}
 
 	return r, nil
 }
 
 // WriteBucketObject writes an object to the given Storage Bucket and returns its URL.
-func WriteBucketObject(t *testing.T, bucketName string, filePath string, body io.Reader, contentType string) string {
+func WriteBucketObject(t testing.TB, bucketName string, filePath string, body io.Reader, contentType string) string {
 	out, err := WriteBucketObjectE(t, bucketName, filePath, body, contentType)
 	if err != nil {
 		t.Fatal(err)
@@ -99,7 +111,11 @@ func WriteBucketObject(t *testing.T, bucketName string, filePath string, body io
 }
 
 // WriteBucketObjectE writes an object to the given Storage Bucket and returns its URL.
-func WriteBucketObjectE(t *testing.T, bucketName string, filePath string, body io.Reader, contentType string) (string, error) {
+func WriteBucketObjectE(t testing.TB, bucketName string, filePath string, body io.Reader, contentType string) (string, error) {
+	if dryrun.Skip(t, "write object %s to storage bucket %s", filePath, bucketName) {
+		return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucketName, filePath), nil
+	}
+
 	// set a default content type
 	if contentType == "" {
 		contentType = "application/octet-stream"
@@ -109,7 +125,7 @@ func WriteBucketObjectE(t *testing.T, bucketName string, filePath string, body i
 
 	ctx := context.Background()
 
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return "", err
 	}
@@ -134,7 +150,7 @@ func WriteBucketObjectE(t *testing.T, bucketName string, filePath string, body i
 }
 
 // EmptyStorageBucket removes the contents of a storage bucket with the given name.
-func EmptyStorageBucket(t *testing.T, name string) {
+func EmptyStorageBucket(t testing.TB, name string) {
 	err := EmptyStorageBucketE(t, name)
 	if err != nil {
 		t.Fatal(err)
@@ -142,12 +158,16 @@ func EmptyStorageBucket(t *testing.T, name string) {
 }
 
 // EmptyStorageBucketE removes the contents of a storage bucket with the given name.
-func EmptyStorageBucketE(t *testing.T, name string) error {
+func EmptyStorageBucketE(t testing.TB, name string) error {
+	if dryrun.Skip(t, "empty storage bucket %s", name) {
+		return nil
+	}
+
 	logger.Logf(t, "Emptying storage bucket %s", name)
 
 	ctx := context.Background()
 
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return err
 	}
@@ -178,7 +198,7 @@ func EmptyStorageBucketE(t *testing.T, name string) error {
 }
 
 // AssertStorageBucketExists checks if the given storage bucket exists and fails the test if it does not.
-func AssertStorageBucketExists(t *testing.T, name string) {
+func AssertStorageBucketExists(t testing.TB, name string) {
 	err := AssertStorageBucketExistsE(t, name)
 	if err != nil {
 		t.Fatal(err)
@@ -186,13 +206,13 @@ func AssertStorageBucketExists(t *testing.T, name string) {
 }
 
 // AssertStorageBucketExistsE checks if the given storage bucket exists and returns an error if it does not.
-func AssertStorageBucketExistsE(t *testing.T, name string) error {
+func AssertStorageBucketExistsE(t testing.TB, name string) error {
 	logger.Logf(t, "Finding bucket %s", name)
 
 	ctx := context.Background()
 
 	// Creates a client.
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return err
 	}
@@ -219,95 +239,93 @@ func AssertStorageBucketExistsE(t *testing.T, name string) error {
 }
 
 // ReadBucketObject reads an object from the given Storage Bucket and returns its contents.
-func CheckBucketAttribs(t *testing.T, bucketName string, attributeName string, attributeValue string) string {
-	result, err := CheckBucketAttribsE(t, bucketName, attributeName,attributeValue)
+func CheckBucketAttribs(t testing.TB, bucketName string, attributeName string, attributeValue string) string {
+	result, err := CheckBucketAttribsE(t, bucketName, attributeName, attributeValue)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if result !="success" {
+	if result != "success" {
 		t.Fatal(result)
 	}
 	return result
 }
 
-
 // ReadBucketObjectE reads an object from the given Storage Bucket and returns its contents.
-func CheckBucketAttribsE(t *testing.T, bucketName string, attributeName string, attributeValue string) (string, error) {
-	logger.Logf(t, "Reading object attrib %s for bucket %s with value %s", attributeName,bucketName,attributeValue)
+func CheckBucketAttribsE(t testing.TB, bucketName string, attributeName string, attributeValue string) (string, error) {
+	logger.Logf(t, "Reading object attrib %s for bucket %s with value %s", attributeName, bucketName, attributeValue)
 
 	ctx := context.Background()
 
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return "error", err
 	}
 
 	attrs, err := client.Bucket(bucketName).Attrs(ctx)
-	if (attrs.Name == bucketName) {
+	if attrs.Name == bucketName {
 		switch strings.ToLower(attributeName) {
 		case "location":
-			logger.Logf(t,"LOCATION ")
-			if (strings.HasPrefix(strings.ToLower(attrs.Location),strings.ToLower(attributeValue))) {
-				return "success",nil
-			}else{
+			logger.Logf(t, "LOCATION ")
+			if strings.HasPrefix(strings.ToLower(attrs.Location), strings.ToLower(attributeValue)) {
+				return "success", nil
+			} else {
 				if err != nil {
 					return "error", err
 				}
-				return join("Bucket Location and Region must start with ",attributeValue),nil
-			}		
+				return join("Bucket Location and Region must start with ", attributeValue), nil
+			}
 		case "storageclass":
-			logger.Logf(t,"StorageClass")
-			if (strings.Compare(strings.ToUpper(attrs.StorageClass),strings.ToUpper(attributeValue))==0) {
-				return "success",nil
-			}else{
+			logger.Logf(t, "StorageClass")
+			if strings.Compare(strings.ToUpper(attrs.StorageClass), strings.ToUpper(attributeValue)) == 0 {
+				return "success", nil
+			} else {
 				if err != nil {
 					return "error", err
 				}
-				return join("Storage Class is ", strings.ToUpper(attrs.StorageClass), " does not match to what is expected - ",attributeValue),nil
-			}		
+				return join("Storage Class is ", strings.ToUpper(attrs.StorageClass), " does not match to what is expected - ", attributeValue), nil
+			}
 		case "version":
-			logger.Logf(t,"version")
-			logger.Logf(t,"versioning enabled? %t", attrs.VersioningEnabled) 
-			if (strings.ToLower(attributeValue) == "true") {
-				if  attrs.VersioningEnabled  {
-					return "success",nil
-				}else{
-					return join("Bucket Versioning should be enabled but is not enabled "),nil
-				}	
-			}else {
-				if  attrs.VersioningEnabled  {
-					return join("Bucket Versioning should not be enabled but is enabled "),nil
-				} else{
-					return "success",nil
+			logger.Logf(t, "version")
+			logger.Logf(t, "versioning enabled? %t", attrs.VersioningEnabled)
+			if strings.ToLower(attributeValue) == "true" {
+				if attrs.VersioningEnabled {
+					return "success", nil
+				} else {
+					return join("Bucket Versioning should be enabled but is not enabled "), nil
+				}
+			} else {
+				if attrs.VersioningEnabled {
+					return join("Bucket Versioning should not be enabled but is enabled "), nil
+				} else {
+					return "success", nil
 				}
 			}
 		case "labels":
-			logger.Logf(t,"Labels %s", attrs.Labels)
+			logger.Logf(t, "Labels %s", attrs.Labels)
 		}
 	}
 	return "success", nil
 }
 
-
 // ReadBucketObject reads an object from the given Storage Bucket and returns its contents.
-func CheckBucketLabels(t *testing.T, bucketName string, labelName string, labelValue string) string {
-	result, err := CheckBucketLabelsE(t, bucketName, "labels",labelName,labelValue)
+func CheckBucketLabels(t testing.TB, bucketName string, labelName string, labelValue string) string {
+	result, err := CheckBucketLabelsE(t, bucketName, "labels", labelName, labelValue)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if result !="success" {
+	if result != "success" {
 		t.Fatal(result)
 	}
 	return result
-}	
+}
 
 // ReadBucketObjectE reads an object from the given Storage Bucket and returns its contents.
-func CheckBucketLabelsE(t *testing.T, bucketName string, attributeName string, labelName string, labelValue string) (string, error) {
-	logger.Logf(t, "Reading object attrib %s for bucket %s with value %s", labelName,bucketName,labelValue)
+func CheckBucketLabelsE(t testing.TB, bucketName string, attributeName string, labelName string, labelValue string) (string, error) {
+	logger.Logf(t, "Reading object attrib %s for bucket %s with value %s", labelName, bucketName, labelValue)
 
 	ctx := context.Background()
 
-	client, err := storage.NewClient(ctx)
+	client, err := newStorageClientE(t, ctx)
 	if err != nil {
 		return "error", err
 	}
@@ -316,29 +334,27 @@ func CheckBucketLabelsE(t *testing.T, bucketName string, attributeName string, l
 	if err != nil {
 		return "error", err
 	}
-	if (attrs.Name == bucketName) {
-		logger.Logf(t,"Labels %s", attrs.Labels)
+	if attrs.Name == bucketName {
+		logger.Logf(t, "Labels %s", attrs.Labels)
 		var mapLabels map[string]string = attrs.Labels
 
-		logger.Logf(t,"Labels variable %s", mapLabels)
+		logger.Logf(t, "Labels variable %s", mapLabels)
 		if mapLabels == nil {
 			return "error", err
 		}
 		if mapLabels != nil {
-			logger.Logf(t,"Labels %s %s", labelName, mapLabels[labelName])
-			if (strings.Compare(mapLabels[labelName],labelValue)==0){ 
-			//if (mapLabels[labelName]== mapLabels[labelValue]){
-				logger.Logf(t,"Matching Labels found %s = %s", labelName, mapLabels[labelName])
+			logger.Logf(t, "Labels %s %s", labelName, mapLabels[labelName])
+			if strings.Compare(mapLabels[labelName], labelValue) == 0 {
+				//if (mapLabels[labelName]== mapLabels[labelValue]){
+				logger.Logf(t, "Matching Labels found %s = %s", labelName, mapLabels[labelName])
 				return "success", nil
-			}else{
+			} else {
 				if err != nil {
 					return "error", err
 				}
-				return join("Expected value for label ",labelName," is ", labelValue, "but the value is ", mapLabels[labelName] ),nil
+				return join("Expected value for label ", labelName, " is ", labelValue, "but the value is ", mapLabels[labelName]), nil
 			}
 		}
-			
-		
 
 	}
 
@@ -351,3 +367,107 @@ func join(strs ...string) string {
 	}
 	return sb.String()
 }
+
+// ListBucketObjects returns the names of the objects in the given Storage Bucket whose names start with the given
+// prefix.
+func ListBucketObjects(t testing.TB, bucketName string, prefix string) []string {
+	names, err := ListBucketObjectsE(t, bucketName, prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+// ListBucketObjectsE returns the names of the objects in the given Storage Bucket whose names start with the given
+// prefix.
+func ListBucketObjectsE(t testing.TB, bucketName string, prefix string) ([]string, error) {
+	logger.Logf(t, "Listing objects in bucket %s with prefix %s", bucketName, prefix)
+
+	ctx := context.Background()
+
+	client, err := newStorageClientE(t, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	it := client.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		objectAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, objectAttrs.Name)
+	}
+
+	return names, nil
+}
+
+// DeleteBucketObject deletes the object at the given path from the given Storage Bucket.
+func DeleteBucketObject(t testing.TB, bucketName string, filePath string) {
+	err := DeleteBucketObjectE(t, bucketName, filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DeleteBucketObjectE deletes the object at the given path from the given Storage Bucket.
+func DeleteBucketObjectE(t testing.TB, bucketName string, filePath string) error {
+	if dryrun.Skip(t, "delete object %s from storage bucket %s", filePath, bucketName) {
+		return nil
+	}
+
+	logger.Logf(t, "Deleting object from bucket %s using path %s", bucketName, filePath)
+
+	ctx := context.Background()
+
+	client, err := newStorageClientE(t, ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.Bucket(bucketName).Object(filePath).Delete(ctx)
+}
+
+// GetSignedBucketObjectUrl returns a URL that grants anyone who has it access to the object at the given path for
+// the given HTTP method (e.g. GET or PUT) until it expires. The URL is signed with the service account key in the
+// default credentials, so GOOGLE_APPLICATION_CREDENTIALS must point at a service account key file.
+func GetSignedBucketObjectUrl(t testing.TB, bucketName string, filePath string, method string, expiry time.Duration) string {
+	url, err := GetSignedBucketObjectUrlE(t, bucketName, filePath, method, expiry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return url
+}
+
+// GetSignedBucketObjectUrlE returns a URL that grants anyone who has it access to the object at the given path for
+// the given HTTP method (e.g. GET or PUT) until it expires. The URL is signed with the service account key in the
+// default credentials, so GOOGLE_APPLICATION_CREDENTIALS must point at a service account key file.
+func GetSignedBucketObjectUrlE(t testing.TB, bucketName string, filePath string, method string, expiry time.Duration) (string, error) {
+	logger.Logf(t, "Signing %s URL for object %s in bucket %s, valid for %s", method, filePath, bucketName, expiry)
+
+	ctx := context.Background()
+
+	credentials, err := google.FindDefaultCredentials(ctx, storage.ScopeReadOnly)
+	if err != nil {
+		return "", err
+	}
+	if len(credentials.JSON) == 0 {
+		return "", fmt.Errorf("Signing URLs requires a service account key, but the default credentials have none. Set GOOGLE_APPLICATION_CREDENTIALS to the path of a service account key file.")
+	}
+
+	jwtConfig, err := google.JWTConfigFromJSON(credentials.JSON)
+	if err != nil {
+		return "", err
+	}
+
+	return storage.SignedURL(bucketName, filePath, &storage.SignedURLOptions{
+		GoogleAccessID: jwtConfig.Email,
+		PrivateKey:     jwtConfig.PrivateKey,
+		Method:         method,
+		Expires:        time.Now().Add(expiry),
+	})
+}
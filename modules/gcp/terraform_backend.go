@@ -0,0 +1,102 @@
+package gcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// GcsBackend is the location of the state of a test run in a GCS backend.
+type GcsBackend struct {
+	ProjectID string // The project the bucket is created in, if it doesn't exist
+	Bucket    string // The bucket that stores the state
+	Prefix    string // The prefix of the state in the bucket. It's unique to each test run.
+}
+
+// ConfigureGcsBackend configures the given options to store the state in the given GCS bucket, under a prefix that's
+// unique to this test run, and creates the bucket in the given project if it doesn't exist yet. The Terraform code
+// must declare an empty gcs backend (backend "gcs" {}). Call CleanupGcsBackend at the end of the test (after destroy)
+// to delete the state. For example:
+//
+//	backend := gcp.ConfigureGcsBackend(t, options, projectID, "my-test-state-bucket")
+//	defer gcp.CleanupGcsBackend(t, backend)
+//	defer terraform.Destroy(t, options)
+//	terraform.InitAndApply(t, options)
+func ConfigureGcsBackend(t testing.TB, options *terraform.Options, projectID string, bucket string) *GcsBackend {
+	backend, err := ConfigureGcsBackendE(t, options, projectID, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backend
+}
+
+// ConfigureGcsBackendE configures the given options to store the state in the given GCS bucket, under a prefix that's
+// unique to this test run, and creates the bucket in the given project if it doesn't exist yet. The Terraform code
+// must declare an empty gcs backend (backend "gcs" {}). Call CleanupGcsBackendE at the end of the test (after destroy)
+// to delete the state.
+func ConfigureGcsBackendE(t testing.TB, options *terraform.Options, projectID string, bucket string) (*GcsBackend, error) {
+	if err := AssertStorageBucketExistsE(t, bucket); err != nil {
+		if err != storage.ErrBucketNotExist {
+			return nil, err
+		}
+		if err := CreateStorageBucketE(t, projectID, bucket, &storage.BucketAttrs{}); err != nil {
+			return nil, err
+		}
+	}
+
+	backend := &GcsBackend{
+		ProjectID: projectID,
+		Bucket:    bucket,
+		Prefix:    formatGcsStatePrefix(t.Name(), random.UniqueId()),
+	}
+	options.Logger.Logf(t, "Storing the state in gs://%s/%s", backend.Bucket, backend.Prefix)
+
+	options.BackendConfig = withGcsBackendConfig(options.BackendConfig, backend)
+	return backend, nil
+}
+
+// CleanupGcsBackend deletes the state (and any lock files) that the test run stored under the prefix of the given
+// backend. It doesn't delete the bucket, so that it can be shared by test runs.
+func CleanupGcsBackend(t testing.TB, backend *GcsBackend) {
+	if err := CleanupGcsBackendE(t, backend); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CleanupGcsBackendE deletes the state (and any lock files) that the test run stored under the prefix of the given
+// backend. It doesn't delete the bucket, so that it can be shared by test runs.
+func CleanupGcsBackendE(t testing.TB, backend *GcsBackend) error {
+	// The trailing slash stops this from matching the state of other test runs whose prefix starts with this one
+	objects, err := ListBucketObjectsE(t, backend.Bucket, backend.Prefix+"/")
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		if err := DeleteBucketObjectE(t, backend.Bucket, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withGcsBackendConfig returns a copy of the given backend config with the bucket and prefix of the given backend
+func withGcsBackendConfig(backendConfig map[string]interface{}, backend *GcsBackend) map[string]interface{} {
+	config := map[string]interface{}{}
+	for key, value := range backendConfig {
+		config[key] = value
+	}
+	config["bucket"] = backend.Bucket
+	config["prefix"] = backend.Prefix
+	return config
+}
+
+// formatGcsStatePrefix returns the prefix to store the state of the test with the given name under, e.g.
+// terratest/TestFoo-abc123. The slashes of subtest names are replaced, so each test run gets a single directory.
+func formatGcsStatePrefix(testName string, uniqueID string) string {
+	return fmt.Sprintf("terratest/%s-%s", strings.Replace(testName, "/", "_", -1), uniqueID)
+}
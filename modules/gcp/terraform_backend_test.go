@@ -0,0 +1,53 @@
+package gcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatGcsStatePrefix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "terratest/TestFoo-abc123", formatGcsStatePrefix("TestFoo", "abc123"))
+	assert.Equal(t, "terratest/TestFoo_bar-abc123", formatGcsStatePrefix("TestFoo/bar", "abc123"))
+}
+
+func TestWithGcsBackendConfig(t *testing.T) {
+	t.Parallel()
+
+	original := map[string]interface{}{"credentials": "creds.json", "prefix": "old"}
+	config := withGcsBackendConfig(original, &GcsBackend{Bucket: "bucket", Prefix: "terratest/TestFoo-abc123"})
+
+	assert.Equal(t, map[string]interface{}{"credentials": "creds.json", "bucket": "bucket", "prefix": "terratest/TestFoo-abc123"}, config)
+	assert.Equal(t, "old", original["prefix"])
+}
+
+func TestConfigureGcsBackend(t *testing.T) {
+	t.Parallel()
+
+	projectID := GetGoogleProjectIDFromEnvVar(t)
+	bucket := fmt.Sprintf("terratest-state-%s", strings.ToLower(random.UniqueId()))
+	defer DeleteStorageBucket(t, bucket)
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-backend-gcs", t.Name())
+	require.NoError(t, err)
+
+	options := &terraform.Options{
+		TerraformDir: testFolder,
+	}
+	backend := ConfigureGcsBackend(t, options, projectID, bucket)
+
+	terraform.InitAndApply(t, options)
+	assert.Equal(t, []string{backend.Prefix + "/default.tfstate"}, ListBucketObjects(t, bucket, backend.Prefix+"/"))
+
+	terraform.Destroy(t, options)
+	CleanupGcsBackend(t, backend)
+	assert.Empty(t, ListBucketObjects(t, bucket, backend.Prefix+"/"))
+}
@@ -8,7 +8,7 @@ import (
 )
 
 // GetCurrentBranchName retrieves the current branch name.
-func GetCurrentBranchName(t *testing.T) string {
+func GetCurrentBranchName(t testing.TB) string {
 	out, err := GetCurrentBranchNameE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -17,7 +17,7 @@ func GetCurrentBranchName(t *testing.T) string {
 }
 
 // GetCurrentBranchNameE retrieves the current branch name.
-func GetCurrentBranchNameE(t *testing.T) (string, error) {
+func GetCurrentBranchNameE(t testing.TB) (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
 	bytes, err := cmd.Output()
 	if err != nil {
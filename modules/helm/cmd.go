@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/gruntwork-io/gruntwork-cli/errors"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/shell"
 )
 
@@ -25,7 +26,7 @@ func getCommonArgs(options *Options, args ...string) []string {
 }
 
 // getValuesArgsE computes the args to pass in for setting values
-func getValuesArgsE(t *testing.T, options *Options, args ...string) ([]string, error) {
+func getValuesArgsE(t testing.TB, options *Options, args ...string) ([]string, error) {
 	args = append(args, formatSetValuesAsArgs(options.SetValues, "--set")...)
 	args = append(args, formatSetValuesAsArgs(options.SetStrValues, "--set-string")...)
 
@@ -44,16 +45,32 @@ func getValuesArgsE(t *testing.T, options *Options, args ...string) ([]string, e
 }
 
 // RunHelmCommandAndGetOutputE runs helm with the given arguments and options and returns stdout/stderr.
-func RunHelmCommandAndGetOutputE(t *testing.T, options *Options, cmd string, additionalArgs ...string) (string, error) {
+func RunHelmCommandAndGetOutputE(t testing.TB, options *Options, cmd string, additionalArgs ...string) (string, error) {
 	args := []string{cmd}
 	args = getCommonArgs(options, args...)
 	args = append(args, additionalArgs...)
 
+	if helmCommandChangesResources(cmd) && dryrun.Skip(t, "run helm %v", args) {
+		return "", nil
+	}
+
 	helmCmd := shell.Command{
 		Command:    "helm",
 		Args:       args,
 		WorkingDir: ".",
 		Env:        options.EnvVars,
+		Logger:     options.Logger,
 	}
 	return shell.RunCommandAndGetOutputE(t, helmCmd)
 }
+
+// helmCommandChangesResources returns true if the given helm command can install, change, or delete releases, and so
+// must not run in a dry run.
+func helmCommandChangesResources(cmd string) bool {
+	switch cmd {
+	case "delete", "install", "rollback", "uninstall", "upgrade":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,61 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+)
+
+func TestHelmCommandChangesResources(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, helmCommandChangesResources("install"))
+	assert.True(t, helmCommandChangesResources("upgrade"))
+	assert.True(t, helmCommandChangesResources("delete"))
+	assert.False(t, helmCommandChangesResources("status"))
+	assert.False(t, helmCommandChangesResources("template"))
+}
+
+func TestGetInstallReleaseAndChartArgs(t *testing.T) {
+	t.Parallel()
+
+	// Helm 2 takes the release name with -n, but charts from OCI registries need Helm 3, which takes it as a positional
+	// arg
+	assert.Equal(t, []string{"-n", "my-release", "stable/chartmuseum"}, getInstallReleaseAndChartArgs("my-release", "stable/chartmuseum"))
+	assert.Equal(
+		t,
+		[]string{"my-release", "oci://us-docker.pkg.dev/my-project/charts/chartmuseum"},
+		getInstallReleaseAndChartArgs("my-release", "oci://us-docker.pkg.dev/my-project/charts/chartmuseum"),
+	)
+}
+
+func TestGetChartVersionArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{}, getChartVersionArgs(&Options{}))
+	assert.Equal(t, []string{"--version", "1.2.3"}, getChartVersionArgs(&Options{Version: "1.2.3"}))
+}
+
+// This test does not call t.Parallel, as it enables dry run for the whole process.
+func TestInstallUpgradeAndDeleteSkippedInDryRun(t *testing.T) {
+	restore := dryrun.Enable(t)
+	defer restore()
+
+	// The kubeconfig doesn't exist, so any command that actually runs fails
+	options := &Options{
+		KubectlOptions: k8s.NewKubectlOptions("", "/tmp/terratest-kubeconfig-that-does-not-exist"),
+	}
+	releaseName := "terratest-dry-run"
+
+	require.NoError(t, InstallE(t, options, "stable/nginx-ingress", releaseName))
+	require.NoError(t, InstallE(t, options, "oci://us-docker.pkg.dev/my-project/charts/nginx-ingress", releaseName))
+	require.NoError(t, UpgradeE(t, options, "stable/nginx-ingress", releaseName))
+	require.NoError(t, DeleteE(t, options, releaseName, true))
+
+	_, err := RunHelmCommandAndGetOutputE(t, options, "status", releaseName)
+	assert.Error(t, err)
+}
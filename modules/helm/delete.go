@@ -8,13 +8,13 @@ import (
 
 // Delete will delete the provided release from Tiller. If you set purge to true, Tiller will delete the release object
 // as well so that the release name can be reused. This will fail the test if there is an error.
-func Delete(t *testing.T, options *Options, releaseName string, purge bool) {
+func Delete(t testing.TB, options *Options, releaseName string, purge bool) {
 	require.NoError(t, DeleteE(t, options, releaseName, purge))
 }
 
 // DeleteE will delete the provided release from Tiller. If you set purge to true, Tiller will delete the release object
 // as well so that the release name can be reused.
-func DeleteE(t *testing.T, options *Options, releaseName string, purge bool) error {
+func DeleteE(t testing.TB, options *Options, releaseName string, purge bool) error {
 	args := []string{}
 	if purge {
 		args = append(args, "--purge")
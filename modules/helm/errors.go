@@ -40,3 +40,14 @@ type ChartNotFoundError struct {
 func (err ChartNotFoundError) Error() string {
 	return fmt.Sprintf("Could not chart path %s", err.Path)
 }
+
+// HelmTestFailedError is returned when the test hooks of a release fail
+type HelmTestFailedError struct {
+	ReleaseName string
+	Output      string
+	Underlying  error
+}
+
+func (err HelmTestFailedError) Error() string {
+	return fmt.Sprintf("Tests of release %s failed: %s\n%s", err.ReleaseName, err.Underlying, err.Output)
+}
@@ -2,6 +2,7 @@ package helm
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/gruntwork-cli/errors"
@@ -10,36 +11,80 @@ import (
 	"github.com/gruntwork-io/terratest/modules/files"
 )
 
-// Install will install the selected helm chart with the provided options under the given release name. This will fail
-// the test if there is an error.
-func Install(t *testing.T, options *Options, chart string, releaseName string) {
+// The prefix of references to charts in OCI registries, e.g. oci://us-docker.pkg.dev/my-project/charts/my-chart
+const ociChartPrefix = "oci://"
+
+// Install will install the selected helm chart with the provided options under the given release name. The chart can be
+// a local path, REPO_NAME/CHART_NAME for a repository added with AddRepo, or an oci:// reference to a chart in an OCI
+// registry. Installing from OCI registries requires Helm 3.8 or newer; use RegistryLogin first if the registry is
+// private. This will fail the test if there is an error.
+func Install(t testing.TB, options *Options, chart string, releaseName string) {
 	require.NoError(t, InstallE(t, options, chart, releaseName))
 }
 
-// InstallE will install the selected helm chart with the provided options under the given release name.
-func InstallE(t *testing.T, options *Options, chart string, releaseName string) error {
-	// If the chart refers to a path, convert to absolute path. Otherwise, pass straight through as it may be a remote
-	// chart.
-	if files.FileExists(chart) {
-		absChartDir, err := filepath.Abs(chart)
-		if err != nil {
-			return errors.WithStackTrace(err)
-		}
-		chart = absChartDir
+// InstallE will install the selected helm chart with the provided options under the given release name. The chart can
+// be a local path, REPO_NAME/CHART_NAME for a repository added with AddRepo, or an oci:// reference to a chart in an OCI
+// registry. Installing from OCI registries requires Helm 3.8 or newer; use RegistryLoginE first if the registry is
+// private.
+func InstallE(t testing.TB, options *Options, chart string, releaseName string) error {
+	chart, err := resolveChartPathE(chart)
+	if err != nil {
+		return err
 	}
 
 	// Now call out to helm install to install the charts with the provided options
-	// Declare err here so that we can update args later
-	var err error
-	args := []string{}
-	if options.KubectlOptions != nil && options.KubectlOptions.Namespace != "" {
-		args = append(args, "--namespace", options.KubectlOptions.Namespace)
-	}
-	args, err = getValuesArgsE(t, options, args...)
+	args, err := getNamespaceAndValuesArgsE(t, options)
 	if err != nil {
 		return err
 	}
-	args = append(args, "-n", releaseName, chart)
+	args = append(args, getChartVersionArgs(options)...)
+	args = append(args, getInstallReleaseAndChartArgs(releaseName, chart)...)
 	_, err = RunHelmCommandAndGetOutputE(t, options, "install", args...)
 	return err
 }
+
+// isOCIChart returns true if the given chart is a reference to a chart in an OCI registry.
+func isOCIChart(chart string) bool {
+	return strings.HasPrefix(chart, ociChartPrefix)
+}
+
+// getInstallReleaseAndChartArgs returns the args of helm install for the given release name and chart. Charts in OCI
+// registries need Helm 3, which takes the release name as a positional arg, whereas Helm 2 takes it with -n (which is
+// the namespace flag in Helm 3).
+func getInstallReleaseAndChartArgs(releaseName string, chart string) []string {
+	if isOCIChart(chart) {
+		return []string{releaseName, chart}
+	}
+	return []string{"-n", releaseName, chart}
+}
+
+// getChartVersionArgs returns the args to select the version of the chart set in the provided options, if any.
+func getChartVersionArgs(options *Options) []string {
+	if options.Version == "" {
+		return []string{}
+	}
+	return []string{"--version", options.Version}
+}
+
+// resolveChartPathE converts the chart to an absolute path if it refers to a local path. Otherwise, it is returned
+// unchanged, as it may be a remote chart.
+func resolveChartPathE(chart string) (string, error) {
+	if !files.FileExists(chart) {
+		return chart, nil
+	}
+	absChartDir, err := filepath.Abs(chart)
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+	return absChartDir, nil
+}
+
+// getNamespaceAndValuesArgsE returns the args to target the namespace of the KubectlOptions, if any, and to set the
+// values in the provided options.
+func getNamespaceAndValuesArgsE(t testing.TB, options *Options) ([]string, error) {
+	args := []string{}
+	if options.KubectlOptions != nil && options.KubectlOptions.Namespace != "" {
+		args = append(args, "--namespace", options.KubectlOptions.Namespace)
+	}
+	return getValuesArgsE(t, options, args...)
+}
@@ -73,6 +73,7 @@ func TestRemoteChartInstall(t *testing.T) {
 	http_helper.HttpGetWithRetryWithCustomValidation(
 		t,
 		fmt.Sprintf("http://%s", endpoint),
+		nil,
 		30,
 		10*time.Second,
 		func(statusCode int, body string) bool {
@@ -2,6 +2,7 @@ package helm
 
 import (
 	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 type Options struct {
@@ -9,7 +10,9 @@ type Options struct {
 	SetValues      map[string]string   // Values that should be set via the command line.
 	SetStrValues   map[string]string   // Values that should be set via the command line explicitly as `string` types.
 	SetFiles       map[string]string   // Values that should be set from a file. These should be file paths. Use to avoid logging secrets.
+	Version        string              // The version of the chart to install or upgrade to. Empty string means the latest version.
 	KubectlOptions *k8s.KubectlOptions // KubectlOptions to control how to authenticate to kubernetes cluster. `nil` => use defaults.
 	HomePath       string              // The path to the helm home to use when calling out to helm. Empty string means use default ($HOME/.helm).
 	EnvVars        map[string]string   // Environment variables to set when running helm
+	Logger         *logger.Logger      // The logger to log helm commands and their output with. Defaults to logger.Global.
 }
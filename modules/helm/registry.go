@@ -0,0 +1,54 @@
+package helm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+)
+
+// RegistryLogin will log in to the given OCI registry (e.g. us-docker.pkg.dev), so that charts can be installed from it
+// with oci:// references. This requires Helm 3.8 or newer. This will fail the test if there is an error.
+func RegistryLogin(t testing.TB, options *Options, registry string, username string, password string) {
+	require.NoError(t, RegistryLoginE(t, options, registry, username, password))
+}
+
+// RegistryLoginE will log in to the given OCI registry (e.g. us-docker.pkg.dev), so that charts can be installed from
+// it with oci:// references. This requires Helm 3.8 or newer. The password is passed to helm on stdin, so it doesn't show
+// up in the logs.
+func RegistryLoginE(t testing.TB, options *Options, registry string, username string, password string) error {
+	options.Logger.Logf(t, "Logging in to OCI registry %s as %s", registry, username)
+
+	// Make sure the password doesn't show up in the logs, e.g. if the command echoes it back in an error message
+	logger.RegisterSecret(password)
+
+	helmCmd := shell.Command{
+		Command:    "helm",
+		Args:       formatRegistryLoginArgs(registry, username),
+		WorkingDir: ".",
+		Env:        options.EnvVars,
+		Stdin:      strings.NewReader(password),
+		Logger:     options.Logger,
+	}
+	_, err := shell.RunCommandAndGetOutputE(t, helmCmd)
+	return err
+}
+
+// RegistryLogout will log out of the given OCI registry. This will fail the test if there is an error.
+func RegistryLogout(t testing.TB, options *Options, registry string) {
+	require.NoError(t, RegistryLogoutE(t, options, registry))
+}
+
+// RegistryLogoutE will log out of the given OCI registry.
+func RegistryLogoutE(t testing.TB, options *Options, registry string) error {
+	_, err := RunHelmCommandAndGetOutputE(t, options, "registry", "logout", registry)
+	return err
+}
+
+// formatRegistryLoginArgs formats the arguments for the 'helm registry login' command. The password is read from stdin.
+func formatRegistryLoginArgs(registry string, username string) []string {
+	return []string{"registry", "login", registry, "--username", username, "--password-stdin"}
+}
@@ -0,0 +1,17 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRegistryLoginArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		[]string{"registry", "login", "us-docker.pkg.dev", "--username", "oauth2accesstoken", "--password-stdin"},
+		formatRegistryLoginArgs("us-docker.pkg.dev", "oauth2accesstoken"),
+	)
+}
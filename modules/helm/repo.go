@@ -0,0 +1,31 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// AddRepo will add the chart repository at the given URL under the given name, so that charts can be installed from it
+// as REPO_NAME/CHART_NAME. This will fail the test if there is an error.
+func AddRepo(t testing.TB, options *Options, repoName string, repoURL string) {
+	require.NoError(t, AddRepoE(t, options, repoName, repoURL))
+}
+
+// AddRepoE will add the chart repository at the given URL under the given name, so that charts can be installed from
+// it as REPO_NAME/CHART_NAME.
+func AddRepoE(t testing.TB, options *Options, repoName string, repoURL string) error {
+	_, err := RunHelmCommandAndGetOutputE(t, options, "repo", "add", repoName, repoURL)
+	return err
+}
+
+// RemoveRepo will remove the chart repository with the given name. This will fail the test if there is an error.
+func RemoveRepo(t testing.TB, options *Options, repoName string) {
+	require.NoError(t, RemoveRepoE(t, options, repoName))
+}
+
+// RemoveRepoE will remove the chart repository with the given name.
+func RemoveRepoE(t testing.TB, options *Options, repoName string) error {
+	_, err := RunHelmCommandAndGetOutputE(t, options, "repo", "remove", repoName)
+	return err
+}
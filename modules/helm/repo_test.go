@@ -0,0 +1,36 @@
+// +build kubeall helm
+
+// NOTE: we have build tags to differentiate kubernetes tests from non-kubernetes tests, and further differentiate helm
+// tests. This is done because minikube is heavy and can interfere with docker related tests in terratest. Similarly,
+// helm can overload the minikube system and thus interfere with the other kubernetes tests. To avoid overloading the
+// system, we run the kubernetes tests and helm tests separately from the others.
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terratest/modules/random"
+)
+
+func TestAddAndRemoveRepo(t *testing.T) {
+	t.Parallel()
+
+	options := &Options{}
+	repoName := fmt.Sprintf("terratest-%s", strings.ToLower(random.UniqueId()))
+
+	AddRepo(t, options, repoName, "https://kubernetes-charts.storage.googleapis.com")
+	repos, err := RunHelmCommandAndGetOutputE(t, options, "repo", "list")
+	require.NoError(t, err)
+	assert.Contains(t, repos, repoName)
+
+	RemoveRepo(t, options, repoName)
+	repos, err = RunHelmCommandAndGetOutputE(t, options, "repo", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, repos, repoName)
+}
@@ -0,0 +1,19 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Rollback will roll the release with the given name back to the given revision (e.g., "1" for the first install).
+// This will fail the test if there is an error.
+func Rollback(t testing.TB, options *Options, releaseName string, revision string) {
+	require.NoError(t, RollbackE(t, options, releaseName, revision))
+}
+
+// RollbackE will roll the release with the given name back to the given revision (e.g., "1" for the first install).
+func RollbackE(t testing.TB, options *Options, releaseName string, revision string) error {
+	_, err := RunHelmCommandAndGetOutputE(t, options, "rollback", releaseName, revision)
+	return err
+}
@@ -0,0 +1,60 @@
+package helm
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+)
+
+// helmTestPodRegexp matches the lines `helm test` prints for each test pod it runs, e.g. "RUNNING: my-release-test".
+var helmTestPodRegexp = regexp.MustCompile(`(?m)^RUNNING: (\S+)\s*$`)
+
+// RunHelmTest runs the test hooks of the given release with `helm test`, logs the output of each test pod, and cleans
+// the test pods up afterwards. This will fail the test if any of the chart tests fail.
+func RunHelmTest(t testing.TB, options *Options, releaseName string) {
+	require.NoError(t, RunHelmTestE(t, options, releaseName))
+}
+
+// RunHelmTestE runs the test hooks of the given release with `helm test`, logs the output of each test pod, and cleans
+// the test pods up afterwards. If any of the chart tests fail, this returns a HelmTestFailedError with the output of
+// `helm test`.
+func RunHelmTestE(t testing.TB, options *Options, releaseName string) error {
+	// Don't pass --cleanup, as that deletes the test pods before we get a chance to fetch their logs
+	out, testErr := RunHelmCommandAndGetOutputE(t, options, "test", releaseName)
+
+	kubectlOptions := options.KubectlOptions
+	if kubectlOptions == nil {
+		kubectlOptions = k8s.NewKubectlOptions("", "")
+	}
+
+	for _, podName := range parseHelmTestPodNames(out) {
+		logs, err := k8s.GetPodLogsE(t, kubectlOptions, podName, "")
+		if err != nil {
+			options.Logger.Logf(t, "Failed to get the logs of helm test pod %s: %s", podName, err)
+		} else {
+			options.Logger.Logf(t, "Logs of helm test pod %s:\n%s", podName, logs)
+		}
+
+		// Delete the test pod, or running the tests of this release again would fail as the pod already exists
+		if err := k8s.RunKubectlE(t, kubectlOptions, "delete", "pod", podName); err != nil {
+			options.Logger.Logf(t, "Failed to delete helm test pod %s: %s", podName, err)
+		}
+	}
+
+	if testErr != nil {
+		return HelmTestFailedError{ReleaseName: releaseName, Output: out, Underlying: testErr}
+	}
+	return nil
+}
+
+// parseHelmTestPodNames returns the names of the test pods listed in the output of `helm test`.
+func parseHelmTestPodNames(output string) []string {
+	podNames := []string{}
+	for _, match := range helmTestPodRegexp.FindAllStringSubmatch(output, -1) {
+		podNames = append(podNames, match[1])
+	}
+	return podNames
+}
@@ -0,0 +1,40 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHelmTestPodNames(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		output   string
+		expected []string
+	}{
+		{"NoTests", "", []string{}},
+		{
+			"Passed",
+			"RUNNING: chartmuseum-abc123-test\nPASSED: chartmuseum-abc123-test",
+			[]string{"chartmuseum-abc123-test"},
+		},
+		{
+			"Failed",
+			"RUNNING: release-test-connection\nPASSED: release-test-connection\nRUNNING: release-test-credentials\nFAILED: release-test-credentials, run `kubectl logs release-test-credentials --namespace default` for more info\nError: 1 test(s) failed",
+			[]string{"release-test-connection", "release-test-credentials"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, parseHelmTestPodNames(testCase.output))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Upgrade will upgrade the release with the given name to the selected helm chart, with the provided options. If the
+// release does not exist yet, it will be installed. See Install for the charts that are supported. This will fail the
+// test if there is an error.
+func Upgrade(t testing.TB, options *Options, chart string, releaseName string) {
+	require.NoError(t, UpgradeE(t, options, chart, releaseName))
+}
+
+// UpgradeE will upgrade the release with the given name to the selected helm chart, with the provided options. If the
+// release does not exist yet, it will be installed. See InstallE for the charts that are supported.
+func UpgradeE(t testing.TB, options *Options, chart string, releaseName string) error {
+	chart, err := resolveChartPathE(chart)
+	if err != nil {
+		return err
+	}
+
+	args, err := getNamespaceAndValuesArgsE(t, options)
+	if err != nil {
+		return err
+	}
+	args = append(args, getChartVersionArgs(options)...)
+	args = append(args, "--install", releaseName, chart)
+	_, err = RunHelmCommandAndGetOutputE(t, options, "upgrade", args...)
+	return err
+}
@@ -0,0 +1,57 @@
+// +build kubeall helm
+
+// NOTE: we have build tags to differentiate kubernetes tests from non-kubernetes tests, and further differentiate helm
+// tests. This is done because minikube is heavy and can interfere with docker related tests in terratest. Similarly,
+// helm can overload the minikube system and thus interfere with the other kubernetes tests. To avoid overloading the
+// system, we run the kubernetes tests and helm tests separately from the others.
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/random"
+)
+
+// Test that we can upgrade a release to new values and roll it back again
+func TestRemoteChartUpgradeAndRollback(t *testing.T) {
+	t.Parallel()
+
+	helmChart := "stable/chartmuseum"
+
+	kubectlOptions := k8s.NewKubectlOptions("", "")
+	defer k8s.UseUniqueNamespace(t, kubectlOptions, t.Name())()
+
+	options := &Options{
+		KubectlOptions: kubectlOptions,
+		SetValues: map[string]string{
+			"replicaCount": "1",
+		},
+	}
+
+	releaseName := fmt.Sprintf(
+		"chartmuseum-%s",
+		strings.ToLower(random.UniqueId()),
+	)
+	defer Delete(t, options, releaseName, true)
+
+	// Upgrade installs the release if it doesn't exist yet
+	Upgrade(t, options, helmChart, releaseName)
+	filters := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=chartmuseum,release=%s", releaseName),
+	}
+	k8s.WaitUntilNumPodsCreated(t, kubectlOptions, filters, 1, 30, 10*time.Second)
+
+	options.SetValues["replicaCount"] = "2"
+	Upgrade(t, options, helmChart, releaseName)
+	k8s.WaitUntilNumPodsCreated(t, kubectlOptions, filters, 2, 30, 10*time.Second)
+
+	Rollback(t, options, releaseName, "1")
+	k8s.WaitUntilNumPodsCreated(t, kubectlOptions, filters, 1, 30, 10*time.Second)
+}
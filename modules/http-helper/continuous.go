@@ -18,7 +18,7 @@ type GetResponse struct {
 // to stream the responses for each check.
 // Note that the channel has a buffer of 1000, after which it will start to drop the send events
 func ContinuouslyCheckUrl(
-	t *testing.T,
+	t testing.TB,
 	url string,
 	stopChecking <-chan bool,
 	sleepBetweenChecks time.Duration,
@@ -35,7 +35,7 @@ func ContinuouslyCheckUrl(
 				logger.Logf(t, "Got signal to stop downtime checks for URL %s.\n", url)
 				return
 			case <-time.After(sleepBetweenChecks):
-				statusCode, body, err := HttpGetE(t, url)
+				statusCode, body, err := HttpGetE(t, url, nil)
 				// Nonblocking send, defaulting to logging a warning if there is no channel reader
 				select {
 				case responses <- GetResponse{StatusCode: statusCode, Body: body}:
@@ -0,0 +1,195 @@
+package http_helper
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// DeploymentCheckOptions configures VerifyDeployment.
+type DeploymentCheckOptions struct {
+	Url                string        // The URL to sample during the deployment
+	TlsConfig          *tls.Config   // The TLS configuration to use for the requests. Defaults to the default TLS configuration.
+	SleepBetweenChecks time.Duration // How long to wait between requests
+	SettleTime         time.Duration // How long to keep sampling after the deployment function returns
+
+	// Extracts the version that served a response, e.g. by parsing it out of the body. Defaults to the whole body.
+	ExtractVersion func(statusCode int, body string) string
+
+	AllowedVersions   []string // If set, every successful response must come from one of these versions
+	FinalVersion      string   // If set, the last response must come from this version
+	MaxFailedRequests int      // The number of failed requests to tolerate. Defaults to 0, for zero-downtime deployments.
+}
+
+// DeploymentSample is the result of a single request made while verifying a deployment.
+type DeploymentSample struct {
+	Time       time.Time
+	StatusCode int
+	Body       string
+	Version    string
+	Err        error
+}
+
+// Failed returns true if the request failed or got a non-200 response.
+func (sample DeploymentSample) Failed() bool {
+	return sample.Err != nil || sample.StatusCode != 200
+}
+
+// DeploymentReport holds the samples taken while verifying a deployment.
+type DeploymentReport struct {
+	Samples []DeploymentSample
+}
+
+// Failures returns the samples for requests that failed or got a non-200 response.
+func (report DeploymentReport) Failures() []DeploymentSample {
+	failures := []DeploymentSample{}
+	for _, sample := range report.Samples {
+		if sample.Failed() {
+			failures = append(failures, sample)
+		}
+	}
+	return failures
+}
+
+// VersionFractions returns the fraction of successful responses served by each version between the given times,
+// e.g. to check that a canary got roughly the share of traffic it should have.
+func (report DeploymentReport) VersionFractions(from time.Time, to time.Time) map[string]float64 {
+	counts := map[string]int{}
+	total := 0
+	for _, sample := range report.Samples {
+		if sample.Failed() || sample.Time.Before(from) || sample.Time.After(to) {
+			continue
+		}
+		counts[sample.Version]++
+		total++
+	}
+
+	fractions := map[string]float64{}
+	for version, count := range counts {
+		fractions[version] = float64(count) / float64(total)
+	}
+	return fractions
+}
+
+// VerifyDeployment repeatedly samples the URL in the given options while running the given deployment function (e.g.
+// a terraform apply that rolls out a new version), and fails the test if more requests failed than allowed or the
+// responses did not come from the expected versions. This is useful for validating blue/green, canary, and rolling
+// deployment modules.
+func VerifyDeployment(t testing.TB, options DeploymentCheckOptions, deploy func() error) DeploymentReport {
+	report, err := VerifyDeploymentE(t, options, deploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+// VerifyDeploymentE repeatedly samples the URL in the given options while running the given deployment function (e.g.
+// a terraform apply that rolls out a new version), and returns an error if the deployment function failed, more
+// requests failed than allowed, or the responses did not come from the expected versions. The report of all samples
+// is returned either way. This is useful for validating blue/green, canary, and rolling deployment modules.
+func VerifyDeploymentE(t testing.TB, options DeploymentCheckOptions, deploy func() error) (DeploymentReport, error) {
+	extractVersion := options.ExtractVersion
+	if extractVersion == nil {
+		extractVersion = func(statusCode int, body string) string { return body }
+	}
+
+	var mutex sync.Mutex
+	report := DeploymentReport{}
+	stop := make(chan bool)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		for {
+			sample := DeploymentSample{Time: time.Now()}
+			sample.StatusCode, sample.Body, sample.Err = HttpGetE(t, options.Url, options.TlsConfig)
+			if !sample.Failed() {
+				sample.Version = extractVersion(sample.StatusCode, sample.Body)
+			}
+
+			mutex.Lock()
+			report.Samples = append(report.Samples, sample)
+			mutex.Unlock()
+
+			select {
+			case <-stop:
+				return
+			case <-time.After(options.SleepBetweenChecks):
+			}
+		}
+	}()
+
+	deployErr := deploy()
+	time.Sleep(options.SettleTime)
+	close(stop)
+	wg.Wait()
+
+	if deployErr != nil {
+		return report, fmt.Errorf("Deployment failed: %v", deployErr)
+	}
+
+	logger.Logf(t, "Made %d requests to %s during the deployment, of which %d failed", len(report.Samples), options.Url, len(report.Failures()))
+
+	return report, checkDeploymentReport(report, options)
+}
+
+// checkDeploymentReport checks the given report against the expectations in the given options.
+func checkDeploymentReport(report DeploymentReport, options DeploymentCheckOptions) error {
+	failures := report.Failures()
+	if len(failures) > options.MaxFailedRequests {
+		descriptions := []string{}
+		for _, failure := range failures {
+			if failure.Err != nil {
+				descriptions = append(descriptions, fmt.Sprintf("%s: %v", failure.Time.Format(time.RFC3339Nano), failure.Err))
+			} else {
+				descriptions = append(descriptions, fmt.Sprintf("%s: status %d", failure.Time.Format(time.RFC3339Nano), failure.StatusCode))
+			}
+		}
+		return fmt.Errorf("%d of %d requests to %s failed during the deployment, but at most %d may fail:\n%s", len(failures), len(report.Samples), options.Url, options.MaxFailedRequests, strings.Join(descriptions, "\n"))
+	}
+
+	if len(options.AllowedVersions) > 0 {
+		unexpected := map[string]bool{}
+		for _, sample := range report.Samples {
+			if !sample.Failed() && !containsString(options.AllowedVersions, sample.Version) {
+				unexpected[sample.Version] = true
+			}
+		}
+		if len(unexpected) > 0 {
+			versions := []string{}
+			for version := range unexpected {
+				versions = append(versions, version)
+			}
+			sort.Strings(versions)
+			return fmt.Errorf("Got responses from unexpected versions %v of %s during the deployment. Allowed versions: %v", versions, options.Url, options.AllowedVersions)
+		}
+	}
+
+	if options.FinalVersion != "" {
+		if len(report.Samples) == 0 {
+			return fmt.Errorf("No requests were made to %s during the deployment", options.Url)
+		}
+		last := report.Samples[len(report.Samples)-1]
+		if last.Failed() || last.Version != options.FinalVersion {
+			return fmt.Errorf("Expected %s to be served by version %s at the end of the deployment, but the last response was from version '%s' (status %d)", options.Url, options.FinalVersion, last.Version, last.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
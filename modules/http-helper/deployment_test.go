@@ -0,0 +1,115 @@
+package http_helper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionServer returns a server that responds with the current version and a function to change it. Setting the
+// version to the empty string makes the server respond with a 503, as if it were down.
+func versionServer(initialVersion string) (*httptest.Server, func(string)) {
+	var mutex sync.Mutex
+	version := initialVersion
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if version == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, version)
+	}))
+
+	setVersion := func(newVersion string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		version = newVersion
+	}
+
+	return server, setVersion
+}
+
+func TestVerifyDeploymentZeroDowntime(t *testing.T) {
+	t.Parallel()
+
+	server, setVersion := versionServer("v1")
+	defer server.Close()
+
+	options := DeploymentCheckOptions{
+		Url:                server.URL,
+		SleepBetweenChecks: 10 * time.Millisecond,
+		SettleTime:         100 * time.Millisecond,
+		AllowedVersions:    []string{"v1", "v2"},
+		FinalVersion:       "v2",
+	}
+
+	report := VerifyDeployment(t, options, func() error {
+		time.Sleep(100 * time.Millisecond)
+		setVersion("v2")
+		return nil
+	})
+
+	assert.Empty(t, report.Failures())
+	fractions := report.VersionFractions(time.Time{}, time.Now())
+	assert.True(t, fractions["v1"] > 0)
+	assert.True(t, fractions["v2"] > 0)
+	assert.InDelta(t, 1.0, fractions["v1"]+fractions["v2"], 0.0001)
+}
+
+func TestVerifyDeploymentDetectsDowntime(t *testing.T) {
+	t.Parallel()
+
+	server, setVersion := versionServer("v1")
+	defer server.Close()
+
+	options := DeploymentCheckOptions{Url: server.URL, SleepBetweenChecks: 10 * time.Millisecond, SettleTime: 50 * time.Millisecond}
+
+	report, err := VerifyDeploymentE(t, options, func() error {
+		setVersion("")
+		time.Sleep(100 * time.Millisecond)
+		setVersion("v2")
+		return nil
+	})
+
+	require.Error(t, err)
+	assert.NotEmpty(t, report.Failures())
+}
+
+func TestVerifyDeploymentReturnsDeployErrors(t *testing.T) {
+	t.Parallel()
+
+	server, _ := versionServer("v1")
+	defer server.Close()
+
+	options := DeploymentCheckOptions{Url: server.URL, SleepBetweenChecks: 10 * time.Millisecond}
+
+	_, err := VerifyDeploymentE(t, options, func() error { return errors.New("apply failed") })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "apply failed")
+}
+
+func TestCheckDeploymentReportVersions(t *testing.T) {
+	t.Parallel()
+
+	report := DeploymentReport{Samples: []DeploymentSample{
+		{StatusCode: 200, Version: "v1"},
+		{StatusCode: 200, Version: "v3"},
+		{StatusCode: 200, Version: "v2"},
+	}}
+
+	err := checkDeploymentReport(report, DeploymentCheckOptions{AllowedVersions: []string{"v1", "v2"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[v3]")
+
+	assert.NoError(t, checkDeploymentReport(report, DeploymentCheckOptions{FinalVersion: "v2"}))
+	assert.Error(t, checkDeploymentReport(report, DeploymentCheckOptions{FinalVersion: "v1"}))
+}
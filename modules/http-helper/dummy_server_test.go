@@ -20,7 +20,7 @@ func TestRunDummyServer(t *testing.T) {
 	defer shutDownServer(t, listener)
 
 	url := fmt.Sprintf("http://localhost:%d", port)
-	HttpGetWithValidation(t, url, 200, text)
+	HttpGetWithValidation(t, url, nil, 200, text)
 }
 
 func TestContinuouslyCheck(t *testing.T) {
@@ -54,3 +54,19 @@ func shutDownServer(t *testing.T, listener io.Closer) {
 	err := listener.Close()
 	assert.NoError(t, err)
 }
+
+func TestRunDummyServerPicksFreePorts(t *testing.T) {
+	t.Parallel()
+
+	// The text is not a format string, so it's returned as is
+	text := "100% dummy"
+
+	listener1, port1 := RunDummyServer(t, text)
+	defer shutDownServer(t, listener1)
+	listener2, port2 := RunDummyServer(t, text)
+	defer shutDownServer(t, listener2)
+
+	assert.NotEqual(t, port1, port2)
+	HttpGetWithValidation(t, fmt.Sprintf("http://localhost:%d", port1), nil, 200, text)
+	HttpGetWithValidation(t, fmt.Sprintf("http://localhost:%d", port2), nil, 200, text)
+}
@@ -0,0 +1,173 @@
+package http_helper
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+)
+
+// defaultRequestTimeout is how long a request may take if HttpRequest doesn't set a Timeout
+const defaultRequestTimeout = 10 * time.Second
+
+// HttpRequest configures an HTTP request made by HTTPDo and the related functions.
+type HttpRequest struct {
+	Method    string            // The HTTP method, e.g. POST. Defaults to GET.
+	Url       string            // The URL to send the request to
+	Body      []byte            // The body of the request, if any. It's sent again on each retry.
+	Headers   map[string]string // Headers to set on the request, e.g. Content-Type or Authorization
+	TlsConfig *tls.Config       // The TLS configuration to use. Defaults to the default TLS configuration.
+	Timeout   time.Duration     // How long the request may take. Defaults to 10 seconds.
+}
+
+// HTTPDo performs the given HTTP request and returns the HTTP status code and body. If there's any error, fail the test.
+func HTTPDo(t testing.TB, request HttpRequest) (int, string) {
+	statusCode, body, err := HTTPDoE(t, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return statusCode, body
+}
+
+// HTTPDoE performs the given HTTP request and returns the HTTP status code, body, and any error.
+func HTTPDoE(t testing.TB, request HttpRequest) (int, string, error) {
+	method := request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	logger.Logf(t, "Making an HTTP %s call to URL %s", method, request.Url)
+
+	timeout := request.Timeout
+	if timeout == 0 {
+		timeout = defaultRequestTimeout
+	}
+	client := newHttpClient(request.TlsConfig, timeout)
+
+	req, err := http.NewRequest(method, request.Url, bytes.NewReader(request.Body))
+	if err != nil {
+		return -1, "", err
+	}
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+	// Header.Set can't set the Host header, as Go sends req.Host instead
+	if host, hasHost := request.Headers["Host"]; hasHost {
+		req.Host = host
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, "", err
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return -1, "", err
+	}
+
+	return resp.StatusCode, strings.TrimSpace(string(body)), nil
+}
+
+// HTTPDoWithValidation performs the given HTTP request and verifies that you get back the expected status code and body.
+// If either doesn't match, fail the test.
+func HTTPDoWithValidation(t testing.TB, request HttpRequest, expectedStatusCode int, expectedBody string) {
+	err := HTTPDoWithValidationE(t, request, expectedStatusCode, expectedBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// HTTPDoWithValidationE performs the given HTTP request and verifies that you get back the expected status code and
+// body. If either doesn't match, return an error.
+func HTTPDoWithValidationE(t testing.TB, request HttpRequest, expectedStatusCode int, expectedBody string) error {
+	return HTTPDoWithCustomValidationE(t, request, func(statusCode int, body string) bool {
+		return statusCode == expectedStatusCode && body == expectedBody
+	})
+}
+
+// HTTPDoWithCustomValidation performs the given HTTP request and validates the returned status code and body using the
+// given function. If the validation fails, fail the test.
+func HTTPDoWithCustomValidation(t testing.TB, request HttpRequest, validateResponse func(int, string) bool) {
+	err := HTTPDoWithCustomValidationE(t, request, validateResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// HTTPDoWithCustomValidationE performs the given HTTP request and validates the returned status code and body using the
+// given function. If the validation fails, return a ValidationFunctionFailed error.
+func HTTPDoWithCustomValidationE(t testing.TB, request HttpRequest, validateResponse func(int, string) bool) error {
+	statusCode, body, err := HTTPDoE(t, request)
+
+	if err != nil {
+		return err
+	}
+
+	if !validateResponse(statusCode, body) {
+		return ValidationFunctionFailed{Url: request.Url, Status: statusCode, Body: body}
+	}
+
+	return nil
+}
+
+// HTTPDoWithRetry repeatedly performs the given HTTP request until the given status code is returned or until max
+// retries has been exceeded, and returns the body of the response.
+func HTTPDoWithRetry(t testing.TB, request HttpRequest, expectedStatus int, retries int, sleepBetweenRetries time.Duration) string {
+	body, err := HTTPDoWithRetryE(t, request, expectedStatus, retries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// HTTPDoWithRetryE repeatedly performs the given HTTP request until the given status code is returned or until max
+// retries has been exceeded, and returns the body of the response.
+func HTTPDoWithRetryE(t testing.TB, request HttpRequest, expectedStatus int, retries int, sleepBetweenRetries time.Duration) (string, error) {
+	return retry.DoWithRetryE(t, formatRequestDescription(request), retries, sleepBetweenRetries, func() (string, error) {
+		statusCode, body, err := HTTPDoE(t, request)
+		if err != nil {
+			return "", err
+		}
+		if statusCode != expectedStatus {
+			return "", ValidationFunctionFailed{Url: request.Url, Status: statusCode, Body: body}
+		}
+		return body, nil
+	})
+}
+
+// HTTPDoWithRetryWithCustomValidation repeatedly performs the given HTTP request until the given validation function
+// returns true or max retries has been exceeded.
+func HTTPDoWithRetryWithCustomValidation(t testing.TB, request HttpRequest, retries int, sleepBetweenRetries time.Duration, validateResponse func(int, string) bool) {
+	err := HTTPDoWithRetryWithCustomValidationE(t, request, retries, sleepBetweenRetries, validateResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// HTTPDoWithRetryWithCustomValidationE repeatedly performs the given HTTP request until the given validation function
+// returns true or max retries has been exceeded.
+func HTTPDoWithRetryWithCustomValidationE(t testing.TB, request HttpRequest, retries int, sleepBetweenRetries time.Duration, validateResponse func(int, string) bool) error {
+	_, err := retry.DoWithRetryE(t, formatRequestDescription(request), retries, sleepBetweenRetries, func() (string, error) {
+		return "", HTTPDoWithCustomValidationE(t, request, validateResponse)
+	})
+
+	return err
+}
+
+// formatRequestDescription returns a description of the given request for logging, e.g. HTTP POST to URL http://foo
+func formatRequestDescription(request HttpRequest) string {
+	method := request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return fmt.Sprintf("HTTP %s to URL %s", method, request.Url)
+}
@@ -0,0 +1,89 @@
+package http_helper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoHandler responds with the method, Content-Type header, and body of each request
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, "%s %s %s", r.Method, r.Header.Get("Content-Type"), string(body))
+}
+
+func TestHTTPDo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(echoHandler))
+	defer server.Close()
+
+	testCases := []struct {
+		name         string
+		request      HttpRequest
+		expectedBody string
+	}{
+		{"Defaults to GET", HttpRequest{Url: server.URL}, "GET"},
+		{"POST with body and headers", HttpRequest{Method: "POST", Url: server.URL, Body: []byte(`{"foo":"bar"}`), Headers: map[string]string{"Content-Type": "application/json"}}, `POST application/json {"foo":"bar"}`},
+		{"DELETE", HttpRequest{Method: "DELETE", Url: server.URL}, "DELETE"},
+	}
+
+	// Group the parallel subtests, so that the server is only closed once they have all finished
+	t.Run("group", func(t *testing.T) {
+		for _, testCase := range testCases {
+			// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+			// change when the subtests parallelize and switch contexts.
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+				HTTPDoWithValidation(t, testCase.request, http.StatusCreated, testCase.expectedBody)
+			})
+		}
+	})
+}
+
+func TestHTTPDoTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+	}))
+	defer server.Close()
+
+	_, _, err := HTTPDoE(t, HttpRequest{Url: server.URL, Timeout: 50 * time.Millisecond})
+	assert.Error(t, err)
+}
+
+func TestHTTPDoWithRetry(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+		if len(requests) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		echoHandler(w, r)
+	}))
+	defer server.Close()
+
+	request := HttpRequest{Method: "PUT", Url: server.URL, Body: []byte("payload")}
+	body, err := HTTPDoWithRetryE(t, request, http.StatusCreated, 5, 10*time.Millisecond)
+	require.NoError(t, err)
+	// The body is sent again on each retry
+	assert.Equal(t, "PUT  payload", body)
+
+	err = HTTPDoWithRetryWithCustomValidationE(t, request, 1, 10*time.Millisecond, func(statusCode int, body string) bool {
+		return statusCode == http.StatusOK
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package http_helper
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpGetWithTlsConfig(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "Hello, TLS")
+	}))
+	defer server.Close()
+
+	// The server uses a self-signed certificate, so the default TLS configuration rejects it
+	_, _, err := HttpGetE(t, server.URL, nil)
+	assert.Error(t, err)
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+	statusCode, body := HttpGet(t, server.URL, &tls.Config{RootCAs: certPool})
+	assert.Equal(t, 200, statusCode)
+	assert.Equal(t, "Hello, TLS", body)
+
+	HttpGetWithValidation(t, server.URL, &tls.Config{InsecureSkipVerify: true}, 200, "Hello, TLS")
+}
+
+func TestHttpGetWithRetryUntilValid(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+		if len(requests) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ready")
+	}))
+	defer server.Close()
+
+	require.NoError(t, HttpGetWithRetryE(t, server.URL, nil, 200, "ready", 5, 10*time.Millisecond))
+
+	err := HttpGetWithCustomValidationE(t, server.URL, nil, func(statusCode int, body string) bool {
+		return body == "not ready"
+	})
+	assert.IsType(t, ValidationFunctionFailed{}, err)
+}
@@ -0,0 +1,215 @@
+// Package interrupt runs emergency cleanup when a test run is interrupted (e.g. a cancelled CI job sends SIGINT or
+// SIGTERM), so that the infrastructure the tests deployed isn't left running.
+//
+// When a test binary is interrupted, it exits right away, and none of the deferred terraform.Destroy calls in the tests
+// run. To avoid that, call Handle at the start of the test (or in TestMain), and register the cleanup of each piece of
+// infrastructure with OnInterrupt:
+//
+//	stop := interrupt.Handle(t, 10*time.Minute)
+//	defer stop()
+//
+//	done := interrupt.OnInterrupt(t, "destroy vpc", func() error {
+//		_, err := terraform.DestroyE(t, terraformOptions)
+//		return err
+//	})
+//	defer done()
+//	defer terraform.Destroy(t, terraformOptions)
+//
+// On SIGINT or SIGTERM, Interrupted starts returning true, which stops the retry loops (see the retry package) that
+// were already running from issuing new attempts, and the registered cleanup functions run in reverse order of
+// registration, until they are all done or the deadline passes. Retry loops started by the cleanup functions
+// themselves (e.g. the one in terraform.DestroyE) retry as usual. The handler then logs which cleanups succeeded, failed, or did not finish, and exits.
+package interrupt
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// ExitCode is the exit code of the test binary after an interrupt, following the shell convention of 128 + SIGINT
+const ExitCode = 130
+
+var (
+	mutex       sync.Mutex
+	interrupted bool
+	// When the run was interrupted
+	interruptedAt time.Time
+	nextHookId    int
+	hooks         = map[int]hook{}
+
+	// Overridden in tests so the test binary doesn't exit
+	exitFunc = os.Exit
+)
+
+type hook struct {
+	id          int
+	description string
+	cleanup     func() error
+}
+
+// Report lists what happened to each cleanup function registered with OnInterrupt after an interrupt.
+type Report struct {
+	// Descriptions of the cleanups that succeeded
+	Cleaned []string
+	// Errors of the cleanups that failed, keyed by description
+	Failed map[string]error
+	// Descriptions of the cleanups that were still running, or had not started, when the deadline passed
+	Unfinished []string
+}
+
+// Success returns true if every cleanup succeeded.
+func (report Report) Success() bool {
+	return len(report.Failed) == 0 && len(report.Unfinished) == 0
+}
+
+func (report Report) String() string {
+	failed := []string{}
+	for description, err := range report.Failed {
+		failed = append(failed, fmt.Sprintf("%s (%v)", description, err))
+	}
+	sort.Strings(failed)
+
+	return fmt.Sprintf("cleaned up: %v; failed: %v; did not finish before the deadline: %v", report.Cleaned, failed, report.Unfinished)
+}
+
+// Handle starts handling SIGINT and SIGTERM: on either signal, it marks the run as interrupted, runs the cleanup
+// functions registered with OnInterrupt with the given overall deadline, logs a Report, and exits the test binary. Call
+// the returned function to stop handling signals.
+func Handle(t testing.TB, deadline time.Duration) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+
+	go func() {
+		select {
+		case sig := <-signals:
+			logger.Logf(t, "Received %s. Running emergency cleanup with a deadline of %s.", sig, deadline)
+			report := RunCleanups(t, deadline)
+			if report.Success() {
+				logger.Logf(t, "Emergency cleanup finished: %s", report)
+			} else {
+				logger.Logf(t, "[WARNING] Emergency cleanup did not clean up everything, check for leftover resources: %s", report)
+			}
+			exitFunc(ExitCode)
+		case <-stop:
+		}
+	}()
+
+	return func() {
+		stopOnce.Do(func() {
+			signal.Stop(signals)
+			close(stop)
+		})
+	}
+}
+
+// OnInterrupt registers a function to clean up the resource with the given description if the run is interrupted.
+// Call the returned function once the resource has been cleaned up normally, so that it isn't cleaned up again.
+func OnInterrupt(t testing.TB, description string, cleanup func() error) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	id := nextHookId
+	nextHookId++
+	hooks[id] = hook{id: id, description: description, cleanup: cleanup}
+	logger.Logf(t, "Registered emergency cleanup: %s", description)
+
+	return func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		delete(hooks, id)
+	}
+}
+
+// Interrupted returns true if the run has been interrupted. Long running operations should check it and stop rather
+// than start new work.
+func Interrupted() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return interrupted
+}
+
+// InterruptedAfter returns true if the run was interrupted after the given time. Operations that were already running
+// when the run was interrupted should check it and stop, while those started by the emergency cleanup, which runs after
+// the interrupt, can carry on.
+func InterruptedAfter(start time.Time) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return interrupted && !interruptedAt.Before(start)
+}
+
+// RunCleanups marks the run as interrupted and runs the registered cleanup functions one at a time, in reverse order
+// of registration, until they are all done or the deadline passes. Each cleanup function runs at most once. Handle
+// calls this on SIGINT or SIGTERM; call it directly to trigger the emergency cleanup some other way.
+func RunCleanups(t testing.TB, deadline time.Duration) Report {
+	mutex.Lock()
+	if !interrupted {
+		interrupted = true
+		interruptedAt = time.Now()
+	}
+	pending := []hook{}
+	for _, registered := range hooks {
+		pending = append(pending, registered)
+	}
+	hooks = map[int]hook{}
+	mutex.Unlock()
+
+	sort.Slice(pending, func(i, j int) bool { return pending[i].id > pending[j].id })
+
+	type result struct {
+		hook hook
+		err  error
+	}
+	results := make(chan result, len(pending))
+
+	go func() {
+		for _, registered := range pending {
+			logger.Logf(t, "Running emergency cleanup: %s", registered.description)
+			results <- result{hook: registered, err: registered.cleanup()}
+		}
+	}()
+
+	report := Report{Cleaned: []string{}, Failed: map[string]error{}, Unfinished: []string{}}
+	finished := map[int]bool{}
+	timeout := time.After(deadline)
+
+	for len(finished) < len(pending) {
+		select {
+		case res := <-results:
+			finished[res.hook.id] = true
+			if res.err == nil {
+				report.Cleaned = append(report.Cleaned, res.hook.description)
+			} else {
+				report.Failed[res.hook.description] = res.err
+			}
+		case <-timeout:
+			for _, registered := range pending {
+				if !finished[registered.id] {
+					report.Unfinished = append(report.Unfinished, registered.description)
+				}
+			}
+			return report
+		}
+	}
+
+	return report
+}
+
+// Cancelled is returned by operations that stop because the run was interrupted.
+type Cancelled struct {
+	Description string
+}
+
+func (err Cancelled) Error() string {
+	return fmt.Sprintf("'%s' was cancelled because the test run was interrupted", err.Description)
+}
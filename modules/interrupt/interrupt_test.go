@@ -0,0 +1,98 @@
+package interrupt
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file share the package-level state, so they can't run in parallel.
+
+func reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	interrupted = false
+	interruptedAt = time.Time{}
+	hooks = map[int]hook{}
+}
+
+func TestRunCleanupsInReverseOrder(t *testing.T) {
+	defer reset()
+
+	order := []string{}
+	OnInterrupt(t, "vpc", func() error { order = append(order, "vpc"); return nil })
+	OnInterrupt(t, "cluster", func() error { order = append(order, "cluster"); return errors.New("boom") })
+	done := OnInterrupt(t, "already destroyed", func() error { order = append(order, "already destroyed"); return nil })
+	done()
+
+	assert.False(t, Interrupted())
+	report := RunCleanups(t, time.Minute)
+	assert.True(t, Interrupted())
+
+	assert.Equal(t, []string{"cluster", "vpc"}, order)
+	assert.Equal(t, []string{"vpc"}, report.Cleaned)
+	assert.Len(t, report.Failed, 1)
+	assert.EqualError(t, report.Failed["cluster"], "boom")
+	assert.False(t, report.Success())
+
+	// Cleanups run at most once
+	assert.True(t, RunCleanups(t, time.Minute).Success())
+	assert.Equal(t, []string{"cluster", "vpc"}, order)
+}
+
+func TestInterruptedAfter(t *testing.T) {
+	defer reset()
+
+	beforeInterrupt := time.Now()
+	assert.False(t, InterruptedAfter(beforeInterrupt))
+
+	RunCleanups(t, time.Minute)
+	afterInterrupt := time.Now()
+
+	assert.True(t, InterruptedAfter(beforeInterrupt))
+	assert.False(t, InterruptedAfter(afterInterrupt))
+}
+
+func TestRunCleanupsDeadline(t *testing.T) {
+	defer reset()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	OnInterrupt(t, "never runs", func() error { return nil })
+	OnInterrupt(t, "hangs", func() error { <-release; return nil })
+	OnInterrupt(t, "fast", func() error { return nil })
+
+	report := RunCleanups(t, 100*time.Millisecond)
+
+	assert.Equal(t, []string{"fast"}, report.Cleaned)
+	assert.Equal(t, []string{"hangs", "never runs"}, report.Unfinished)
+	assert.False(t, report.Success())
+}
+
+func TestHandleRunsCleanupsOnSignal(t *testing.T) {
+	defer reset()
+
+	exitCodes := make(chan int, 1)
+	exitFunc = func(code int) { exitCodes <- code }
+
+	cleanedUp := false
+	OnInterrupt(t, "vpc", func() error { cleanedUp = true; return nil })
+
+	stop := Handle(t, time.Minute)
+	defer stop()
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+
+	select {
+	case code := <-exitCodes:
+		assert.Equal(t, ExitCode, code)
+		assert.True(t, cleanedUp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the interrupt handler to exit")
+	}
+}
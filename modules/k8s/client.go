@@ -3,17 +3,16 @@ package k8s
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/kubernetes"
 
 	// The following line loads the gcp plugin which is required to authenticate against GKE clusters.
 	// See: https://github.com/kubernetes/client-go/issues/242
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
-
-	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 // GetKubernetesClientE returns a Kubernetes API client that can be used to make requests.
-func GetKubernetesClientE(t *testing.T) (*kubernetes.Clientset, error) {
+func GetKubernetesClientE(t testing.TB) (*kubernetes.Clientset, error) {
 	kubeConfigPath, err := GetKubeConfigPathE(t)
 	if err != nil {
 		return nil, err
@@ -23,15 +22,23 @@ func GetKubernetesClientE(t *testing.T) (*kubernetes.Clientset, error) {
 	return GetKubernetesClientFromOptionsE(t, options)
 }
 
+// GetKubernetesClientFromOptions returns a Kubernetes API client given a configured KubectlOptions object. This will
+// fail the test if there is an error.
+func GetKubernetesClientFromOptions(t testing.TB, options *KubectlOptions) *kubernetes.Clientset {
+	clientset, err := GetKubernetesClientFromOptionsE(t, options)
+	require.NoError(t, err)
+	return clientset
+}
+
 // GetKubernetesClientFromOptionsE returns a Kubernetes API client given a configured KubectlOptions object.
-func GetKubernetesClientFromOptionsE(t *testing.T, options *KubectlOptions) (*kubernetes.Clientset, error) {
+func GetKubernetesClientFromOptionsE(t testing.TB, options *KubectlOptions) (*kubernetes.Clientset, error) {
 	var err error
 
 	kubeConfigPath, err := options.GetConfigPath(t)
 	if err != nil {
 		return nil, err
 	}
-	logger.Logf(t, "Configuring kubectl using config file %s with context %s", kubeConfigPath, options.ContextName)
+	options.Logger.Logf(t, "Configuring kubectl using config file %s with context %s", kubeConfigPath, options.ContextName)
 	// Load API config (instead of more low level ClientConfig)
 	config, err := LoadApiClientConfigE(kubeConfigPath, options.ContextName)
 	if err != nil {
@@ -9,14 +9,14 @@ import (
 )
 
 // GetClusterRole returns a Kubernetes ClusterRole resource with the given name. This will fail the test if there is an error.
-func GetClusterRole(t *testing.T, options *KubectlOptions, roleName string) *rbacv1.ClusterRole {
+func GetClusterRole(t testing.TB, options *KubectlOptions, roleName string) *rbacv1.ClusterRole {
 	role, err := GetClusterRoleE(t, options, roleName)
 	require.NoError(t, err)
 	return role
 }
 
 // GetClusterRoleE returns a Kubernetes ClusterRole resource with the given name.
-func GetClusterRoleE(t *testing.T, options *KubectlOptions, roleName string) (*rbacv1.ClusterRole, error) {
+func GetClusterRoleE(t testing.TB, options *KubectlOptions, roleName string) (*rbacv1.ClusterRole, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
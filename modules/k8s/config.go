@@ -44,7 +44,7 @@ func LoadApiClientConfigE(configPath string, contextName string) (*restclient.Co
 // DeleteConfigContextE will remove the context specified at the provided name, and remove any clusters and authinfos
 // that are orphaned as a result of it. The config path is either specified in the environment variable KUBECONFIG or at
 // the user's home directory under `.kube/config`.
-func DeleteConfigContextE(t *testing.T, contextName string) error {
+func DeleteConfigContextE(t testing.TB, contextName string) error {
 	kubeConfigPath, err := GetKubeConfigPathE(t)
 	if err != nil {
 		return err
@@ -55,7 +55,7 @@ func DeleteConfigContextE(t *testing.T, contextName string) error {
 
 // DeleteConfigContextWithPathE will remove the context specified at the provided name, and remove any clusters and
 // authinfos that are orphaned as a result of it.
-func DeleteConfigContextWithPathE(t *testing.T, kubeConfigPath string, contextName string) error {
+func DeleteConfigContextWithPathE(t testing.TB, kubeConfigPath string, contextName string) error {
 	logger.Logf(t, "Removing kubectl config context %s from config at path %s", contextName, kubeConfigPath)
 
 	// Load config and get data structure representing config info
@@ -125,7 +125,7 @@ func RemoveOrphanedClusterAndAuthInfoConfig(config *api.Config) {
 }
 
 // GetKubeConfigPathE determines which file path to use as the kubectl config path
-func GetKubeConfigPathE(t *testing.T) (string, error) {
+func GetKubeConfigPathE(t testing.TB) (string, error) {
 	kubeConfigPath := environment.GetFirstNonEmptyEnvVarOrEmptyString(t, []string{"KUBECONFIG"})
 	if kubeConfigPath == "" {
 		configPath, err := KubeConfigPathFromHomeDirE()
@@ -150,7 +150,7 @@ func KubeConfigPathFromHomeDirE() (string, error) {
 
 // CopyHomeKubeConfigToTemp will copy the kubeconfig in the home directory to a temp file. This will fail the test if
 // there are any errors.
-func CopyHomeKubeConfigToTemp(t *testing.T) string {
+func CopyHomeKubeConfigToTemp(t testing.TB) string {
 	path, err := CopyHomeKubeConfigToTempE(t)
 	if err != nil {
 		if path != "" {
@@ -162,7 +162,7 @@ func CopyHomeKubeConfigToTemp(t *testing.T) string {
 }
 
 // CopyHomeKubeConfigToTemp will copy the kubeconfig in the home directory to a temp file.
-func CopyHomeKubeConfigToTempE(t *testing.T) (string, error) {
+func CopyHomeKubeConfigToTempE(t testing.TB) (string, error) {
 	configPath, err := KubeConfigPathFromHomeDirE()
 	if err != nil {
 		return "", err
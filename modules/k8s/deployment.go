@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
+)
+
+// ListDeployments will look for deployments in the given namespace that match the given filters and return them. This
+// will fail the test if there is an error.
+func ListDeployments(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) []appsv1.Deployment {
+	deployments, err := ListDeploymentsE(t, options, filters)
+	require.NoError(t, err)
+	return deployments
+}
+
+// ListDeploymentsE will look for deployments in the given namespace that match the given filters and return them.
+func ListDeploymentsE(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) ([]appsv1.Deployment, error) {
+	clientset, err := GetKubernetesClientFromOptionsE(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clientset.AppsV1().Deployments(options.Namespace).List(filters)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// GetDeployment returns a Kubernetes deployment resource in the provided namespace with the given name. This will
+// fail the test if there is an error.
+func GetDeployment(t testing.TB, options *KubectlOptions, deploymentName string) *appsv1.Deployment {
+	deployment, err := GetDeploymentE(t, options, deploymentName)
+	require.NoError(t, err)
+	return deployment
+}
+
+// GetDeploymentE returns a Kubernetes deployment resource in the provided namespace with the given name.
+func GetDeploymentE(t testing.TB, options *KubectlOptions, deploymentName string) (*appsv1.Deployment, error) {
+	clientset, err := GetKubernetesClientFromOptionsE(t, options)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := clientset.AppsV1().Deployments(options.Namespace).Get(deploymentName, metav1.GetOptions{})
+	return deployment, wrapKubernetesError(err, "Deployment", deploymentName, options.Namespace)
+}
+
+// WaitUntilDeploymentAvailable waits until all the pods of the deployment are updated and available, retrying the check
+// for the specified amount of times, sleeping for the provided duration between each try. This will fail the test if
+// there is an error or if the check times out.
+func WaitUntilDeploymentAvailable(t testing.TB, options *KubectlOptions, deploymentName string, retries int, sleepBetweenRetries time.Duration) {
+	require.NoError(t, WaitUntilDeploymentAvailableE(t, options, deploymentName, retries, sleepBetweenRetries))
+}
+
+// WaitUntilDeploymentAvailableE waits until all the pods of the deployment are updated and available, retrying the
+// check for the specified amount of times, sleeping for the provided duration between each try.
+func WaitUntilDeploymentAvailableE(t testing.TB, options *KubectlOptions, deploymentName string, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "k8s.WaitUntilDeploymentAvailable", time.Now(), &err)
+
+	statusMsg := fmt.Sprintf("Wait for deployment %s to be provisioned.", deploymentName)
+	message, err := retry.DoWithRetryE(
+		t,
+		statusMsg,
+		retries,
+		sleepBetweenRetries,
+		func() (string, error) {
+			deployment, err := GetDeploymentE(t, options, deploymentName)
+			if err != nil {
+				return "", err
+			}
+			if !IsDeploymentAvailable(deployment) {
+				return "", NewDeploymentNotAvailableError(deployment)
+			}
+			return "Deployment is now available", nil
+		},
+	)
+	if err != nil {
+		options.Logger.Logf(t, "Timedout waiting for Deployment to be provisioned: %s", err)
+		return err
+	}
+	options.Logger.Logf(t, message)
+	return nil
+}
+
+// IsDeploymentAvailable returns true if the latest spec of the deployment has been rolled out, and all of its desired
+// replicas are updated and available.
+func IsDeploymentAvailable(deployment *appsv1.Deployment) bool {
+	// Deployments default to 1 replica if none is set
+	desiredReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desiredReplicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == desiredReplicas &&
+		deployment.Status.AvailableReplicas == desiredReplicas &&
+		deployment.Status.UnavailableReplicas == 0
+}
@@ -0,0 +1,113 @@
+// +build kubeall kubernetes
+
+// NOTE: we have build tags to differentiate kubernetes tests from non-kubernetes tests. This is done because minikube
+// is heavy and can interfere with docker related tests in terratest. Specifically, many of the tests start to fail with
+// `connection refused` errors from `minikube`. To avoid overloading the system, we run the kubernetes tests and helm
+// tests separately from the others. This may not be necessary if you have a sufficiently powerful machine.  We
+// recommend at least 4 cores and 16GB of RAM if you want to run all the tests together.
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gruntwork-io/terratest/modules/random"
+)
+
+func TestGetDeploymentEReturnsErrorForNonExistantDeployment(t *testing.T) {
+	t.Parallel()
+
+	options := NewKubectlOptions("", "")
+	_, err := GetDeploymentE(t, options, "nginx-deployment")
+	require.Error(t, err)
+}
+
+func TestWaitUntilDeploymentAvailableReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+
+	uniqueID := strings.ToLower(random.UniqueId())
+	options := NewKubectlOptions("", "")
+	options.Namespace = uniqueID
+	configData := fmt.Sprintf(EXAMPLE_SCALED_DEPLOYMENT_YAML_TEMPLATE, uniqueID, uniqueID)
+	defer KubectlDeleteFromString(t, options, configData)
+	KubectlApplyFromString(t, options, configData)
+
+	WaitUntilDeploymentAvailable(t, options, "nginx-deployment", 60, 1*time.Second)
+
+	deployments := ListDeployments(t, options, metav1.ListOptions{})
+	require.Equal(t, len(deployments), 1)
+	require.Equal(t, deployments[0].Name, "nginx-deployment")
+	WaitUntilNumPodsCreated(t, options, metav1.ListOptions{LabelSelector: "app=nginx"}, 2, 60, 1*time.Second)
+}
+
+func TestIsDeploymentAvailable(t *testing.T) {
+	t.Parallel()
+
+	two := int32(2)
+
+	testCases := []struct {
+		name      string
+		replicas  *int32
+		status    appsv1.DeploymentStatus
+		available bool
+	}{
+		{"all replicas available", &two, appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 2, AvailableReplicas: 2}, true},
+		{"default replica count", nil, appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1, AvailableReplicas: 1}, true},
+		{"rollout not observed", &two, appsv1.DeploymentStatus{ObservedGeneration: 0, UpdatedReplicas: 2, AvailableReplicas: 2}, false},
+		{"replicas still updating", &two, appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1, AvailableReplicas: 2}, false},
+		{"replicas unavailable", &two, appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 2, AvailableReplicas: 1, UnavailableReplicas: 1}, false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "nginx-deployment", Generation: 1},
+				Spec:       appsv1.DeploymentSpec{Replicas: testCase.replicas},
+				Status:     testCase.status,
+			}
+			assert.Equal(t, testCase.available, IsDeploymentAvailable(deployment))
+		})
+	}
+}
+
+const EXAMPLE_SCALED_DEPLOYMENT_YAML_TEMPLATE = `---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: %s
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx-deployment
+  namespace: %s
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: nginx
+  template:
+    metadata:
+      labels:
+        app: nginx
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.15.7
+        ports:
+        - containerPort: 80
+`
@@ -0,0 +1,117 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// GenerateKubeconfigForEksCluster writes a kubeconfig for the specified EKS cluster and returns KubectlOptions that
+// target it. This will fail the test if there are any errors.
+func GenerateKubeconfigForEksCluster(t testing.TB, region string, clusterName string, kubeConfigPath string) *KubectlOptions {
+	options, err := GenerateKubeconfigForEksClusterE(t, region, clusterName, kubeConfigPath)
+	require.NoError(t, err)
+	return options
+}
+
+// GenerateKubeconfigForEksClusterE writes a kubeconfig for the specified EKS cluster and returns KubectlOptions that
+// target it. The cluster is added to the kubeconfig at the given path under a context named after its ARN, like
+// `aws eks update-kubeconfig` does. If kubeConfigPath is empty, a new temp file is used, which you should remove at the
+// end of the test.
+//
+// The kubeconfig authenticates with a token from aws.GetEksTokenE rather than running aws-iam-authenticator, so no
+// extra tools are needed, but EKS only accepts the token for 15 minutes. Call this again to refresh the token in tests
+// that take longer.
+func GenerateKubeconfigForEksClusterE(t testing.TB, region string, clusterName string, kubeConfigPath string) (*KubectlOptions, error) {
+	cluster, err := aws.GetEksClusterE(t, region, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := aws.GetEksTokenE(t, region, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if kubeConfigPath == "" {
+		kubeConfigFile, err := ioutil.TempFile("", fmt.Sprintf("kubeconfig-%s", clusterName))
+		if err != nil {
+			return nil, err
+		}
+		kubeConfigFile.Close()
+		kubeConfigPath = kubeConfigFile.Name()
+	}
+
+	logger.Logf(t, "Writing kubeconfig for EKS cluster %s to %s", clusterName, kubeConfigPath)
+
+	config, err := loadKubeConfigOrEmpty(kubeConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	contextName, err := addEksClusterToKubeConfig(config, cluster, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := clientcmd.WriteToFile(*config, kubeConfigPath); err != nil {
+		return nil, err
+	}
+
+	return NewKubectlOptions(contextName, kubeConfigPath), nil
+}
+
+// loadKubeConfigOrEmpty loads the kubeconfig at the given path, or returns an empty config if the file is empty or
+// doesn't exist yet.
+func loadKubeConfigOrEmpty(kubeConfigPath string) (*api.Config, error) {
+	info, err := os.Stat(kubeConfigPath)
+	if os.IsNotExist(err) || (err == nil && info.Size() == 0) {
+		return api.NewConfig(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.LoadFromFile(kubeConfigPath)
+}
+
+// addEksClusterToKubeConfig adds a cluster, user, and context for the given EKS cluster to the given kubeconfig, all
+// named after the ARN of the cluster, and returns the name of the context. The context becomes the current context if
+// there is none yet.
+func addEksClusterToKubeConfig(config *api.Config, cluster *eks.Cluster, token string) (string, error) {
+	name := awsgo.StringValue(cluster.Arn)
+
+	if cluster.CertificateAuthority == nil {
+		return "", fmt.Errorf("EKS cluster %s does not have a certificate authority yet", awsgo.StringValue(cluster.Name))
+	}
+	caData, err := base64.StdEncoding.DecodeString(awsgo.StringValue(cluster.CertificateAuthority.Data))
+	if err != nil {
+		return "", err
+	}
+
+	kubeCluster := api.NewCluster()
+	kubeCluster.Server = awsgo.StringValue(cluster.Endpoint)
+	kubeCluster.CertificateAuthorityData = caData
+	config.Clusters[name] = kubeCluster
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = token
+	config.AuthInfos[name] = authInfo
+
+	UpsertConfigContext(config, name, name, name)
+	if config.CurrentContext == "" {
+		config.CurrentContext = name
+	}
+
+	return name, nil
+}
@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"testing"
+
+	awsgo "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestAddEksClusterToKubeConfig(t *testing.T) {
+	t.Parallel()
+
+	arn := "arn:aws:eks:us-east-1:000000000000:cluster/terratest"
+	cluster := &eks.Cluster{
+		Name:                 awsgo.String("terratest"),
+		Arn:                  awsgo.String(arn),
+		Endpoint:             awsgo.String("https://ABCDEF.gr7.us-east-1.eks.amazonaws.com"),
+		CertificateAuthority: &eks.Certificate{Data: awsgo.String(base64.StdEncoding.EncodeToString([]byte("ca-cert")))},
+	}
+
+	config := api.NewConfig()
+	config.CurrentContext = "existing"
+
+	contextName, err := addEksClusterToKubeConfig(config, cluster, "k8s-aws-v1.token")
+	require.NoError(t, err)
+
+	assert.Equal(t, arn, contextName)
+	assert.Equal(t, "existing", config.CurrentContext)
+	assert.Equal(t, "https://ABCDEF.gr7.us-east-1.eks.amazonaws.com", config.Clusters[arn].Server)
+	assert.Equal(t, []byte("ca-cert"), config.Clusters[arn].CertificateAuthorityData)
+	assert.Equal(t, "k8s-aws-v1.token", config.AuthInfos[arn].Token)
+	assert.Equal(t, arn, config.Contexts[arn].Cluster)
+	assert.Equal(t, arn, config.Contexts[arn].AuthInfo)
+}
+
+func TestAddEksClusterToKubeConfigWithoutCertificateAuthority(t *testing.T) {
+	t.Parallel()
+
+	cluster := &eks.Cluster{Name: awsgo.String("terratest"), Arn: awsgo.String("arn:aws:eks:us-east-1:000000000000:cluster/terratest")}
+
+	_, err := addEksClusterToKubeConfig(api.NewConfig(), cluster, "k8s-aws-v1.token")
+	assert.Error(t, err)
+}
@@ -2,10 +2,15 @@ package k8s
 
 import (
 	"fmt"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gruntwork-io/terratest/modules/customerrors"
 )
 
 // IngressNotAvailable is returned when a Kubernetes service is not yet available to accept traffic.
@@ -17,6 +22,15 @@ func (err IngressNotAvailable) Error() string {
 	return fmt.Sprintf("Ingress %s is not available", err.ingress.Name)
 }
 
+// GatewayNotProgrammed is returned when a Gateway API Gateway has not yet been programmed or assigned an address.
+type GatewayNotProgrammed struct {
+	Name string
+}
+
+func (err GatewayNotProgrammed) Error() string {
+	return fmt.Sprintf("Gateway %s is not programmed", err.Name)
+}
+
 // UnknownKubeResourceType is returned if the given resource type does not match the list of known resource types.
 type UnknownKubeResourceType struct {
 	ResourceType KubeResourceType
@@ -59,6 +73,41 @@ func NewPodNotAvailableError(pod *corev1.Pod) PodNotAvailable {
 	return PodNotAvailable{pod}
 }
 
+// DeploymentNotAvailable is returned when a Kubernetes deployment does not yet have all its replicas updated and
+// available.
+type DeploymentNotAvailable struct {
+	deployment *appsv1.Deployment
+}
+
+func (err DeploymentNotAvailable) Error() string {
+	return fmt.Sprintf(
+		"Deployment %s is not available: %d of %d replicas updated, %d available",
+		err.deployment.Name,
+		err.deployment.Status.UpdatedReplicas,
+		err.deployment.Status.Replicas,
+		err.deployment.Status.AvailableReplicas,
+	)
+}
+
+func NewDeploymentNotAvailableError(deployment *appsv1.Deployment) DeploymentNotAvailable {
+	return DeploymentNotAvailable{deployment}
+}
+
+// PodLogMatchNotFound is returned when the logs of a Kubernetes pod do not contain the expected text.
+type PodLogMatchNotFound struct {
+	PodName       string
+	ContainerName string
+	Match         string
+	Timeout       time.Duration
+}
+
+func (err PodLogMatchNotFound) Error() string {
+	if err.Timeout > 0 {
+		return fmt.Sprintf("Logs of pod %s did not contain %q within %s", err.PodName, err.Match, err.Timeout)
+	}
+	return fmt.Sprintf("Logs of pod %s do not contain %q", err.PodName, err.Match)
+}
+
 // ServiceNotAvailable is returned when a Kubernetes service is not yet available to accept traffic.
 type ServiceNotAvailable struct {
 	service *corev1.Service
@@ -99,6 +148,20 @@ func NewUnknownServicePortError(service *corev1.Service, port int32) UnknownServ
 	return UnknownServicePort{service, port}
 }
 
+// UnknownContainerPort is returned when none of the containers of a pod expose a port with the given name.
+type UnknownContainerPort struct {
+	pod      *corev1.Pod
+	portName string
+}
+
+func (err UnknownContainerPort) Error() string {
+	return fmt.Sprintf("Pod %s has no container port named %s", err.pod.Name, err.portName)
+}
+
+func NewUnknownContainerPortError(pod *corev1.Pod, portName string) UnknownContainerPort {
+	return UnknownContainerPort{pod, portName}
+}
+
 // NoNodesInKubernetes is returned when the Kubernetes cluster has no nodes registered.
 type NoNodesInKubernetes struct{}
 
@@ -135,3 +198,25 @@ func (err MalformedNodeID) Error() string {
 func NewMalformedNodeIDError(node *corev1.Node) MalformedNodeID {
 	return MalformedNodeID{node}
 }
+
+// wrapKubernetesError converts an error returned by the Kubernetes API about the given resource into a
+// customerrors.NotFoundError, customerrors.ThrottledError, or customerrors.AuthError, so test code can branch on the
+// category of the error. Any other error, including nil, is returned unchanged.
+func wrapKubernetesError(err error, kind string, name string, namespace string) error {
+	if err == nil {
+		return nil
+	}
+
+	ctx := customerrors.ResourceContext{Kind: kind, Name: name, Region: namespace}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return customerrors.NotFoundError{ResourceContext: ctx, Underlying: err}
+	case apierrors.IsTooManyRequests(err):
+		return customerrors.ThrottledError{ResourceContext: ctx, Underlying: err}
+	case apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err):
+		return customerrors.AuthError{ResourceContext: ctx, Underlying: err}
+	default:
+		return err
+	}
+}
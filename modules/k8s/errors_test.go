@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/gruntwork-io/terratest/modules/customerrors"
+)
+
+func TestWrapKubernetesError(t *testing.T) {
+	t.Parallel()
+
+	pods := schema.GroupResource{Resource: "pods"}
+	plainErr := errors.New("boom")
+	conflictErr := apierrors.NewConflict(pods, "my-pod", plainErr)
+
+	testCases := []struct {
+		name         string
+		err          error
+		expectedType interface{}
+	}{
+		{"not found", apierrors.NewNotFound(pods, "my-pod"), customerrors.NotFoundError{}},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), customerrors.ThrottledError{}},
+		{"unauthorized", apierrors.NewUnauthorized("who are you"), customerrors.AuthError{}},
+		{"forbidden", apierrors.NewForbidden(pods, "my-pod", plainErr), customerrors.AuthError{}},
+		{"conflict", conflictErr, conflictErr},
+		{"not an api error", plainErr, plainErr},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.IsType(t, testCase.expectedType, wrapKubernetesError(testCase.err, "Pod", "my-pod", "default"))
+		})
+	}
+
+	assert.NoError(t, wrapKubernetesError(nil, "Pod", "my-pod", "default"))
+}
@@ -0,0 +1,204 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
+)
+
+// The Gateway API (https://gateway-api.sigs.k8s.io) is installed as CRDs rather than being built into Kubernetes, so
+// there is no typed client for it in client-go. Instead, the functions in this file fetch the resources with kubectl
+// and decode the subset of their fields that tests care about into the types below.
+
+// Gateway is a Gateway API Gateway resource (gateway.networking.k8s.io).
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GatewaySpec   `json:"spec"`
+	Status            GatewayStatus `json:"status,omitempty"`
+}
+
+// GatewaySpec is the desired state of a Gateway.
+type GatewaySpec struct {
+	GatewayClassName string            `json:"gatewayClassName"`
+	Listeners        []GatewayListener `json:"listeners,omitempty"`
+}
+
+// GatewayListener is a logical endpoint on which a Gateway accepts network connections.
+type GatewayListener struct {
+	Name     string `json:"name"`
+	Hostname string `json:"hostname,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// GatewayStatus is the observed state of a Gateway.
+type GatewayStatus struct {
+	Addresses  []GatewayAddress   `json:"addresses,omitempty"`
+	Conditions []GatewayCondition `json:"conditions,omitempty"`
+}
+
+// GatewayAddress is an address that has been bound to a Gateway.
+type GatewayAddress struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+// GatewayCondition is a status condition of a Gateway API resource.
+type GatewayCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// HTTPRoute is a Gateway API HTTPRoute resource (gateway.networking.k8s.io).
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              HTTPRouteSpec   `json:"spec"`
+	Status            HTTPRouteStatus `json:"status,omitempty"`
+}
+
+// HTTPRouteSpec is the desired state of an HTTPRoute.
+type HTTPRouteSpec struct {
+	ParentRefs []GatewayParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string                 `json:"hostnames,omitempty"`
+}
+
+// GatewayParentReference identifies the Gateway (or Gateway listener) a route attaches to.
+type GatewayParentReference struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// HTTPRouteStatus is the observed state of an HTTPRoute.
+type HTTPRouteStatus struct {
+	Parents []RouteParentStatus `json:"parents,omitempty"`
+}
+
+// RouteParentStatus is the status of a route with respect to one of the Gateways it attaches to.
+type RouteParentStatus struct {
+	ParentRef      GatewayParentReference `json:"parentRef"`
+	ControllerName string                 `json:"controllerName"`
+	Conditions     []GatewayCondition     `json:"conditions,omitempty"`
+}
+
+// GetGateway returns the Gateway API Gateway in the provided namespace with the given name. This will fail the test if
+// there is an error.
+func GetGateway(t testing.TB, options *KubectlOptions, gatewayName string) *Gateway {
+	gateway, err := GetGatewayE(t, options, gatewayName)
+	require.NoError(t, err)
+	return gateway
+}
+
+// GetGatewayE returns the Gateway API Gateway in the provided namespace with the given name.
+func GetGatewayE(t testing.TB, options *KubectlOptions, gatewayName string) (*Gateway, error) {
+	var gateway Gateway
+	if err := getGatewayAPIResourceE(t, options, "gateways", gatewayName, &gateway); err != nil {
+		return nil, err
+	}
+	return &gateway, nil
+}
+
+// IsGatewayProgrammed returns true if the Gateway has been programmed into the underlying load balancer and has at
+// least one address assigned to it.
+func IsGatewayProgrammed(gateway *Gateway) bool {
+	// Implementations of Gateway API versions before v0.7 report the Ready condition instead of Programmed
+	isProgrammed := hasTrueGatewayCondition(gateway.Status.Conditions, "Programmed") ||
+		hasTrueGatewayCondition(gateway.Status.Conditions, "Ready")
+	return isProgrammed && len(gateway.Status.Addresses) > 0
+}
+
+// WaitUntilGatewayProgrammed waits until the Gateway has been programmed and has an address assigned to it. This will
+// fail the test if there is an error or if the check times out.
+func WaitUntilGatewayProgrammed(t testing.TB, options *KubectlOptions, gatewayName string, retries int, sleepBetweenRetries time.Duration) {
+	require.NoError(t, WaitUntilGatewayProgrammedE(t, options, gatewayName, retries, sleepBetweenRetries))
+}
+
+// WaitUntilGatewayProgrammedE waits until the Gateway has been programmed and has an address assigned to it.
+func WaitUntilGatewayProgrammedE(t testing.TB, options *KubectlOptions, gatewayName string, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "k8s.WaitUntilGatewayProgrammed", time.Now(), &err)
+
+	statusMsg := fmt.Sprintf("Wait for gateway %s to be programmed.", gatewayName)
+	message, err := retry.DoWithRetryE(
+		t,
+		statusMsg,
+		retries,
+		sleepBetweenRetries,
+		func() (string, error) {
+			gateway, err := GetGatewayE(t, options, gatewayName)
+			if err != nil {
+				return "", err
+			}
+			if !IsGatewayProgrammed(gateway) {
+				return "", GatewayNotProgrammed{Name: gatewayName}
+			}
+			return "Gateway is now programmed", nil
+		},
+	)
+	if err != nil {
+		options.Logger.Logf(t, "Timedout waiting for Gateway to be programmed: %s", err)
+		return err
+	}
+	options.Logger.Logf(t, message)
+	return nil
+}
+
+// GetHTTPRoute returns the Gateway API HTTPRoute in the provided namespace with the given name. This will fail the
+// test if there is an error.
+func GetHTTPRoute(t testing.TB, options *KubectlOptions, routeName string) *HTTPRoute {
+	route, err := GetHTTPRouteE(t, options, routeName)
+	require.NoError(t, err)
+	return route
+}
+
+// GetHTTPRouteE returns the Gateway API HTTPRoute in the provided namespace with the given name.
+func GetHTTPRouteE(t testing.TB, options *KubectlOptions, routeName string) (*HTTPRoute, error) {
+	var route HTTPRoute
+	if err := getGatewayAPIResourceE(t, options, "httproutes", routeName, &route); err != nil {
+		return nil, err
+	}
+	return &route, nil
+}
+
+// IsHTTPRouteAccepted returns true if the HTTPRoute has been accepted by all the Gateways it attaches to.
+func IsHTTPRouteAccepted(route *HTTPRoute) bool {
+	if len(route.Status.Parents) == 0 {
+		return false
+	}
+	for _, parent := range route.Status.Parents {
+		if !hasTrueGatewayCondition(parent.Conditions, "Accepted") {
+			return false
+		}
+	}
+	return true
+}
+
+// getGatewayAPIResourceE fetches the Gateway API resource of the given kind and name with kubectl and decodes it into
+// the given object.
+func getGatewayAPIResourceE(t testing.TB, options *KubectlOptions, kind string, name string, into interface{}) error {
+	out, err := RunKubectlAndGetOutputE(t, options, "get", kind+".gateway.networking.k8s.io", name, "--output", "json")
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(out), into)
+}
+
+// hasTrueGatewayCondition returns true if the given conditions contain a condition of the given type with status True.
+func hasTrueGatewayCondition(conditions []GatewayCondition, conditionType string) bool {
+	for _, condition := range conditions {
+		if condition.Type == conditionType && condition.Status == "True" {
+			return true
+		}
+	}
+	return false
+}
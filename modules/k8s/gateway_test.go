@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleGatewayJSON = `{
+  "apiVersion": "gateway.networking.k8s.io/v1beta1",
+  "kind": "Gateway",
+  "metadata": {"name": "external-http", "namespace": "terratest"},
+  "spec": {
+    "gatewayClassName": "gke-l7-global-external-managed",
+    "listeners": [{"name": "http", "port": 80, "protocol": "HTTP"}]
+  },
+  "status": {
+    "addresses": [{"type": "IPAddress", "value": "34.120.1.2"}],
+    "conditions": [
+      {"type": "Accepted", "status": "True", "reason": "Accepted"},
+      {"type": "Programmed", "status": "True", "reason": "Programmed"}
+    ]
+  }
+}`
+
+const exampleHTTPRouteJSON = `{
+  "apiVersion": "gateway.networking.k8s.io/v1beta1",
+  "kind": "HTTPRoute",
+  "metadata": {"name": "store", "namespace": "terratest"},
+  "spec": {
+    "parentRefs": [{"name": "external-http"}],
+    "hostnames": ["store.example.com"]
+  },
+  "status": {
+    "parents": [{
+      "parentRef": {"name": "external-http"},
+      "controllerName": "networking.gke.io/gateway",
+      "conditions": [{"type": "Accepted", "status": "True"}, {"type": "ResolvedRefs", "status": "True"}]
+    }]
+  }
+}`
+
+func TestDecodeGateway(t *testing.T) {
+	t.Parallel()
+
+	var gateway Gateway
+	require.NoError(t, json.Unmarshal([]byte(exampleGatewayJSON), &gateway))
+
+	assert.Equal(t, "external-http", gateway.Name)
+	assert.Equal(t, "gke-l7-global-external-managed", gateway.Spec.GatewayClassName)
+	assert.Equal(t, int32(80), gateway.Spec.Listeners[0].Port)
+	assert.Equal(t, "34.120.1.2", gateway.Status.Addresses[0].Value)
+	assert.True(t, IsGatewayProgrammed(&gateway))
+}
+
+func TestIsGatewayProgrammed(t *testing.T) {
+	t.Parallel()
+
+	addresses := []GatewayAddress{{Type: "IPAddress", Value: "34.120.1.2"}}
+
+	testCases := []struct {
+		name       string
+		status     GatewayStatus
+		programmed bool
+	}{
+		{"programmed", GatewayStatus{Addresses: addresses, Conditions: []GatewayCondition{{Type: "Programmed", Status: "True"}}}, true},
+		{"ready on older versions", GatewayStatus{Addresses: addresses, Conditions: []GatewayCondition{{Type: "Ready", Status: "True"}}}, true},
+		{"not programmed yet", GatewayStatus{Addresses: addresses, Conditions: []GatewayCondition{{Type: "Programmed", Status: "False"}}}, false},
+		{"no address yet", GatewayStatus{Conditions: []GatewayCondition{{Type: "Programmed", Status: "True"}}}, false},
+		{"no status yet", GatewayStatus{}, false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.programmed, IsGatewayProgrammed(&Gateway{Status: testCase.status}))
+		})
+	}
+}
+
+func TestDecodeHTTPRoute(t *testing.T) {
+	t.Parallel()
+
+	var route HTTPRoute
+	require.NoError(t, json.Unmarshal([]byte(exampleHTTPRouteJSON), &route))
+
+	assert.Equal(t, "store", route.Name)
+	assert.Equal(t, []string{"store.example.com"}, route.Spec.Hostnames)
+	assert.Equal(t, "external-http", route.Spec.ParentRefs[0].Name)
+	assert.True(t, IsHTTPRouteAccepted(&route))
+
+	route.Status.Parents[0].Conditions[0].Status = "False"
+	assert.False(t, IsHTTPRouteAccepted(&route))
+
+	route.Status.Parents = nil
+	assert.False(t, IsHTTPRouteAccepted(&route))
+}
@@ -9,20 +9,20 @@ import (
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
 )
 
 // ListIngresses will look for Ingress resources in the given namespace that match the given filters and return them.
 // This will fail the test if there is an error.
-func ListIngresses(t *testing.T, options *KubectlOptions, filters metav1.ListOptions) []extensionsv1beta1.Ingress {
+func ListIngresses(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) []extensionsv1beta1.Ingress {
 	ingresses, err := ListIngressesE(t, options, filters)
 	require.NoError(t, err)
 	return ingresses
 }
 
 // ListIngressesE will look for Ingress resources in the given namespace that match the given filters and return them.
-func ListIngressesE(t *testing.T, options *KubectlOptions, filters metav1.ListOptions) ([]extensionsv1beta1.Ingress, error) {
+func ListIngressesE(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) ([]extensionsv1beta1.Ingress, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
@@ -37,14 +37,14 @@ func ListIngressesE(t *testing.T, options *KubectlOptions, filters metav1.ListOp
 
 // GetIngress returns a Kubernetes Ingress resource in the provided namespace with the given name. This will fail the
 // test if there is an error.
-func GetIngress(t *testing.T, options *KubectlOptions, ingressName string) *extensionsv1beta1.Ingress {
+func GetIngress(t testing.TB, options *KubectlOptions, ingressName string) *extensionsv1beta1.Ingress {
 	ingress, err := GetIngressE(t, options, ingressName)
 	require.NoError(t, err)
 	return ingress
 }
 
 // GetIngressE returns a Kubernetes Ingress resource in the provided namespace with the given name.
-func GetIngressE(t *testing.T, options *KubectlOptions, ingressName string) (*extensionsv1beta1.Ingress, error) {
+func GetIngressE(t testing.TB, options *KubectlOptions, ingressName string) (*extensionsv1beta1.Ingress, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
@@ -59,10 +59,19 @@ func IsIngressAvailable(ingress *extensionsv1beta1.Ingress) bool {
 	return len(endpoints) > 0
 }
 
-// WaitUntilIngressAvailable waits until the Ingress resource has an endpoint provisioned for it.
-func WaitUntilIngressAvailable(t *testing.T, options *KubectlOptions, ingressName string, retries int, sleepBetweenRetries time.Duration) {
+// WaitUntilIngressAvailable waits until the Ingress resource has an endpoint provisioned for it. This will fail the
+// test if there is an error or if the check times out.
+func WaitUntilIngressAvailable(t testing.TB, options *KubectlOptions, ingressName string, retries int, sleepBetweenRetries time.Duration) {
+	require.NoError(t, WaitUntilIngressAvailableE(t, options, ingressName, retries, sleepBetweenRetries))
+}
+
+// WaitUntilIngressAvailableE waits until the Ingress resource has an endpoint provisioned for it, i.e. until an
+// address has been assigned to it.
+func WaitUntilIngressAvailableE(t testing.TB, options *KubectlOptions, ingressName string, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "k8s.WaitUntilIngressAvailable", time.Now(), &err)
+
 	statusMsg := fmt.Sprintf("Wait for ingress %s to be provisioned.", ingressName)
-	message := retry.DoWithRetry(
+	message, err := retry.DoWithRetryE(
 		t,
 		statusMsg,
 		retries,
@@ -78,5 +87,10 @@ func WaitUntilIngressAvailable(t *testing.T, options *KubectlOptions, ingressNam
 			return "Ingress is now available", nil
 		},
 	)
-	logger.Logf(t, message)
+	if err != nil {
+		options.Logger.Logf(t, "Timedout waiting for Ingress to be provisioned: %s", err)
+		return err
+	}
+	options.Logger.Logf(t, message)
+	return nil
 }
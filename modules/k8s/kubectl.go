@@ -8,23 +8,48 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/shell"
 )
 
 // RunKubectl will call kubectl using the provided options and args, failing the test on error.
-func RunKubectl(t *testing.T, options *KubectlOptions, args ...string) {
+func RunKubectl(t testing.TB, options *KubectlOptions, args ...string) {
 	require.NoError(t, RunKubectlE(t, options, args...))
 }
 
 // RunKubectlE will call kubectl using the provided options and args.
-func RunKubectlE(t *testing.T, options *KubectlOptions, args ...string) error {
+func RunKubectlE(t testing.TB, options *KubectlOptions, args ...string) error {
 	_, err := RunKubectlAndGetOutputE(t, options, args...)
 	return err
 }
 
+// RunKubectlAndGetOutput will call kubectl using the provided options and args, returning the output of stdout and
+// stderr. This will fail the test if there is an error.
+func RunKubectlAndGetOutput(t testing.TB, options *KubectlOptions, args ...string) string {
+	out, err := RunKubectlAndGetOutputE(t, options, args...)
+	require.NoError(t, err)
+	return out
+}
+
 // RunKubectlAndGetOutputE will call kubectl using the provided options and args, returning the output of stdout and
 // stderr.
-func RunKubectlAndGetOutputE(t *testing.T, options *KubectlOptions, args ...string) (string, error) {
+func RunKubectlAndGetOutputE(t testing.TB, options *KubectlOptions, args ...string) (string, error) {
+	if kubectlCommandChangesResources(args) && dryrun.Skip(t, "run kubectl %v", getKubectlArgs(options, args...)) {
+		return "", nil
+	}
+
+	command := shell.Command{
+		Command: "kubectl",
+		Args:    getKubectlArgs(options, args...),
+		Env:     options.Env,
+		Logger:  options.Logger,
+	}
+	return shell.RunCommandAndGetOutputE(t, command)
+}
+
+// getKubectlArgs returns the given kubectl args, prefixed with the flags that target the context, config file, and
+// namespace set in the provided options.
+func getKubectlArgs(options *KubectlOptions, args ...string) []string {
 	cmdArgs := []string{}
 	if options.ContextName != "" {
 		cmdArgs = append(cmdArgs, "--context", options.ContextName)
@@ -35,35 +60,47 @@ func RunKubectlAndGetOutputE(t *testing.T, options *KubectlOptions, args ...stri
 	if options.Namespace != "" {
 		cmdArgs = append(cmdArgs, "--namespace", options.Namespace)
 	}
-	cmdArgs = append(cmdArgs, args...)
-	command := shell.Command{
-		Command: "kubectl",
-		Args:    cmdArgs,
-		Env:     options.Env,
+	return append(cmdArgs, args...)
+}
+
+// kubectlCommandChangesResources returns true if the kubectl command with the given args can create, change, or delete
+// resources in the cluster, and so must not run in a dry run.
+func kubectlCommandChangesResources(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "annotate", "apply", "autoscale", "cordon", "create", "delete", "drain", "edit", "expose", "label", "patch",
+		"replace", "run", "scale", "set", "taint", "uncordon":
+		return true
+	case "rollout":
+		return len(args) > 1 && args[1] != "history" && args[1] != "status"
+	default:
+		return false
 	}
-	return shell.RunCommandAndGetOutputE(t, command)
 }
 
 // KubectlDelete will take in a file path and delete it from the cluster targeted by KubectlOptions. If there are any
 // errors, fail the test immediately.
-func KubectlDelete(t *testing.T, options *KubectlOptions, configPath string) {
+func KubectlDelete(t testing.TB, options *KubectlOptions, configPath string) {
 	require.NoError(t, KubectlDeleteE(t, options, configPath))
 }
 
 // KubectlDeleteE will take in a file path and delete it from the cluster targeted by KubectlOptions.
-func KubectlDeleteE(t *testing.T, options *KubectlOptions, configPath string) error {
+func KubectlDeleteE(t testing.TB, options *KubectlOptions, configPath string) error {
 	return RunKubectlE(t, options, "delete", "-f", configPath)
 }
 
 // KubectlDeleteFromString will take in a kubernetes resource config as a string and delete it on the cluster specified
 // by the provided kubectl options.
-func KubectlDeleteFromString(t *testing.T, options *KubectlOptions, configData string) {
+func KubectlDeleteFromString(t testing.TB, options *KubectlOptions, configData string) {
 	require.NoError(t, KubectlDeleteFromStringE(t, options, configData))
 }
 
 // KubectlDeleteFromStringE will take in a kubernetes resource config as a string and delete it on the cluster specified
 // by the provided kubectl options. If it fails, this will return the error.
-func KubectlDeleteFromStringE(t *testing.T, options *KubectlOptions, configData string) error {
+func KubectlDeleteFromStringE(t testing.TB, options *KubectlOptions, configData string) error {
 	tmpfile, err := StoreConfigToTempFileE(t, configData)
 	if err != nil {
 		return err
@@ -74,24 +111,24 @@ func KubectlDeleteFromStringE(t *testing.T, options *KubectlOptions, configData
 
 // KubectlApply will take in a file path and apply it to the cluster targeted by KubectlOptions. If there are any
 // errors, fail the test immediately.
-func KubectlApply(t *testing.T, options *KubectlOptions, configPath string) {
+func KubectlApply(t testing.TB, options *KubectlOptions, configPath string) {
 	require.NoError(t, KubectlApplyE(t, options, configPath))
 }
 
 // KubectlApplyE will take in a file path and apply it to the cluster targeted by KubectlOptions.
-func KubectlApplyE(t *testing.T, options *KubectlOptions, configPath string) error {
+func KubectlApplyE(t testing.TB, options *KubectlOptions, configPath string) error {
 	return RunKubectlE(t, options, "apply", "-f", configPath)
 }
 
 // KubectlApplyFromString will take in a kubernetes resource config as a string and apply it on the cluster specified
 // by the provided kubectl options.
-func KubectlApplyFromString(t *testing.T, options *KubectlOptions, configData string) {
+func KubectlApplyFromString(t testing.TB, options *KubectlOptions, configData string) {
 	require.NoError(t, KubectlApplyFromStringE(t, options, configData))
 }
 
 // KubectlApplyFromStringE will take in a kubernetes resource config as a string and apply it on the cluster specified
 // by the provided kubectl options. If it fails, this will return the error.
-func KubectlApplyFromStringE(t *testing.T, options *KubectlOptions, configData string) error {
+func KubectlApplyFromStringE(t testing.TB, options *KubectlOptions, configData string) error {
 	tmpfile, err := StoreConfigToTempFileE(t, configData)
 	if err != nil {
 		return err
@@ -102,7 +139,7 @@ func KubectlApplyFromStringE(t *testing.T, options *KubectlOptions, configData s
 
 // StoreConfigToTempFile will store the provided config data to a temporary file created on the os and return the
 // filename.
-func StoreConfigToTempFile(t *testing.T, configData string) string {
+func StoreConfigToTempFile(t testing.TB, configData string) string {
 	out, err := StoreConfigToTempFileE(t, configData)
 	require.NoError(t, err)
 	return out
@@ -110,7 +147,7 @@ func StoreConfigToTempFile(t *testing.T, configData string) string {
 
 // StoreConfigToTempFileE will store the provided config data to a temporary file created on the os and return the
 // filename, or error.
-func StoreConfigToTempFileE(t *testing.T, configData string) (string, error) {
+func StoreConfigToTempFileE(t testing.TB, configData string) (string, error) {
 	escapedTestName := url.PathEscape(t.Name())
 	tmpfile, err := ioutil.TempFile("", escapedTestName)
 	if err != nil {
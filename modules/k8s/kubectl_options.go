@@ -1,6 +1,10 @@
 package k8s
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
 
 // Represents common options necessary to specify for all Kubectl calls
 type KubectlOptions struct {
@@ -8,6 +12,7 @@ type KubectlOptions struct {
 	ConfigPath  string
 	Namespace   string
 	Env         map[string]string
+	Logger      *logger.Logger // The logger to log kubectl commands and their output with. Defaults to logger.Global.
 }
 
 func NewKubectlOptions(contextName string, configPath string) *KubectlOptions {
@@ -17,8 +22,16 @@ func NewKubectlOptions(contextName string, configPath string) *KubectlOptions {
 	}
 }
 
+// NewKubectlOptionsWithNamespace returns KubectlOptions that target the given namespace, in addition to the given
+// context and kubeconfig file.
+func NewKubectlOptionsWithNamespace(contextName string, configPath string, namespace string) *KubectlOptions {
+	options := NewKubectlOptions(contextName, configPath)
+	options.Namespace = namespace
+	return options
+}
+
 // GetConfigPath will return a sensible default if the config path is not set on the options.
-func (kubectlOptions *KubectlOptions) GetConfigPath(t *testing.T) (string, error) {
+func (kubectlOptions *KubectlOptions) GetConfigPath(t testing.TB) (string, error) {
 	// We predeclare `err` here so that we can update `kubeConfigPath` in the if block below. Otherwise, go complains
 	// saying `err` is undefined.
 	var err error
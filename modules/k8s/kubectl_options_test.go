@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+)
+
+func TestGetKubectlArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		options  *KubectlOptions
+		expected []string
+	}{
+		{"no options", NewKubectlOptions("", ""), []string{"get", "pods"}},
+		{"context", NewKubectlOptions("minikube", ""), []string{"--context", "minikube", "get", "pods"}},
+		{"config path", NewKubectlOptions("", "/tmp/kubeconfig"), []string{"--kubeconfig", "/tmp/kubeconfig", "get", "pods"}},
+		{
+			"all",
+			NewKubectlOptionsWithNamespace("gke", "/tmp/kubeconfig", "terratest"),
+			[]string{"--context", "gke", "--kubeconfig", "/tmp/kubeconfig", "--namespace", "terratest", "get", "pods"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, getKubectlArgs(testCase.options, "get", "pods"))
+		})
+	}
+}
+
+func TestKubectlCommandChangesResources(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"apply", "-f", "deployment.yml"}, true},
+		{[]string{"delete", "-f", "deployment.yml"}, true},
+		{[]string{"scale", "deployment/nginx", "--replicas=3"}, true},
+		{[]string{"rollout", "restart", "deployment/nginx"}, true},
+		{[]string{"rollout", "status", "deployment/nginx"}, false},
+		{[]string{"get", "pods"}, false},
+		{[]string{"describe", "service", "nginx"}, false},
+		{[]string{}, false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(strings.Join(testCase.args, " "), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, kubectlCommandChangesResources(testCase.args))
+		})
+	}
+}
+
+// This test does not call t.Parallel, as it enables dry run for the whole process.
+func TestKubectlApplyAndDeleteSkippedInDryRun(t *testing.T) {
+	restore := dryrun.Enable(t)
+	defer restore()
+
+	// The kubeconfig doesn't exist, so any command that actually runs fails
+	options := NewKubectlOptions("", "/tmp/terratest-kubeconfig-that-does-not-exist")
+
+	require.NoError(t, KubectlApplyE(t, options, "deployment.yml"))
+	require.NoError(t, KubectlDeleteE(t, options, "deployment.yml"))
+
+	_, err := RunKubectlAndGetOutputE(t, options, "get", "pods")
+	assert.Error(t, err)
+}
@@ -0,0 +1,177 @@
+// Package localcluster creates and destroys ephemeral local Kubernetes clusters, so that chart and manifest tests can
+// run against a real cluster without any cloud credentials.
+package localcluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/stretchr/testify/require"
+)
+
+// DefaultKindWaitForReady is how long kind waits for the control plane of a new cluster to be ready.
+const DefaultKindWaitForReady = 5 * time.Minute
+
+// KindOptions represents the options for creating a kind (Kubernetes in Docker) cluster.
+type KindOptions struct {
+	ClusterName    string            // The name of the cluster. If empty, a unique name is generated when it is created.
+	NodeImage      string            // The kindest/node image to use, which pins the Kubernetes version. Defaults to kind's default.
+	ConfigPath     string            // The path to a kind cluster config file, e.g. to create multiple nodes.
+	KubeConfigPath string            // Where to write the kubeconfig of the cluster. If empty, a temp file is used.
+	WaitForReady   time.Duration     // How long to wait for the control plane to be ready. Defaults to DefaultKindWaitForReady.
+	EnvVars        map[string]string // Extra environment variables to set when running kind
+	Logger         *logger.Logger    // The logger to log kind commands and their output with. Defaults to logger.Global.
+
+	// Whether KubeConfigPath is a temp file created by CreateKindClusterE, which DeleteKindClusterE should remove
+	generatedKubeConfig bool
+}
+
+// CreateKindCluster creates a new kind cluster with the given options and returns KubectlOptions that target it. You
+// should defer a call to DeleteKindCluster right away. This will fail the test if there is an error in creating the
+// cluster.
+func CreateKindCluster(t testing.TB, options *KindOptions) *k8s.KubectlOptions {
+	kubectlOptions, err := CreateKindClusterE(t, options)
+	require.NoError(t, err)
+	return kubectlOptions
+}
+
+// CreateKindClusterE creates a new kind cluster with the given options and returns KubectlOptions that target it. If
+// ClusterName or KubeConfigPath are not set, they are filled in on the options, so that the same options can be
+// passed to LoadDockerImageIntoKindE and DeleteKindClusterE. You should defer a call to DeleteKindClusterE right away.
+func CreateKindClusterE(t testing.TB, options *KindOptions) (*k8s.KubectlOptions, error) {
+	if options.ClusterName == "" {
+		options.ClusterName = k8s.UniqueNamespaceName(fmt.Sprintf("terratest-%s", t.Name()))
+	}
+
+	if dryrun.Skip(t, "create kind cluster %s", options.ClusterName) {
+		return k8s.NewKubectlOptions(getKindContextName(options), options.KubeConfigPath), nil
+	}
+
+	if options.KubeConfigPath == "" {
+		kubeConfigFile, err := ioutil.TempFile("", fmt.Sprintf("kubeconfig-%s", options.ClusterName))
+		if err != nil {
+			return nil, err
+		}
+		kubeConfigFile.Close()
+		options.KubeConfigPath = kubeConfigFile.Name()
+		options.generatedKubeConfig = true
+	}
+
+	options.Logger.Logf(t, "Creating kind cluster %s", options.ClusterName)
+	if _, err := runKindCommandE(t, options, getCreateKindClusterArgs(options)...); err != nil {
+		return nil, err
+	}
+
+	kubectlOptions := k8s.NewKubectlOptions(getKindContextName(options), options.KubeConfigPath)
+	kubectlOptions.Logger = options.Logger
+	return kubectlOptions, nil
+}
+
+// LoadDockerImageIntoKind copies the given Docker images from the local Docker daemon onto every node of the kind
+// cluster, so that pods can use images that were built by the test without pushing them to a registry. Make sure
+// the pods don't use an imagePullPolicy of Always, or Kubernetes will still try to pull them. This will fail the test
+// if there is an error in loading the images.
+func LoadDockerImageIntoKind(t testing.TB, options *KindOptions, images ...string) {
+	require.NoError(t, LoadDockerImageIntoKindE(t, options, images...))
+}
+
+// LoadDockerImageIntoKindE copies the given Docker images from the local Docker daemon onto every node of the kind
+// cluster, so that pods can use images that were built by the test without pushing them to a registry. Make sure
+// the pods don't use an imagePullPolicy of Always, or Kubernetes will still try to pull them.
+func LoadDockerImageIntoKindE(t testing.TB, options *KindOptions, images ...string) error {
+	if dryrun.Skip(t, "load Docker images %s into kind cluster %s", strings.Join(images, ", "), options.ClusterName) {
+		return nil
+	}
+
+	options.Logger.Logf(t, "Loading Docker images %s into kind cluster %s", strings.Join(images, ", "), options.ClusterName)
+	_, err := runKindCommandE(t, options, getLoadDockerImageArgs(options, images...)...)
+	return err
+}
+
+// DeleteKindCluster deletes the kind cluster with the given options, along with the kubeconfig file if it was
+// generated by CreateKindCluster. This will fail the test if there is an error in deleting the cluster.
+func DeleteKindCluster(t testing.TB, options *KindOptions) {
+	require.NoError(t, DeleteKindClusterE(t, options))
+}
+
+// DeleteKindClusterE deletes the kind cluster with the given options, along with the kubeconfig file if it was
+// generated by CreateKindClusterE.
+func DeleteKindClusterE(t testing.TB, options *KindOptions) error {
+	if dryrun.Skip(t, "delete kind cluster %s", options.ClusterName) {
+		return nil
+	}
+
+	options.Logger.Logf(t, "Deleting kind cluster %s", options.ClusterName)
+	if _, err := runKindCommandE(t, options, getDeleteKindClusterArgs(options)...); err != nil {
+		return err
+	}
+
+	if options.generatedKubeConfig {
+		if err := os.Remove(options.KubeConfigPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		options.KubeConfigPath = ""
+		options.generatedKubeConfig = false
+	}
+
+	return nil
+}
+
+// runKindCommandE runs kind with the given arguments and returns stdout/stderr.
+func runKindCommandE(t testing.TB, options *KindOptions, args ...string) (string, error) {
+	cmd := shell.Command{
+		Command: "kind",
+		Args:    args,
+		Env:     options.EnvVars,
+		Logger:  options.Logger,
+	}
+	return shell.RunCommandAndGetOutputE(t, cmd)
+}
+
+// getCreateKindClusterArgs returns the args for kind to create the cluster described by the given options.
+func getCreateKindClusterArgs(options *KindOptions) []string {
+	waitForReady := options.WaitForReady
+	if waitForReady == 0 {
+		waitForReady = DefaultKindWaitForReady
+	}
+
+	args := []string{"create", "cluster", "--name", options.ClusterName, "--wait", waitForReady.String()}
+	if options.NodeImage != "" {
+		args = append(args, "--image", options.NodeImage)
+	}
+	if options.ConfigPath != "" {
+		args = append(args, "--config", options.ConfigPath)
+	}
+	if options.KubeConfigPath != "" {
+		args = append(args, "--kubeconfig", options.KubeConfigPath)
+	}
+	return args
+}
+
+// getLoadDockerImageArgs returns the args for kind to load the given Docker images into the cluster.
+func getLoadDockerImageArgs(options *KindOptions, images ...string) []string {
+	args := append([]string{"load", "docker-image"}, images...)
+	return append(args, "--name", options.ClusterName)
+}
+
+// getDeleteKindClusterArgs returns the args for kind to delete the cluster.
+func getDeleteKindClusterArgs(options *KindOptions) []string {
+	args := []string{"delete", "cluster", "--name", options.ClusterName}
+	if options.KubeConfigPath != "" {
+		args = append(args, "--kubeconfig", options.KubeConfigPath)
+	}
+	return args
+}
+
+// getKindContextName returns the name of the kubeconfig context kind creates for the cluster.
+func getKindContextName(options *KindOptions) string {
+	return fmt.Sprintf("kind-%s", options.ClusterName)
+}
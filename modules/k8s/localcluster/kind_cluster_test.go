@@ -0,0 +1,38 @@
+// +build kubeall kind
+
+// NOTE: we have build tags to differentiate the kind tests from the others. Creating a kind cluster runs several
+// Docker containers and takes a few minutes, so we run these tests separately, on machines that have Docker and kind
+// installed.
+
+package localcluster
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndDeleteKindCluster(t *testing.T) {
+	t.Parallel()
+
+	options := &KindOptions{}
+	kubectlOptions := CreateKindCluster(t, options)
+	kubeConfigPath := options.KubeConfigPath
+	defer func() {
+		DeleteKindCluster(t, options)
+
+		_, err := os.Stat(kubeConfigPath)
+		assert.True(t, os.IsNotExist(err))
+	}()
+
+	assert.NotEmpty(t, options.ClusterName)
+	assert.Equal(t, "kind-"+options.ClusterName, kubectlOptions.ContextName)
+
+	k8s.WaitUntilAllNodesReady(t, kubectlOptions, 30, 10*time.Second)
+	nodes := k8s.GetNodes(t, kubectlOptions)
+	require.NotEmpty(t, nodes)
+}
@@ -0,0 +1,61 @@
+package localcluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCreateKindClusterArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		options  *KindOptions
+		expected []string
+	}{
+		{"defaults", &KindOptions{ClusterName: "test"}, []string{"create", "cluster", "--name", "test", "--wait", "5m0s"}},
+		{"wait", &KindOptions{ClusterName: "test", WaitForReady: 90 * time.Second}, []string{"create", "cluster", "--name", "test", "--wait", "1m30s"}},
+		{
+			"all",
+			&KindOptions{ClusterName: "test", NodeImage: "kindest/node:v1.13.4", ConfigPath: "kind.yaml", KubeConfigPath: "/tmp/kubeconfig"},
+			[]string{"create", "cluster", "--name", "test", "--wait", "5m0s", "--image", "kindest/node:v1.13.4", "--config", "kind.yaml", "--kubeconfig", "/tmp/kubeconfig"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, getCreateKindClusterArgs(testCase.options))
+		})
+	}
+}
+
+func TestGetLoadDockerImageArgs(t *testing.T) {
+	t.Parallel()
+
+	args := getLoadDockerImageArgs(&KindOptions{ClusterName: "test"}, "app:latest", "sidecar:1.0")
+	assert.Equal(t, []string{"load", "docker-image", "app:latest", "sidecar:1.0", "--name", "test"}, args)
+}
+
+func TestGetDeleteKindClusterArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"delete", "cluster", "--name", "test"}, getDeleteKindClusterArgs(&KindOptions{ClusterName: "test"}))
+	assert.Equal(
+		t,
+		[]string{"delete", "cluster", "--name", "test", "--kubeconfig", "/tmp/kubeconfig"},
+		getDeleteKindClusterArgs(&KindOptions{ClusterName: "test", KubeConfigPath: "/tmp/kubeconfig"}),
+	)
+}
+
+func TestGetKindContextName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "kind-test", getKindContextName(&KindOptions{ClusterName: "test"}))
+}
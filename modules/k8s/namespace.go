@@ -1,8 +1,13 @@
 package k8s
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,12 +15,16 @@ import (
 
 // CreateNamespace will create a new Kubernetes namespace on the cluster targeted by the provided options. This will
 // fail the test if there is an error in creating the namespace.
-func CreateNamespace(t *testing.T, options *KubectlOptions, namespaceName string) {
+func CreateNamespace(t testing.TB, options *KubectlOptions, namespaceName string) {
 	require.NoError(t, CreateNamespaceE(t, options, namespaceName))
 }
 
 // CreateNamespaceE will create a new Kubernetes namespace on the cluster targeted by the provided options.
-func CreateNamespaceE(t *testing.T, options *KubectlOptions, namespaceName string) error {
+func CreateNamespaceE(t testing.TB, options *KubectlOptions, namespaceName string) error {
+	if dryrun.Skip(t, "create namespace %s", namespaceName) {
+		return nil
+	}
+
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return err
@@ -32,14 +41,14 @@ func CreateNamespaceE(t *testing.T, options *KubectlOptions, namespaceName strin
 
 // GetNamespace will query the Kubernetes cluster targeted by the provided options for the requested namespace. This will
 // fail the test if there is an error in creating the namespace.
-func GetNamespace(t *testing.T, options *KubectlOptions, namespaceName string) corev1.Namespace {
+func GetNamespace(t testing.TB, options *KubectlOptions, namespaceName string) corev1.Namespace {
 	namespace, err := GetNamespaceE(t, options, namespaceName)
 	require.NoError(t, err)
 	return namespace
 }
 
 // GetNamespaceE will query the Kubernetes cluster targeted by the provided options for the requested namespace.
-func GetNamespaceE(t *testing.T, options *KubectlOptions, namespaceName string) (corev1.Namespace, error) {
+func GetNamespaceE(t testing.TB, options *KubectlOptions, namespaceName string) (corev1.Namespace, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return corev1.Namespace{}, err
@@ -51,12 +60,16 @@ func GetNamespaceE(t *testing.T, options *KubectlOptions, namespaceName string)
 
 // DeleteNamespace will delete the requested namespace from the Kubernetes cluster targeted by the provided options. This will
 // fail the test if there is an error in creating the namespace.
-func DeleteNamespace(t *testing.T, options *KubectlOptions, namespaceName string) {
+func DeleteNamespace(t testing.TB, options *KubectlOptions, namespaceName string) {
 	require.NoError(t, DeleteNamespaceE(t, options, namespaceName))
 }
 
 // DeleteNamespaceE will delete the requested namespace from the Kubernetes cluster targeted by the provided options.
-func DeleteNamespaceE(t *testing.T, options *KubectlOptions, namespaceName string) error {
+func DeleteNamespaceE(t testing.TB, options *KubectlOptions, namespaceName string) error {
+	if dryrun.Skip(t, "delete namespace %s", namespaceName) {
+		return nil
+	}
+
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return err
@@ -64,3 +77,57 @@ func DeleteNamespaceE(t *testing.T, options *KubectlOptions, namespaceName strin
 
 	return clientset.CoreV1().Namespaces().Delete(namespaceName, &metav1.DeleteOptions{})
 }
+
+// maxNamespaceNameLength is the maximum length of a namespace name, which has to be a valid DNS label.
+const maxNamespaceNameLength = 63
+
+// invalidNamespaceNameChars matches the characters that are not allowed in a namespace name.
+var invalidNamespaceNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// UniqueNamespaceName returns a namespace name that starts with the given prefix (e.g., the name of the test) and ends
+// with a random unique ID, so tests running in parallel on a shared cluster don't collide. The prefix is lower cased,
+// stripped of characters that aren't valid in a namespace name, and truncated as needed.
+func UniqueNamespaceName(prefix string) string {
+	uniqueID := strings.ToLower(random.UniqueId())
+
+	prefix = invalidNamespaceNameChars.ReplaceAllString(strings.ToLower(prefix), "-")
+	maxPrefixLength := maxNamespaceNameLength - len(uniqueID) - 1
+	if len(prefix) > maxPrefixLength {
+		prefix = prefix[:maxPrefixLength]
+	}
+	prefix = strings.Trim(prefix, "-")
+
+	if prefix == "" {
+		return uniqueID
+	}
+	return fmt.Sprintf("%s-%s", prefix, uniqueID)
+}
+
+// UseUniqueNamespace creates a namespace with a unique name built from the given prefix (see UniqueNamespaceName),
+// and sets it as the Namespace of the provided options, so that all subsequent calls with those options target it. It
+// returns a function that deletes the namespace again, which you should defer right away. This will fail the test if
+// there is an error in creating the namespace.
+func UseUniqueNamespace(t testing.TB, options *KubectlOptions, prefix string) func() {
+	cleanup, err := UseUniqueNamespaceE(t, options, prefix)
+	require.NoError(t, err)
+	return cleanup
+}
+
+// UseUniqueNamespaceE creates a namespace with a unique name built from the given prefix (see UniqueNamespaceName),
+// and sets it as the Namespace of the provided options, so that all subsequent calls with those options target it. It
+// returns a function that deletes the namespace again, which you should defer right away.
+func UseUniqueNamespaceE(t testing.TB, options *KubectlOptions, prefix string) (func(), error) {
+	namespaceName := UniqueNamespaceName(prefix)
+	options.Logger.Logf(t, "Creating unique namespace %s", namespaceName)
+
+	if err := CreateNamespaceE(t, options, namespaceName); err != nil {
+		return nil, err
+	}
+	options.Namespace = namespaceName
+
+	cleanup := func() {
+		options.Logger.Logf(t, "Deleting unique namespace %s", namespaceName)
+		DeleteNamespace(t, options, namespaceName)
+	}
+	return cleanup, nil
+}
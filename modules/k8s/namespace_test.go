@@ -34,3 +34,50 @@ func TestNamespaces(t *testing.T) {
 	namespace := GetNamespace(t, options, namespaceName)
 	require.Equal(t, namespace.Name, namespaceName)
 }
+
+func TestUseUniqueNamespace(t *testing.T) {
+	t.Parallel()
+
+	options := NewKubectlOptions("", "")
+	cleanup := UseUniqueNamespace(t, options, t.Name())
+
+	require.True(t, strings.HasPrefix(options.Namespace, "testuseuniquenamespace-"))
+	namespace := GetNamespace(t, options, options.Namespace)
+	require.Equal(t, namespace.Name, options.Namespace)
+
+	cleanup()
+	namespace = GetNamespace(t, options, options.Namespace)
+	require.Equal(t, namespace.Status.Phase, corev1.NamespaceTerminating)
+}
+
+func TestUniqueNamespaceName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		prefix         string
+		expectedPrefix string
+	}{
+		{"simple", "terratest", "terratest-"},
+		{"test name", "TestFoo/sub_test", "testfoo-sub-test-"},
+		{"empty", "", ""},
+		{"invalid only", "///", ""},
+		{"long", strings.Repeat("a", 100), strings.Repeat("a", maxNamespaceNameLength-7) + "-"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			name := UniqueNamespaceName(testCase.prefix)
+			require.True(t, strings.HasPrefix(name, testCase.expectedPrefix), name)
+			require.True(t, len(name) <= maxNamespaceNameLength, name)
+			require.Regexp(t, "^[a-z0-9]([a-z0-9-]*[a-z0-9])?$", name)
+			require.NotEqual(t, name, UniqueNamespaceName(testCase.prefix))
+		})
+	}
+}
@@ -9,21 +9,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
 )
 
 // GetNodes queries Kubernetes for information about the worker nodes registered to the cluster. If anything goes wrong,
 // the function will automatically fail the test.
-func GetNodes(t *testing.T, options *KubectlOptions) []corev1.Node {
+func GetNodes(t testing.TB, options *KubectlOptions) []corev1.Node {
 	nodes, err := GetNodesE(t, options)
 	require.NoError(t, err)
 	return nodes
 }
 
 // GetNodesE queries Kubernetes for information about the worker nodes registered to the cluster.
-func GetNodesE(t *testing.T, options *KubectlOptions) ([]corev1.Node, error) {
-	logger.Logf(t, "Getting list of nodes from Kubernetes")
+func GetNodesE(t testing.TB, options *KubectlOptions) ([]corev1.Node, error) {
+	options.Logger.Logf(t, "Getting list of nodes from Kubernetes")
 
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
@@ -39,7 +39,7 @@ func GetNodesE(t *testing.T, options *KubectlOptions) ([]corev1.Node, error) {
 
 // GetReadyNodes queries Kubernetes for information about the worker nodes registered to the cluster and only returns
 // those that are in the ready state. If anything goes wrong, the function will automatically fail the test.
-func GetReadyNodes(t *testing.T, options *KubectlOptions) []corev1.Node {
+func GetReadyNodes(t testing.TB, options *KubectlOptions) []corev1.Node {
 	nodes, err := GetReadyNodesE(t, options)
 	require.NoError(t, err)
 	return nodes
@@ -47,12 +47,12 @@ func GetReadyNodes(t *testing.T, options *KubectlOptions) []corev1.Node {
 
 // GetReadyNodesE queries Kubernetes for information about the worker nodes registered to the cluster and only returns
 // those that are in the ready state.
-func GetReadyNodesE(t *testing.T, options *KubectlOptions) ([]corev1.Node, error) {
+func GetReadyNodesE(t testing.TB, options *KubectlOptions) ([]corev1.Node, error) {
 	nodes, err := GetNodesE(t, options)
 	if err != nil {
 		return nil, err
 	}
-	logger.Logf(t, "Filtering list of nodes from Kubernetes for Ready nodes")
+	options.Logger.Logf(t, "Filtering list of nodes from Kubernetes for Ready nodes")
 	nodesFiltered := []corev1.Node{}
 	for _, node := range nodes {
 		if IsNodeReady(node) {
@@ -74,14 +74,16 @@ func IsNodeReady(node corev1.Node) bool {
 
 // WaitUntilAllNodesReady continuously polls the Kubernetes cluster until all nodes in the cluster reach the ready
 // state, or runs out of retries. Will fail the test immediately if it times out.
-func WaitUntilAllNodesReady(t *testing.T, options *KubectlOptions, retries int, sleepBetweenRetries time.Duration) {
+func WaitUntilAllNodesReady(t testing.TB, options *KubectlOptions, retries int, sleepBetweenRetries time.Duration) {
 	err := WaitUntilAllNodesReadyE(t, options, retries, sleepBetweenRetries)
 	require.NoError(t, err)
 }
 
 // WaitUntilAllNodesReadyE continuously polls the Kubernetes cluster until all nodes in the cluster reach the ready
 // state, or runs out of retries.
-func WaitUntilAllNodesReadyE(t *testing.T, options *KubectlOptions, retries int, sleepBetweenRetries time.Duration) error {
+func WaitUntilAllNodesReadyE(t testing.TB, options *KubectlOptions, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "k8s.WaitUntilAllNodesReady", time.Now(), &err)
+
 	message, err := retry.DoWithRetryE(
 		t,
 		"Wait for all Kube Nodes to be ready",
@@ -95,19 +97,19 @@ func WaitUntilAllNodesReadyE(t *testing.T, options *KubectlOptions, retries int,
 			return "All nodes ready", nil
 		},
 	)
-	logger.Logf(t, message)
+	options.Logger.Logf(t, message)
 	return err
 }
 
 // AreAllNodesReady checks if all nodes are ready in the Kubernetes cluster targeted by the current config context
-func AreAllNodesReady(t *testing.T, options *KubectlOptions) bool {
+func AreAllNodesReady(t testing.TB, options *KubectlOptions) bool {
 	nodesReady, _ := AreAllNodesReadyE(t, options)
 	return nodesReady
 }
 
 // AreAllNodesReadyE checks if all nodes are ready in the Kubernetes cluster targeted by the current config context. If
 // false, returns an error indicating the reason.
-func AreAllNodesReadyE(t *testing.T, options *KubectlOptions) (bool, error) {
+func AreAllNodesReadyE(t testing.TB, options *KubectlOptions) (bool, error) {
 	nodes, err := GetNodesE(t, options)
 	if err != nil {
 		return false, err
@@ -1,7 +1,10 @@
 package k8s
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,20 +12,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
 )
 
 // ListPods will look for pods in the given namespace that match the given filters and return them. This will fail the
 // test if there is an error.
-func ListPods(t *testing.T, options *KubectlOptions, filters metav1.ListOptions) []corev1.Pod {
+func ListPods(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) []corev1.Pod {
 	pods, err := ListPodsE(t, options, filters)
 	require.NoError(t, err)
 	return pods
 }
 
 // ListPodsE will look for pods in the given namespace that match the given filters and return them.
-func ListPodsE(t *testing.T, options *KubectlOptions, filters metav1.ListOptions) ([]corev1.Pod, error) {
+func ListPodsE(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) ([]corev1.Pod, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
@@ -37,26 +40,27 @@ func ListPodsE(t *testing.T, options *KubectlOptions, filters metav1.ListOptions
 
 // GetPod returns a Kubernetes pod resource in the provided namespace with the given name. This will
 // fail the test if there is an error.
-func GetPod(t *testing.T, options *KubectlOptions, podName string) *corev1.Pod {
+func GetPod(t testing.TB, options *KubectlOptions, podName string) *corev1.Pod {
 	pod, err := GetPodE(t, options, podName)
 	require.NoError(t, err)
 	return pod
 }
 
 // GetPodE returns a Kubernetes pod resource in the provided namespace with the given name.
-func GetPodE(t *testing.T, options *KubectlOptions, podName string) (*corev1.Pod, error) {
+func GetPodE(t testing.TB, options *KubectlOptions, podName string) (*corev1.Pod, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
 	}
-	return clientset.CoreV1().Pods(options.Namespace).Get(podName, metav1.GetOptions{})
+	pod, err := clientset.CoreV1().Pods(options.Namespace).Get(podName, metav1.GetOptions{})
+	return pod, wrapKubernetesError(err, "Pod", podName, options.Namespace)
 }
 
 // WaitUntilNumPodsCreated waits until the desired number of pods are created that match the provided filter. This will
 // retry the check for the specified amount of times, sleeping for the provided duration between each try. This will
 // fail the test if the retry times out.
 func WaitUntilNumPodsCreated(
-	t *testing.T,
+	t testing.TB,
 	options *KubectlOptions,
 	filters metav1.ListOptions,
 	desiredCount int,
@@ -69,13 +73,15 @@ func WaitUntilNumPodsCreated(
 // WaitUntilNumPodsCreatedE waits until the desired number of pods are created that match the provided filter. This will
 // retry the check for the specified amount of times, sleeping for the provided duration between each try.
 func WaitUntilNumPodsCreatedE(
-	t *testing.T,
+	t testing.TB,
 	options *KubectlOptions,
 	filters metav1.ListOptions,
 	desiredCount int,
 	retries int,
 	sleepBetweenRetries time.Duration,
-) error {
+) (err error) {
+	defer timing.RecordSince(t, "k8s.WaitUntilNumPodsCreated", time.Now(), &err)
+
 	statusMsg := fmt.Sprintf("Wait for num pods created to match desired count %d.", desiredCount)
 	message, err := retry.DoWithRetryE(
 		t,
@@ -94,22 +100,24 @@ func WaitUntilNumPodsCreatedE(
 		},
 	)
 	if err != nil {
-		logger.Logf(t, "Timedout waiting for the desired number of Pods to be created: %s", err)
+		options.Logger.Logf(t, "Timedout waiting for the desired number of Pods to be created: %s", err)
 		return err
 	}
-	logger.Logf(t, message)
+	options.Logger.Logf(t, message)
 	return nil
 }
 
 // WaitUntilPodAvailable waits until the pod is running, retrying the check for the specified amount of times, sleeping
 // for the provided duration between each try. This will fail the test if there is an error or if the check times out.
-func WaitUntilPodAvailable(t *testing.T, options *KubectlOptions, podName string, retries int, sleepBetweenRetries time.Duration) {
+func WaitUntilPodAvailable(t testing.TB, options *KubectlOptions, podName string, retries int, sleepBetweenRetries time.Duration) {
 	require.NoError(t, WaitUntilPodAvailableE(t, options, podName, retries, sleepBetweenRetries))
 }
 
 // WaitUntilPodAvailableE waits until the pod is running, retrying the check for the specified amount of times, sleeping
 // for the provided duration between each try.
-func WaitUntilPodAvailableE(t *testing.T, options *KubectlOptions, podName string, retries int, sleepBetweenRetries time.Duration) error {
+func WaitUntilPodAvailableE(t testing.TB, options *KubectlOptions, podName string, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "k8s.WaitUntilPodAvailable", time.Now(), &err)
+
 	statusMsg := fmt.Sprintf("Wait for pod %s to be provisioned.", podName)
 	message, err := retry.DoWithRetryE(
 		t,
@@ -128,10 +136,10 @@ func WaitUntilPodAvailableE(t *testing.T, options *KubectlOptions, podName strin
 		},
 	)
 	if err != nil {
-		logger.Logf(t, "Timedout waiting for Pod to be provisioned: %s", err)
+		options.Logger.Logf(t, "Timedout waiting for Pod to be provisioned: %s", err)
 		return err
 	}
-	logger.Logf(t, message)
+	options.Logger.Logf(t, message)
 	return nil
 }
 
@@ -139,3 +147,153 @@ func WaitUntilPodAvailableE(t *testing.T, options *KubectlOptions, podName strin
 func IsPodAvailable(pod *corev1.Pod) bool {
 	return pod.Status.Phase == corev1.PodRunning
 }
+
+// ExecInPod runs the given command in the given container of the pod with the given name, in the provided namespace,
+// and returns its stdout and stderr. The container name may be left empty if the pod only has one container. This is
+// useful to run checks from within the cluster, such as curling the internal DNS name of a service. This will fail the
+// test if there is an error, including the command exiting with a non-zero status.
+func ExecInPod(t testing.TB, options *KubectlOptions, podName string, containerName string, command ...string) string {
+	out, err := ExecInPodE(t, options, podName, containerName, command...)
+	require.NoError(t, err)
+	return out
+}
+
+// ExecInPodE runs the given command in the given container of the pod with the given name, in the provided namespace,
+// and returns its stdout and stderr. The container name may be left empty if the pod only has one container. This is
+// useful to run checks from within the cluster, such as curling the internal DNS name of a service.
+func ExecInPodE(t testing.TB, options *KubectlOptions, podName string, containerName string, command ...string) (string, error) {
+	return RunKubectlAndGetOutputE(t, options, getExecArgs(podName, containerName, command...)...)
+}
+
+// getExecArgs returns the kubectl args to run the given command in the given container of the given pod.
+func getExecArgs(podName string, containerName string, command ...string) []string {
+	args := []string{"exec", podName}
+	if containerName != "" {
+		args = append(args, "--container", containerName)
+	}
+	args = append(args, "--")
+	return append(args, command...)
+}
+
+// GetPodLogs returns the logs of the given container in the pod with the given name, in the provided namespace. The
+// container name may be left empty if the pod only has one container. This will fail the test if there is an error.
+func GetPodLogs(t testing.TB, options *KubectlOptions, podName string, containerName string) string {
+	logs, err := GetPodLogsE(t, options, podName, containerName)
+	require.NoError(t, err)
+	return logs
+}
+
+// GetPodLogsE returns the logs of the given container in the pod with the given name, in the provided namespace. The
+// container name may be left empty if the pod only has one container.
+func GetPodLogsE(t testing.TB, options *KubectlOptions, podName string, containerName string) (string, error) {
+	clientset, err := GetKubernetesClientFromOptionsE(t, options)
+	if err != nil {
+		return "", err
+	}
+
+	logs, err := clientset.CoreV1().Pods(options.Namespace).GetLogs(podName, &corev1.PodLogOptions{Container: containerName}).DoRaw()
+	if err != nil {
+		return "", wrapKubernetesError(err, "Pod", podName, options.Namespace)
+	}
+	return string(logs), nil
+}
+
+// AssertPodLogContains checks that the logs of the given container in the pod with the given name contain the expected
+// text, and fails the test if they don't.
+func AssertPodLogContains(t testing.TB, options *KubectlOptions, podName string, containerName string, expected string) {
+	require.NoError(t, AssertPodLogContainsE(t, options, podName, containerName, expected))
+}
+
+// AssertPodLogContainsE checks that the logs of the given container in the pod with the given name contain the
+// expected text, and returns an error if they don't.
+func AssertPodLogContainsE(t testing.TB, options *KubectlOptions, podName string, containerName string, expected string) error {
+	logs, err := GetPodLogsE(t, options, podName, containerName)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(logs, expected) {
+		return PodLogMatchNotFound{PodName: podName, ContainerName: containerName, Match: expected}
+	}
+	return nil
+}
+
+// StreamPodLogsUntil follows the logs of the given container in the pod with the given name until a line contains the
+// given match, and returns the logs read up to and including that line. This is useful to wait for application level
+// readiness signals (e.g., "server started on :8080") that the pod phase doesn't capture. This will fail the test if
+// there is an error, or if no line matches within the given timeout.
+func StreamPodLogsUntil(t testing.TB, options *KubectlOptions, podName string, containerName string, match string, timeout time.Duration) string {
+	logs, err := StreamPodLogsUntilE(t, options, podName, containerName, match, timeout)
+	require.NoError(t, err)
+	return logs
+}
+
+// StreamPodLogsUntilE follows the logs of the given container in the pod with the given name until a line contains
+// the given match, and returns the logs read up to and including that line. This is useful to wait for application
+// level readiness signals (e.g., "server started on :8080") that the pod phase doesn't capture. If no line matches
+// within the given timeout, this returns the logs read so far along with a PodLogMatchNotFound error.
+func StreamPodLogsUntilE(t testing.TB, options *KubectlOptions, podName string, containerName string, match string, timeout time.Duration) (string, error) {
+	options.Logger.Logf(t, "Waiting up to %s for the logs of pod %s to contain %q", timeout, podName, match)
+
+	clientset, err := GetKubernetesClientFromOptionsE(t, options)
+	if err != nil {
+		return "", err
+	}
+
+	stream, err := clientset.CoreV1().Pods(options.Namespace).GetLogs(podName, &corev1.PodLogOptions{Container: containerName, Follow: true}).Stream()
+	if err != nil {
+		return "", wrapKubernetesError(err, "Pod", podName, options.Namespace)
+	}
+	defer stream.Close()
+
+	logs, matched, err := readLogsUntilMatch(stream, match, timeout)
+	if err != nil {
+		return logs, err
+	}
+	if !matched {
+		return logs, PodLogMatchNotFound{PodName: podName, ContainerName: containerName, Match: match, Timeout: timeout}
+	}
+	options.Logger.Logf(t, "Found %q in the logs of pod %s", match, podName)
+	return logs, nil
+}
+
+// readLogsUntilMatch reads lines from the given log stream until one contains the given match, the stream ends, or the
+// timeout passes, whichever comes first. It returns the lines read so far and whether the last of them matched. The
+// caller must close the stream afterwards, as a read may still be blocked on it.
+func readLogsUntilMatch(stream io.Reader, match string, timeout time.Duration) (string, bool, error) {
+	lines := make(chan string)
+	scanErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stream)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-done:
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	var logs []string
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				// The stream ended (e.g., the container exited), so no more lines will come
+				return strings.Join(logs, "\n"), false, <-scanErr
+			}
+			logs = append(logs, line)
+			if strings.Contains(line, match) {
+				return strings.Join(logs, "\n"), true, nil
+			}
+		case <-timer.C:
+			return strings.Join(logs, "\n"), false, nil
+		}
+	}
+}
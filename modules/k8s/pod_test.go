@@ -10,6 +10,7 @@ package k8s
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -86,6 +87,105 @@ func TestWaitUntilPodAvailableReturnsSuccessfully(t *testing.T) {
 	WaitUntilPodAvailable(t, options, "nginx-pod", 60, 1*time.Second)
 }
 
+func TestExecInPod(t *testing.T) {
+	t.Parallel()
+
+	uniqueID := strings.ToLower(random.UniqueId())
+	options := NewKubectlOptions("", "")
+	options.Namespace = uniqueID
+	configData := fmt.Sprintf(EXAMPLE_POD_YAML_TEMPLATE, uniqueID, uniqueID)
+	defer KubectlDeleteFromString(t, options, configData)
+	KubectlApplyFromString(t, options, configData)
+
+	WaitUntilPodAvailable(t, options, "nginx-pod", 60, 1*time.Second)
+
+	// nginx -v prints its version to stderr
+	require.Contains(t, ExecInPod(t, options, "nginx-pod", "nginx", "nginx", "-v"), "nginx/1.15.7")
+	require.Equal(t, "hello", ExecInPod(t, options, "nginx-pod", "", "echo", "hello"))
+
+	_, err := ExecInPodE(t, options, "nginx-pod", "nginx", "ls", "/does-not-exist")
+	require.Error(t, err)
+}
+
+func TestGetExecArgs(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"exec", "nginx-pod", "--", "ls", "-l"}, getExecArgs("nginx-pod", "", "ls", "-l"))
+	require.Equal(t, []string{"exec", "nginx-pod", "--container", "nginx", "--", "ls"}, getExecArgs("nginx-pod", "nginx", "ls"))
+}
+
+func TestPodLogHelpers(t *testing.T) {
+	t.Parallel()
+
+	uniqueID := strings.ToLower(random.UniqueId())
+	options := NewKubectlOptions("", "")
+	options.Namespace = uniqueID
+	configData := fmt.Sprintf(EXAMPLE_LOGGING_POD_YAML_TEMPLATE, uniqueID, uniqueID)
+	defer KubectlDeleteFromString(t, options, configData)
+	KubectlApplyFromString(t, options, configData)
+
+	WaitUntilPodAvailable(t, options, "logging-pod", 60, 1*time.Second)
+
+	logs := StreamPodLogsUntil(t, options, "logging-pod", "", "server started on :8080", 60*time.Second)
+	require.Equal(t, "booting\nserver started on :8080", logs)
+	AssertPodLogContains(t, options, "logging-pod", "busybox", "booting")
+	require.Error(t, AssertPodLogContainsE(t, options, "logging-pod", "busybox", "panic"))
+	require.Contains(t, GetPodLogs(t, options, "logging-pod", ""), "server started on :8080")
+}
+
+func TestReadLogsUntilMatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("match", func(t *testing.T) {
+		t.Parallel()
+
+		logs, matched, err := readLogsUntilMatch(strings.NewReader("booting\nserver started on :8080\nserving\n"), "started", time.Second)
+		require.NoError(t, err)
+		require.True(t, matched)
+		require.Equal(t, "booting\nserver started on :8080", logs)
+	})
+
+	t.Run("stream ends", func(t *testing.T) {
+		t.Parallel()
+
+		logs, matched, err := readLogsUntilMatch(strings.NewReader("booting\ncrashed\n"), "started", time.Second)
+		require.NoError(t, err)
+		require.False(t, matched)
+		require.Equal(t, "booting\ncrashed", logs)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer := io.Pipe()
+		defer reader.Close()
+		go writer.Write([]byte("booting\n"))
+
+		logs, matched, err := readLogsUntilMatch(reader, "started", 500*time.Millisecond)
+		require.NoError(t, err)
+		require.False(t, matched)
+		require.Equal(t, "booting", logs)
+	})
+}
+
+const EXAMPLE_LOGGING_POD_YAML_TEMPLATE = `---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: %s
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: logging-pod
+  namespace: %s
+spec:
+  containers:
+  - name: busybox
+    image: busybox:1.30
+    command: ["sh", "-c", "echo booting; sleep 5; echo server started on :8080; sleep 3600"]
+`
+
 const EXAMPLE_POD_YAML_TEMPLATE = `---
 apiVersion: v1
 kind: Namespace
@@ -10,7 +10,7 @@ import (
 
 // GetRole returns a Kubernetes role resource in the provided namespace with the given name. The namespace used
 // is the one provided in the KubectlOptions. This will fail the test if there is an error.
-func GetRole(t *testing.T, options *KubectlOptions, roleName string) *rbacv1.Role {
+func GetRole(t testing.TB, options *KubectlOptions, roleName string) *rbacv1.Role {
 	role, err := GetRoleE(t, options, roleName)
 	require.NoError(t, err)
 	return role
@@ -18,7 +18,7 @@ func GetRole(t *testing.T, options *KubectlOptions, roleName string) *rbacv1.Rol
 
 // GetRole returns a Kubernetes role resource in the provided namespace with the given name. The namespace used
 // is the one provided in the KubectlOptions.
-func GetRoleE(t *testing.T, options *KubectlOptions, roleName string) (*rbacv1.Role, error) {
+func GetRoleE(t testing.TB, options *KubectlOptions, roleName string) (*rbacv1.Role, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
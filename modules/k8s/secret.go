@@ -10,7 +10,7 @@ import (
 
 // GetSecret returns a Kubernetes secret resource in the provided namespace with the given name. The namespace used
 // is the one provided in the KubectlOptions. This will fail the test if there is an error.
-func GetSecret(t *testing.T, options *KubectlOptions, secretName string) *corev1.Secret {
+func GetSecret(t testing.TB, options *KubectlOptions, secretName string) *corev1.Secret {
 	secret, err := GetSecretE(t, options, secretName)
 	require.NoError(t, err)
 	return secret
@@ -18,10 +18,11 @@ func GetSecret(t *testing.T, options *KubectlOptions, secretName string) *corev1
 
 // GetSecret returns a Kubernetes secret resource in the provided namespace with the given name. The namespace used
 // is the one provided in the KubectlOptions.
-func GetSecretE(t *testing.T, options *KubectlOptions, secretName string) (*corev1.Secret, error) {
+func GetSecretE(t testing.TB, options *KubectlOptions, secretName string) (*corev1.Secret, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
 	}
-	return clientset.CoreV1().Secrets(options.Namespace).Get(secretName, metav1.GetOptions{})
+	secret, err := clientset.CoreV1().Secrets(options.Namespace).Get(secretName, metav1.GetOptions{})
+	return secret, wrapKubernetesError(err, "Secret", secretName, options.Namespace)
 }
@@ -6,13 +6,11 @@ import (
 	"github.com/gruntwork-io/gruntwork-cli/errors"
 	"github.com/stretchr/testify/require"
 	authv1 "k8s.io/api/authorization/v1"
-
-	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 // CanIDo returns whether or not the provided action is allowed by the client configured by the provided kubectl option.
 // This will fail if there are any errors accessing the kubernetes API (but not if the action is denied).
-func CanIDo(t *testing.T, options *KubectlOptions, action authv1.ResourceAttributes) bool {
+func CanIDo(t testing.TB, options *KubectlOptions, action authv1.ResourceAttributes) bool {
 	allowed, err := CanIDoE(t, options, action)
 	require.NoError(t, err)
 	return allowed
@@ -20,7 +18,7 @@ func CanIDo(t *testing.T, options *KubectlOptions, action authv1.ResourceAttribu
 
 // CanIDoE returns whether or not the provided action is allowed by the client configured by the provided kubectl option.
 // This will an error if there are problems accessing the kubernetes API (but not if the action is simply denied).
-func CanIDoE(t *testing.T, options *KubectlOptions, action authv1.ResourceAttributes) (bool, error) {
+func CanIDoE(t testing.TB, options *KubectlOptions, action authv1.ResourceAttributes) (bool, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return false, err
@@ -33,7 +31,7 @@ func CanIDoE(t *testing.T, options *KubectlOptions, action authv1.ResourceAttrib
 		return false, errors.WithStackTrace(err)
 	}
 	if !resp.Status.Allowed {
-		logger.Logf(t, "Denied action %s on resource %s with name '%s' for reason %s", action.Verb, action.Resource, action.Name, resp.Status.Reason)
+		options.Logger.Logf(t, "Denied action %s on resource %s with name '%s' for reason %s", action.Verb, action.Resource, action.Name, resp.Status.Reason)
 	}
 	return resp.Status.Allowed, nil
 }
@@ -10,23 +10,24 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/gruntwork-io/terratest/modules/aws"
-	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/timing"
 )
 
 // ListServices will look for services in the given namespace that match the given filters and return them. This will
 // fail the test if there is an error.
-func ListServices(t *testing.T, options *KubectlOptions, filters metav1.ListOptions) []corev1.Service {
+func ListServices(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) []corev1.Service {
 	service, err := ListServicesE(t, options, filters)
 	require.NoError(t, err)
 	return service
 }
 
 // ListServicesE will look for services in the given namespace that match the given filters and return them.
-func ListServicesE(t *testing.T, options *KubectlOptions, filters metav1.ListOptions) ([]corev1.Service, error) {
+func ListServicesE(t testing.TB, options *KubectlOptions, filters metav1.ListOptions) ([]corev1.Service, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
@@ -40,25 +41,36 @@ func ListServicesE(t *testing.T, options *KubectlOptions, filters metav1.ListOpt
 
 // GetService returns a Kubernetes service resource in the provided namespace with the given name. This will
 // fail the test if there is an error.
-func GetService(t *testing.T, options *KubectlOptions, serviceName string) *corev1.Service {
+func GetService(t testing.TB, options *KubectlOptions, serviceName string) *corev1.Service {
 	service, err := GetServiceE(t, options, serviceName)
 	require.NoError(t, err)
 	return service
 }
 
 // GetServiceE returns a Kubernetes service resource in the provided namespace with the given name.
-func GetServiceE(t *testing.T, options *KubectlOptions, serviceName string) (*corev1.Service, error) {
+func GetServiceE(t testing.TB, options *KubectlOptions, serviceName string) (*corev1.Service, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
 	}
-	return clientset.CoreV1().Services(options.Namespace).Get(serviceName, metav1.GetOptions{})
+	service, err := clientset.CoreV1().Services(options.Namespace).Get(serviceName, metav1.GetOptions{})
+	return service, wrapKubernetesError(err, "Service", serviceName, options.Namespace)
 }
 
-// WaitUntilServiceAvailable waits until the service endpoint is ready to accept traffic.
-func WaitUntilServiceAvailable(t *testing.T, options *KubectlOptions, serviceName string, retries int, sleepBetweenRetries time.Duration) {
+// WaitUntilServiceAvailable waits until the service endpoint is ready to accept traffic, retrying the check for the
+// specified amount of times, sleeping for the provided duration between each try. This will fail the test if there is
+// an error or if the check times out.
+func WaitUntilServiceAvailable(t testing.TB, options *KubectlOptions, serviceName string, retries int, sleepBetweenRetries time.Duration) {
+	require.NoError(t, WaitUntilServiceAvailableE(t, options, serviceName, retries, sleepBetweenRetries))
+}
+
+// WaitUntilServiceAvailableE waits until the service endpoint is ready to accept traffic, retrying the check for the
+// specified amount of times, sleeping for the provided duration between each try.
+func WaitUntilServiceAvailableE(t testing.TB, options *KubectlOptions, serviceName string, retries int, sleepBetweenRetries time.Duration) (err error) {
+	defer timing.RecordSince(t, "k8s.WaitUntilServiceAvailable", time.Now(), &err)
+
 	statusMsg := fmt.Sprintf("Wait for service %s to be provisioned.", serviceName)
-	message := retry.DoWithRetry(
+	message, err := retry.DoWithRetryE(
 		t,
 		statusMsg,
 		retries,
@@ -74,7 +86,12 @@ func WaitUntilServiceAvailable(t *testing.T, options *KubectlOptions, serviceNam
 			return "Service is now available", nil
 		},
 	)
-	logger.Logf(t, message)
+	if err != nil {
+		options.Logger.Logf(t, "Timedout waiting for Service to be provisioned: %s", err)
+		return err
+	}
+	options.Logger.Logf(t, message)
+	return nil
 }
 
 // IsServiceAvailable returns true if the service endpoint is ready to accept traffic.
@@ -92,19 +109,20 @@ func IsServiceAvailable(service *corev1.Service) bool {
 
 // GetServiceEndpoint will return the service access point. If the service endpoint is not ready, will fail the test
 // immediately.
-func GetServiceEndpoint(t *testing.T, options *KubectlOptions, service *corev1.Service, servicePort int) string {
+func GetServiceEndpoint(t testing.TB, options *KubectlOptions, service *corev1.Service, servicePort int) string {
 	endpoint, err := GetServiceEndpointE(t, options, service, servicePort)
 	require.NoError(t, err)
 	return endpoint
 }
 
 // GetServiceEndpointE will return the service access point using the following logic:
-// - For ClusterIP service type, return the URL that maps to ClusterIP and Service Port
+// - For ClusterIP service type, return the URL that maps to ClusterIP and Service Port. Note that this is only
+//   reachable from within the cluster. Use OpenServiceEndpointE to reach it from the test.
 // - For NodePort service type, identify the public IP of the node (if it exists, otherwise return the bound hostname),
 //   and the assigned node port for the provided service port, and return the URL that maps to node ip and node port.
-// - For LoadBalancer service type, return the publicly accessible hostname of the load balancer.
+// - For LoadBalancer service type, return the publicly accessible hostname (or IP) of the load balancer.
 // - All other service types are not supported.
-func GetServiceEndpointE(t *testing.T, options *KubectlOptions, service *corev1.Service, servicePort int) (string, error) {
+func GetServiceEndpointE(t testing.TB, options *KubectlOptions, service *corev1.Service, servicePort int) (string, error) {
 	switch service.Spec.Type {
 	case corev1.ServiceTypeClusterIP:
 		// ClusterIP service type will map directly to service port
@@ -117,16 +135,116 @@ func GetServiceEndpointE(t *testing.T, options *KubectlOptions, service *corev1.
 			return "", NewServiceNotAvailableError(service)
 		}
 		// Load Balancer service type will map directly to service port
-		return fmt.Sprintf("%s:%d", ingress[0].Hostname, servicePort), nil
+		return fmt.Sprintf("%s:%d", getLoadBalancerIngressAddress(ingress[0]), servicePort), nil
 	default:
 		return "", NewUnknownServiceTypeError(service)
 	}
 }
 
+// getLoadBalancerIngressAddress returns the address of the given load balancer ingress point. Some providers (e.g.
+// AWS) expose load balancers by hostname, while others (e.g. GCP) only set the IP.
+func getLoadBalancerIngressAddress(ingress corev1.LoadBalancerIngress) string {
+	if ingress.Hostname != "" {
+		return ingress.Hostname
+	}
+	return ingress.IP
+}
+
+// ServiceEndpoint is an address from which a Service can be reached outside the Kubernetes cluster. For ClusterIP
+// Services, it is backed by a port forwarding tunnel that must be closed once the test is done with it.
+type ServiceEndpoint struct {
+	Endpoint string
+	tunnel   *Tunnel
+}
+
+// Close closes the port forwarding tunnel backing the endpoint, if any.
+func (endpoint *ServiceEndpoint) Close() {
+	if endpoint.tunnel != nil {
+		endpoint.tunnel.Close()
+	}
+}
+
+// OpenServiceEndpoint returns an endpoint from which the given service port can be reached outside the Kubernetes
+// cluster, whatever the service type. You should defer a call to Close on the returned ServiceEndpoint. This will fail
+// the test if there is an error.
+func OpenServiceEndpoint(t testing.TB, options *KubectlOptions, service *corev1.Service, servicePort int) *ServiceEndpoint {
+	endpoint, err := OpenServiceEndpointE(t, options, service, servicePort)
+	require.NoError(t, err)
+	return endpoint
+}
+
+// OpenServiceEndpointE returns an endpoint from which the given service port can be reached outside the Kubernetes
+// cluster, whatever the service type, so the same test code works on e.g. GKE, kind, and minikube:
+// - For ClusterIP service type, open a port forwarding tunnel to a pod of the service and return the local endpoint.
+// - For NodePort and LoadBalancer service types, return the same endpoint as GetServiceEndpointE.
+// You should defer a call to Close on the returned ServiceEndpoint.
+func OpenServiceEndpointE(t testing.TB, options *KubectlOptions, service *corev1.Service, servicePort int) (*ServiceEndpoint, error) {
+	if service.Spec.Type != corev1.ServiceTypeClusterIP {
+		endpoint, err := GetServiceEndpointE(t, options, service, servicePort)
+		if err != nil {
+			return nil, err
+		}
+		return &ServiceEndpoint{Endpoint: endpoint}, nil
+	}
+
+	tunnel := NewTunnel(options, ResourceTypeService, service.Name, 0, 0)
+	podName, err := tunnel.getAttachablePodForResourceE(t)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := GetPodE(t, options, podName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tunnel connects straight to the pod, so it has to target the container port the service port maps to
+	tunnel.remotePort, err = findTargetPortE(service, pod, int32(servicePort))
+	if err != nil {
+		return nil, err
+	}
+	if err := tunnel.ForwardPortE(t); err != nil {
+		return nil, err
+	}
+
+	return &ServiceEndpoint{Endpoint: tunnel.Endpoint(), tunnel: tunnel}, nil
+}
+
+// findTargetPortE returns the container port on the given pod that the given service port routes traffic to.
+func findTargetPortE(service *corev1.Service, pod *corev1.Pod, servicePort int32) (int, error) {
+	for _, port := range service.Spec.Ports {
+		if port.Port != servicePort {
+			continue
+		}
+
+		switch {
+		case port.TargetPort.Type == intstr.String:
+			return findNamedContainerPortE(pod, port.TargetPort.StrVal)
+		case port.TargetPort.IntVal != 0:
+			return int(port.TargetPort.IntVal), nil
+		default:
+			// An unset target port defaults to the service port
+			return int(port.Port), nil
+		}
+	}
+	return -1, NewUnknownServicePortError(service, servicePort)
+}
+
+// findNamedContainerPortE returns the number of the container port with the given name on the given pod.
+func findNamedContainerPortE(pod *corev1.Pod, portName string) (int, error) {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == portName {
+				return int(port.ContainerPort), nil
+			}
+		}
+	}
+	return -1, NewUnknownContainerPortError(pod, portName)
+}
+
 // Extracts a endpoint that can be reached outside the kubernetes cluster. NodePort type needs to find the right
 // allocated node port mapped to the service port, as well as find out the externally reachable ip (if available).
 func findEndpointForNodePortService(
-	t *testing.T,
+	t testing.TB,
 	options *KubectlOptions,
 	service *corev1.Service,
 	servicePort int32,
@@ -157,7 +275,7 @@ func findNodePortE(service *corev1.Service, servicePort int32) (int32, error) {
 }
 
 // pickRandomNode will pick a random node in the kubernetes cluster
-func pickRandomNodeE(t *testing.T, options *KubectlOptions) (corev1.Node, error) {
+func pickRandomNodeE(t testing.TB, options *KubectlOptions) (corev1.Node, error) {
 	nodes, err := GetNodesE(t, options)
 	if err != nil {
 		return corev1.Node{}, err
@@ -170,7 +288,7 @@ func pickRandomNodeE(t *testing.T, options *KubectlOptions) (corev1.Node, error)
 }
 
 // Given a node, return the ip address, preferring the external IP
-func findNodeHostnameE(t *testing.T, node corev1.Node) (string, error) {
+func findNodeHostnameE(t testing.TB, node corev1.Node) (string, error) {
 	nodeIDUri, err := url.Parse(node.Spec.ProviderID)
 	if err != nil {
 		return "", err
@@ -186,7 +304,7 @@ func findNodeHostnameE(t *testing.T, node corev1.Node) (string, error) {
 // findAwsNodeHostname will return the public ip of the node, assuming the node is an AWS EC2 instance.
 // If the instance does not have a public IP, will return the internal hostname as recorded on the Kubernetes node
 // object.
-func findAwsNodeHostnameE(t *testing.T, node corev1.Node, awsIDUri *url.URL) (string, error) {
+func findAwsNodeHostnameE(t testing.TB, node corev1.Node, awsIDUri *url.URL) (string, error) {
 	// Path is /AVAILABILITY_ZONE/INSTANCE_ID
 	parts := strings.Split(awsIDUri.Path, "/")
 	if len(parts) != 3 {
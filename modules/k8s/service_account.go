@@ -12,13 +12,13 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 
-	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/retry"
 )
 
 // GetServiceAccount returns a Kubernetes service account resource in the provided namespace with the given name. The
 // namespace used is the one provided in the KubectlOptions. This will fail the test if there is an error.
-func GetServiceAccount(t *testing.T, options *KubectlOptions, serviceAccountName string) *corev1.ServiceAccount {
+func GetServiceAccount(t testing.TB, options *KubectlOptions, serviceAccountName string) *corev1.ServiceAccount {
 	serviceAccount, err := GetServiceAccountE(t, options, serviceAccountName)
 	require.NoError(t, err)
 	return serviceAccount
@@ -26,7 +26,7 @@ func GetServiceAccount(t *testing.T, options *KubectlOptions, serviceAccountName
 
 // GetServiceAccount returns a Kubernetes service account resource in the provided namespace with the given name. The
 // namespace used is the one provided in the KubectlOptions.
-func GetServiceAccountE(t *testing.T, options *KubectlOptions, serviceAccountName string) (*corev1.ServiceAccount, error) {
+func GetServiceAccountE(t testing.TB, options *KubectlOptions, serviceAccountName string) (*corev1.ServiceAccount, error) {
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return nil, err
@@ -36,13 +36,17 @@ func GetServiceAccountE(t *testing.T, options *KubectlOptions, serviceAccountNam
 
 // CreateServiceAccount will create a new service account resource in the provided namespace with the given name. The
 // namespace used is the one provided in the KubectlOptions. This will fail the test if there is an error.
-func CreateServiceAccount(t *testing.T, options *KubectlOptions, serviceAccountName string) {
+func CreateServiceAccount(t testing.TB, options *KubectlOptions, serviceAccountName string) {
 	require.NoError(t, CreateServiceAccountE(t, options, serviceAccountName))
 }
 
 // CreateServiceAccountE will create a new service account resource in the provided namespace with the given name. The
 // namespace used is the one provided in the KubectlOptions.
-func CreateServiceAccountE(t *testing.T, options *KubectlOptions, serviceAccountName string) error {
+func CreateServiceAccountE(t testing.TB, options *KubectlOptions, serviceAccountName string) error {
+	if dryrun.Skip(t, "create ServiceAccount %s", serviceAccountName) {
+		return nil
+	}
+
 	clientset, err := GetKubernetesClientFromOptionsE(t, options)
 	if err != nil {
 		return err
@@ -60,7 +64,7 @@ func CreateServiceAccountE(t *testing.T, options *KubectlOptions, serviceAccount
 
 // GetServiceAccountWithAuthTokenE will retrieve the ServiceAccount token from the cluster so it can be used to
 // authenticate requests as that ServiceAccount. This will fail the test if there is an error.
-func GetServiceAccountAuthToken(t *testing.T, kubectlOptions *KubectlOptions, serviceAccountName string) string {
+func GetServiceAccountAuthToken(t testing.TB, kubectlOptions *KubectlOptions, serviceAccountName string) string {
 	token, err := GetServiceAccountAuthTokenE(t, kubectlOptions, serviceAccountName)
 	require.NoError(t, err)
 	return token
@@ -68,7 +72,7 @@ func GetServiceAccountAuthToken(t *testing.T, kubectlOptions *KubectlOptions, se
 
 // GetServiceAccountWithAuthTokenE will retrieve the ServiceAccount token from the cluster so it can be used to
 // authenticate requests as that ServiceAccount.
-func GetServiceAccountAuthTokenE(t *testing.T, kubectlOptions *KubectlOptions, serviceAccountName string) (string, error) {
+func GetServiceAccountAuthTokenE(t testing.TB, kubectlOptions *KubectlOptions, serviceAccountName string) (string, error) {
 	// Wait for the TokenController to provision a ServiceAccount token
 	msg, err := retry.DoWithRetryE(
 		t,
@@ -76,11 +80,11 @@ func GetServiceAccountAuthTokenE(t *testing.T, kubectlOptions *KubectlOptions, s
 		30,
 		10*time.Second,
 		func() (string, error) {
-			logger.Logf(t, "Checking if service account has secret")
+			kubectlOptions.Logger.Logf(t, "Checking if service account has secret")
 			serviceAccount := GetServiceAccount(t, kubectlOptions, serviceAccountName)
 			if len(serviceAccount.Secrets) == 0 {
 				msg := "No secrets on the service account yet"
-				logger.Logf(t, msg)
+				kubectlOptions.Logger.Logf(t, msg)
 				return "", fmt.Errorf(msg)
 			}
 			return "Service Account has secret", nil
@@ -89,7 +93,7 @@ func GetServiceAccountAuthTokenE(t *testing.T, kubectlOptions *KubectlOptions, s
 	if err != nil {
 		return "", err
 	}
-	logger.Logf(t, msg)
+	kubectlOptions.Logger.Logf(t, msg)
 
 	// Then get the service account token
 	serviceAccount, err := GetServiceAccountE(t, kubectlOptions, serviceAccountName)
@@ -106,7 +110,7 @@ func GetServiceAccountAuthTokenE(t *testing.T, kubectlOptions *KubectlOptions, s
 // AddConfigContextForServiceAccountE will add a new config context that binds the ServiceAccount auth token to the
 // Kubernetes cluster of the current config context.
 func AddConfigContextForServiceAccountE(
-	t *testing.T,
+	t testing.TB,
 	kubectlOptions *KubectlOptions,
 	contextName string,
 	serviceAccountName string,
@@ -15,7 +15,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	http_helper "github.com/gruntwork-io/terratest/modules/http-helper"
 	"github.com/gruntwork-io/terratest/modules/random"
@@ -91,6 +93,7 @@ func TestGetServiceEndpointEReturnsAccessibleEndpointForNodePort(t *testing.T) {
 	http_helper.HttpGetWithRetryWithCustomValidation(
 		t,
 		fmt.Sprintf("http://%s", endpoint),
+		nil,
 		30,
 		10*time.Second,
 		func(statusCode int, body string) bool {
@@ -99,6 +102,90 @@ func TestGetServiceEndpointEReturnsAccessibleEndpointForNodePort(t *testing.T) {
 	)
 }
 
+func TestOpenServiceEndpointEReturnsAccessibleEndpointForClusterIP(t *testing.T) {
+	t.Parallel()
+
+	uniqueID := strings.ToLower(random.UniqueId())
+	options := NewKubectlOptions("", "")
+	options.Namespace = uniqueID
+	configData := fmt.Sprintf(EXAMPLE_DEPLOYMENT_YAML_TEMPLATE, uniqueID, uniqueID, uniqueID)
+	configData = strings.Replace(configData, "type: NodePort", "type: ClusterIP", 1)
+	KubectlApplyFromString(t, options, configData)
+	defer KubectlDeleteFromString(t, options, configData)
+
+	WaitUntilNumPodsCreated(t, options, metav1.ListOptions{LabelSelector: "app=nginx"}, 1, 60, 1*time.Second)
+	service := GetService(t, options, "nginx-service")
+	WaitUntilPodAvailable(t, options, ListPods(t, options, metav1.ListOptions{LabelSelector: "app=nginx"})[0].Name, 60, 1*time.Second)
+
+	endpoint := OpenServiceEndpoint(t, options, service, 80)
+	defer endpoint.Close()
+
+	http_helper.HttpGetWithRetryWithCustomValidation(
+		t,
+		fmt.Sprintf("http://%s", endpoint.Endpoint),
+		nil,
+		30,
+		10*time.Second,
+		func(statusCode int, body string) bool {
+			return statusCode == 200
+		},
+	)
+}
+
+func TestFindTargetPort(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "nginx", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}}},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		targetPort   intstr.IntOrString
+		servicePort  int32
+		expectedPort int
+		expectError  bool
+	}{
+		{"numeric target port", intstr.FromInt(8080), 80, 8080, false},
+		{"unset target port", intstr.IntOrString{}, 80, 80, false},
+		{"named target port", intstr.FromString("http"), 80, 8080, false},
+		{"unknown named target port", intstr.FromString("metrics"), 80, -1, true},
+		{"unknown service port", intstr.FromInt(8080), 443, -1, true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "nginx-service"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80, TargetPort: testCase.targetPort}}},
+			}
+			port, err := findTargetPortE(service, pod, testCase.servicePort)
+			if testCase.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.expectedPort, port)
+		})
+	}
+}
+
+func TestGetLoadBalancerIngressAddressPrefersHostname(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "lb.example.com", getLoadBalancerIngressAddress(corev1.LoadBalancerIngress{Hostname: "lb.example.com", IP: "1.2.3.4"}))
+	require.Equal(t, "1.2.3.4", getLoadBalancerIngressAddress(corev1.LoadBalancerIngress{IP: "1.2.3.4"}))
+}
+
 const EXAMPLE_DEPLOYMENT_YAML_TEMPLATE = `---
 apiVersion: v1
 kind: Namespace
@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/gruntwork-cli/errors"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authorization/v1"
+)
+
+// CanSubjectDo returns whether or not the provided action is allowed for the given user, as a member of the given
+// groups. Unlike CanIDo, this checks the access of an arbitrary subject, so the client configured by the provided
+// kubectl options must be allowed to create SubjectAccessReviews. This will fail if there are any errors accessing the
+// kubernetes API (but not if the action is denied).
+func CanSubjectDo(t testing.TB, options *KubectlOptions, user string, groups []string, action authv1.ResourceAttributes) bool {
+	allowed, err := CanSubjectDoE(t, options, user, groups, action)
+	require.NoError(t, err)
+	return allowed
+}
+
+// CanSubjectDoE returns whether or not the provided action is allowed for the given user, as a member of the given
+// groups. Unlike CanIDoE, this checks the access of an arbitrary subject, so the client configured by the provided
+// kubectl options must be allowed to create SubjectAccessReviews. This will return an error if there are problems
+// accessing the kubernetes API (but not if the action is simply denied).
+func CanSubjectDoE(t testing.TB, options *KubectlOptions, user string, groups []string, action authv1.ResourceAttributes) (bool, error) {
+	clientset, err := GetKubernetesClientFromOptionsE(t, options)
+	if err != nil {
+		return false, err
+	}
+	check := authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &action,
+			User:               user,
+			Groups:             groups,
+		},
+	}
+	resp, err := clientset.AuthorizationV1().SubjectAccessReviews().Create(&check)
+	if err != nil {
+		return false, errors.WithStackTrace(err)
+	}
+	if !resp.Status.Allowed {
+		options.Logger.Logf(t, "Denied action %s on resource %s with name '%s' for %s for reason %s", action.Verb, action.Resource, action.Name, user, resp.Status.Reason)
+	}
+	return resp.Status.Allowed, nil
+}
+
+// CanServiceAccountDo returns whether or not the provided action is allowed for the ServiceAccount with the given name
+// in the given namespace. This is useful to verify the RBAC rules that are bound to the ServiceAccounts of an
+// application. This will fail if there are any errors accessing the kubernetes API (but not if the action is denied).
+func CanServiceAccountDo(t testing.TB, options *KubectlOptions, namespace string, serviceAccountName string, action authv1.ResourceAttributes) bool {
+	allowed, err := CanServiceAccountDoE(t, options, namespace, serviceAccountName, action)
+	require.NoError(t, err)
+	return allowed
+}
+
+// CanServiceAccountDoE returns whether or not the provided action is allowed for the ServiceAccount with the given
+// name in the given namespace. This is useful to verify the RBAC rules that are bound to the ServiceAccounts of an
+// application. This will return an error if there are problems accessing the kubernetes API (but not if the action is
+// simply denied).
+func CanServiceAccountDoE(t testing.TB, options *KubectlOptions, namespace string, serviceAccountName string, action authv1.ResourceAttributes) (bool, error) {
+	user, groups := getServiceAccountSubject(namespace, serviceAccountName)
+	return CanSubjectDoE(t, options, user, groups, action)
+}
+
+// getServiceAccountSubject returns the user name and groups that Kubernetes authenticates the ServiceAccount with the
+// given name in the given namespace as.
+func getServiceAccountSubject(namespace string, serviceAccountName string) (string, []string) {
+	user := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccountName)
+	groups := []string{"system:serviceaccounts", fmt.Sprintf("system:serviceaccounts:%s", namespace), "system:authenticated"}
+	return user, groups
+}
@@ -0,0 +1,74 @@
+// +build kubeall kubernetes
+
+// NOTE: we have build tags to differentiate kubernetes tests from non-kubernetes tests. This is done because minikube
+// is heavy and can interfere with docker related tests in terratest. Specifically, many of the tests start to fail with
+// `connection refused` errors from `minikube`. To avoid overloading the system, we run the kubernetes tests and helm
+// tests separately from the others. This may not be necessary if you have a sufficiently powerful machine.  We
+// recommend at least 4 cores and 16GB of RAM if you want to run all the tests together.
+
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authv1 "k8s.io/api/authorization/v1"
+)
+
+func TestCanServiceAccountDoChecksRoleBindings(t *testing.T) {
+	t.Parallel()
+
+	options := NewKubectlOptions("", "")
+	defer UseUniqueNamespace(t, options, t.Name())()
+	CreateServiceAccount(t, options, "pod-reader")
+
+	listPods := authv1.ResourceAttributes{Namespace: options.Namespace, Verb: "list", Resource: "pods"}
+	assert.False(t, CanServiceAccountDo(t, options, options.Namespace, "pod-reader", listPods))
+
+	configData := fmt.Sprintf(EXAMPLE_POD_READER_ROLE_YAML_TEMPLATE, options.Namespace, options.Namespace, options.Namespace)
+	defer KubectlDeleteFromString(t, options, configData)
+	KubectlApplyFromString(t, options, configData)
+
+	assert.True(t, CanServiceAccountDo(t, options, options.Namespace, "pod-reader", listPods))
+
+	// The Role only grants access within its own namespace
+	listPodsInKubeSystem := authv1.ResourceAttributes{Namespace: "kube-system", Verb: "list", Resource: "pods"}
+	assert.False(t, CanServiceAccountDo(t, options, options.Namespace, "pod-reader", listPodsInKubeSystem))
+	deletePods := authv1.ResourceAttributes{Namespace: options.Namespace, Verb: "delete", Resource: "pods"}
+	assert.False(t, CanServiceAccountDo(t, options, options.Namespace, "pod-reader", deletePods))
+}
+
+func TestGetServiceAccountSubject(t *testing.T) {
+	t.Parallel()
+
+	user, groups := getServiceAccountSubject("terratest", "pod-reader")
+	assert.Equal(t, "system:serviceaccount:terratest:pod-reader", user)
+	assert.Contains(t, groups, "system:serviceaccounts:terratest")
+}
+
+const EXAMPLE_POD_READER_ROLE_YAML_TEMPLATE = `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: pod-reader
+  namespace: %s
+rules:
+- apiGroups: [""]
+  resources: ["pods"]
+  verbs: ["get", "list"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: pod-reader
+  namespace: %s
+subjects:
+- kind: ServiceAccount
+  name: pod-reader
+  namespace: %s
+roleRef:
+  kind: Role
+  name: pod-reader
+  apiGroup: rbac.authorization.k8s.io
+`
@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RenderManifestTemplate renders the given Kubernetes manifest, which is a Go template (see
+// https://golang.org/pkg/text/template/), with the provided values. This lets tests share one fixture manifest and
+// substitute the test specific bits, such as the namespace, image tag, or labels. This will fail the test if there is
+// an error.
+func RenderManifestTemplate(t testing.TB, templateData string, values interface{}) string {
+	out, err := RenderManifestTemplateE(t, templateData, values)
+	require.NoError(t, err)
+	return out
+}
+
+// RenderManifestTemplateE renders the given Kubernetes manifest, which is a Go template (see
+// https://golang.org/pkg/text/template/), with the provided values. Referencing a key that is missing from a map of
+// values is an error, so typos in the manifest don't silently render as empty strings.
+func RenderManifestTemplateE(t testing.TB, templateData string, values interface{}) (string, error) {
+	tmpl, err := template.New(t.Name()).Option("missingkey=error").Parse(templateData)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, values); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// RenderManifestTemplateFile renders the Kubernetes manifest template at the given path with the provided values. See
+// RenderManifestTemplate for details. This will fail the test if there is an error.
+func RenderManifestTemplateFile(t testing.TB, templatePath string, values interface{}) string {
+	out, err := RenderManifestTemplateFileE(t, templatePath, values)
+	require.NoError(t, err)
+	return out
+}
+
+// RenderManifestTemplateFileE renders the Kubernetes manifest template at the given path with the provided values. See
+// RenderManifestTemplateE for details.
+func RenderManifestTemplateFileE(t testing.TB, templatePath string, values interface{}) (string, error) {
+	templateData, err := ioutil.ReadFile(templatePath)
+	if err != nil {
+		return "", err
+	}
+	return RenderManifestTemplateE(t, string(templateData), values)
+}
+
+// KubectlApplyFromTemplate will render the given Kubernetes manifest template with the provided values and apply it
+// on the cluster specified by the provided kubectl options. If there are any errors, fail the test immediately.
+func KubectlApplyFromTemplate(t testing.TB, options *KubectlOptions, templateData string, values interface{}) {
+	require.NoError(t, KubectlApplyFromTemplateE(t, options, templateData, values))
+}
+
+// KubectlApplyFromTemplateE will render the given Kubernetes manifest template with the provided values and apply it
+// on the cluster specified by the provided kubectl options.
+func KubectlApplyFromTemplateE(t testing.TB, options *KubectlOptions, templateData string, values interface{}) error {
+	configData, err := RenderManifestTemplateE(t, templateData, values)
+	if err != nil {
+		return err
+	}
+	return KubectlApplyFromStringE(t, options, configData)
+}
+
+// KubectlDeleteFromTemplate will render the given Kubernetes manifest template with the provided values and delete the
+// resulting resources from the cluster specified by the provided kubectl options. If there are any errors, fail the
+// test immediately.
+func KubectlDeleteFromTemplate(t testing.TB, options *KubectlOptions, templateData string, values interface{}) {
+	require.NoError(t, KubectlDeleteFromTemplateE(t, options, templateData, values))
+}
+
+// KubectlDeleteFromTemplateE will render the given Kubernetes manifest template with the provided values and delete
+// the resulting resources from the cluster specified by the provided kubectl options.
+func KubectlDeleteFromTemplateE(t testing.TB, options *KubectlOptions, templateData string, values interface{}) error {
+	configData, err := RenderManifestTemplateE(t, templateData, values)
+	if err != nil {
+		return err
+	}
+	return KubectlDeleteFromStringE(t, options, configData)
+}
@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleManifestTemplate = `---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx-pod
+  namespace: {{ .Namespace }}
+  labels:
+{{- range $key, $value := .Labels }}
+    {{ $key }}: {{ $value }}
+{{- end }}
+spec:
+  containers:
+  - name: nginx
+    image: nginx:{{ .ImageTag }}
+`
+
+const expectedRenderedManifest = `---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx-pod
+  namespace: terratest
+  labels:
+    app: nginx
+    team: platform
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.15.7
+`
+
+func TestRenderManifestTemplate(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]interface{}{
+		"Namespace": "terratest",
+		"ImageTag":  "1.15.7",
+		"Labels":    map[string]string{"app": "nginx", "team": "platform"},
+	}
+	assert.Equal(t, expectedRenderedManifest, RenderManifestTemplate(t, exampleManifestTemplate, values))
+}
+
+func TestRenderManifestTemplateWithStruct(t *testing.T) {
+	t.Parallel()
+
+	values := struct {
+		Namespace string
+		ImageTag  string
+		Labels    map[string]string
+	}{"terratest", "1.15.7", map[string]string{"app": "nginx", "team": "platform"}}
+	assert.Equal(t, expectedRenderedManifest, RenderManifestTemplate(t, exampleManifestTemplate, values))
+}
+
+func TestRenderManifestTemplateEErrorsOnMissingValue(t *testing.T) {
+	t.Parallel()
+
+	_, err := RenderManifestTemplateE(t, exampleManifestTemplate, map[string]interface{}{"Namespace": "terratest"})
+	assert.Error(t, err)
+}
+
+func TestRenderManifestTemplateEErrorsOnInvalidTemplate(t *testing.T) {
+	t.Parallel()
+
+	_, err := RenderManifestTemplateE(t, "namespace: {{ .Namespace", map[string]string{"Namespace": "terratest"})
+	assert.Error(t, err)
+}
+
+func TestRenderManifestTemplateFile(t *testing.T) {
+	t.Parallel()
+
+	tmpfile, err := ioutil.TempFile("", "manifest-template")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	_, err = tmpfile.WriteString(exampleManifestTemplate)
+	require.NoError(t, err)
+	require.NoError(t, tmpfile.Close())
+
+	values := map[string]interface{}{
+		"Namespace": "terratest",
+		"ImageTag":  "1.15.7",
+		"Labels":    map[string]string{"app": "nginx", "team": "platform"},
+	}
+	assert.Equal(t, expectedRenderedManifest, RenderManifestTemplateFile(t, tmpfile.Name(), values))
+}
@@ -20,8 +20,6 @@ import (
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 	"k8s.io/kubernetes/pkg/kubectl/generate"
-
-	"github.com/gruntwork-io/terratest/modules/logger"
 )
 
 // Global lock to synchronize port selections
@@ -33,6 +31,7 @@ type KubeResourceType int
 const (
 	ResourceTypePod KubeResourceType = iota
 	ResourceTypeService
+	ResourceTypeDeployment
 )
 
 func (resourceType KubeResourceType) String() string {
@@ -41,6 +40,8 @@ func (resourceType KubeResourceType) String() string {
 		return "pod"
 	case ResourceTypeService:
 		return "svc"
+	case ResourceTypeDeployment:
+		return "deployment"
 	default:
 		// This should not happen
 		return "UNKNOWN_RESOURCE_TYPE"
@@ -57,6 +58,7 @@ type Tunnel struct {
 	resourceName   string
 	stopChan       chan struct{}
 	readyChan      chan struct{}
+	closeOnce      sync.Once
 }
 
 // NewTunnel will create a new Tunnel struct. Note that if you use 0 for the local port, an open port on the host system
@@ -79,26 +81,31 @@ func (tunnel *Tunnel) Endpoint() string {
 	return fmt.Sprintf("localhost:%d", tunnel.localPort)
 }
 
-// Close disconnects a tunnel connection by closing the StopChan, thereby stopping the goroutine.
+// Close disconnects a tunnel connection by closing the StopChan, thereby stopping the goroutine. It is safe to call
+// Close more than once.
 func (tunnel *Tunnel) Close() {
-	close(tunnel.stopChan)
+	tunnel.closeOnce.Do(func() {
+		close(tunnel.stopChan)
+	})
 }
 
 // getAttachablePodForResource will find a pod that can be port forwarded to given the provided resource type and return
 // the name.
-func (tunnel *Tunnel) getAttachablePodForResourceE(t *testing.T) (string, error) {
+func (tunnel *Tunnel) getAttachablePodForResourceE(t testing.TB) (string, error) {
 	switch tunnel.resourceType {
 	case ResourceTypePod:
 		return tunnel.resourceName, nil
 	case ResourceTypeService:
 		return tunnel.getAttachablePodForServiceE(t)
+	case ResourceTypeDeployment:
+		return tunnel.getAttachablePodForDeploymentE(t)
 	default:
 		return "", UnknownKubeResourceType{tunnel.resourceType}
 	}
 }
 
 // getAttachablePodForServiceE will find an active pod associated with the Service and return the pod name.
-func (tunnel *Tunnel) getAttachablePodForServiceE(t *testing.T) (string, error) {
+func (tunnel *Tunnel) getAttachablePodForServiceE(t testing.TB) (string, error) {
 	service, err := GetServiceE(t, tunnel.kubectlOptions, tunnel.resourceName)
 	if err != nil {
 		return "", err
@@ -116,15 +123,37 @@ func (tunnel *Tunnel) getAttachablePodForServiceE(t *testing.T) (string, error)
 	return "", ServiceNotAvailable{service}
 }
 
+// getAttachablePodForDeploymentE will find an active pod managed by the Deployment and return the pod name.
+func (tunnel *Tunnel) getAttachablePodForDeploymentE(t testing.TB) (string, error) {
+	deployment, err := GetDeploymentE(t, tunnel.kubectlOptions, tunnel.resourceName)
+	if err != nil {
+		return "", err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	deploymentPods, err := ListPodsE(t, tunnel.kubectlOptions, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range deploymentPods {
+		if IsPodAvailable(&pod) {
+			return pod.Name, nil
+		}
+	}
+	return "", NewDeploymentNotAvailableError(deployment)
+}
+
 // ForwardPort opens a tunnel to a kubernetes resource, as specified by the provided tunnel struct. This will fail the
 // test if there is an error attempting to open the port.
-func (tunnel *Tunnel) ForwardPort(t *testing.T) {
+func (tunnel *Tunnel) ForwardPort(t testing.TB) {
 	require.NoError(t, tunnel.ForwardPortE(t))
 }
 
 // ForwardPortE opens a tunnel to a kubernetes resource, as specified by the provided tunnel struct.
-func (tunnel *Tunnel) ForwardPortE(t *testing.T) error {
-	logger.Logf(
+func (tunnel *Tunnel) ForwardPortE(t testing.TB) error {
+	tunnel.kubectlOptions.Logger.Logf(
 		t,
 		"Creating a port forwarding tunnel for resource %s/%s routing local port %d to remote port %d",
 		tunnel.resourceType.String(),
@@ -136,27 +165,27 @@ func (tunnel *Tunnel) ForwardPortE(t *testing.T) error {
 	// Prepare a kubernetes client for the client-go library
 	clientset, err := GetKubernetesClientFromOptionsE(t, tunnel.kubectlOptions)
 	if err != nil {
-		logger.Logf(t, "Error creating a new Kubernetes client: %s", err)
+		tunnel.kubectlOptions.Logger.Logf(t, "Error creating a new Kubernetes client: %s", err)
 		return err
 	}
 	kubeConfigPath, err := tunnel.kubectlOptions.GetConfigPath(t)
 	if err != nil {
-		logger.Logf(t, "Error getting kube config path: %s", err)
+		tunnel.kubectlOptions.Logger.Logf(t, "Error getting kube config path: %s", err)
 		return err
 	}
 	config, err := LoadApiClientConfigE(kubeConfigPath, tunnel.kubectlOptions.ContextName)
 	if err != nil {
-		logger.Logf(t, "Error loading Kubernetes config: %s", err)
+		tunnel.kubectlOptions.Logger.Logf(t, "Error loading Kubernetes config: %s", err)
 		return err
 	}
 
 	// Find the pod to port forward to
 	podName, err := tunnel.getAttachablePodForResourceE(t)
 	if err != nil {
-		logger.Logf(t, "Error finding available pod: %s", err)
+		tunnel.kubectlOptions.Logger.Logf(t, "Error finding available pod: %s", err)
 		return err
 	}
-	logger.Logf(t, "Selected pod %s to open port forward to", podName)
+	tunnel.kubectlOptions.Logger.Logf(t, "Selected pod %s to open port forward to", podName)
 
 	// Build a url to the portforward endpoint
 	// example: http://localhost:8080/api/v1/namespaces/helm/pods/tiller-deploy-9itlq/portforward
@@ -169,12 +198,12 @@ func (tunnel *Tunnel) ForwardPortE(t *testing.T) error {
 		SubResource("portforward").
 		URL()
 
-	logger.Logf(t, "Using URL %s to create portforward", portForwardCreateURL)
+	tunnel.kubectlOptions.Logger.Logf(t, "Using URL %s to create portforward", portForwardCreateURL)
 
 	// Construct the spdy client required by the client-go portforward library
 	transport, upgrader, err := spdy.RoundTripperFor(config)
 	if err != nil {
-		logger.Logf(t, "Error creating http client: %s", err)
+		tunnel.kubectlOptions.Logger.Logf(t, "Error creating http client: %s", err)
 		return err
 	}
 	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", portForwardCreateURL)
@@ -186,13 +215,13 @@ func (tunnel *Tunnel) ForwardPortE(t *testing.T) error {
 	// since there is a brief moment between `GetAvailablePort` and `portforwader.ForwardPorts` where the selected port
 	// is available for selection again.
 	if tunnel.localPort == 0 {
-		logger.Log(t, "Requested local port is 0. Selecting an open port on host system")
+		tunnel.kubectlOptions.Logger.Logf(t, "Requested local port is 0. Selecting an open port on host system")
 		tunnel.localPort, err = GetAvailablePortE(t)
 		if err != nil {
-			logger.Logf(t, "Error getting available port: %s", err)
+			tunnel.kubectlOptions.Logger.Logf(t, "Error getting available port: %s", err)
 			return err
 		}
-		logger.Logf(t, "Selected port %d", tunnel.localPort)
+		tunnel.kubectlOptions.Logger.Logf(t, "Selected port %d", tunnel.localPort)
 		globalMutex.Lock()
 		defer globalMutex.Unlock()
 	}
@@ -201,7 +230,7 @@ func (tunnel *Tunnel) ForwardPortE(t *testing.T) error {
 	ports := []string{fmt.Sprintf("%d:%d", tunnel.localPort, tunnel.remotePort)}
 	portforwarder, err := portforward.New(dialer, ports, tunnel.stopChan, tunnel.readyChan, tunnel.out, tunnel.out)
 	if err != nil {
-		logger.Logf(t, "Error creating port forwarding tunnel: %s", err)
+		tunnel.kubectlOptions.Logger.Logf(t, "Error creating port forwarding tunnel: %s", err)
 		return err
 	}
 
@@ -215,10 +244,10 @@ func (tunnel *Tunnel) ForwardPortE(t *testing.T) error {
 	// Wait for an error or the tunnel to be ready
 	select {
 	case err = <-errChan:
-		logger.Logf(t, "Error starting port forwarding tunnel: %s", err)
+		tunnel.kubectlOptions.Logger.Logf(t, "Error starting port forwarding tunnel: %s", err)
 		return err
 	case <-portforwarder.Ready:
-		logger.Logf(t, "Successfully created port forwarding tunnel")
+		tunnel.kubectlOptions.Logger.Logf(t, "Successfully created port forwarding tunnel")
 		return nil
 	}
 }
@@ -226,7 +255,7 @@ func (tunnel *Tunnel) ForwardPortE(t *testing.T) error {
 // GetAvailablePort retrieves an available port on the host machine. This delegates the port selection to the golang net
 // library by starting a server and then checking the port that the server is using. This will fail the test if it could
 // not find an available port.
-func GetAvailablePort(t *testing.T) int {
+func GetAvailablePort(t testing.TB) int {
 	port, err := GetAvailablePortE(t)
 	require.NoError(t, err)
 	return port
@@ -234,7 +263,7 @@ func GetAvailablePort(t *testing.T) int {
 
 // GetAvailablePortE retrieves an available port on the host machine. This delegates the port selection to the golang net
 // library by starting a server and then checking the port that the server is using.
-func GetAvailablePortE(t *testing.T) (int, error) {
+func GetAvailablePortE(t testing.TB) (int, error) {
 	l, err := net.Listen("tcp", ":0")
 	if err != nil {
 		return 0, err
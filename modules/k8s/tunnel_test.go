@@ -38,6 +38,7 @@ func TestTunnelOpensAPortForwardTunnelToPod(t *testing.T) {
 	http_helper.HttpGetWithRetryWithCustomValidation(
 		t,
 		fmt.Sprintf("http://%s", tunnel.Endpoint()),
+		nil,
 		60,
 		5*time.Second,
 		verifyNginxWelcomePage,
@@ -65,12 +66,43 @@ func TestTunnelOpensAPortForwardTunnelToService(t *testing.T) {
 	http_helper.HttpGetWithRetryWithCustomValidation(
 		t,
 		fmt.Sprintf("http://%s", tunnel.Endpoint()),
+		nil,
 		60,
 		5*time.Second,
 		verifyNginxWelcomePage,
 	)
 }
 
+func TestTunnelOpensAPortForwardTunnelToDeployment(t *testing.T) {
+	t.Parallel()
+
+	uniqueID := strings.ToLower(random.UniqueId())
+	options := NewKubectlOptions("", "")
+	options.Namespace = uniqueID
+	configData := fmt.Sprintf(EXAMPLE_SCALED_DEPLOYMENT_YAML_TEMPLATE, uniqueID, uniqueID)
+	defer KubectlDeleteFromString(t, options, configData)
+	KubectlApplyFromString(t, options, configData)
+	WaitUntilDeploymentAvailable(t, options, "nginx-deployment", 60, 1*time.Second)
+
+	// Open a tunnel from any available port locally
+	tunnel := NewTunnel(options, ResourceTypeDeployment, "nginx-deployment", 0, 80)
+	defer tunnel.Close()
+	tunnel.ForwardPort(t)
+
+	// Try to access the nginx service on the local port, retrying until we get a good response for up to 5 minutes
+	http_helper.HttpGetWithRetryWithCustomValidation(
+		t,
+		fmt.Sprintf("http://%s", tunnel.Endpoint()),
+		nil,
+		60,
+		5*time.Second,
+		verifyNginxWelcomePage,
+	)
+
+	// Closing the tunnel again, e.g. in a deferred call, is a no-op
+	tunnel.Close()
+}
+
 func verifyNginxWelcomePage(statusCode int, body string) bool {
 	if statusCode != 200 {
 		return false
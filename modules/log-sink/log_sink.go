@@ -0,0 +1,252 @@
+// Package log_sink contains temporary log endpoints for checking that logging agents (e.g. rsyslog, fluentd,
+// fluent-bit) configured by the infrastructure under test actually forward logs.
+package log_sink
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+)
+
+// Entry is a single log entry received by a Sink.
+type Entry struct {
+	Protocol   string    // The protocol the entry was received over: syslog-udp, syslog-tcp, or http
+	Source     string    // The address of the client that sent the entry
+	Message    string    // The raw entry, e.g. a syslog line or a JSON document
+	ReceivedAt time.Time // The time the entry was received
+}
+
+// Sink is a temporary log endpoint that keeps every entry it receives in memory.
+type Sink struct {
+	port    int
+	closers []io.Closer
+	mutex   sync.Mutex
+	entries []Entry
+}
+
+// RunSyslogSink runs a syslog sink that accepts entries over both UDP and TCP on the given port. Set the port to 0 to
+// pick a random free port, which you can then look up with the Port() method. Make sure to call the Close() method
+// when you're done!
+func RunSyslogSink(t testing.TB, port int) *Sink {
+	sink, err := RunSyslogSinkE(t, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sink
+}
+
+// RunSyslogSinkE runs a syslog sink that accepts entries over both UDP and TCP on the given port. Set the port to 0 to
+// pick a random free port, which you can then look up with the Port() method. Make sure to call the Close() method
+// when you're done!
+func RunSyslogSinkE(t testing.TB, port int) (*Sink, error) {
+	tcpListener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("error listening on TCP: %s", err)
+	}
+
+	// Use the same port for UDP as for TCP, which matters when a random port was picked
+	port = tcpListener.Addr().(*net.TCPAddr).Port
+
+	udpConn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		tcpListener.Close()
+		return nil, fmt.Errorf("error listening on UDP: %s", err)
+	}
+
+	sink := &Sink{port: port, closers: []io.Closer{tcpListener, udpConn}}
+	logger.Logf(t, "Started syslog sink on port %d (TCP and UDP)", port)
+
+	go sink.serveSyslogTcp(tcpListener)
+	go sink.serveSyslogUdp(udpConn)
+
+	return sink, nil
+}
+
+// RunHttpSink runs a sink that accepts entries as the bodies of HTTP requests to any path on the given port, as sent
+// by e.g. the fluentd and fluent-bit http outputs. A body with several newline-separated lines (e.g. JSON lines) is
+// split into one entry per line. Set the port to 0 to pick a random free port, which you can then look up with the
+// Port() method. Make sure to call the Close() method when you're done!
+func RunHttpSink(t testing.TB, port int) *Sink {
+	sink, err := RunHttpSinkE(t, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sink
+}
+
+// RunHttpSinkE runs a sink that accepts entries as the bodies of HTTP requests to any path on the given port, as sent
+// by e.g. the fluentd and fluent-bit http outputs. A body with several newline-separated lines (e.g. JSON lines) is
+// split into one entry per line. Set the port to 0 to pick a random free port, which you can then look up with the
+// Port() method. Make sure to call the Close() method when you're done!
+func RunHttpSinkE(t testing.TB, port int) (*Sink, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("error listening: %s", err)
+	}
+
+	sink := &Sink{port: listener.Addr().(*net.TCPAddr).Port, closers: []io.Closer{listener}}
+	logger.Logf(t, "Started HTTP log sink on port %d", sink.port)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, line := range strings.Split(string(body), "\n") {
+			if strings.TrimSpace(line) != "" {
+				sink.record("http", r.RemoteAddr, line)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	go http.Serve(listener, handler)
+
+	return sink, nil
+}
+
+// Port returns the port the sink is listening on.
+func (sink *Sink) Port() int {
+	return sink.port
+}
+
+// Close stops the sink.
+func (sink *Sink) Close() error {
+	var firstErr error
+	for _, closer := range sink.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Entries returns all the entries the sink has received so far.
+func (sink *Sink) Entries() []Entry {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	return append([]Entry{}, sink.entries...)
+}
+
+// WaitForEntry waits until the sink has received an entry for which the given matcher returns true, retrying up to
+// maxRetries times, and returns that entry.
+func (sink *Sink) WaitForEntry(t testing.TB, description string, maxRetries int, sleepBetweenRetries time.Duration, matcher func(Entry) bool) Entry {
+	entry, err := sink.WaitForEntryE(t, description, maxRetries, sleepBetweenRetries, matcher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entry
+}
+
+// WaitForEntryE waits until the sink has received an entry for which the given matcher returns true, retrying up to
+// maxRetries times, and returns that entry.
+func (sink *Sink) WaitForEntryE(t testing.TB, description string, maxRetries int, sleepBetweenRetries time.Duration, matcher func(Entry) bool) (Entry, error) {
+	var found Entry
+
+	_, err := retry.DoWithRetryE(t, fmt.Sprintf("Waiting for log entry: %s", description), maxRetries, sleepBetweenRetries, func() (string, error) {
+		entries := sink.Entries()
+		for _, entry := range entries {
+			if matcher(entry) {
+				found = entry
+				return "", nil
+			}
+		}
+		return "", fmt.Errorf("none of the %d log entries received so far match", len(entries))
+	})
+
+	return found, err
+}
+
+// ContainsText returns a matcher for WaitForEntry that matches entries containing the given text.
+func ContainsText(text string) func(Entry) bool {
+	return func(entry Entry) bool {
+		return strings.Contains(entry.Message, text)
+	}
+}
+
+func (sink *Sink) record(protocol string, source string, message string) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	sink.entries = append(sink.entries, Entry{Protocol: protocol, Source: source, Message: message, ReceivedAt: time.Now()})
+}
+
+func (sink *Sink) serveSyslogUdp(conn net.PacketConn) {
+	buffer := make([]byte, 65536)
+	for {
+		n, addr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			// The connection was closed
+			return
+		}
+		sink.record("syslog-udp", addr.String(), strings.TrimRight(string(buffer[:n]), "\r\n"))
+	}
+}
+
+func (sink *Sink) serveSyslogTcp(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// The listener was closed
+			return
+		}
+
+		go func(conn net.Conn) {
+			defer conn.Close()
+
+			reader := bufio.NewReader(conn)
+			for {
+				message, err := readSyslogFrame(reader)
+				if err != nil {
+					return
+				}
+				if message != "" {
+					sink.record("syslog-tcp", conn.RemoteAddr().String(), message)
+				}
+			}
+		}(conn)
+	}
+}
+
+// readSyslogFrame reads a single syslog message from a TCP stream. Messages are framed either with octet counting
+// (e.g. "12 <13>hi there"), per RFC 6587 section 3.4.1, or by a trailing newline, per section 3.4.2.
+func readSyslogFrame(reader *bufio.Reader) (string, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return "", err
+	}
+
+	if first[0] >= '1' && first[0] <= '9' {
+		lengthStr, err := reader.ReadString(' ')
+		if err != nil {
+			return "", err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(lengthStr))
+		if err != nil {
+			return "", fmt.Errorf("invalid syslog frame length %q: %v", lengthStr, err)
+		}
+		message := make([]byte, length)
+		if _, err := io.ReadFull(reader, message); err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(message), "\r\n"), nil
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,70 @@
+package log_sink
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogSink(t *testing.T) {
+	t.Parallel()
+
+	sink := RunSyslogSink(t, 0)
+	defer sink.Close()
+
+	udpConn, err := net.Dial("udp", fmt.Sprintf("localhost:%d", sink.Port()))
+	require.NoError(t, err)
+	defer udpConn.Close()
+	fmt.Fprint(udpConn, "<13>Jan  1 00:00:00 web-1 app: hello over udp")
+
+	tcpConn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", sink.Port()))
+	require.NoError(t, err)
+	defer tcpConn.Close()
+	fmt.Fprint(tcpConn, "<13>web-1 app: hello over tcp\n")
+
+	udpEntry := sink.WaitForEntry(t, "udp entry", 10, 100*time.Millisecond, ContainsText("hello over udp"))
+	assert.Equal(t, "syslog-udp", udpEntry.Protocol)
+
+	tcpEntry := sink.WaitForEntry(t, "tcp entry", 10, 100*time.Millisecond, ContainsText("hello over tcp"))
+	assert.Equal(t, "syslog-tcp", tcpEntry.Protocol)
+	assert.Equal(t, "<13>web-1 app: hello over tcp", tcpEntry.Message)
+}
+
+func TestHttpSink(t *testing.T) {
+	t.Parallel()
+
+	sink := RunHttpSink(t, 0)
+	defer sink.Close()
+
+	body := "{\"log\": \"first\"}\n{\"log\": \"second\"}\n"
+	response, err := http.Post(fmt.Sprintf("http://localhost:%d/logs", sink.Port()), "application/x-ndjson", strings.NewReader(body))
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	entries := sink.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, `{"log": "first"}`, entries[0].Message)
+	assert.Equal(t, `{"log": "second"}`, entries[1].Message)
+}
+
+func TestReadSyslogFrame(t *testing.T) {
+	t.Parallel()
+
+	reader := bufio.NewReader(strings.NewReader("12 <13>hi there<13>newline framed\n"))
+
+	message, err := readSyslogFrame(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "<13>hi there", message)
+
+	message, err = readSyslogFrame(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "<13>newline framed", message)
+}
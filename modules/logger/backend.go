@@ -0,0 +1,201 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Level is the severity of a log message. A Logger only writes messages at or above its minimum Level.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (level Level) String() string {
+	switch level {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARNING"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("Level(%d)", int(level))
+	}
+}
+
+// TestLogger is a backend that a Logger writes its messages to.
+type TestLogger interface {
+	Logf(t testing.TB, format string, args ...interface{})
+}
+
+// Entry is a single log message, with its level and the fields of the Logger that logged it.
+type Entry struct {
+	Level   Level
+	Message string
+	Fields  map[string]string
+}
+
+// EntryLogger is a TestLogger that handles the level and fields of each message itself (e.g. to write them as
+// structured data), rather than getting them formatted into the message.
+type EntryLogger interface {
+	TestLogger
+	LogEntry(t testing.TB, entry Entry)
+}
+
+// Logger writes log messages at or above its minimum Level to a TestLogger backend. A nil *Logger (e.g. the Logger field
+// of options that weren't given one) writes to Global, so modules can log through an optional *Logger without checking
+// it first.
+type Logger struct {
+	backend  TestLogger
+	minLevel Level
+	fields   map[string]string
+}
+
+// New returns a Logger that writes messages at LevelInfo and above to the given backend.
+func New(backend TestLogger) *Logger {
+	return &Logger{backend: backend, minLevel: LevelInfo}
+}
+
+var (
+	// Default writes to stdout, along with a timestamp and information about what test and file is doing the logging.
+	// See the Logf function for why this is preferable to t.Logf.
+	Default = New(terratestLogger{})
+
+	// Discard drops all messages, e.g. to silence a noisy module in a test.
+	Discard = New(discardLogger{})
+
+	// TestingT writes with t.Logf, so messages are buffered by the testing package and only shown for failing tests
+	// (or with go test -v).
+	TestingT = New(testingTLogger{})
+
+	// Global is the Logger used by the Logf and Log functions, and by any *Logger that is nil. Set it (e.g. in TestMain)
+	// to change how all modules log.
+	Global = Default
+)
+
+// WithLevel returns a copy of the Logger that only writes messages at or above the given Level.
+func (logger *Logger) WithLevel(level Level) *Logger {
+	copied := *logger.orGlobal()
+	copied.minLevel = level
+	return &copied
+}
+
+// WithFields returns a copy of the Logger that adds the given fields (e.g. region or project) to each message, so the
+// messages can be correlated with the cloud resources they are about. The fields are merged with those the Logger
+// already has.
+func (logger *Logger) WithFields(fields map[string]string) *Logger {
+	copied := *logger.orGlobal()
+	copied.fields = map[string]string{}
+	for key, value := range logger.orGlobal().fields {
+		copied.fields[key] = value
+	}
+	for key, value := range fields {
+		copied.fields[key] = value
+	}
+	return &copied
+}
+
+// Logf logs the given format and arguments, formatted using fmt.Sprintf, at LevelInfo.
+func (logger *Logger) Logf(t testing.TB, format string, args ...interface{}) {
+	logger.log(t, LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Debugf logs the given format and arguments, formatted using fmt.Sprintf, at LevelDebug.
+func (logger *Logger) Debugf(t testing.TB, format string, args ...interface{}) {
+	logger.log(t, LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Infof logs the given format and arguments, formatted using fmt.Sprintf, at LevelInfo.
+func (logger *Logger) Infof(t testing.TB, format string, args ...interface{}) {
+	logger.log(t, LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs the given format and arguments, formatted using fmt.Sprintf, at LevelWarn.
+func (logger *Logger) Warnf(t testing.TB, format string, args ...interface{}) {
+	logger.log(t, LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs the given format and arguments, formatted using fmt.Sprintf, at LevelError.
+func (logger *Logger) Errorf(t testing.TB, format string, args ...interface{}) {
+	logger.log(t, LevelError, fmt.Sprintf(format, args...))
+}
+
+// log writes the given message to the backend, if its level is at or above the minimum level, with any secrets redacted
+// (see Redact). Unless the backend is an EntryLogger, messages at levels other than LevelInfo are prefixed with their
+// level (e.g. [WARNING]), and the fields are appended to the message. This must be called directly by the exported
+// logging methods, so that the backends can find the file and line of the code that is logging.
+func (logger *Logger) log(t testing.TB, level Level, message string) {
+	logger = logger.orGlobal()
+	if level < logger.minLevel {
+		return
+	}
+	message = Redact(message)
+
+	if entryLogger, isEntryLogger := logger.backend.(EntryLogger); isEntryLogger {
+		entryLogger.LogEntry(t, Entry{Level: level, Message: message, Fields: logger.fields})
+		return
+	}
+
+	if level != LevelInfo {
+		message = fmt.Sprintf("[%s] %s", level, message)
+	}
+	if len(logger.fields) > 0 {
+		message = fmt.Sprintf("%s %s", message, formatFields(logger.fields))
+	}
+	logger.backend.Logf(t, "%s", message)
+}
+
+// formatFields formats the given fields as key=value pairs, sorted by key
+func formatFields(fields map[string]string) string {
+	keys := []string{}
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, fields[key]))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// orGlobal returns Global if the Logger is nil or has no backend (e.g. because it was loaded from JSON), and the Logger
+// itself otherwise.
+func (logger *Logger) orGlobal() *Logger {
+	if logger == nil || logger.backend == nil {
+		return Global
+	}
+	return logger
+}
+
+// terratestLogger logs with DoLog to stdout
+type terratestLogger struct{}
+
+func (terratestLogger) Logf(t testing.TB, format string, args ...interface{}) {
+	// Skip this method, Logger.log, and the exported logging method, to report the code that called the latter
+	DoLog(t, 4, os.Stdout, fmt.Sprintf(format, args...))
+}
+
+// discardLogger drops all messages
+type discardLogger struct{}
+
+func (discardLogger) Logf(t testing.TB, format string, args ...interface{}) {}
+
+// testingTLogger logs with t.Logf
+type testingTLogger struct{}
+
+func (testingTLogger) Logf(t testing.TB, format string, args ...interface{}) {
+	t.Helper()
+	t.Logf(format, args...)
+}
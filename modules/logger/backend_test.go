@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogger records the messages logged to it
+type recordingLogger struct {
+	messages []string
+}
+
+func (logger *recordingLogger) Logf(t testing.TB, format string, args ...interface{}) {
+	logger.messages = append(logger.messages, fmt.Sprintf(format, args...))
+}
+
+func TestLoggerLevels(t *testing.T) {
+	t.Parallel()
+
+	backend := &recordingLogger{}
+	logger := New(backend)
+
+	logger.Debugf(t, "debug %d", 1)
+	logger.Logf(t, "info %d", 2)
+	logger.Warnf(t, "warn %d", 3)
+	logger.WithLevel(LevelDebug).Debugf(t, "debug %d", 4)
+	logger.WithLevel(LevelError).Warnf(t, "warn %d", 5)
+	logger.Errorf(t, "error %d", 6)
+
+	assert.Equal(t, []string{"info 2", "[WARNING] warn 3", "[DEBUG] debug 4", "[ERROR] error 6"}, backend.messages)
+}
+
+func TestNilLoggerUsesGlobal(t *testing.T) {
+	t.Parallel()
+
+	var logger *Logger
+	assert.Equal(t, Global, logger.orGlobal())
+	assert.Equal(t, Global, (&Logger{}).orGlobal())
+	assert.Equal(t, Discard, Discard.orGlobal())
+
+	// Logging to a nil Logger must not panic
+	logger.Logf(t, "Logging to the global logger")
+}
+
+func TestLevelString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "DEBUG", LevelDebug.String())
+	assert.Equal(t, "WARNING", LevelWarn.String())
+	assert.Equal(t, "Level(7)", Level(7).String())
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// NewJSON returns a Logger that writes each message to the given writer as a JSON object on its own line, so that CI
+// systems can index the logs. Each object has the keys timestamp, level, test, module (the package that logged the
+// message, e.g. terraform), caller, and message, plus the fields of the Logger (see WithFields), e.g.:
+//
+//	{"caller":"command.go:55","level":"INFO","message":"Running command terraform with args [apply]","module":"shell","region":"us-east-1","test":"TestFoo","timestamp":"2019-03-01T12:00:00.123Z"}
+//
+// If a field has the same name as one of the keys above, the key takes precedence.
+func NewJSON(writer io.Writer) *Logger {
+	return New(&jsonLogger{writer: writer})
+}
+
+// jsonLogger writes log messages as JSON objects, one per line
+type jsonLogger struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+func (logger *jsonLogger) Logf(t testing.TB, format string, args ...interface{}) {
+	logger.write(t, Entry{Level: LevelInfo, Message: fmt.Sprintf(format, args...)})
+}
+
+func (logger *jsonLogger) LogEntry(t testing.TB, entry Entry) {
+	logger.write(t, entry)
+}
+
+// write writes the given entry as a JSON object. It must be called directly by Logf or LogEntry, which must be called
+// by Logger.log, so that it can find the file and line of the code that is logging.
+func (logger *jsonLogger) write(t testing.TB, entry Entry) {
+	// Skip this method, Logf or LogEntry, Logger.log, and the exported logging method
+	_, file, line, ok := runtime.Caller(4)
+	if !ok {
+		file = "???"
+		line = 1
+	}
+
+	bytes, err := json.Marshal(formatJSONRecord(t.Name(), time.Now(), file, line, entry))
+	if err != nil {
+		// The record only contains strings, so this can't happen
+		panic(err)
+	}
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	fmt.Fprintln(logger.writer, string(bytes))
+}
+
+// formatJSONRecord returns the keys and values to write for the given entry
+func formatJSONRecord(testName string, timestamp time.Time, file string, line int, entry Entry) map[string]string {
+	record := map[string]string{}
+	for key, value := range entry.Fields {
+		record[key] = value
+	}
+	record["timestamp"] = timestamp.UTC().Format(time.RFC3339Nano)
+	record["level"] = entry.Level.String()
+	record["test"] = testName
+	record["module"] = filepath.Base(filepath.Dir(file))
+	record["caller"] = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	record["message"] = entry.Message
+	return record
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLogger(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+	logger := NewJSON(&buffer).WithFields(map[string]string{"region": "us-east-1", "project": "terratest"})
+
+	logger.Logf(t, "Creating %s", "bucket")
+	logger.WithFields(map[string]string{"region": "eu-west-1"}).Warnf(t, "Bucket %s is public", "foo")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 2)
+
+	records := []map[string]string{}
+	for _, line := range lines {
+		record := map[string]string{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	assert.Equal(t, "INFO", records[0]["level"])
+	assert.Equal(t, "Creating bucket", records[0]["message"])
+	assert.Equal(t, t.Name(), records[0]["test"])
+	assert.Equal(t, "logger", records[0]["module"])
+	assert.Regexp(t, "^json_test.go:[0-9]+$", records[0]["caller"])
+	assert.Equal(t, "us-east-1", records[0]["region"])
+	assert.Equal(t, "terratest", records[0]["project"])
+	assert.NotEmpty(t, records[0]["timestamp"])
+
+	assert.Equal(t, "WARNING", records[1]["level"])
+	assert.Equal(t, "Bucket foo is public", records[1]["message"])
+	assert.Equal(t, "eu-west-1", records[1]["region"])
+	assert.Equal(t, "terratest", records[1]["project"])
+}
+
+func TestFormatJSONRecord(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	entry := Entry{Level: LevelError, Message: "Failed", Fields: map[string]string{"project": "terratest", "level": "overridden"}}
+
+	expected := map[string]string{
+		"timestamp": "2019-03-01T12:00:00Z",
+		"level":     "ERROR",
+		"test":      "TestFoo",
+		"module":    "terraform",
+		"caller":    "apply.go:12",
+		"message":   "Failed",
+		"project":   "terratest",
+	}
+	assert.Equal(t, expected, formatJSONRecord("TestFoo", timestamp, "/go/src/github.com/gruntwork-io/terratest/modules/terraform/apply.go", 12, entry))
+}
+
+func TestTextLoggerFields(t *testing.T) {
+	t.Parallel()
+
+	backend := &recordingLogger{}
+	New(backend).WithFields(map[string]string{"region": "us-east-1", "project": "terratest"}).Logf(t, "Creating bucket")
+
+	assert.Equal(t, []string{"Creating bucket project=terratest region=us-east-1"}, backend.messages)
+}
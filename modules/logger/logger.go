@@ -4,7 +4,6 @@ package logger
 import (
 	"fmt"
 	"io"
-	"os"
 	"runtime"
 	"strings"
 	"testing"
@@ -28,24 +27,26 @@ import (
 //
 // Note that there is a proposal to improve t.Logf (https://github.com/golang/go/issues/24929), but until that's
 // implemented, this method is our best bet.
-func Logf(t *testing.T, format string, args ...interface{}) {
-	DoLog(t, 2, os.Stdout, fmt.Sprintf(format, args...))
+//
+// Logf writes to the Global Logger, which defaults to stdout.
+func Logf(t testing.TB, format string, args ...interface{}) {
+	Global.log(t, LevelInfo, fmt.Sprintf(format, args...))
 }
 
 // Log logs the given arguments to stdout, along with a timestamp and information about what test and file is doing the
 // logging. This is an alternative to t.Logf that logs to stdout immediately, rather than buffering all log output and
 // only displaying it at the very end of the test. See the Logf method for more info.
-func Log(t *testing.T, args ...interface{}) {
-	DoLog(t, 2, os.Stdout, args...)
+func Log(t testing.TB, args ...interface{}) {
+	Global.log(t, LevelInfo, strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
 }
 
 // DoLog logs the given arguments to the given writer, along with a timestamp and information about what test and file is
-// doing the logging.
-func DoLog(t *testing.T, callDepth int, writer io.Writer, args ...interface{}) {
+// doing the logging. Any secrets are redacted (see Redact).
+func DoLog(t testing.TB, callDepth int, writer io.Writer, args ...interface{}) {
 	date := time.Now()
 	prefix := fmt.Sprintf("%s %s %s:", t.Name(), date.Format(time.RFC3339), CallerPrefix(callDepth+1))
 	allArgs := append([]interface{}{prefix}, args...)
-	fmt.Fprintln(writer, allArgs...)
+	fmt.Fprint(writer, Redact(fmt.Sprintln(allArgs...)))
 }
 
 // CallerPrefix returns the file and line number information about the methods that called this method, based on the current
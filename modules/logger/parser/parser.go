@@ -3,6 +3,7 @@ package parser
 
 import (
 	"bufio"
+	"encoding/json"
 	"io"
 	"os"
 	"regexp"
@@ -88,6 +89,24 @@ func isSummaryLine(text string) bool {
 	return regexSummary.MatchString(text)
 }
 
+// getTestNameFromJSONLine takes a log line written by the JSON logger of terratest (see logger.NewJSON) and extracts out
+// the test name. The second return value is false if the line is not a JSON log line with a test name.
+// Example:
+//   in:  {"level":"INFO","message":"Running command terraform","test":"TestSnafu"}
+//   out: TestSnafu, true
+func getTestNameFromJSONLine(text string) (string, bool) {
+	if !strings.HasPrefix(text, "{") {
+		return "", false
+	}
+	var record struct {
+		Test string `json:"test"`
+	}
+	if err := json.Unmarshal([]byte(text), &record); err != nil || record.Test == "" {
+		return "", false
+	}
+	return record.Test, true
+}
+
 // isPanicLine checks if a line of text matches a panic
 func isPanicLine(text string) bool {
 	return regexPanic.MatchString(text)
@@ -129,6 +148,10 @@ func parseAndStoreTestOutput(
 		} else if isStatusLine(data) {
 			testName := getTestNameFromStatusLine(data)
 			logWriter.writeLog(logger, testName, data)
+		} else if testName, isJSONLine := getTestNameFromJSONLine(data); isJSONLine {
+			// Log line written by the JSON logger, which includes the test name as a field
+			logWriter.writeLog(logger, testName, data)
+			previousTestName = testName
 		} else if strings.HasPrefix(data, "Test") {
 			// Heuristic: `go test` will only execute test functions named `Test.*`, so we assume any line prefixed
 			// with `Test` is a test output for a named test. Also assume that test output will be space delimeted and
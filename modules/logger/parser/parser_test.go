@@ -304,3 +304,54 @@ func TestIsPanicLine(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTestNameFromJSONLine(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		in     string
+		out    string
+		isJSON bool
+	}{
+		{
+			"BaseCase",
+			`{"level":"INFO","message":"Running command terraform","test":"TestSnafu","timestamp":"2019-03-01T12:00:00Z"}`,
+			"TestSnafu",
+			true,
+		},
+		{
+			"Subtest",
+			`{"message":"Creating bucket","region":"us-east-1","test":"TestSnafu/Subtest"}`,
+			"TestSnafu/Subtest",
+			true,
+		},
+		{
+			"NoTestName",
+			`{"message":"Creating bucket"}`,
+			"",
+			false,
+		},
+		{
+			"NotJSON",
+			"TestSnafu 2019-03-01T12:00:00Z logger.go:66: {not json}",
+			"",
+			false,
+		},
+		{
+			"InvalidJSON",
+			"{not json}",
+			"",
+			false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			testName, isJSON := getTestNameFromJSONLine(testCase.in)
+			assert.Equal(t, testCase.out, testName)
+			assert.Equal(t, testCase.isJSON, isJSON)
+		})
+	}
+}
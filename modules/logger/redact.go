@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces secrets in log messages
+const RedactedValue = "[REDACTED]"
+
+// defaultSecretPatterns match common credentials in log messages. Only the first capture group of each is redacted, so
+// the messages still show what the redacted value was.
+var defaultSecretPatterns = []*regexp.Regexp{
+	// Signatures of S3 and GCS signed URLs
+	regexp.MustCompile(`(?i)X-(?:Amz|Goog)-Signature=([^&\s"']+)`),
+	regexp.MustCompile(`(?i)[?&]Signature=([^&\s"']+)`),
+	// Tokens in kubeconfig files, and in JSON (e.g. kubectl config view --raw -o json)
+	regexp.MustCompile(`(?i)\b(?:token|client-key-data|password):\s+"?([^\s"]+)`),
+	regexp.MustCompile(`(?i)"(?:token|client-key-data|password)":\s*"([^"]+)"`),
+	// Bearer tokens in HTTP headers
+	regexp.MustCompile(`(?i)\bBearer\s+([A-Za-z0-9\-._~+/]+=*)`),
+	// AWS credentials in environment variables and credentials files
+	regexp.MustCompile(`(?i)\b(?:aws_secret_access_key|aws_session_token)\s*[=:]\s*"?([A-Za-z0-9/+=]+)`),
+}
+
+var (
+	redactMutex    sync.RWMutex
+	secrets        = []string{}
+	secretPatterns = defaultSecretPatterns
+)
+
+// RegisterSecret makes all loggers replace the given value with RedactedValue in every message they log from now on,
+// e.g. a password that a test generates, so it isn't leaked to CI logs. Empty values are ignored. Note that short
+// values would redact unrelated text, so only register values that are unlikely to appear elsewhere.
+func RegisterSecret(value string) {
+	if value == "" {
+		return
+	}
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+	// Copy the secrets, so that Redact can use the old slice without holding the lock
+	secrets = append(append([]string{}, secrets...), value)
+}
+
+// RegisterSecretPattern makes all loggers redact the text that matches the given regular expression in every message
+// they log from now on. If the pattern has a capture group, only the text that matches the first group is redacted,
+// e.g. the value in `api_key=(\S+)`.
+func RegisterSecretPattern(pattern *regexp.Regexp) {
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+	// Copy the patterns, so that Redact can use the old slice without holding the lock
+	secretPatterns = append(append([]*regexp.Regexp{}, secretPatterns...), pattern)
+}
+
+// Redact returns the given text with the registered secrets, and text that matches the registered secret patterns
+// (which include common credentials such as signed URL signatures and kubeconfig tokens), replaced with RedactedValue.
+// All loggers call this on each message before writing it.
+func Redact(text string) string {
+	redactMutex.RLock()
+	currentSecrets := secrets
+	currentPatterns := secretPatterns
+	redactMutex.RUnlock()
+
+	for _, secret := range currentSecrets {
+		text = strings.Replace(text, secret, RedactedValue, -1)
+	}
+	for _, pattern := range currentPatterns {
+		text = redactPattern(text, pattern)
+	}
+	return text
+}
+
+// redactPattern replaces the text that matches the first capture group of the given pattern (or the whole pattern, if
+// it has no groups) with RedactedValue.
+func redactPattern(text string, pattern *regexp.Regexp) string {
+	var result strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[0], match[1]
+		if len(match) >= 4 && match[2] >= 0 {
+			start, end = match[2], match[3]
+		}
+		result.WriteString(text[last:start])
+		result.WriteString(RedactedValue)
+		last = end
+	}
+	result.WriteString(text[last:])
+	return result.String()
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactDefaultPatterns(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		in       string
+		expected string
+	}{
+		{
+			"S3 signed URL",
+			"https://bucket.s3.amazonaws.com/key?X-Amz-Expires=300&X-Amz-Signature=0123456789abcdef&X-Amz-SignedHeaders=host",
+			"https://bucket.s3.amazonaws.com/key?X-Amz-Expires=300&X-Amz-Signature=[REDACTED]&X-Amz-SignedHeaders=host",
+		},
+		{
+			"GCS signed URL",
+			"https://storage.googleapis.com/bucket/key?Expires=1551441600&GoogleAccessId=foo&Signature=abc%2Bdef%3D",
+			"https://storage.googleapis.com/bucket/key?Expires=1551441600&GoogleAccessId=foo&Signature=[REDACTED]",
+		},
+		{"kubeconfig token", "    token: eyJhbGciOiJSUzI1NiJ9.payload.signature", "    token: [REDACTED]"},
+		{"kubeconfig JSON token", `"token": "eyJhbGciOiJSUzI1NiJ9"`, `"token": "[REDACTED]"`},
+		{"bearer token", "Authorization: Bearer abc.def-ghi", "Authorization: Bearer [REDACTED]"},
+		{"AWS secret key", "AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY", "AWS_SECRET_ACCESS_KEY=[REDACTED]"},
+		{"no secrets", "Running command terraform with args [apply]", "Running command terraform with args [apply]"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, Redact(testCase.in))
+		})
+	}
+}
+
+func TestRegisterSecret(t *testing.T) {
+	t.Parallel()
+
+	RegisterSecret("")
+	RegisterSecret("terratest-register-secret-value")
+	RegisterSecretPattern(regexp.MustCompile(`terratest_api_key=(\S+)`))
+
+	assert.Equal(t, "The password is [REDACTED].", Redact("The password is terratest-register-secret-value."))
+	assert.Equal(t, "terratest_api_key=[REDACTED] other", Redact("terratest_api_key=abc123 other"))
+
+	backend := &recordingLogger{}
+	New(backend).Logf(t, "Logging in with %s", "terratest-register-secret-value")
+	assert.Equal(t, []string{"Logging in with [REDACTED]"}, backend.messages)
+}
+
+func TestRedactPatternWithoutGroup(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "key [REDACTED] and [REDACTED]", redactPattern("key AKIA1234 and AKIA5678", regexp.MustCompile(`AKIA[0-9]+`)))
+}
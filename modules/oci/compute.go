@@ -6,13 +6,14 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/oracle/oci-go-sdk/common"
 	"github.com/oracle/oci-go-sdk/core"
 )
 
 // DeleteImage deletes a custom image with given OCID.
-func DeleteImage(t *testing.T, ocid string) {
+func DeleteImage(t testing.TB, ocid string) {
 	err := DeleteImageE(t, ocid)
 	if err != nil {
 		t.Fatal(err)
@@ -20,7 +21,11 @@ func DeleteImage(t *testing.T, ocid string) {
 }
 
 // DeleteImageE deletes a custom image with given OCID.
-func DeleteImageE(t *testing.T, ocid string) error {
+func DeleteImageE(t testing.TB, ocid string) error {
+	if dryrun.Skip(t, "delete Image %s", ocid) {
+		return nil
+	}
+
 	logger.Logf(t, "Deleting image with OCID %s", ocid)
 
 	configProvider := common.DefaultConfigProvider()
@@ -35,7 +40,7 @@ func DeleteImageE(t *testing.T, ocid string) error {
 }
 
 // GetMostRecentImageID gets the OCID of the most recent image in the given compartment that has the given OS name and version.
-func GetMostRecentImageID(t *testing.T, compartmentID string, osName string, osVersion string) string {
+func GetMostRecentImageID(t testing.TB, compartmentID string, osName string, osVersion string) string {
 	ocid, err := GetMostRecentImageIDE(t, compartmentID, osName, osVersion)
 	if err != nil {
 		t.Fatal(err)
@@ -44,7 +49,7 @@ func GetMostRecentImageID(t *testing.T, compartmentID string, osName string, osV
 }
 
 // GetMostRecentImageIDE gets the OCID of the most recent image in the given compartment that has the given OS name and version.
-func GetMostRecentImageIDE(t *testing.T, compartmentID string, osName string, osVersion string) (string, error) {
+func GetMostRecentImageIDE(t testing.TB, compartmentID string, osName string, osVersion string) (string, error) {
 	configProvider := common.DefaultConfigProvider()
 	client, err := core.NewComputeClientWithConfigurationProvider(configProvider)
 	if err != nil {
@@ -13,7 +13,7 @@ import (
 
 // GetRandomSubnetID gets a randomly chosen subnet OCID in the given availability domain.
 // The returned value can be overridden by of the environment variable TF_VAR_subnet_ocid.
-func GetRandomSubnetID(t *testing.T, compartmentID string, availabilityDomain string) string {
+func GetRandomSubnetID(t testing.TB, compartmentID string, availabilityDomain string) string {
 	ocid, err := GetRandomSubnetIDE(t, compartmentID, availabilityDomain)
 	if err != nil {
 		t.Fatal(err)
@@ -23,7 +23,7 @@ func GetRandomSubnetID(t *testing.T, compartmentID string, availabilityDomain st
 
 // GetRandomSubnetIDE gets a randomly chosen subnet OCID in the given availability domain.
 // The returned value can be overridden by of the environment variable TF_VAR_subnet_ocid.
-func GetRandomSubnetIDE(t *testing.T, compartmentID string, availabilityDomain string) (string, error) {
+func GetRandomSubnetIDE(t testing.TB, compartmentID string, availabilityDomain string) (string, error) {
 	configProvider := common.DefaultConfigProvider()
 	client, err := core.NewVirtualNetworkClientWithConfigurationProvider(configProvider)
 	if err != nil {
@@ -56,7 +56,7 @@ func GetRandomSubnetIDE(t *testing.T, compartmentID string, availabilityDomain s
 }
 
 // GetAllVcnIDs gets the list of VCNs available in the given compartment.
-func GetAllVcnIDs(t *testing.T, compartmentID string) []string {
+func GetAllVcnIDs(t testing.TB, compartmentID string) []string {
 	vcnIDS, err := GetAllVcnIDsE(t, compartmentID)
 	if err != nil {
 		t.Fatal(err)
@@ -65,7 +65,7 @@ func GetAllVcnIDs(t *testing.T, compartmentID string) []string {
 }
 
 // GetAllVcnIDsE gets the list of VCNs available in the given compartment.
-func GetAllVcnIDsE(t *testing.T, compartmentID string) ([]string, error) {
+func GetAllVcnIDsE(t testing.TB, compartmentID string) ([]string, error) {
 	configProvider := common.DefaultConfigProvider()
 	client, err := core.NewVirtualNetworkClientWithConfigurationProvider(configProvider)
 	if err != nil {
@@ -21,7 +21,7 @@ const subnetIDEnvVar = "TF_VAR_subnet_ocid"
 const passPhraseEnvVar = "TF_VAR_pass_phrase"
 
 // GetRootComparmentID gets an OCID of the root compartment (a.k.a. tenancy OCID).
-func GetRootCompartmentID(t *testing.T) string {
+func GetRootCompartmentID(t testing.TB) string {
 	tenancyID, err := GetRootCompartmentIDE(t)
 	if err != nil {
 		t.Fatal(err)
@@ -30,7 +30,7 @@ func GetRootCompartmentID(t *testing.T) string {
 }
 
 // GetRootComparmentIDE gets an OCID of the root compartment (a.k.a. tenancy OCID).
-func GetRootCompartmentIDE(t *testing.T) (string, error) {
+func GetRootCompartmentIDE(t testing.TB) (string, error) {
 	configProvider := common.DefaultConfigProvider()
 	tenancyID, err := configProvider.TenancyOCID()
 	if err != nil {
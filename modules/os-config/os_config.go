@@ -0,0 +1,223 @@
+// Package os_config contains assertions about the configuration of provisioned hosts, such as which files,
+// services, packages, and listening ports they have, for validating machine images and bootstrap scripts.
+package os_config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/ssh"
+)
+
+const (
+	passMarker = "TERRATEST_CHECK_PASSED"
+	failMarker = "TERRATEST_CHECK_FAILED"
+)
+
+// CommandRunner runs a command on a host and returns its stdout.
+type CommandRunner func(t testing.TB, command string) (string, error)
+
+// Host is a host to check the configuration of.
+type Host struct {
+	Name       string        // A name for the host to use in log and error messages
+	RunCommand CommandRunner // Runs commands on the host: sh commands on Linux, or PowerShell commands on Windows
+	Windows    bool          // Set to true for Windows hosts, which are checked using PowerShell
+}
+
+// SshCommandRunner returns a CommandRunner that runs commands on the given host over SSH. For Windows hosts, the
+// OpenSSH server on the host must be configured to use PowerShell as its default shell.
+func SshCommandRunner(host ssh.Host) CommandRunner {
+	return func(t testing.TB, command string) (string, error) {
+		return ssh.CheckSshCommandE(t, host, command)
+	}
+}
+
+// SsmCommandRunner returns a CommandRunner that runs shell commands on the given Linux EC2 Instance via the SSM Agent.
+func SsmCommandRunner(awsRegion string, instanceID string, timeout time.Duration) CommandRunner {
+	return func(t testing.TB, command string) (string, error) {
+		return aws.CheckSsmCommandE(t, awsRegion, instanceID, command, timeout)
+	}
+}
+
+// SsmPowerShellCommandRunner returns a CommandRunner that runs PowerShell commands on the given Windows EC2 Instance
+// via the SSM Agent.
+func SsmPowerShellCommandRunner(awsRegion string, instanceID string, timeout time.Duration) CommandRunner {
+	return func(t testing.TB, command string) (string, error) {
+		return aws.CheckSsmPowerShellCommandE(t, awsRegion, instanceID, command, timeout)
+	}
+}
+
+// Spec declares the configuration a host is expected to have.
+type Spec struct {
+	Files    []string // Paths of files or folders that must exist
+	Services []string // Names of services that must be running
+	Packages []string // Names of packages that must be installed
+	Ports    []int    // TCP ports that must have a process listening on them
+}
+
+// AssertSpec checks that the given host matches everything in the given Spec and fails the test, listing every
+// mismatch, if it does not.
+func AssertSpec(t testing.TB, host Host, spec Spec) {
+	err := AssertSpecE(t, host, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertSpecE checks that the given host matches everything in the given Spec and returns an error, listing every
+// mismatch, if it does not.
+func AssertSpecE(t testing.TB, host Host, spec Spec) error {
+	errs := []string{}
+	addErr := func(err error) {
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	for _, path := range spec.Files {
+		addErr(AssertFileExistsE(t, host, path))
+	}
+	for _, service := range spec.Services {
+		addErr(AssertServiceRunningE(t, host, service))
+	}
+	for _, pkg := range spec.Packages {
+		addErr(AssertPackageInstalledE(t, host, pkg))
+	}
+	for _, port := range spec.Ports {
+		addErr(AssertPortListeningE(t, host, port))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Host %s does not match the expected configuration:\n%s", host.Name, strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// AssertFileExists checks that a file or folder exists at the given path on the given host and fails the test if it
+// does not.
+func AssertFileExists(t testing.TB, host Host, path string) {
+	err := AssertFileExistsE(t, host, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertFileExistsE checks that a file or folder exists at the given path on the given host and returns an error if
+// it does not.
+func AssertFileExistsE(t testing.TB, host Host, path string) error {
+	return runCheckE(t, host, fmt.Sprintf("file %s exists", path), fileExistsCondition(host.Windows, path))
+}
+
+// AssertServiceRunning checks that the service with the given name is running on the given host (according to
+// systemd or SysV init on Linux, or the Service Control Manager on Windows) and fails the test if it is not.
+func AssertServiceRunning(t testing.TB, host Host, name string) {
+	err := AssertServiceRunningE(t, host, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertServiceRunningE checks that the service with the given name is running on the given host (according to
+// systemd or SysV init on Linux, or the Service Control Manager on Windows) and returns an error if it is not.
+func AssertServiceRunningE(t testing.TB, host Host, name string) error {
+	return runCheckE(t, host, fmt.Sprintf("service %s is running", name), serviceRunningCondition(host.Windows, name))
+}
+
+// AssertPackageInstalled checks that the package with the given name is installed on the given host (according to
+// dpkg, rpm, or apk on Linux, or Get-Package on Windows) and fails the test if it is not.
+func AssertPackageInstalled(t testing.TB, host Host, name string) {
+	err := AssertPackageInstalledE(t, host, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertPackageInstalledE checks that the package with the given name is installed on the given host (according to
+// dpkg, rpm, or apk on Linux, or Get-Package on Windows) and returns an error if it is not.
+func AssertPackageInstalledE(t testing.TB, host Host, name string) error {
+	return runCheckE(t, host, fmt.Sprintf("package %s is installed", name), packageInstalledCondition(host.Windows, name))
+}
+
+// AssertPortListening checks that a process on the given host is listening on the given TCP port and fails the test
+// if there is none.
+func AssertPortListening(t testing.TB, host Host, port int) {
+	err := AssertPortListeningE(t, host, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AssertPortListeningE checks that a process on the given host is listening on the given TCP port and returns an
+// error if there is none.
+func AssertPortListeningE(t testing.TB, host Host, port int) error {
+	return runCheckE(t, host, fmt.Sprintf("port %d is listening", port), portListeningCondition(host.Windows, port))
+}
+
+// runCheckE runs the given condition on the host and returns an error if it does not hold. The check prints a marker
+// rather than relying on the exit code, so that a condition that does not hold can be told apart from a failure to
+// run the command at all.
+func runCheckE(t testing.TB, host Host, description string, condition string) error {
+	logger.Logf(t, "Checking that %s on host %s", description, host.Name)
+
+	out, err := host.RunCommand(t, checkCommand(host.Windows, condition))
+	if err != nil {
+		return fmt.Errorf("Failed to check that %s on host %s: %v", description, host.Name, err)
+	}
+
+	switch {
+	case strings.Contains(out, failMarker):
+		return fmt.Errorf("Expected %s on host %s, but it is not", description, host.Name)
+	case strings.Contains(out, passMarker):
+		return nil
+	default:
+		return fmt.Errorf("Unexpected output checking that %s on host %s: %s", description, host.Name, out)
+	}
+}
+
+func checkCommand(windows bool, condition string) string {
+	if windows {
+		return fmt.Sprintf("if (%s) { '%s' } else { '%s' }", condition, passMarker, failMarker)
+	}
+	return fmt.Sprintf("if %s; then echo %s; else echo %s; fi", condition, passMarker, failMarker)
+}
+
+func fileExistsCondition(windows bool, path string) string {
+	if windows {
+		return fmt.Sprintf("Test-Path -LiteralPath %s", powerShellQuote(path))
+	}
+	return fmt.Sprintf("test -e %s", shellQuote(path))
+}
+
+func serviceRunningCondition(windows bool, name string) string {
+	if windows {
+		return fmt.Sprintf("(Get-Service -Name %s -ErrorAction SilentlyContinue).Status -eq 'Running'", powerShellQuote(name))
+	}
+	return fmt.Sprintf("systemctl is-active --quiet %[1]s 2>/dev/null || service %[1]s status > /dev/null 2>&1", shellQuote(name))
+}
+
+func packageInstalledCondition(windows bool, name string) string {
+	if windows {
+		return fmt.Sprintf("Get-Package -Name %s -ErrorAction SilentlyContinue", powerShellQuote(name))
+	}
+	return fmt.Sprintf("dpkg -s %[1]s > /dev/null 2>&1 || rpm -q %[1]s > /dev/null 2>&1 || apk info -e %[1]s > /dev/null 2>&1", shellQuote(name))
+}
+
+func portListeningCondition(windows bool, port int) string {
+	if windows {
+		return fmt.Sprintf("Get-NetTCPConnection -State Listen -LocalPort %d -ErrorAction SilentlyContinue", port)
+	}
+	return fmt.Sprintf("(ss -ltn 2>/dev/null || netstat -ltn 2>/dev/null) | grep -Eq '[:.]%d[[:space:]]'", port)
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+func powerShellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
@@ -0,0 +1,92 @@
+package os_config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localHost returns a Host that runs its commands on the local machine
+func localHost() Host {
+	return Host{
+		Name: "local",
+		RunCommand: func(t testing.TB, command string) (string, error) {
+			out, err := exec.Command("sh", "-c", command).CombinedOutput()
+			return string(out), err
+		},
+	}
+}
+
+func TestAssertFileExistsLocally(t *testing.T) {
+	t.Parallel()
+
+	file, err := ioutil.TempFile("", "os-config-test")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	file.Close()
+
+	host := localHost()
+	assert.NoError(t, AssertFileExistsE(t, host, file.Name()))
+	assert.Error(t, AssertFileExistsE(t, host, file.Name()+"-does-not-exist"))
+}
+
+func TestAssertSpecListsAllMismatches(t *testing.T) {
+	t.Parallel()
+
+	spec := Spec{Files: []string{"/does/not/exist-1", "/does/not/exist-2"}}
+
+	err := AssertSpecE(t, localHost(), spec)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exist-1")
+	assert.Contains(t, err.Error(), "exist-2")
+}
+
+func TestRunCheckReportsRunnerErrors(t *testing.T) {
+	t.Parallel()
+
+	host := Host{
+		Name: "unreachable",
+		RunCommand: func(t testing.TB, command string) (string, error) {
+			return "", errors.New("connection refused")
+		},
+	}
+
+	err := AssertServiceRunningE(t, host, "nginx")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestCheckCommands(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		windows  bool
+		check    func(windows bool) string
+		expected string
+	}{
+		{"linux file", false, func(w bool) string { return fileExistsCondition(w, "/etc/it's") }, `test -e '/etc/it'\''s'`},
+		{"windows file", true, func(w bool) string { return fileExistsCondition(w, `C:\it's`) }, `Test-Path -LiteralPath 'C:\it''s'`},
+		{"linux service", false, func(w bool) string { return serviceRunningCondition(w, "nginx") }, "systemctl is-active --quiet 'nginx' 2>/dev/null || service 'nginx' status > /dev/null 2>&1"},
+		{"windows service", true, func(w bool) string { return serviceRunningCondition(w, "W3SVC") }, "(Get-Service -Name 'W3SVC' -ErrorAction SilentlyContinue).Status -eq 'Running'"},
+		{"linux package", false, func(w bool) string { return packageInstalledCondition(w, "curl") }, "dpkg -s 'curl' > /dev/null 2>&1 || rpm -q 'curl' > /dev/null 2>&1 || apk info -e 'curl' > /dev/null 2>&1"},
+		{"linux port", false, func(w bool) string { return portListeningCondition(w, 22) }, "(ss -ltn 2>/dev/null || netstat -ltn 2>/dev/null) | grep -Eq '[:.]22[[:space:]]'"},
+		{"windows port", true, func(w bool) string { return portListeningCondition(w, 3389) }, "Get-NetTCPConnection -State Listen -LocalPort 3389 -ErrorAction SilentlyContinue"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, testCase.check(testCase.windows))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -26,13 +27,57 @@ type Options struct {
 	RetryableErrors    map[string]string // If packer build fails with one of these (transient) errors, retry. The keys are a regexp to match against the error and the message is what to display to a user if that error is matched.
 	MaxRetries         int               // Maximum number of times to retry errors matching RetryableErrors
 	TimeBetweenRetries time.Duration     // The amount of time to wait between retries
+	Logger             *logger.Logger    // The logger to log Packer and its output with. Defaults to logger.Global.
+}
+
+// DefaultRetryablePackerErrors are the transient errors that commonly break Packer builds for reasons unrelated to
+// the template being tested: cloud API throttling, network flakes, and builder instances that are slow to become
+// reachable. Use WithDefaultRetryableErrors to retry them.
+var DefaultRetryablePackerErrors = map[string]string{
+	// Network flakes
+	".*(TLS handshake timeout|i/o timeout|connection reset by peer).*": "Failed due to transient network error.",
+	".*Script disconnected unexpectedly.*":                             "Lost the connection to the builder instance while provisioning.",
+	".*Timeout waiting for SSH.*":                                      "Builder instance was not reachable over SSH in time.",
+
+	// Cloud API throttling
+	".*(Throttling|RequestLimitExceeded|Rate exceeded).*": "AWS API request was throttled.",
+	".*rateLimitExceeded.*":                               "GCP API request was throttled.",
+
+	// Eventual consistency
+	".*Value .* for parameter iamInstanceProfile.name is invalid.*": "IAM instance profile was not yet usable after being created.",
+	".*Error 409.*is not ready.*":                                   "GCP resource was not yet ready.",
+}
+
+// WithDefaultRetryableErrors returns a copy of the given Options with DefaultRetryablePackerErrors added to
+// RetryableErrors. Errors already configured in the Options take precedence. If MaxRetries and TimeBetweenRetries are
+// not set, they are set to 3 retries, 5 seconds apart.
+func WithDefaultRetryableErrors(t testing.TB, originalOptions *Options) *Options {
+	newOptions := *originalOptions
+
+	newOptions.RetryableErrors = map[string]string{}
+	for regex, message := range DefaultRetryablePackerErrors {
+		newOptions.RetryableErrors[regex] = message
+	}
+	for regex, message := range originalOptions.RetryableErrors {
+		newOptions.RetryableErrors[regex] = message
+	}
+
+	if newOptions.MaxRetries == 0 {
+		newOptions.MaxRetries = 3
+	}
+	if newOptions.TimeBetweenRetries == 0 {
+		newOptions.TimeBetweenRetries = 5 * time.Second
+	}
+
+	newOptions.Logger.Logf(t, "Retrying up to %d times on %d known transient Packer errors", newOptions.MaxRetries, len(newOptions.RetryableErrors))
+	return &newOptions
 }
 
 // BuildArtifacts can take a map of identifierName <-> Options and then parallelize
 // the packer builds. Once all the packer builds have completed a map of identifierName <-> generated identifier
 // is returned. The identifierName can be anything you want, it is only used so that you can
 // know which generated artifact is which.
-func BuildArtifacts(t *testing.T, artifactNameToOptions map[string]*Options) map[string]string {
+func BuildArtifacts(t testing.TB, artifactNameToOptions map[string]*Options) map[string]string {
 	result, err := BuildArtifactsE(t, artifactNameToOptions)
 
 	if err != nil {
@@ -47,7 +92,7 @@ func BuildArtifacts(t *testing.T, artifactNameToOptions map[string]*Options) map
 // is returned. If any artifact fails to build, the errors are accumulated and returned
 // as a MultiError. The identifierName can be anything you want, it is only used so that you can
 // know which generated artifact is which.
-func BuildArtifactsE(t *testing.T, artifactNameToOptions map[string]*Options) (map[string]string, error) {
+func BuildArtifactsE(t testing.TB, artifactNameToOptions map[string]*Options) (map[string]string, error) {
 	var waitForArtifacts sync.WaitGroup
 	waitForArtifacts.Add(len(artifactNameToOptions))
 
@@ -77,7 +122,7 @@ func BuildArtifactsE(t *testing.T, artifactNameToOptions map[string]*Options) (m
 }
 
 // BuildArtifact builds the given Packer template and return the generated Artifact ID.
-func BuildArtifact(t *testing.T, options *Options) string {
+func BuildArtifact(t testing.TB, options *Options) string {
 	artifactID, err := BuildArtifactE(t, options)
 	if err != nil {
 		t.Fatal(err)
@@ -86,13 +131,14 @@ func BuildArtifact(t *testing.T, options *Options) string {
 }
 
 // BuildArtifactE builds the given Packer template and return the generated Artifact ID.
-func BuildArtifactE(t *testing.T, options *Options) (string, error) {
-	logger.Logf(t, "Running Packer to generate a custom artifact for template %s", options.Template)
+func BuildArtifactE(t testing.TB, options *Options) (string, error) {
+	options.Logger.Logf(t, "Running Packer to generate a custom artifact for template %s", options.Template)
 
 	cmd := shell.Command{
 		Command: "packer",
 		Args:    formatPackerArgs(options),
 		Env:     options.Env,
+		Logger:  options.Logger,
 	}
 
 	description := fmt.Sprintf("%s %v", cmd.Command, cmd.Args)
@@ -110,14 +156,14 @@ func BuildArtifactE(t *testing.T, options *Options) (string, error) {
 // BuildAmi builds the given Packer template and return the generated AMI ID.
 //
 // Deprecated: Use BuildArtifact instead.
-func BuildAmi(t *testing.T, options *Options) string {
+func BuildAmi(t testing.TB, options *Options) string {
 	return BuildArtifact(t, options)
 }
 
 // BuildAmiE builds the given Packer template and return the generated AMI ID.
 //
 // Deprecated: Use BuildArtifactE instead.
-func BuildAmiE(t *testing.T, options *Options) (string, error) {
+func BuildAmiE(t testing.TB, options *Options) (string, error) {
 	return BuildArtifactE(t, options)
 }
 
@@ -147,8 +193,15 @@ func extractArtifactID(packerLogOutput string) (string, error) {
 func formatPackerArgs(options *Options) []string {
 	args := []string{"build", "-machine-readable"}
 
-	for key, value := range options.Vars {
-		args = append(args, "-var", fmt.Sprintf("%s=%s", key, value))
+	// Sort the var names, so the args are the same on every run
+	keys := []string{}
+	for key := range options.Vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, "-var", fmt.Sprintf("%s=%s", key, options.Vars[key]))
 	}
 
 	for _, file_path := range options.VarFiles {
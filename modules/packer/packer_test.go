@@ -2,10 +2,13 @@ package packer
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractAmiIdFromOneLine(t *testing.T) {
@@ -156,6 +159,17 @@ func TestFormatPackerArgs(t *testing.T) {
 			},
 			expected: "build -machine-readable -var foo=bar -var-file foofile.json packer.json",
 		},
+		{
+			option: &Options{
+				Template: "packer.json",
+				Vars: map[string]string{
+					"region":   "us-east-1",
+					"ami_name": "test",
+					"foo":      "bar",
+				},
+			},
+			expected: "build -machine-readable -var ami_name=test -var foo=bar -var region=us-east-1 packer.json",
+		},
 	}
 
 	for _, test := range tests {
@@ -163,3 +177,45 @@ func TestFormatPackerArgs(t *testing.T) {
 		assert.Equal(t, strings.Join(args, " "), test.expected)
 	}
 }
+
+func TestDefaultRetryablePackerErrorsCompile(t *testing.T) {
+	t.Parallel()
+
+	for regex := range DefaultRetryablePackerErrors {
+		_, err := regexp.Compile(regex)
+		require.NoError(t, err, regex)
+	}
+}
+
+func TestWithDefaultRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	originalOptions := &Options{
+		Template: "packer.json",
+		RetryableErrors: map[string]string{
+			".*rateLimitExceeded.*": "Custom message",
+			".*flaky.*":             "Flaky test fixture",
+		},
+	}
+
+	options := WithDefaultRetryableErrors(t, originalOptions)
+
+	assert.Equal(t, 3, options.MaxRetries)
+	assert.Equal(t, 5*time.Second, options.TimeBetweenRetries)
+	assert.Equal(t, "Flaky test fixture", options.RetryableErrors[".*flaky.*"])
+	assert.Equal(t, "Custom message", options.RetryableErrors[".*rateLimitExceeded.*"])
+	assert.Equal(t, DefaultRetryablePackerErrors[".*Timeout waiting for SSH.*"], options.RetryableErrors[".*Timeout waiting for SSH.*"])
+
+	// The original options are left untouched
+	assert.Equal(t, 0, originalOptions.MaxRetries)
+	assert.Len(t, originalOptions.RetryableErrors, 2)
+}
+
+func TestWithDefaultRetryableErrorsKeepsRetrySettings(t *testing.T) {
+	t.Parallel()
+
+	options := WithDefaultRetryableErrors(t, &Options{MaxRetries: 10, TimeBetweenRetries: time.Minute})
+
+	assert.Equal(t, 10, options.MaxRetries)
+	assert.Equal(t, time.Minute, options.TimeBetweenRetries)
+}
@@ -0,0 +1,72 @@
+package retry
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// You can set this environment variable to a duration (e.g. 1m) to make Terratest log a heartbeat line at that interval
+// while it is waiting on a long-running operation, such as a terraform apply or a retry loop polling a cloud API. This
+// keeps CI systems that kill jobs after a period with no output (e.g. CircleCI after 10 minutes) from killing the test.
+const heartbeatIntervalEnvVarName = "TERRATEST_HEARTBEAT_INTERVAL"
+
+// Heartbeat periodically logs that an operation is still running. To stop it, call the Stop() function.
+type Heartbeat struct {
+	stop     chan bool
+	stopOnce *sync.Once
+}
+
+// Stop stops the heartbeat. It is safe to call Stop on a heartbeat that is disabled, and to call it more than once.
+func (heartbeat Heartbeat) Stop() {
+	if heartbeat.stop != nil {
+		heartbeat.stopOnce.Do(func() { close(heartbeat.stop) })
+	}
+}
+
+// StartHeartbeat starts logging a line with the given operation and the time elapsed since it started, once every
+// interval, until Stop() is called on the returned value. If the interval is zero or negative, no heartbeat is logged.
+func StartHeartbeat(t testing.TB, operation string, interval time.Duration) Heartbeat {
+	if interval <= 0 {
+		return Heartbeat{}
+	}
+
+	stop := make(chan bool)
+	start := time.Now()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				logger.Logf(t, "Still waiting on '%s' (elapsed: %s)", operation, time.Since(start).Round(time.Second))
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return Heartbeat{stop: stop, stopOnce: &sync.Once{}}
+}
+
+// StartHeartbeatFromEnv starts a heartbeat for the given operation at the interval set in the
+// TERRATEST_HEARTBEAT_INTERVAL environment variable. If the environment variable is not set or is not a valid duration,
+// no heartbeat is logged.
+func StartHeartbeatFromEnv(t testing.TB, operation string) Heartbeat {
+	return StartHeartbeat(t, operation, heartbeatIntervalFromEnv())
+}
+
+// heartbeatIntervalFromEnv returns the heartbeat interval set in the TERRATEST_HEARTBEAT_INTERVAL environment variable,
+// or zero if it is not set or invalid.
+func heartbeatIntervalFromEnv() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv(heartbeatIntervalEnvVarName))
+	if err != nil {
+		return 0
+	}
+	return interval
+}
@@ -0,0 +1,39 @@
+package retry
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatIntervalFromEnv(t *testing.T) {
+	// This test modifies an environment variable, so it can't run in parallel with other tests that read it
+
+	defer os.Unsetenv(heartbeatIntervalEnvVarName)
+
+	os.Unsetenv(heartbeatIntervalEnvVarName)
+	assert.Equal(t, time.Duration(0), heartbeatIntervalFromEnv())
+
+	os.Setenv(heartbeatIntervalEnvVarName, "90s")
+	assert.Equal(t, 90*time.Second, heartbeatIntervalFromEnv())
+
+	os.Setenv(heartbeatIntervalEnvVarName, "not-a-duration")
+	assert.Equal(t, time.Duration(0), heartbeatIntervalFromEnv())
+}
+
+func TestStartHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	disabled := StartHeartbeat(t, "disabled heartbeat", 0)
+	assert.Nil(t, disabled.stop)
+	disabled.Stop()
+
+	enabled := StartHeartbeat(t, "enabled heartbeat", 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	enabled.Stop()
+
+	// Stopping a heartbeat again, e.g. from a deferred call after an explicit one, must not panic
+	enabled.Stop()
+}
@@ -0,0 +1,96 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// maxDuration is the longest possible time.Duration. Intervals that would be longer are clamped to it, as converting a
+// float64 beyond the range of int64 to a time.Duration doesn't give a meaningful (or even positive) result.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// Policy configures how DoWithPolicy retries an action: how many times, how long to sleep between attempts, and how
+// long each attempt may take. Use FixedPolicy or ExponentialPolicy to create one, and WithJitter and WithAttemptTimeout
+// to refine it.
+type Policy struct {
+	MaxRetries      int           // The maximum number of retries after the first attempt
+	InitialInterval time.Duration // How long to sleep before the first retry
+	Multiplier      float64       // The factor to grow the sleep by after each retry. 0 or 1 means the sleep is fixed.
+	MaxInterval     time.Duration // The maximum sleep between retries. 0 means there is no maximum.
+	Jitter          float64       // The fraction (between 0 and 1) by which each sleep is randomly varied, so parallel tests don't retry in lockstep
+	AttemptTimeout  time.Duration // How long each attempt may take before it's treated as failed (and retried). 0 means there is no timeout. See DoWithPolicyContextE to cancel the attempt too.
+}
+
+// FixedPolicy returns a Policy that sleeps for the same interval between each of up to maxRetries retries.
+func FixedPolicy(maxRetries int, interval time.Duration) Policy {
+	return Policy{MaxRetries: maxRetries, InitialInterval: interval}
+}
+
+// ExponentialPolicy returns a Policy that sleeps for initialInterval before the first retry, and doubles the sleep after
+// each retry, up to maxInterval, for up to maxRetries retries. This is a good fit for calls to cloud APIs that throttle
+// requests.
+func ExponentialPolicy(maxRetries int, initialInterval time.Duration, maxInterval time.Duration) Policy {
+	return Policy{MaxRetries: maxRetries, InitialInterval: initialInterval, Multiplier: 2, MaxInterval: maxInterval}
+}
+
+// WithJitter returns a copy of the Policy that varies each sleep randomly by up to the given fraction (between 0 and 1)
+// of the sleep, e.g. 0.2 for +/- 20%.
+func (policy Policy) WithJitter(jitter float64) Policy {
+	policy.Jitter = jitter
+	return policy
+}
+
+// WithAttemptTimeout returns a copy of the Policy that treats each attempt that takes longer than the given timeout as
+// failed. DoWithPolicy leaves such an attempt running in the background, while DoWithPolicyContext cancels the context
+// it passed to the attempt.
+func (policy Policy) WithAttemptTimeout(timeout time.Duration) Policy {
+	policy.AttemptTimeout = timeout
+	return policy
+}
+
+// Interval returns how long to sleep before the given retry (starting at 0 for the first retry), without jitter.
+func (policy Policy) Interval(retry int) time.Duration {
+	interval := float64(policy.InitialInterval)
+	if policy.Multiplier > 1 {
+		for i := 0; i < retry; i++ {
+			interval *= policy.Multiplier
+			// Stop growing once past the maximum (or the longest possible duration), so that the interval can't overflow
+			if (policy.MaxInterval > 0 && interval >= float64(policy.MaxInterval)) || interval >= float64(maxDuration) {
+				break
+			}
+		}
+	}
+
+	if policy.MaxInterval > 0 && interval > float64(policy.MaxInterval) {
+		return policy.MaxInterval
+	}
+	return clampDuration(interval)
+}
+
+// sleepBeforeRetry returns how long to sleep before the given retry (starting at 0 for the first retry), including
+// jitter.
+func (policy Policy) sleepBeforeRetry(retry int) time.Duration {
+	return addJitter(policy.Interval(retry), policy.Jitter, rand.Float64())
+}
+
+// addJitter varies the given interval by up to the given fraction of it, based on the given random number between 0
+// and 1.
+func addJitter(interval time.Duration, jitter float64, random float64) time.Duration {
+	if jitter <= 0 {
+		return interval
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	// Scale the random number from [0, 1) to [-jitter, jitter)
+	return clampDuration(float64(interval) * (1 + jitter*(2*random-1)))
+}
+
+// clampDuration converts the given number of nanoseconds to a time.Duration, clamping it to maxDuration.
+func clampDuration(nanoseconds float64) time.Duration {
+	if nanoseconds >= float64(maxDuration) {
+		return maxDuration
+	}
+	return time.Duration(nanoseconds)
+}
@@ -0,0 +1,113 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyInterval(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		description string
+		policy      Policy
+		retry       int
+		expected    time.Duration
+	}{
+		{"Fixed first retry", FixedPolicy(5, time.Second), 0, time.Second},
+		{"Fixed later retry", FixedPolicy(5, time.Second), 4, time.Second},
+		{"Exponential first retry", ExponentialPolicy(10, time.Second, time.Minute), 0, time.Second},
+		{"Exponential third retry", ExponentialPolicy(10, time.Second, time.Minute), 2, 4 * time.Second},
+		{"Exponential capped at max interval", ExponentialPolicy(10, time.Second, 10*time.Second), 5, 10 * time.Second},
+		{"Exponential many retries", ExponentialPolicy(1000, time.Second, time.Minute), 999, time.Minute},
+		{"Custom multiplier", Policy{InitialInterval: time.Second, Multiplier: 3}, 2, 9 * time.Second},
+		{"Exponential many retries without max interval", ExponentialPolicy(1000, time.Second, 0), 999, maxDuration},
+		{"Exponential just before overflow without max interval", ExponentialPolicy(100, time.Nanosecond, 0), 62, time.Duration(1 << 62)},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, testCase.policy.Interval(testCase.retry))
+		})
+	}
+}
+
+func TestAddJitter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 10*time.Second, addJitter(10*time.Second, 0, 0.9))
+	assert.Equal(t, 8*time.Second, addJitter(10*time.Second, 0.2, 0))
+	assert.Equal(t, 10*time.Second, addJitter(10*time.Second, 0.2, 0.5))
+	assert.Equal(t, 12*time.Second, addJitter(10*time.Second, 0.2, 1))
+	assert.Equal(t, time.Duration(0), addJitter(10*time.Second, 5, 0))
+	assert.Equal(t, maxDuration, addJitter(maxDuration, 0.2, 1))
+}
+
+func TestDoWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	out, err := DoWithPolicyE(t, "Succeed on third attempt", ExponentialPolicy(5, time.Millisecond, 4*time.Millisecond).WithJitter(0.5), func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", fmt.Errorf("attempt %d failed", attempts)
+		}
+		return "expected", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "expected", out)
+	assert.Equal(t, 3, attempts)
+
+	_, err = DoWithPolicyE(t, "Always fail", FixedPolicy(2, time.Millisecond), func() (string, error) {
+		return "", fmt.Errorf("expected error")
+	})
+	assert.Equal(t, MaxRetriesExceeded{Description: "Always fail", MaxRetries: 2}, err)
+}
+
+func TestDoWithPolicyAttemptTimeout(t *testing.T) {
+	t.Parallel()
+
+	// The hung attempt keeps running in the background, so the attempts are counted atomically
+	var attempts int32
+	out, err := DoWithPolicyE(t, "Hang on first attempt", FixedPolicy(1, time.Millisecond).WithAttemptTimeout(50*time.Millisecond), func() (string, error) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			time.Sleep(time.Second)
+		}
+		return "expected", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "expected", out)
+}
+
+func TestDoWithPolicyContextCancelsTimedOutAttempt(t *testing.T) {
+	t.Parallel()
+
+	cancelled := make(chan struct{})
+	var attempts int32
+	out, err := DoWithPolicyContextE(t, "Hang on first attempt until cancelled", FixedPolicy(1, time.Millisecond).WithAttemptTimeout(50*time.Millisecond), func(ctx context.Context) (string, error) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-ctx.Done()
+			close(cancelled)
+			return "", ctx.Err()
+		}
+		return "expected", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "expected", out)
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the context of the first attempt to be cancelled")
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/gruntwork-io/terratest/modules/interrupt"
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"golang.org/x/net/context"
 )
@@ -21,7 +22,7 @@ type Either struct {
 
 // DoWithTimeout runs the specified action and waits up to the specified timeout for it to complete. Return the output of the action if
 // it completes on time or fail the test otherwise.
-func DoWithTimeout(t *testing.T, actionDescription string, timeout time.Duration, action func() (string, error)) string {
+func DoWithTimeout(t testing.TB, actionDescription string, timeout time.Duration, action func() (string, error)) string {
 	out, err := DoWithTimeoutE(t, actionDescription, timeout, action)
 	if err != nil {
 		t.Fatal(err)
@@ -31,14 +32,50 @@ func DoWithTimeout(t *testing.T, actionDescription string, timeout time.Duration
 
 // DoWithTimeoutE runs the specified action and waits up to the specified timeout for it to complete. Return the output of the action if
 // it completes on time or an error otherwise.
-func DoWithTimeoutE(t *testing.T, actionDescription string, timeout time.Duration, action func() (string, error)) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+func DoWithTimeoutE(t testing.TB, actionDescription string, timeout time.Duration, action func() (string, error)) (string, error) {
+	heartbeat := StartHeartbeatFromEnv(t, actionDescription)
+	defer heartbeat.Stop()
+
+	return doWithTimeoutE(actionDescription, timeout, action)
+}
+
+func doWithTimeoutE(actionDescription string, timeout time.Duration, action func() (string, error)) (string, error) {
+	return doWithContextTimeoutE(context.Background(), actionDescription, timeout, func(ctx context.Context) (string, error) {
+		return action()
+	})
+}
+
+// DoWithContextTimeout runs the specified action with a context that is cancelled once the specified timeout expires
+// (or the given parent context is done), and waits up to the timeout for it to complete. Return the output of the
+// action if it completes on time or fail the test otherwise.
+func DoWithContextTimeout(t testing.TB, ctx context.Context, actionDescription string, timeout time.Duration, action func(ctx context.Context) (string, error)) string {
+	out, err := DoWithContextTimeoutE(t, ctx, actionDescription, timeout, action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// DoWithContextTimeoutE runs the specified action with a context that is cancelled once the specified timeout expires
+// (or the given parent context is done), and waits up to the timeout for it to complete. Unlike DoWithTimeoutE, this
+// lets the action stop its in-flight work (e.g., an SDK call made WithContext) when the deadline expires, rather than
+// leaving it running in the background. Return the output of the action if it completes on time, a TimeoutExceeded
+// error if the timeout expires, or the error of the parent context if it's done first.
+func DoWithContextTimeoutE(t testing.TB, ctx context.Context, actionDescription string, timeout time.Duration, action func(ctx context.Context) (string, error)) (string, error) {
+	heartbeat := StartHeartbeatFromEnv(t, actionDescription)
+	defer heartbeat.Stop()
+
+	return doWithContextTimeoutE(ctx, actionDescription, timeout, action)
+}
+
+func doWithContextTimeoutE(parentCtx context.Context, actionDescription string, timeout time.Duration, action func(ctx context.Context) (string, error)) (string, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
 	resultChannel := make(chan Either, 1)
 
 	go func() {
-		out, err := action()
+		out, err := action(ctx)
 		resultChannel <- Either{Result: out, Error: err}
 	}()
 
@@ -46,6 +83,9 @@ func DoWithTimeoutE(t *testing.T, actionDescription string, timeout time.Duratio
 	case either := <-resultChannel:
 		return either.Result, either.Error
 	case <-ctx.Done():
+		if parentErr := parentCtx.Err(); parentErr != nil {
+			return "", parentErr
+		}
 		return "", TimeoutExceeded{Description: actionDescription, Timeout: timeout}
 	}
 }
@@ -53,7 +93,7 @@ func DoWithTimeoutE(t *testing.T, actionDescription string, timeout time.Duratio
 // DoWithRetry runs the specified action. If it returns a value, return that value. If it returns a FatalError, return that error
 // immediately. If it returns any other type of error, sleep for sleepBetweenRetries and try again, up to a maximum of
 // maxRetries retries. If maxRetries is exceeded, fail the test.
-func DoWithRetry(t *testing.T, actionDescription string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) string {
+func DoWithRetry(t testing.TB, actionDescription string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) string {
 	out, err := DoWithRetryE(t, actionDescription, maxRetries, sleepBetweenRetries, action)
 	if err != nil {
 		t.Fatal(err)
@@ -63,15 +103,72 @@ func DoWithRetry(t *testing.T, actionDescription string, maxRetries int, sleepBe
 
 // DoWithRetryE runs the specified action. If it returns a value, return that value. If it returns a FatalError, return that error
 // immediately. If it returns any other type of error, sleep for sleepBetweenRetries and try again, up to a maximum of
-// maxRetries retries. If maxRetries is exceeded, return a MaxRetriesExceeded error.
-func DoWithRetryE(t *testing.T, actionDescription string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) (string, error) {
+// maxRetries retries. If maxRetries is exceeded, return a MaxRetriesExceeded error. If the test run is interrupted (see
+// the interrupt package) while this is retrying, return an interrupt.Cancelled error instead of retrying. Retries that
+// start after the interrupt, such as those of the emergency cleanup, are not cancelled.
+func DoWithRetryE(t testing.TB, actionDescription string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) (string, error) {
+	return DoWithPolicyE(t, actionDescription, FixedPolicy(maxRetries, sleepBetweenRetries), action)
+}
+
+// DoWithPolicy runs the specified action. If it returns a value, return that value. If it returns a FatalError, return
+// that error immediately. If it returns any other type of error (or the attempt exceeds the AttemptTimeout of the
+// policy), sleep for as long as the given policy says and try again, up to the MaxRetries of the policy. If MaxRetries
+// is exceeded, fail the test.
+func DoWithPolicy(t testing.TB, actionDescription string, policy Policy, action func() (string, error)) string {
+	out, err := DoWithPolicyE(t, actionDescription, policy, action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// DoWithPolicyE runs the specified action. If it returns a value, return that value. If it returns a FatalError, return
+// that error immediately. If it returns any other type of error (or the attempt exceeds the AttemptTimeout of the
+// policy), sleep for as long as the given policy says and try again, up to the MaxRetries of the policy. If MaxRetries
+// is exceeded, return a MaxRetriesExceeded error. If the test run is interrupted (see the interrupt package) while this
+// is retrying, return an interrupt.Cancelled error instead of retrying. Retries that start after the interrupt, such as
+// those of the emergency cleanup, are not cancelled.
+//
+// Note that an attempt that exceeds the AttemptTimeout is not stopped: it keeps running in the background while the
+// next attempt starts. Use DoWithPolicyContextE for actions that can be cancelled.
+func DoWithPolicyE(t testing.TB, actionDescription string, policy Policy, action func() (string, error)) (string, error) {
+	return DoWithPolicyContextE(t, actionDescription, policy, func(ctx context.Context) (string, error) {
+		return action()
+	})
+}
+
+// DoWithPolicyContext runs the specified action like DoWithPolicy, but passes each attempt a context that is
+// cancelled once the attempt exceeds the AttemptTimeout of the policy, so the action can stop its in-flight work (e.g.,
+// an SDK call made WithContext) before the next attempt starts. If MaxRetries is exceeded, fail the test.
+func DoWithPolicyContext(t testing.TB, actionDescription string, policy Policy, action func(ctx context.Context) (string, error)) string {
+	out, err := DoWithPolicyContextE(t, actionDescription, policy, action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// DoWithPolicyContextE runs the specified action like DoWithPolicyE, but passes each attempt a context that is
+// cancelled once the attempt exceeds the AttemptTimeout of the policy, so the action can stop its in-flight work (e.g.,
+// an SDK call made WithContext) before the next attempt starts. If the policy has no AttemptTimeout, the context is
+// never cancelled.
+func DoWithPolicyContextE(t testing.TB, actionDescription string, policy Policy, action func(ctx context.Context) (string, error)) (string, error) {
 	var output string
 	var err error
 
-	for i := 0; i <= maxRetries; i++ {
+	heartbeat := StartHeartbeatFromEnv(t, actionDescription)
+	defer heartbeat.Stop()
+
+	start := time.Now()
+
+	for i := 0; i <= policy.MaxRetries; i++ {
 		logger.Log(t, actionDescription)
 
-		output, err = action()
+		if policy.AttemptTimeout > 0 {
+			output, err = doWithContextTimeoutE(context.Background(), actionDescription, policy.AttemptTimeout, action)
+		} else {
+			output, err = action(context.Background())
+		}
 		if err == nil {
 			return output, nil
 		}
@@ -81,11 +178,20 @@ func DoWithRetryE(t *testing.T, actionDescription string, maxRetries int, sleepB
 			return output, err
 		}
 
-		logger.Logf(t, "%s returned an error: %s. Sleeping for %s and will try again.", actionDescription, err.Error(), sleepBetweenRetries)
-		time.Sleep(sleepBetweenRetries)
+		// Don't keep retrying once the test run has been interrupted, so the emergency cleanup isn't held up. The retries
+		// of the emergency cleanup itself start after the interrupt, so they carry on.
+		if interrupt.InterruptedAfter(start) {
+			logger.Logf(t, "%s returned an error: %s. Not retrying, as the test run was interrupted.", actionDescription, err.Error())
+			return output, interrupt.Cancelled{Description: actionDescription}
+		}
+
+		sleep := policy.sleepBeforeRetry(i)
+		logger.Logf(t, "%s returned an error: %s. Sleeping for %s and will try again.", actionDescription, err.Error(), sleep)
+		time.Sleep(sleep)
 	}
 
-	return output, MaxRetriesExceeded{Description: actionDescription, MaxRetries: maxRetries}
+	err = MaxRetriesExceeded{Description: actionDescription, MaxRetries: policy.MaxRetries}
+	return output, err
 }
 
 // DoWithRetryableErrors runs the specified action. If it returns a value, return that value. If it returns an error,
@@ -93,7 +199,7 @@ func DoWithRetryE(t *testing.T, actionDescription string, maxRetries int, sleepB
 // matches any of the regular expressions in the specified retryableErrors map. If there is a match, sleep for
 // sleepBetweenRetries, and retry the specified action, up to a maximum of maxRetries retries. If there is no match,
 // return that error immediately, wrapped in a FatalError. If maxRetries is exceeded, return a MaxRetriesExceeded error.
-func DoWithRetryableErrors(t *testing.T, actionDescription string, retryableErrors map[string]string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) string {
+func DoWithRetryableErrors(t testing.TB, actionDescription string, retryableErrors map[string]string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) string {
 	out, err := DoWithRetryableErrorsE(t, actionDescription, retryableErrors, maxRetries, sleepBetweenRetries, action)
 	require.NoError(t, err)
 	return out
@@ -104,7 +210,21 @@ func DoWithRetryableErrors(t *testing.T, actionDescription string, retryableErro
 // matches any of the regular expressions in the specified retryableErrors map. If there is a match, sleep for
 // sleepBetweenRetries, and retry the specified action, up to a maximum of maxRetries retries. If there is no match,
 // return that error immediately, wrapped in a FatalError. If maxRetries is exceeded, return a MaxRetriesExceeded error.
-func DoWithRetryableErrorsE(t *testing.T, actionDescription string, retryableErrors map[string]string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) (string, error) {
+func DoWithRetryableErrorsE(t testing.TB, actionDescription string, retryableErrors map[string]string, maxRetries int, sleepBetweenRetries time.Duration, action func() (string, error)) (string, error) {
+	return DoWithRetryableErrorsAndPolicyE(t, actionDescription, retryableErrors, FixedPolicy(maxRetries, sleepBetweenRetries), action)
+}
+
+// DoWithRetryableErrorsAndPolicy runs the specified action like DoWithRetryableErrors, but sleeps between retries, and
+// limits the number of retries, as the given policy says.
+func DoWithRetryableErrorsAndPolicy(t testing.TB, actionDescription string, retryableErrors map[string]string, policy Policy, action func() (string, error)) string {
+	out, err := DoWithRetryableErrorsAndPolicyE(t, actionDescription, retryableErrors, policy, action)
+	require.NoError(t, err)
+	return out
+}
+
+// DoWithRetryableErrorsAndPolicyE runs the specified action like DoWithRetryableErrorsE, but sleeps between retries,
+// and limits the number of retries, as the given policy says.
+func DoWithRetryableErrorsAndPolicyE(t testing.TB, actionDescription string, retryableErrors map[string]string, policy Policy, action func() (string, error)) (string, error) {
 	retryableErrorsRegexp := map[*regexp.Regexp]string{}
 	for errorStr, errorMessage := range retryableErrors {
 		errorRegex, err := regexp.Compile(errorStr)
@@ -114,7 +234,7 @@ func DoWithRetryableErrorsE(t *testing.T, actionDescription string, retryableErr
 		retryableErrorsRegexp[errorRegex] = errorMessage
 	}
 
-	return DoWithRetryE(t, actionDescription, maxRetries, sleepBetweenRetries, func() (string, error) {
+	return DoWithPolicyE(t, actionDescription, policy, func() (string, error) {
 		output, err := action()
 		if err == nil {
 			return output, nil
@@ -143,7 +263,7 @@ func (done Done) Done() {
 
 // DoInBackgroundUntilStopped runs the specified action in the background (in a goroutine) repeatedly, waiting the specified amount of time between
 // repetitions. To stop this action, call the Done() function on the returned value.
-func DoInBackgroundUntilStopped(t *testing.T, actionDescription string, sleepBetweenRepeats time.Duration, action func()) Done {
+func DoInBackgroundUntilStopped(t testing.TB, actionDescription string, sleepBetweenRepeats time.Duration, action func()) Done {
 	stop := make(chan bool)
 
 	go func() {
@@ -1,11 +1,14 @@
 package retry
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/gruntwork-io/terratest/modules/interrupt"
 )
 
 func TestDoWithRetry(t *testing.T) {
@@ -112,6 +115,33 @@ func TestDoWithTimeout(t *testing.T) {
 	}
 }
 
+func TestDoWithContextTimeout(t *testing.T) {
+	t.Parallel()
+
+	cancelled := make(chan error, 1)
+	_, err := DoWithContextTimeoutE(t, context.Background(), "Hangs until cancelled", 100*time.Millisecond, func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return "", ctx.Err()
+	})
+	assert.Equal(t, TimeoutExceeded{Description: "Hangs until cancelled", Timeout: 100 * time.Millisecond}, err)
+	assert.Equal(t, context.DeadlineExceeded, <-cancelled)
+
+	out, err := DoWithContextTimeoutE(t, context.Background(), "Returns value immediately", 5*time.Second, func(ctx context.Context) (string, error) {
+		return "expected", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "expected", out)
+
+	parentCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = DoWithContextTimeoutE(t, parentCtx, "Parent context cancelled", 5*time.Second, func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	assert.Equal(t, context.Canceled, err)
+}
+
 func TestDoInBackgroundUntilStopped(t *testing.T) {
 	t.Parallel()
 
@@ -261,3 +291,34 @@ type ErrorCounter int
 func (count ErrorCounter) Error() string {
 	return fmt.Sprintf("%d", int(count))
 }
+
+// This test does not call t.Parallel, as interrupting the run affects every retry loop in the process that is running
+// at the time.
+func TestDoWithRetryAfterInterrupt(t *testing.T) {
+	cleanupAttempts := 0
+	var cleanupErr error
+	done := interrupt.OnInterrupt(t, "flaky cleanup", func() error {
+		_, cleanupErr = DoWithRetryE(t, "flaky cleanup", 3, time.Millisecond, func() (string, error) {
+			cleanupAttempts++
+			if cleanupAttempts < 3 {
+				return "", fmt.Errorf("cleanup attempt %d failed", cleanupAttempts)
+			}
+			return "cleaned up", nil
+		})
+		return cleanupErr
+	})
+	defer done()
+
+	// The run is interrupted during the first attempt, so this loop must stop, while the retries of the cleanup carry on
+	testAttempts := 0
+	_, err := DoWithRetryE(t, "interrupted action", 3, time.Millisecond, func() (string, error) {
+		testAttempts++
+		interrupt.RunCleanups(t, time.Minute)
+		return "", fmt.Errorf("attempt %d failed", testAttempts)
+	})
+
+	assert.IsType(t, interrupt.Cancelled{}, err)
+	assert.Equal(t, 1, testAttempts)
+	assert.NoError(t, cleanupErr)
+	assert.Equal(t, 3, cleanupAttempts)
+}
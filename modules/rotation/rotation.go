@@ -0,0 +1,61 @@
+// Package rotation contains functions for checking that credential rotation is wired up end to end: after a secret is
+// rotated, the services that depend on it must pick up the new credential.
+package rotation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+)
+
+// Rotator rotates a credential and returns the new value (or a version ID that identifies it).
+type Rotator func() (string, error)
+
+// Checker returns an error unless the dependent service is using the given new credential, e.g. because a request
+// authenticated with it succeeds, or because the service reports it as its current credential version.
+type Checker func(newCredential string) error
+
+// AwsSecretsManagerRotator returns a Rotator that triggers the rotation Lambda function of the given Secrets Manager
+// secret, waits for the rotation to finish, and returns the new secret value.
+func AwsSecretsManagerRotator(t testing.TB, awsRegion string, secretID string, maxRetries int, sleepBetweenRetries time.Duration) Rotator {
+	return func() (string, error) {
+		return aws.RotateSecretE(t, awsRegion, secretID, maxRetries, sleepBetweenRetries)
+	}
+}
+
+// VerifyRotation rotates a credential using the given Rotator (e.g. AwsSecretsManagerRotator, or a function that
+// adds a new secret version or requests new dynamic credentials from Vault) and then runs the given Checker, retrying
+// up to maxRetries times, until the dependent service has picked up the new credential. The test fails if it does not
+// within that window.
+func VerifyRotation(t testing.TB, rotate Rotator, check Checker, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := VerifyRotationE(t, rotate, check, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// VerifyRotationE rotates a credential using the given Rotator (e.g. AwsSecretsManagerRotator, or a function that
+// adds a new secret version or requests new dynamic credentials from Vault) and then runs the given Checker, retrying
+// up to maxRetries times, until the dependent service has picked up the new credential. An error is returned if it
+// does not within that window.
+func VerifyRotationE(t testing.TB, rotate Rotator, check Checker, maxRetries int, sleepBetweenRetries time.Duration) error {
+	newCredential, err := rotate()
+	if err != nil {
+		return fmt.Errorf("Failed to rotate credential: %v", err)
+	}
+
+	start := time.Now()
+	_, err = retry.DoWithRetryE(t, "Waiting for dependent services to pick up the rotated credential", maxRetries, sleepBetweenRetries, func() (string, error) {
+		return "", check(newCredential)
+	})
+	if err != nil {
+		return fmt.Errorf("Dependent services did not pick up the rotated credential: %v", err)
+	}
+
+	logger.Logf(t, "Dependent services picked up the rotated credential after %s", time.Since(start))
+	return nil
+}
@@ -0,0 +1,60 @@
+package rotation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyRotationWaitsForPickup(t *testing.T) {
+	t.Parallel()
+
+	credential := "v1"
+	serviceCredential := "v1"
+	checks := 0
+
+	rotate := func() (string, error) {
+		credential = "v2"
+		return credential, nil
+	}
+	check := func(newCredential string) error {
+		checks++
+		// The service picks up the new credential on its third check, as if it polled for it
+		if checks == 3 {
+			serviceCredential = credential
+		}
+		if serviceCredential != newCredential {
+			return fmt.Errorf("service still uses %s", serviceCredential)
+		}
+		return nil
+	}
+
+	VerifyRotation(t, rotate, check, 5, time.Millisecond)
+	assert.Equal(t, 3, checks)
+}
+
+func TestVerifyRotationFailsIfNeverPickedUp(t *testing.T) {
+	t.Parallel()
+
+	rotate := func() (string, error) { return "v2", nil }
+	check := func(newCredential string) error { return errors.New("service still uses v1") }
+
+	err := VerifyRotationE(t, rotate, check, 2, time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not pick up")
+}
+
+func TestVerifyRotationReturnsRotateErrors(t *testing.T) {
+	t.Parallel()
+
+	rotate := func() (string, error) { return "", errors.New("rotation Lambda failed") }
+	check := func(newCredential string) error { return nil }
+
+	err := VerifyRotationE(t, rotate, check, 2, time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rotation Lambda failed")
+}
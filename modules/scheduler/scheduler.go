@@ -0,0 +1,174 @@
+// Package scheduler paces parallel tests so that the resources they create fit within the available quota, rather
+// than letting them fail halfway through an apply when the quota runs out.
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/gcp"
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// Requirements are the resources a test needs, keyed by resource name, e.g. {"CPUS": 8, "IN_USE_ADDRESSES": 2,
+// "clusters": 1}. The names are up to you, but must match the names used for the Scheduler's capacity.
+type Requirements map[string]float64
+
+// Scheduler hands out capacity to tests, making tests wait while other tests hold the capacity they need.
+type Scheduler struct {
+	capacity map[string]float64
+	mutex    sync.Mutex
+	inUse    map[string]float64
+	released chan struct{}
+}
+
+// NewScheduler creates a Scheduler with the given capacity, keyed by resource name. Resources without a capacity are
+// treated as unlimited.
+func NewScheduler(t testing.TB, capacity map[string]float64) *Scheduler {
+	logger.Logf(t, "Scheduling tests with capacity %s", formatResources(capacity))
+
+	copied := map[string]float64{}
+	for name, amount := range capacity {
+		copied[name] = amount
+	}
+
+	return &Scheduler{capacity: copied, inUse: map[string]float64{}, released: make(chan struct{})}
+}
+
+// NewSchedulerFromGcpQuotas creates a Scheduler whose capacity is the current headroom (limit minus usage) of the
+// given Compute Engine quota metrics (e.g. CPUS, IN_USE_ADDRESSES) in the given region. Use gcp.GlobalLocation as the
+// region for project-wide quotas.
+func NewSchedulerFromGcpQuotas(t testing.TB, projectID string, region string, metrics []string) *Scheduler {
+	scheduler, err := NewSchedulerFromGcpQuotasE(t, projectID, region, metrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return scheduler
+}
+
+// NewSchedulerFromGcpQuotasE creates a Scheduler whose capacity is the current headroom (limit minus usage) of the
+// given Compute Engine quota metrics (e.g. CPUS, IN_USE_ADDRESSES) in the given region. Use gcp.GlobalLocation as the
+// region for project-wide quotas.
+func NewSchedulerFromGcpQuotasE(t testing.TB, projectID string, region string, metrics []string) (*Scheduler, error) {
+	capacity := map[string]float64{}
+	for _, metric := range metrics {
+		quota, err := gcp.GetComputeQuotaE(t, projectID, region, metric)
+		if err != nil {
+			return nil, err
+		}
+		capacity[metric] = quota.Limit - quota.Usage
+	}
+
+	return NewScheduler(t, capacity), nil
+}
+
+// Acquire waits, up to the given timeout, until the capacity for the given requirements is free and then reserves it.
+// Call the returned function, typically with defer, to release the capacity when the test has destroyed its
+// resources. The test is skipped, with a message saying which resource was short, if the requirements can never fit
+// or do not fit before the timeout.
+func (scheduler *Scheduler) Acquire(t testing.TB, requirements Requirements, timeout time.Duration) func() {
+	release, err := scheduler.AcquireE(t, requirements, timeout)
+	if err != nil {
+		t.Skip(err.Error())
+	}
+	return release
+}
+
+// AcquireE waits, up to the given timeout, until the capacity for the given requirements is free and then reserves
+// it. Call the returned function, typically with defer, to release the capacity when the test has destroyed its
+// resources. An InsufficientCapacity error is returned if the requirements can never fit or do not fit before the
+// timeout.
+func (scheduler *Scheduler) AcquireE(t testing.TB, requirements Requirements, timeout time.Duration) (func(), error) {
+	if shortfall := scheduler.shortfall(requirements, map[string]float64{}); shortfall != "" {
+		return nil, InsufficientCapacity{Requirements: requirements, Reason: fmt.Sprintf("%s exceeds the total capacity", shortfall)}
+	}
+
+	deadline := time.After(timeout)
+	waiting := false
+	for {
+		scheduler.mutex.Lock()
+		shortfall := scheduler.shortfall(requirements, scheduler.inUse)
+		if shortfall == "" {
+			for name, amount := range requirements {
+				scheduler.inUse[name] += amount
+			}
+			scheduler.mutex.Unlock()
+
+			logger.Logf(t, "Acquired capacity for %s", formatResources(requirements))
+			return func() { scheduler.release(t, requirements) }, nil
+		}
+		released := scheduler.released
+		scheduler.mutex.Unlock()
+
+		if !waiting {
+			logger.Logf(t, "Waiting for capacity for %s: %s is in use by other tests", formatResources(requirements), shortfall)
+			waiting = true
+		}
+
+		select {
+		case <-released:
+		case <-deadline:
+			return nil, InsufficientCapacity{Requirements: requirements, Reason: fmt.Sprintf("%s was still in use by other tests after waiting %s", shortfall, timeout)}
+		}
+	}
+}
+
+func (scheduler *Scheduler) release(t testing.TB, requirements Requirements) {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	for name, amount := range requirements {
+		scheduler.inUse[name] -= amount
+	}
+
+	// Wake up every waiting test so they can check whether they fit now
+	close(scheduler.released)
+	scheduler.released = make(chan struct{})
+
+	logger.Logf(t, "Released capacity for %s", formatResources(requirements))
+}
+
+// shortfall returns a description of the first resource (in name order) for which the given requirements do not fit
+// in the capacity left over after the given amounts in use, or an empty string if they fit.
+func (scheduler *Scheduler) shortfall(requirements Requirements, inUse map[string]float64) string {
+	names := []string{}
+	for name := range requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		capacity, limited := scheduler.capacity[name]
+		if !limited {
+			continue
+		}
+		if inUse[name]+requirements[name] > capacity {
+			return fmt.Sprintf("%s (need %v, %v of %v available)", name, requirements[name], capacity-inUse[name], capacity)
+		}
+	}
+
+	return ""
+}
+
+// InsufficientCapacity is returned when there is not enough capacity for a test's requirements.
+type InsufficientCapacity struct {
+	Requirements Requirements
+	Reason       string
+}
+
+func (err InsufficientCapacity) Error() string {
+	return fmt.Sprintf("Not enough capacity for %s: %s", formatResources(err.Requirements), err.Reason)
+}
+
+func formatResources(resources map[string]float64) string {
+	parts := []string{}
+	for name, amount := range resources {
+		parts = append(parts, fmt.Sprintf("%s=%v", name, amount))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireFailsIfRequirementsNeverFit(t *testing.T) {
+	t.Parallel()
+
+	scheduler := NewScheduler(t, map[string]float64{"CPUS": 8})
+
+	_, err := scheduler.AcquireE(t, Requirements{"CPUS": 16}, time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the total capacity")
+}
+
+func TestAcquireTimesOutWhileCapacityInUse(t *testing.T) {
+	t.Parallel()
+
+	scheduler := NewScheduler(t, map[string]float64{"CPUS": 8})
+
+	release, err := scheduler.AcquireE(t, Requirements{"CPUS": 6}, time.Second)
+	require.NoError(t, err)
+	defer release()
+
+	_, err = scheduler.AcquireE(t, Requirements{"CPUS": 4}, 50*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "still in use")
+}
+
+func TestAcquireIgnoresResourcesWithoutCapacity(t *testing.T) {
+	t.Parallel()
+
+	scheduler := NewScheduler(t, map[string]float64{"CPUS": 8})
+
+	release, err := scheduler.AcquireE(t, Requirements{"CPUS": 2, "clusters": 100}, time.Second)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAcquirePacesTestsToFit(t *testing.T) {
+	t.Parallel()
+
+	scheduler := NewScheduler(t, map[string]float64{"CPUS": 8})
+
+	var mutex sync.Mutex
+	running := 0
+	maxRunning := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := scheduler.AcquireE(t, Requirements{"CPUS": 4}, 10*time.Second)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mutex.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+			release()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, maxRunning)
+}
@@ -21,10 +21,12 @@ type Command struct {
 	Args       []string          // The args to pass to the command
 	WorkingDir string            // The working directory
 	Env        map[string]string // Additional environment variables to set
+	Stdin      io.Reader         // The stdin to pass to the command. Defaults to the stdin of this Go program.
+	Logger     *logger.Logger    // The logger to log the command and its output with. Defaults to logger.Global.
 }
 
 // RunCommand runs a shell command and redirects its stdout and stderr to the stdout of the atomic script itself.
-func RunCommand(t *testing.T, command Command) {
+func RunCommand(t testing.TB, command Command) {
 	err := RunCommandE(t, command)
 	if err != nil {
 		t.Fatal(err)
@@ -32,14 +34,14 @@ func RunCommand(t *testing.T, command Command) {
 }
 
 // RunCommandE runs a shell command and redirects its stdout and stderr to the stdout of the atomic script itself.
-func RunCommandE(t *testing.T, command Command) error {
+func RunCommandE(t testing.TB, command Command) error {
 	_, err := RunCommandAndGetOutputE(t, command)
 	return err
 }
 
 // RunCommandAndGetOutput runs a shell command and returns its stdout and stderr as a string. The stdout and stderr of that command will also
 // be printed to the stdout and stderr of this Go program to make debugging easier.
-func RunCommandAndGetOutput(t *testing.T, command Command) string {
+func RunCommandAndGetOutput(t testing.TB, command Command) string {
 	out, err := RunCommandAndGetOutputE(t, command)
 	if err != nil {
 		t.Fatal(err)
@@ -49,30 +51,76 @@ func RunCommandAndGetOutput(t *testing.T, command Command) string {
 
 // RunCommandAndGetOutputE runs a shell command and returns its stdout and stderr as a string. The stdout and stderr of that command will also
 // be printed to the stdout and stderr of this Go program to make debugging easier.
-func RunCommandAndGetOutputE(t *testing.T, command Command) (string, error) {
-	logger.Logf(t, "Running command %s with args %s", command.Command, command.Args)
+func RunCommandAndGetOutputE(t testing.TB, command Command) (string, error) {
+	output, err := runCommandE(t, command)
+	return output.Combined(), err
+}
+
+// RunCommandAndGetStdOut runs a shell command and returns solely its stdout (but not stderr) as a string. This is
+// useful to parse the output of commands that write warnings to stderr. The stdout and stderr of that command will also
+// be printed to the stdout and stderr of this Go program to make debugging easier.
+func RunCommandAndGetStdOut(t testing.TB, command Command) string {
+	out, err := RunCommandAndGetStdOutE(t, command)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// RunCommandAndGetStdOutE runs a shell command and returns solely its stdout (but not stderr) as a string. This is
+// useful to parse the output of commands that write warnings to stderr. The stdout and stderr of that command will also
+// be printed to the stdout and stderr of this Go program to make debugging easier.
+func RunCommandAndGetStdOutE(t testing.TB, command Command) (string, error) {
+	output, err := runCommandE(t, command)
+	return output.Stdout(), err
+}
+
+// commandOutput is the output of a command: the lines it wrote to stdout, and all the lines it wrote to stdout and stderr, in
+// the order they were read.
+type commandOutput struct {
+	stdout   []string
+	combined []string
+}
+
+// Stdout returns the lines the command wrote to stdout.
+func (output *commandOutput) Stdout() string {
+	return strings.Join(output.stdout, "\n")
+}
+
+// Combined returns the lines the command wrote to stdout and stderr, interleaved in the order they were read.
+func (output *commandOutput) Combined() string {
+	return strings.Join(output.combined, "\n")
+}
+
+// runCommandE runs a shell command and returns its output. The output is never nil, and holds what the command wrote
+// before it failed, if it did.
+func runCommandE(t testing.TB, command Command) (*commandOutput, error) {
+	command.Logger.Logf(t, "Running command %s with args %s", command.Command, command.Args)
 
 	cmd := exec.Command(command.Command, command.Args...)
 	cmd.Dir = command.WorkingDir
 	cmd.Stdin = os.Stdin
+	if command.Stdin != nil {
+		cmd.Stdin = command.Stdin
+	}
 	cmd.Env = formatEnvVars(command)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return "", err
+		return &commandOutput{}, err
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		return "", err
+		return &commandOutput{}, err
 	}
 
 	err = cmd.Start()
 	if err != nil {
-		return "", err
+		return &commandOutput{}, err
 	}
 
-	output, err := readStdoutAndStderr(t, stdout, stderr)
+	output, err := readStdoutAndStderr(t, command.Logger, stdout, stderr)
 	if err != nil {
 		return output, err
 	}
@@ -85,8 +133,8 @@ func RunCommandAndGetOutputE(t *testing.T, command Command) (string, error) {
 }
 
 // This function captures stdout and stderr while still printing it to the stdout and stderr of this Go program
-func readStdoutAndStderr(t *testing.T, stdout io.ReadCloser, stderr io.ReadCloser) (string, error) {
-	allOutput := []string{}
+func readStdoutAndStderr(t testing.TB, log *logger.Logger, stdout io.ReadCloser, stderr io.ReadCloser) (*commandOutput, error) {
+	allOutput := &commandOutput{}
 
 	stdoutScanner := bufio.NewScanner(stdout)
 	stderrScanner := bufio.NewScanner(stderr)
@@ -94,33 +142,36 @@ func readStdoutAndStderr(t *testing.T, stdout io.ReadCloser, stderr io.ReadClose
 	wg := &sync.WaitGroup{}
 	mutex := &sync.Mutex{}
 	wg.Add(2)
-	go readData(t, stdoutScanner, wg, mutex, &allOutput)
-	go readData(t, stderrScanner, wg, mutex, &allOutput)
+	go readData(t, log, stdoutScanner, wg, mutex, allOutput, true)
+	go readData(t, log, stderrScanner, wg, mutex, allOutput, false)
 	wg.Wait()
 
 	if err := stdoutScanner.Err(); err != nil {
-		return "", err
+		return &commandOutput{}, err
 	}
 
 	if err := stderrScanner.Err(); err != nil {
-		return "", err
+		return &commandOutput{}, err
 	}
 
-	return strings.Join(allOutput, "\n"), nil
+	return allOutput, nil
 }
 
-func readData(t *testing.T, scanner *bufio.Scanner, wg *sync.WaitGroup, mutex *sync.Mutex, allOutput *[]string) {
+func readData(t testing.TB, log *logger.Logger, scanner *bufio.Scanner, wg *sync.WaitGroup, mutex *sync.Mutex, allOutput *commandOutput, isStdout bool) {
 	defer wg.Done()
 	for scanner.Scan() {
-		logTextAndAppendToOutput(t, mutex, scanner.Text(), allOutput)
+		logTextAndAppendToOutput(t, log, mutex, scanner.Text(), allOutput, isStdout)
 	}
 }
 
-func logTextAndAppendToOutput(t *testing.T, mutex *sync.Mutex, text string, allOutput *[]string) {
+func logTextAndAppendToOutput(t testing.TB, log *logger.Logger, mutex *sync.Mutex, text string, allOutput *commandOutput, isStdout bool) {
 	defer mutex.Unlock()
-	logger.Log(t, text)
+	log.Logf(t, "%s", text)
 	mutex.Lock()
-	*allOutput = append(*allOutput, text)
+	allOutput.combined = append(allOutput.combined, text)
+	if isStdout {
+		allOutput.stdout = append(allOutput.stdout, text)
+	}
 }
 
 // GetExitCodeForRunCommandError tries to read the exit code for the error object returned from running a shell command. This is a bit tricky to do
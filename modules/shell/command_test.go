@@ -24,6 +24,18 @@ func TestRunCommandAndGetOutput(t *testing.T) {
 	assert.Equal(t, text, strings.TrimSpace(out))
 }
 
+func TestRunCommandAndGetStdOut(t *testing.T) {
+	t.Parallel()
+
+	cmd := Command{
+		Command: "bash",
+		Args:    []string{"-c", `echo "WARNING: no swap limit support" >&2; echo '[{"Id": "abc"}]'`},
+	}
+
+	out := RunCommandAndGetStdOut(t, cmd)
+	assert.Equal(t, `[{"Id": "abc"}]`, out)
+}
+
 func TestRunCommandAndGetOutputOrder(t *testing.T) {
 	t.Parallel()
 
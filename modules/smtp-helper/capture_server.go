@@ -0,0 +1,231 @@
+// Package smtp_helper contains helpers for checking that infrastructure under test sends the emails it should.
+package smtp_helper
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+)
+
+// Message is an email captured by a CaptureServer or read from MailHog.
+type Message struct {
+	From    string      // The envelope sender
+	To      []string    // The envelope recipients
+	Subject string      // The Subject header
+	Headers mail.Header // All the headers of the email
+	Body    string      // The body of the email, as it was sent
+}
+
+// CaptureServer is a disposable SMTP server that accepts every email sent to it and keeps it in memory, so tests can
+// check what was sent. It does not support TLS or authentication, so configure the system under test to send to it
+// over plain SMTP.
+type CaptureServer struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	messages []Message
+}
+
+// RunCaptureServer runs an SMTP capture server on the given port. Set the port to 0 to pick a random free port, which
+// you can then look up with the Port() method. Make sure to call the Close() method when you're done!
+func RunCaptureServer(t testing.TB, port int) *CaptureServer {
+	server, err := RunCaptureServerE(t, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+// RunCaptureServerE runs an SMTP capture server on the given port. Set the port to 0 to pick a random free port, which
+// you can then look up with the Port() method. Make sure to call the Close() method when you're done!
+func RunCaptureServerE(t testing.TB, port int) (*CaptureServer, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("error listening: %s", err)
+	}
+
+	server := &CaptureServer{listener: listener}
+	logger.Logf(t, "Started SMTP capture server on port %d", server.Port())
+
+	go server.serve()
+
+	return server, nil
+}
+
+// Port returns the port the server is listening on.
+func (server *CaptureServer) Port() int {
+	return server.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Close stops the server.
+func (server *CaptureServer) Close() error {
+	return server.listener.Close()
+}
+
+// Messages returns all the emails the server has captured so far.
+func (server *CaptureServer) Messages() []Message {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]Message{}, server.messages...)
+}
+
+// WaitForMessage waits until the server has captured an email for which the given matcher returns true, retrying up
+// to maxRetries times, and returns that email.
+func (server *CaptureServer) WaitForMessage(t testing.TB, description string, maxRetries int, sleepBetweenRetries time.Duration, matcher func(Message) bool) Message {
+	message, err := server.WaitForMessageE(t, description, maxRetries, sleepBetweenRetries, matcher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+// WaitForMessageE waits until the server has captured an email for which the given matcher returns true, retrying up
+// to maxRetries times, and returns that email.
+func (server *CaptureServer) WaitForMessageE(t testing.TB, description string, maxRetries int, sleepBetweenRetries time.Duration, matcher func(Message) bool) (Message, error) {
+	return waitForMessageE(t, description, maxRetries, sleepBetweenRetries, matcher, func() ([]Message, error) {
+		return server.Messages(), nil
+	})
+}
+
+func (server *CaptureServer) serve() {
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			// The listener was closed
+			return
+		}
+		go server.handleConnection(conn)
+	}
+}
+
+// handleConnection speaks just enough SMTP (RFC 5321) to accept an email from a client.
+func (server *CaptureServer) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+
+	reply("220 terratest SMTP capture server ready")
+
+	var from string
+	var to []string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "HELO"), strings.HasPrefix(command, "EHLO"):
+			reply("250 Hello")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			from = parseAddress(line[len("MAIL FROM:"):])
+			to = nil
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			to = append(to, parseAddress(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := readData(reader)
+			if err != nil {
+				return
+			}
+			server.capture(from, to, data)
+			reply("250 OK")
+		case command == "RSET":
+			from = ""
+			to = nil
+			reply("250 OK")
+		case command == "NOOP":
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (server *CaptureServer) capture(from string, to []string, data string) {
+	message := Message{From: from, To: to, Body: data, Headers: mail.Header{}}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(data))
+	if err == nil {
+		message.Headers = parsed.Header
+		message.Subject = parsed.Header.Get("Subject")
+		if body, err := ioutil.ReadAll(parsed.Body); err == nil {
+			message.Body = string(body)
+		}
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.messages = append(server.messages, message)
+}
+
+// readData reads the lines of an SMTP DATA command up to the terminating "." line, undoing dot-stuffing.
+func readData(reader *bufio.Reader) (string, error) {
+	lines := []string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "." {
+			return strings.Join(lines, "\r\n"), nil
+		}
+		lines = append(lines, strings.TrimPrefix(line, "."))
+	}
+}
+
+// parseAddress extracts the address from the argument of a MAIL FROM or RCPT TO command, e.g. "<foo@example.com>".
+func parseAddress(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if end := strings.Index(arg, ">"); strings.HasPrefix(arg, "<") && end > 0 {
+		return arg[1:end]
+	}
+	if fields := strings.Fields(arg); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// waitForMessageE polls the given function for messages until one of them matches.
+func waitForMessageE(t testing.TB, description string, maxRetries int, sleepBetweenRetries time.Duration, matcher func(Message) bool, getMessages func() ([]Message, error)) (Message, error) {
+	var found Message
+
+	_, err := retry.DoWithRetryE(t, fmt.Sprintf("Waiting for email: %s", description), maxRetries, sleepBetweenRetries, func() (string, error) {
+		messages, err := getMessages()
+		if err != nil {
+			return "", err
+		}
+
+		for _, message := range messages {
+			if matcher(message) {
+				found = message
+				return "", nil
+			}
+		}
+
+		return "", fmt.Errorf("none of the %d emails received so far match", len(messages))
+	})
+
+	return found, err
+}
@@ -0,0 +1,58 @@
+package smtp_helper
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureServer(t *testing.T) {
+	t.Parallel()
+
+	server := RunCaptureServer(t, 0)
+	defer server.Close()
+
+	body := "Subject: CPU alarm\r\n\r\nCPU is above 90%\r\n.leading dot\r\n"
+	err := smtp.SendMail(fmt.Sprintf("localhost:%d", server.Port()), nil, "alerts@example.com", []string{"oncall@example.com"}, []byte(body))
+	require.NoError(t, err)
+
+	message := server.WaitForMessage(t, "CPU alarm", 10, 100*time.Millisecond, func(message Message) bool {
+		return message.Subject == "CPU alarm"
+	})
+
+	assert.Equal(t, "alerts@example.com", message.From)
+	assert.Equal(t, []string{"oncall@example.com"}, message.To)
+	assert.Equal(t, "CPU is above 90%\r\n.leading dot", message.Body)
+}
+
+func TestCaptureServerNoMatch(t *testing.T) {
+	t.Parallel()
+
+	server := RunCaptureServer(t, 0)
+	defer server.Close()
+
+	_, err := server.WaitForMessageE(t, "nothing sent", 1, 10*time.Millisecond, func(message Message) bool { return true })
+	assert.Error(t, err)
+}
+
+func TestParseMailHogMessages(t *testing.T) {
+	t.Parallel()
+
+	body := `{"total": 1, "items": [{
+		"Raw": {"From": "alerts@example.com", "To": ["oncall@example.com"]},
+		"Content": {"Headers": {"Subject": ["CPU alarm"]}, "Body": "CPU is above 90%"}
+	}]}`
+
+	messages, err := parseMailHogMessages([]byte(body))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "alerts@example.com", messages[0].From)
+	assert.Equal(t, []string{"oncall@example.com"}, messages[0].To)
+	assert.Equal(t, "CPU alarm", messages[0].Subject)
+	assert.True(t, strings.HasPrefix(messages[0].Body, "CPU"))
+}
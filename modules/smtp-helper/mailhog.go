@@ -0,0 +1,102 @@
+package smtp_helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// The subset of the MailHog API v2 messages response (https://github.com/mailhog/MailHog/blob/master/docs/APIv2.md)
+// that we need.
+type mailHogMessages struct {
+	Items []struct {
+		Raw struct {
+			From string
+			To   []string
+		}
+		Content struct {
+			Headers map[string][]string
+			Body    string
+		}
+	} `json:"items"`
+}
+
+// GetMailHogMessages gets all the emails captured by the MailHog server with the given base URL (e.g.
+// http://localhost:8025).
+func GetMailHogMessages(t testing.TB, mailHogUrl string) []Message {
+	messages, err := GetMailHogMessagesE(t, mailHogUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return messages
+}
+
+// GetMailHogMessagesE gets all the emails captured by the MailHog server with the given base URL (e.g.
+// http://localhost:8025).
+func GetMailHogMessagesE(t testing.TB, mailHogUrl string) ([]Message, error) {
+	url := strings.TrimSuffix(mailHogUrl, "/") + "/api/v2/messages"
+	logger.Logf(t, "Getting emails from MailHog at %s", url)
+
+	response, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d: %s", url, response.StatusCode, string(body))
+	}
+
+	return parseMailHogMessages(body)
+}
+
+// WaitForMailHogMessage waits until the MailHog server with the given base URL has captured an email for which the
+// given matcher returns true, retrying up to maxRetries times, and returns that email.
+func WaitForMailHogMessage(t testing.TB, mailHogUrl string, description string, maxRetries int, sleepBetweenRetries time.Duration, matcher func(Message) bool) Message {
+	message, err := WaitForMailHogMessageE(t, mailHogUrl, description, maxRetries, sleepBetweenRetries, matcher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+// WaitForMailHogMessageE waits until the MailHog server with the given base URL has captured an email for which the
+// given matcher returns true, retrying up to maxRetries times, and returns that email.
+func WaitForMailHogMessageE(t testing.TB, mailHogUrl string, description string, maxRetries int, sleepBetweenRetries time.Duration, matcher func(Message) bool) (Message, error) {
+	return waitForMessageE(t, description, maxRetries, sleepBetweenRetries, matcher, func() ([]Message, error) {
+		return GetMailHogMessagesE(t, mailHogUrl)
+	})
+}
+
+func parseMailHogMessages(body []byte) ([]Message, error) {
+	var response mailHogMessages
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("Failed to parse MailHog response: %v", err)
+	}
+
+	messages := []Message{}
+	for _, item := range response.Items {
+		headers := mail.Header(item.Content.Headers)
+		messages = append(messages, Message{
+			From:    item.Raw.From,
+			To:      item.Raw.To,
+			Subject: headers.Get("Subject"),
+			Headers: headers,
+			Body:    item.Content.Body,
+		})
+	}
+
+	return messages, nil
+}
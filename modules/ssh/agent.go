@@ -24,7 +24,7 @@ type SshAgent struct {
 
 // Create SSH agent, start it in background and returns control back to the main thread
 // You should stop the agent to cleanup files afterwards by calling `defer s.Stop()`
-func NewSshAgent(t *testing.T, socketDir string, socketFile string) (*SshAgent, error) {
+func NewSshAgent(t testing.TB, socketDir string, socketFile string) (*SshAgent, error) {
 	var err error
 	s := &SshAgent{make(chan bool), make(chan bool), socketDir, socketFile, agent.NewKeyring(), nil}
 	s.ln, err = net.Listen("unix", s.socketFile)
@@ -41,7 +41,7 @@ func (s *SshAgent) SocketFile() string {
 }
 
 // SSH Agent listener and handler
-func (s *SshAgent) run(t *testing.T) {
+func (s *SshAgent) run(t testing.TB) {
 	defer close(s.stopped)
 	for {
 		select {
@@ -83,7 +83,7 @@ func (s *SshAgent) Stop() {
 
 // Instantiates and returns an in-memory ssh agent with the given KeyPair already added
 // You should stop the agent to cleanup files afterwards by calling `defer sshAgent.Stop()`
-func SshAgentWithKeyPair(t *testing.T, keyPair *KeyPair) *SshAgent {
+func SshAgentWithKeyPair(t testing.TB, keyPair *KeyPair) *SshAgent {
 	sshAgent, err := SshAgentWithKeyPairE(t, keyPair)
 
 	if err != nil {
@@ -93,12 +93,12 @@ func SshAgentWithKeyPair(t *testing.T, keyPair *KeyPair) *SshAgent {
 	return sshAgent
 }
 
-func SshAgentWithKeyPairE(t *testing.T, keyPair *KeyPair) (*SshAgent, error) {
+func SshAgentWithKeyPairE(t testing.TB, keyPair *KeyPair) (*SshAgent, error) {
 	sshAgent, err := SshAgentWithKeyPairsE(t, []*KeyPair{keyPair})
 	return sshAgent, err
 }
 
-func SshAgentWithKeyPairs(t *testing.T, keyPairs []*KeyPair) *SshAgent {
+func SshAgentWithKeyPairs(t testing.TB, keyPairs []*KeyPair) *SshAgent {
 	sshAgent, err := SshAgentWithKeyPairsE(t, keyPairs)
 
 	if err != nil {
@@ -110,7 +110,7 @@ func SshAgentWithKeyPairs(t *testing.T, keyPairs []*KeyPair) *SshAgent {
 
 // Instantiates and returns an in-memory ssh agent with the given KeyPair(s) already added
 // You should stop the agent to cleanup files afterwards by calling `defer sshAgent.Stop()`
-func SshAgentWithKeyPairsE(t *testing.T, keyPairs []*KeyPair) (*SshAgent, error) {
+func SshAgentWithKeyPairsE(t testing.TB, keyPairs []*KeyPair) (*SshAgent, error) {
 	t.Log("Generating SSH Agent with given KeyPair(s)")
 
 	// Instantiate a temporary SSH agent
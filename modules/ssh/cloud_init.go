@@ -0,0 +1,80 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+)
+
+// cloudInitStatusCommand prints the cloud-init status. Versions of cloud-init older than 18.2 do not have a status
+// command, so for those we fall back to checking for the boot-finished file cloud-init writes when it is done.
+const cloudInitStatusCommand = "cloud-init status 2>/dev/null || (test -f /var/lib/cloud/instance/boot-finished && echo 'status: done' || echo 'status: running')"
+
+const cloudInitLogCommand = "sudo tail -n 100 /var/log/cloud-init-output.log"
+
+// WaitForCloudInit waits until cloud-init has finished running on the given host, retrying the check (including
+// connecting over SSH, which may not be up yet) up to maxRetries times. The test fails, showing the end of the
+// cloud-init output log, if cloud-init reports an error. Use this instead of a fixed sleep before running SSH
+// assertions against a new server.
+func WaitForCloudInit(t testing.TB, host Host, maxRetries int, sleepBetweenRetries time.Duration) {
+	err := WaitForCloudInitE(t, host, maxRetries, sleepBetweenRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// WaitForCloudInitE waits until cloud-init has finished running on the given host, retrying the check (including
+// connecting over SSH, which may not be up yet) up to maxRetries times. An error, including the end of the cloud-init
+// output log, is returned if cloud-init reports an error. Use this instead of a fixed sleep before running SSH
+// assertions against a new server.
+func WaitForCloudInitE(t testing.TB, host Host, maxRetries int, sleepBetweenRetries time.Duration) error {
+	description := fmt.Sprintf("Waiting for cloud-init to finish on %s", host.Hostname)
+
+	_, err := retry.DoWithRetryE(t, description, maxRetries, sleepBetweenRetries, func() (string, error) {
+		out, err := CheckSshCommandE(t, host, cloudInitStatusCommand)
+		if err != nil {
+			return "", err
+		}
+
+		switch parseCloudInitStatus(out) {
+		case cloudInitDone:
+			return "", nil
+		case cloudInitError:
+			log, _ := CheckSshCommandE(t, host, cloudInitLogCommand)
+			return "", retry.FatalError{Underlying: fmt.Errorf("cloud-init failed on %s. End of /var/log/cloud-init-output.log:\n%s", host.Hostname, log)}
+		default:
+			return "", fmt.Errorf("cloud-init is still running on %s", host.Hostname)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Logf(t, "cloud-init has finished on %s", host.Hostname)
+	return nil
+}
+
+type cloudInitState int
+
+const (
+	cloudInitRunning cloudInitState = iota
+	cloudInitDone
+	cloudInitError
+)
+
+// parseCloudInitStatus parses the output of cloudInitStatusCommand, e.g. "status: done". A host where cloud-init is
+// disabled has nothing to wait for, so that counts as done.
+func parseCloudInitStatus(out string) cloudInitState {
+	switch {
+	case strings.Contains(out, "status: error"):
+		return cloudInitError
+	case strings.Contains(out, "status: done"), strings.Contains(out, "status: disabled"):
+		return cloudInitDone
+	default:
+		return cloudInitRunning
+	}
+}
@@ -0,0 +1,36 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCloudInitStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		out      string
+		expected cloudInitState
+	}{
+		{"Running", "status: running\n", cloudInitRunning},
+		{"NotStarted", "status: not run\n", cloudInitRunning},
+		{"Done", "status: done\n", cloudInitDone},
+		{"Disabled", "status: disabled\n", cloudInitDone},
+		{"Error", "status: error\n", cloudInitError},
+		{"ErrorWithFallback", "status: error\nstatus: done\n", cloudInitError},
+		{"Empty", "", cloudInitRunning},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, parseCloudInitStatus(testCase.out))
+		})
+	}
+}
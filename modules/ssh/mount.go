@@ -0,0 +1,114 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/random"
+)
+
+// ShareMountOptions describes a network file share (e.g. Filestore or EFS over NFS, or Azure Files over SMB) to mount
+// from a test host.
+type ShareMountOptions struct {
+	FsType       string // The file system type to pass to mount -t, e.g. nfs, nfs4, or cifs (for SMB)
+	Source       string // The share to mount, e.g. 10.0.0.2:/vol1 for NFS or //account.file.core.windows.net/share for SMB
+	MountOptions string // Extra options to pass to mount -o, e.g. nfsvers=4.1 or vers=3.0
+	Username     string // The user name for shares that require credentials, such as SMB shares
+	Password     string // The password for shares that require credentials. It is passed to mount in a credentials file.
+	MountPoint   string // The folder on the test host to mount the share on. Defaults to a unique folder in /tmp.
+}
+
+// CheckShareMount connects to the given host over SSH, mounts the given share, writes a file to it, reads the file
+// back, deletes it, and unmounts the share, failing the test if any of these steps fail. The host must have the
+// client for the share installed (e.g. nfs-common or cifs-utils) and the SSH user must be able to run sudo.
+func CheckShareMount(t testing.TB, host Host, options ShareMountOptions) {
+	err := CheckShareMountE(t, host, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CheckShareMountE connects to the given host over SSH, mounts the given share, writes a file to it, reads the file
+// back, deletes it, and unmounts the share, returning an error if any of these steps fail. The host must have the
+// client for the share installed (e.g. nfs-common or cifs-utils) and the SSH user must be able to run sudo.
+func CheckShareMountE(t testing.TB, host Host, options ShareMountOptions) error {
+	id := strings.ToLower(random.UniqueId())
+	if options.MountPoint == "" {
+		options.MountPoint = "/tmp/terratest-mount-" + id
+	}
+	testFile := "terratest-" + id + ".txt"
+	content := "terratest-" + random.UniqueId()
+
+	// Pass the credentials to mount in a file only the SSH user can read, rather than on the command line, where they
+	// would show up in the logs and in the process list of the host. The mount command removes the file on exit.
+	credentialsFile := ""
+	if options.Username != "" || options.Password != "" {
+		credentialsFile = "/tmp/terratest-mount-" + id + ".credentials"
+		if err := ScpFileToE(t, host, 0600, credentialsFile, formatShareCredentials(options)); err != nil {
+			return fmt.Errorf("Failed to upload the credentials for %s to %s: %v", options.Source, host.Hostname, err)
+		}
+	}
+
+	out, err := CheckSshCommandE(t, host, buildShareMountCommand(options, credentialsFile, testFile, content))
+	if err != nil {
+		return fmt.Errorf("Failed to mount, write to, and read from %s on %s: %v\n%s", options.Source, host.Hostname, err, out)
+	}
+
+	if !strings.Contains(out, content) {
+		return fmt.Errorf("Expected to read back '%s' from %s on %s, but got: %s", content, options.Source, host.Hostname, out)
+	}
+
+	return nil
+}
+
+// buildShareMountCommand returns a shell command that mounts the share, writes the given content to the given test
+// file, reads it back, and deletes it. If credentialsFile is not empty, mount reads the credentials from it. The share
+// is unmounted and the mount point and credentials file removed on exit, even if one of the steps fails, so the test
+// host is left as it was.
+func buildShareMountCommand(options ShareMountOptions, credentialsFile string, testFile string, content string) string {
+	mountOptions := []string{}
+	if credentialsFile != "" {
+		mountOptions = append(mountOptions, "credentials="+credentialsFile)
+	}
+	if options.MountOptions != "" {
+		mountOptions = append(mountOptions, options.MountOptions)
+	}
+
+	mountArgs := []string{"-t", shellQuote(options.FsType)}
+	if len(mountOptions) > 0 {
+		mountArgs = append(mountArgs, "-o", shellQuote(strings.Join(mountOptions, ",")))
+	}
+	mountArgs = append(mountArgs, shellQuote(options.Source), shellQuote(options.MountPoint))
+
+	mountPoint := shellQuote(options.MountPoint)
+	filePath := shellQuote(options.MountPoint + "/" + testFile)
+
+	cleanup := fmt.Sprintf("sudo umount %s 2>/dev/null; sudo rmdir %s 2>/dev/null", mountPoint, mountPoint)
+	if credentialsFile != "" {
+		cleanup += fmt.Sprintf("; rm -f %s", shellQuote(credentialsFile))
+	}
+
+	commands := []string{
+		"set -e",
+		fmt.Sprintf(`trap "%s" EXIT`, cleanup),
+		fmt.Sprintf("sudo mkdir -p %s", mountPoint),
+		fmt.Sprintf("sudo mount %s", strings.Join(mountArgs, " ")),
+		fmt.Sprintf("echo %s | sudo tee %s > /dev/null", shellQuote(content), filePath),
+		fmt.Sprintf("sudo cat %s", filePath),
+		fmt.Sprintf("sudo rm %s", filePath),
+	}
+
+	return strings.Join(commands, "; ")
+}
+
+// shellQuote wraps the given value in single quotes so the shell passes it through as is.
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+// formatShareCredentials returns the contents of a credentials file for mount.cifs with the user name and password in
+// the given options.
+func formatShareCredentials(options ShareMountOptions) string {
+	return fmt.Sprintf("username=%s\npassword=%s\n", options.Username, options.Password)
+}
@@ -0,0 +1,48 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildShareMountCommandNfs(t *testing.T) {
+	t.Parallel()
+
+	options := ShareMountOptions{FsType: "nfs", Source: "10.0.0.2:/vol1", MountOptions: "nfsvers=4.1", MountPoint: "/mnt/test"}
+	command := buildShareMountCommand(options, "", "test.txt", "hello")
+
+	assert.Equal(t, "set -e; "+
+		`trap "sudo umount '/mnt/test' 2>/dev/null; sudo rmdir '/mnt/test' 2>/dev/null" EXIT; `+
+		"sudo mkdir -p '/mnt/test'; "+
+		"sudo mount -t 'nfs' -o 'nfsvers=4.1' '10.0.0.2:/vol1' '/mnt/test'; "+
+		"echo 'hello' | sudo tee '/mnt/test/test.txt' > /dev/null; "+
+		"sudo cat '/mnt/test/test.txt'; "+
+		"sudo rm '/mnt/test/test.txt'", command)
+}
+
+func TestBuildShareMountCommandSmbWithCredentials(t *testing.T) {
+	t.Parallel()
+
+	options := ShareMountOptions{FsType: "cifs", Source: "//account.file.core.windows.net/share", Username: "account", Password: "it's-secret", MountOptions: "vers=3.0", MountPoint: "/mnt/test"}
+	command := buildShareMountCommand(options, "/tmp/test.credentials", "test.txt", "hello")
+
+	assert.Contains(t, command, `trap "sudo umount '/mnt/test' 2>/dev/null; sudo rmdir '/mnt/test' 2>/dev/null; rm -f '/tmp/test.credentials'" EXIT`)
+	assert.Contains(t, command, `sudo mount -t 'cifs' -o 'credentials=/tmp/test.credentials,vers=3.0' '//account.file.core.windows.net/share' '/mnt/test'`)
+	assert.NotContains(t, command, "it's-secret")
+	assert.NotContains(t, command, "username=")
+}
+
+func TestFormatShareCredentials(t *testing.T) {
+	t.Parallel()
+
+	options := ShareMountOptions{Username: "account", Password: "it's-secret"}
+	assert.Equal(t, "username=account\npassword=it's-secret\n", formatShareCredentials(options))
+}
+
+func TestShellQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "'foo'", shellQuote("foo"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
@@ -39,7 +39,7 @@ type SshSession struct {
 }
 
 // Cleanup cleans up an existing SSH session.
-func (sshSession *SshSession) Cleanup(t *testing.T) {
+func (sshSession *SshSession) Cleanup(t testing.TB) {
 	if sshSession == nil {
 		return
 	}
@@ -59,7 +59,7 @@ type JumpHostSession struct {
 }
 
 // Cleanup cleans the jump host session up.
-func (jumpHost *JumpHostSession) Cleanup(t *testing.T) {
+func (jumpHost *JumpHostSession) Cleanup(t testing.TB) {
 	if jumpHost == nil {
 		return
 	}
@@ -77,7 +77,7 @@ type Closeable interface {
 }
 
 // Close closes a Closeable.
-func Close(t *testing.T, closeable Closeable, ignoreErrors ...string) {
+func Close(t testing.TB, closeable Closeable, ignoreErrors ...string) {
 	if interfaceIsNil(closeable) {
 		return
 	}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -29,18 +30,29 @@ type Host struct {
 	SshKeyPair       *KeyPair  // ssh key pair to use as authentication method (disabled by default)
 	SshAgent         bool      // enable authentication using your existing local SSH agent (disabled by default)
 	OverrideSshAgent *SshAgent // enable an in process `SshAgent` for connections to this host (disabled by default)
+	CustomPort       int       // port to connect to instead of the default SSH port 22 (optional)
+}
+
+// getPort returns the port to use for SSH connections to this host: CustomPort if it is set, or 22 otherwise.
+func (host Host) getPort() int {
+	if host.CustomPort != 0 {
+		return host.CustomPort
+	}
+	return 22
 }
 
 type ScpDownloadOptions struct {
-	FileNameFilters []string //File names to match. May include bash-style wildcards. E.g., *.log.
-	MaxFileSizeMB   int      //Don't grab any files > MaxFileSizeMB
-	RemoteDir       string   //Copy from this directory on the remote machine
-	LocalDir        string   //Copy RemoteDir to this directory on the local machine
-	RemoteHost      Host     //Connection information for the remote machine
+	FileNameFilters        []string //File names to match. May include bash-style wildcards. E.g., *.log.
+	ExcludeFileNameFilters []string //File names to skip, even if they match FileNameFilters. May include bash-style wildcards. E.g., *.gz.
+	MaxFileSizeMB          int      //Don't grab any files > MaxFileSizeMB
+	MaxParallelTransfers   int      //Download up to this many files at once. Files are downloaded one at a time if unset.
+	RemoteDir              string   //Copy from this directory on the remote machine
+	LocalDir               string   //Copy RemoteDir to this directory on the local machine
+	RemoteHost             Host     //Connection information for the remote machine
 }
 
 // ScpFileToE uploads the contents using SCP to the given host and fails the test if the connection fails.
-func ScpFileTo(t *testing.T, host Host, mode os.FileMode, remotePath, contents string) {
+func ScpFileTo(t testing.TB, host Host, mode os.FileMode, remotePath, contents string) {
 	err := ScpFileToE(t, host, mode, remotePath, contents)
 	if err != nil {
 		t.Fatal(err)
@@ -48,7 +60,7 @@ func ScpFileTo(t *testing.T, host Host, mode os.FileMode, remotePath, contents s
 }
 
 // ScpFileToE uploads the contents using SCP to the given host and return an error if the process fails.
-func ScpFileToE(t *testing.T, host Host, mode os.FileMode, remotePath, contents string) error {
+func ScpFileToE(t testing.TB, host Host, mode os.FileMode, remotePath, contents string) error {
 	authMethods, err := createAuthMethodsForHost(host)
 	if err != nil {
 		return err
@@ -58,7 +70,7 @@ func ScpFileToE(t *testing.T, host Host, mode os.FileMode, remotePath, contents
 	hostOptions := SshConnectionOptions{
 		Username:    host.SshUserName,
 		Address:     host.Hostname,
-		Port:        22,
+		Port:        host.getPort(),
 		Command:     "/usr/bin/scp -t " + dir,
 		AuthMethods: authMethods,
 	}
@@ -78,7 +90,7 @@ func ScpFileToE(t *testing.T, host Host, mode os.FileMode, remotePath, contents
 }
 
 // ScpFileFrom downloads the file from remotePath on the given host using SCP.
-func ScpFileFrom(t *testing.T, host Host, remotePath string, localDestination *os.File, useSudo bool) {
+func ScpFileFrom(t testing.TB, host Host, remotePath string, localDestination *os.File, useSudo bool) {
 	err := ScpFileFromE(t, host, remotePath, localDestination, useSudo)
 
 	if err != nil {
@@ -87,7 +99,7 @@ func ScpFileFrom(t *testing.T, host Host, remotePath string, localDestination *o
 }
 
 // ScpFileFromE downloads the file from remotePath on the given host using SCP and returns an error if the process fails.
-func ScpFileFromE(t *testing.T, host Host, remotePath string, localDestination *os.File, useSudo bool) error {
+func ScpFileFromE(t testing.TB, host Host, remotePath string, localDestination *os.File, useSudo bool) error {
 	authMethods, err := createAuthMethodsForHost(host)
 
 	if err != nil {
@@ -99,7 +111,7 @@ func ScpFileFromE(t *testing.T, host Host, remotePath string, localDestination *
 	hostOptions := SshConnectionOptions{
 		Username:    host.SshUserName,
 		Address:     host.Hostname,
-		Port:        22,
+		Port:        host.getPort(),
 		Command:     "/usr/bin/scp -t " + dir,
 		AuthMethods: authMethods,
 	}
@@ -115,7 +127,7 @@ func ScpFileFromE(t *testing.T, host Host, remotePath string, localDestination *
 }
 
 // ScpDirFrom downloads all the files from remotePath on the given host using SCP.
-func ScpDirFrom(t *testing.T, options ScpDownloadOptions, useSudo bool) {
+func ScpDirFrom(t testing.TB, options ScpDownloadOptions, useSudo bool) {
 	err := ScpDirFromE(t, options, useSudo)
 
 	if err != nil {
@@ -124,31 +136,17 @@ func ScpDirFrom(t *testing.T, options ScpDownloadOptions, useSudo bool) {
 }
 
 // ScpDirFromE downloads all the files from remotePath on the given host using SCP
-// and returns an error if the process fails. NOTE: only files within remotePath will
-// be downloaded. This function will not recursively download subdirectories or follow
-// symlinks.
-func ScpDirFromE(t *testing.T, options ScpDownloadOptions, useSudo bool) error {
+// and returns an error if the process fails. Use the FileNameFilters and ExcludeFileNameFilters
+// in options to select which files to download, and MaxParallelTransfers to download several
+// files at once. NOTE: only files within remotePath will be downloaded. This function will not
+// recursively download subdirectories or follow symlinks.
+func ScpDirFromE(t testing.TB, options ScpDownloadOptions, useSudo bool) error {
 	authMethods, err := createAuthMethodsForHost(options.RemoteHost)
 	if err != nil {
 		return err
 	}
 
-	hostOptions := SshConnectionOptions{
-		Username:    options.RemoteHost.SshUserName,
-		Address:     options.RemoteHost.Hostname,
-		Port:        22,
-		Command:     "/usr/bin/scp -t " + options.RemoteDir,
-		AuthMethods: authMethods,
-	}
-
-	sshSession := &SshSession{
-		Options:  &hostOptions,
-		JumpHost: &JumpHostSession{},
-	}
-
-	defer sshSession.Cleanup(t)
-
-	filesInDir, err := listFileInRemoteDir(t, sshSession, options, useSudo)
+	filesInDir, err := listFileInRemoteDir(t, options, useSudo)
 
 	if err != nil {
 		return err
@@ -162,29 +160,64 @@ func ScpDirFromE(t *testing.T, options ScpDownloadOptions, useSudo bool) error {
 		}
 	}
 
-	errorsOccurred := []error{}
+	parallelTransfers := options.MaxParallelTransfers
+	if parallelTransfers < 1 {
+		parallelTransfers = 1
+	}
 
-	for _, fullRemoteFilePath := range filesInDir {
-		fileName := filepath.Base(fullRemoteFilePath)
+	// Each download gets its own SSH session, so limit the number of sessions open at once with a semaphore
+	semaphore := make(chan struct{}, parallelTransfers)
+	errorsOccurred := make([]error, len(filesInDir))
+	var wg sync.WaitGroup
 
-		localFilePath := filepath.Join(options.LocalDir, fileName)
-		localFile, err := os.Create(localFilePath)
+	for i, fullRemoteFilePath := range filesInDir {
+		wg.Add(1)
+		semaphore <- struct{}{}
 
-		if err != nil {
-			return err
-		}
+		go func(i int, fullRemoteFilePath string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
 
-		logger.Logf(t, "Copying remote file: %s to local path %s", fullRemoteFilePath, localFilePath)
-
-		err = copyFileFromRemote(t, sshSession, localFile, fullRemoteFilePath, useSudo)
-		errorsOccurred = append(errorsOccurred, err)
+			localFilePath := filepath.Join(options.LocalDir, filepath.Base(fullRemoteFilePath))
+			errorsOccurred[i] = downloadRemoteFileE(t, options.RemoteHost, authMethods, fullRemoteFilePath, localFilePath, useSudo)
+		}(i, fullRemoteFilePath)
 	}
 
+	wg.Wait()
+
 	return customerrors.NewMultiError(errorsOccurred...)
 }
 
+// downloadRemoteFileE downloads the file at remotePath on the given host to localPath over a new SSH session.
+func downloadRemoteFileE(t testing.TB, host Host, authMethods []ssh.AuthMethod, remotePath string, localPath string, useSudo bool) error {
+	localFile, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer localFile.Close()
+
+	hostOptions := SshConnectionOptions{
+		Username:    host.SshUserName,
+		Address:     host.Hostname,
+		Port:        host.getPort(),
+		Command:     "/usr/bin/scp -t " + filepath.Dir(remotePath),
+		AuthMethods: authMethods,
+	}
+
+	sshSession := &SshSession{
+		Options:  &hostOptions,
+		JumpHost: &JumpHostSession{},
+	}
+
+	defer sshSession.Cleanup(t)
+
+	logger.Logf(t, "Copying remote file: %s to local path %s", remotePath, localPath)
+
+	return copyFileFromRemote(t, sshSession, localFile, remotePath, useSudo)
+}
+
 // CheckSshConnection checks that you can connect via SSH to the given host and fail the test if the connection fails.
-func CheckSshConnection(t *testing.T, host Host) {
+func CheckSshConnection(t testing.TB, host Host) {
 	err := CheckSshConnectionE(t, host)
 	if err != nil {
 		t.Fatal(err)
@@ -192,13 +225,13 @@ func CheckSshConnection(t *testing.T, host Host) {
 }
 
 // CheckSshConnectionE checks that you can connect via SSH to the given host and return an error if the connection fails.
-func CheckSshConnectionE(t *testing.T, host Host) error {
+func CheckSshConnectionE(t testing.TB, host Host) error {
 	_, err := CheckSshCommandE(t, host, "'exit'")
 	return err
 }
 
 // CheckSshCommand checks that you can connect via SSH to the given host and run the given command. Returns the stdout/stderr.
-func CheckSshCommand(t *testing.T, host Host, command string) string {
+func CheckSshCommand(t testing.TB, host Host, command string) string {
 	out, err := CheckSshCommandE(t, host, command)
 	if err != nil {
 		t.Fatal(err)
@@ -207,7 +240,7 @@ func CheckSshCommand(t *testing.T, host Host, command string) string {
 }
 
 // CheckSshCommandE checks that you can connect via SSH to the given host and run the given command. Returns the stdout/stderr.
-func CheckSshCommandE(t *testing.T, host Host, command string) (string, error) {
+func CheckSshCommandE(t testing.TB, host Host, command string) (string, error) {
 	authMethods, err := createAuthMethodsForHost(host)
 	if err != nil {
 		return "", err
@@ -216,7 +249,7 @@ func CheckSshCommandE(t *testing.T, host Host, command string) (string, error) {
 	hostOptions := SshConnectionOptions{
 		Username:    host.SshUserName,
 		Address:     host.Hostname,
-		Port:        22,
+		Port:        host.getPort(),
 		Command:     command,
 		AuthMethods: authMethods,
 	}
@@ -234,7 +267,7 @@ func CheckSshCommandE(t *testing.T, host Host, command string) (string, error) {
 // CheckPrivateSshConnection attempts to connect to privateHost (which is not addressable from the Internet) via a
 // separate publicHost (which is addressable from the Internet) and then executes "command" on privateHost and returns
 // its output. It is useful for checking that it's possible to SSH from a Bastion Host to a private instance.
-func CheckPrivateSshConnection(t *testing.T, publicHost Host, privateHost Host, command string) string {
+func CheckPrivateSshConnection(t testing.TB, publicHost Host, privateHost Host, command string) string {
 	out, err := CheckPrivateSshConnectionE(t, publicHost, privateHost, command)
 	if err != nil {
 		t.Fatal(err)
@@ -245,7 +278,7 @@ func CheckPrivateSshConnection(t *testing.T, publicHost Host, privateHost Host,
 // CheckPrivateSshConnectionE attempts to connect to privateHost (which is not addressable from the Internet) via a
 // separate publicHost (which is addressable from the Internet) and then executes "command" on privateHost and returns
 // its output. It is useful for checking that it's possible to SSH from a Bastion Host to a private instance.
-func CheckPrivateSshConnectionE(t *testing.T, publicHost Host, privateHost Host, command string) (string, error) {
+func CheckPrivateSshConnectionE(t testing.TB, publicHost Host, privateHost Host, command string) (string, error) {
 	jumpHostAuthMethods, err := createAuthMethodsForHost(publicHost)
 	if err != nil {
 		return "", err
@@ -254,7 +287,7 @@ func CheckPrivateSshConnectionE(t *testing.T, publicHost Host, privateHost Host,
 	jumpHostOptions := SshConnectionOptions{
 		Username:    publicHost.SshUserName,
 		Address:     publicHost.Hostname,
-		Port:        22,
+		Port:        publicHost.getPort(),
 		AuthMethods: jumpHostAuthMethods,
 	}
 
@@ -266,7 +299,7 @@ func CheckPrivateSshConnectionE(t *testing.T, publicHost Host, privateHost Host,
 	hostOptions := SshConnectionOptions{
 		Username:    privateHost.SshUserName,
 		Address:     privateHost.Hostname,
-		Port:        22,
+		Port:        privateHost.getPort(),
 		Command:     command,
 		AuthMethods: hostAuthMethods,
 		JumpHost:    &jumpHostOptions,
@@ -285,7 +318,7 @@ func CheckPrivateSshConnectionE(t *testing.T, publicHost Host, privateHost Host,
 // FetchContentsOfFiles connects to the given host via SSH and fetches the contents of the files at the given filePaths.
 // If useSudo is true, then the contents will be retrieved using sudo. This method returns a map from file path to
 // contents.
-func FetchContentsOfFiles(t *testing.T, host Host, useSudo bool, filePaths ...string) map[string]string {
+func FetchContentsOfFiles(t testing.TB, host Host, useSudo bool, filePaths ...string) map[string]string {
 	out, err := FetchContentsOfFilesE(t, host, useSudo, filePaths...)
 	if err != nil {
 		t.Fatal(err)
@@ -296,7 +329,7 @@ func FetchContentsOfFiles(t *testing.T, host Host, useSudo bool, filePaths ...st
 // FetchContentsOfFilesE connects to the given host via SSH and fetches the contents of the files at the given filePaths.
 // If useSudo is true, then the contents will be retrieved using sudo. This method returns a map from file path to
 // contents.
-func FetchContentsOfFilesE(t *testing.T, host Host, useSudo bool, filePaths ...string) (map[string]string, error) {
+func FetchContentsOfFilesE(t testing.TB, host Host, useSudo bool, filePaths ...string) (map[string]string, error) {
 	filePathToContents := map[string]string{}
 
 	for _, filePath := range filePaths {
@@ -313,7 +346,7 @@ func FetchContentsOfFilesE(t *testing.T, host Host, useSudo bool, filePaths ...s
 
 // FetchContentsOfFile connects to the given host via SSH and fetches the contents of the file at the given filePath.
 // If useSudo is true, then the contents will be retrieved using sudo. This method returns the contents of that file.
-func FetchContentsOfFile(t *testing.T, host Host, useSudo bool, filePath string) string {
+func FetchContentsOfFile(t testing.TB, host Host, useSudo bool, filePath string) string {
 	out, err := FetchContentsOfFileE(t, host, useSudo, filePath)
 	if err != nil {
 		t.Fatal(err)
@@ -323,7 +356,7 @@ func FetchContentsOfFile(t *testing.T, host Host, useSudo bool, filePath string)
 
 // FetchContentsOfFileE connects to the given host via SSH and fetches the contents of the file at the given filePath.
 // If useSudo is true, then the contents will be retrieved using sudo. This method returns the contents of that file.
-func FetchContentsOfFileE(t *testing.T, host Host, useSudo bool, filePath string) (string, error) {
+func FetchContentsOfFileE(t testing.TB, host Host, useSudo bool, filePath string) (string, error) {
 	command := fmt.Sprintf("cat %s", filePath)
 	if useSudo {
 		command = fmt.Sprintf("sudo %s", command)
@@ -332,10 +365,24 @@ func FetchContentsOfFileE(t *testing.T, host Host, useSudo bool, filePath string
 	return CheckSshCommandE(t, host, command)
 }
 
-func listFileInRemoteDir(t *testing.T, sshSession *SshSession, options ScpDownloadOptions, useSudo bool) ([]string, error) {
-	logger.Logf(t, "Running command %s on %s@%s", sshSession.Options.Command, sshSession.Options.Username, sshSession.Options.Address)
+func listFileInRemoteDir(t testing.TB, options ScpDownloadOptions, useSudo bool) ([]string, error) {
+	resultString, err := CheckSshCommandE(t, options.RemoteHost, buildFindCommand(options, useSudo))
+	if err != nil {
+		return nil, err
+	}
 
+	// find prints one file per line, with a trailing newline, so skip the blank entries that leaves behind
 	var result []string
+	for _, line := range strings.Split(resultString, "\n") {
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return result, nil
+}
+
+// buildFindCommand returns the find command that lists the files in options.RemoteDir that should be downloaded.
+func buildFindCommand(options ScpDownloadOptions, useSudo bool) string {
 	var findCommandArgs []string
 
 	if useSudo {
@@ -363,28 +410,19 @@ func listFileInRemoteDir(t *testing.T, sshSession *SshSession, options ScpDownlo
 		findCommandArgs = append(findCommandArgs, "\\)")
 	}
 
-	if options.MaxFileSizeMB != 0 {
-		findCommandArgs = append(findCommandArgs, "-size", fmt.Sprintf("-%dM", options.MaxFileSizeMB))
+	for _, curFilter := range options.ExcludeFileNameFilters {
+		findCommandArgs = append(findCommandArgs, "!", "-name", fmt.Sprintf("'%s'", curFilter))
 	}
 
-	finalCommandString := strings.Join(findCommandArgs, " ")
-	resultString, err := CheckSshCommandE(t, options.RemoteHost, finalCommandString)
-
-	if err != nil {
-		return result, err
+	if options.MaxFileSizeMB != 0 {
+		findCommandArgs = append(findCommandArgs, "-size", fmt.Sprintf("-%dM", options.MaxFileSizeMB))
 	}
 
-	// The last character returned is `\n` this results in an extra "" array
-	// member when we do the split below. Cut off the last character to avoid
-	// having to remove the blank entry in the array.
-	resultString = resultString[:len(resultString)-1]
-
-	result = append(result, strings.Split(resultString, "\n")...)
-	return result, nil
+	return strings.Join(findCommandArgs, " ")
 }
 
 // Added based on code: https://github.com/bramvdbogaerde/go-scp/pull/6/files
-func copyFileFromRemote(t *testing.T, sshSession *SshSession, file *os.File, remotePath string, useSudo bool) error {
+func copyFileFromRemote(t testing.TB, sshSession *SshSession, file *os.File, remotePath string, useSudo bool) error {
 	logger.Logf(t, "Running command %s on %s@%s", sshSession.Options.Command, sshSession.Options.Username, sshSession.Options.Address)
 	if err := setUpSSHClient(sshSession); err != nil {
 		return err
@@ -410,7 +448,7 @@ func copyFileFromRemote(t *testing.T, sshSession *SshSession, file *os.File, rem
 	return err
 }
 
-func runSSHCommand(t *testing.T, sshSession *SshSession) (string, error) {
+func runSSHCommand(t testing.TB, sshSession *SshSession) (string, error) {
 	logger.Logf(t, "Running command %s on %s@%s", sshSession.Options.Command, sshSession.Options.Username, sshSession.Options.Address)
 	if err := setUpSSHClient(sshSession); err != nil {
 		return "", err
@@ -0,0 +1,325 @@
+package ssh
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateAuthMethodsForHost(t *testing.T) {
+	t.Parallel()
+
+	keyPair := GenerateRSAKeyPair(t, 2048)
+
+	methods, err := createAuthMethodsForHost(Host{SshKeyPair: keyPair})
+	require.NoError(t, err)
+	assert.Len(t, methods, 1)
+
+	_, err = createAuthMethodsForHost(Host{})
+	assert.Error(t, err)
+
+	_, err = createAuthMethodsForHost(Host{SshKeyPair: &KeyPair{PrivateKey: "not a key"}})
+	assert.Error(t, err)
+}
+
+func TestHostGetPort(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 22, Host{}.getPort())
+	assert.Equal(t, 2222, Host{CustomPort: 2222}.getPort())
+}
+
+func TestCheckSshCommandWithKeyPair(t *testing.T) {
+	t.Parallel()
+
+	keyPair := GenerateRSAKeyPair(t, 2048)
+	port := runTestSshServer(t, keyPair)
+
+	host := Host{Hostname: "127.0.0.1", CustomPort: port, SshUserName: "terratest", SshKeyPair: keyPair}
+
+	out, err := CheckSshCommandE(t, host, "echo hello")
+	require.NoError(t, err)
+	assert.Equal(t, "ran: echo hello\n", out)
+
+	assert.NoError(t, CheckSshConnectionE(t, host))
+}
+
+func TestCheckSshCommandWithOverrideSshAgent(t *testing.T) {
+	t.Parallel()
+
+	keyPair := GenerateRSAKeyPair(t, 2048)
+	port := runTestSshServer(t, keyPair)
+
+	sshAgent := SshAgentWithKeyPair(t, keyPair)
+	defer sshAgent.Stop()
+
+	host := Host{Hostname: "127.0.0.1", CustomPort: port, SshUserName: "terratest", OverrideSshAgent: sshAgent}
+
+	out, err := CheckSshCommandE(t, host, "uptime")
+	require.NoError(t, err)
+	assert.Equal(t, "ran: uptime\n", out)
+}
+
+func TestCheckSshCommandWithWrongKeyFails(t *testing.T) {
+	t.Parallel()
+
+	port := runTestSshServer(t, GenerateRSAKeyPair(t, 2048))
+
+	host := Host{Hostname: "127.0.0.1", CustomPort: port, SshUserName: "terratest", SshKeyPair: GenerateRSAKeyPair(t, 2048)}
+
+	_, err := CheckSshCommandE(t, host, "uptime")
+	assert.Error(t, err)
+}
+
+func TestCheckPrivateSshConnectionViaBastion(t *testing.T) {
+	t.Parallel()
+
+	bastionKeyPair := GenerateRSAKeyPair(t, 2048)
+	privateKeyPair := GenerateRSAKeyPair(t, 2048)
+	bastionPort := runTestSshServer(t, bastionKeyPair)
+	privatePort := runTestSshServer(t, privateKeyPair)
+
+	publicHost := Host{Hostname: "127.0.0.1", CustomPort: bastionPort, SshUserName: "bastion", SshKeyPair: bastionKeyPair}
+	privateHost := Host{Hostname: "127.0.0.1", CustomPort: privatePort, SshUserName: "private", SshKeyPair: privateKeyPair}
+
+	out, err := CheckPrivateSshConnectionE(t, publicHost, privateHost, "hostname")
+	require.NoError(t, err)
+	assert.Equal(t, "ran: hostname\n", out)
+}
+
+func TestBuildFindCommand(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		options  ScpDownloadOptions
+		useSudo  bool
+		expected string
+	}{
+		{"all files", ScpDownloadOptions{RemoteDir: "/var/log"}, false, "find /var/log -type f"},
+		{"sudo", ScpDownloadOptions{RemoteDir: "/var/log"}, true, "sudo find /var/log -type f"},
+		{"include", ScpDownloadOptions{RemoteDir: "/var/log", FileNameFilters: []string{"*.log", "*.txt"}}, false, "find /var/log -type f \\( -name '*.log' -o -name '*.txt' \\)"},
+		{"exclude", ScpDownloadOptions{RemoteDir: "/var/log", ExcludeFileNameFilters: []string{"*.gz", "*.1"}}, false, "find /var/log -type f ! -name '*.gz' ! -name '*.1'"},
+		{"include, exclude, and size", ScpDownloadOptions{RemoteDir: "/var/log", FileNameFilters: []string{"*.log"}, ExcludeFileNameFilters: []string{"debug*"}, MaxFileSizeMB: 5}, false, "find /var/log -type f \\( -name '*.log' \\) ! -name 'debug*' -size -5M"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, buildFindCommand(testCase.options, testCase.useSudo))
+		})
+	}
+}
+
+func TestScpDirFromDownloadsFilesInParallel(t *testing.T) {
+	t.Parallel()
+
+	remoteFiles := []string{"app.log", "access.log", "error.log", "debug.log", "audit.log"}
+	var inFlight, maxInFlight int32
+
+	keyPair := GenerateRSAKeyPair(t, 2048)
+	port := runTestSshServerWithHandler(t, keyPair, func(command string) string {
+		if strings.HasPrefix(command, "find ") {
+			out := ""
+			for _, file := range remoteFiles {
+				out += "/var/log/app/" + file + "\n"
+			}
+			return out
+		}
+
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		return "contents of " + strings.TrimPrefix(command, "dd if=/var/log/app/")
+	})
+
+	localDir, err := ioutil.TempDir("", "scp-dir-from")
+	require.NoError(t, err)
+	defer os.RemoveAll(localDir)
+
+	options := ScpDownloadOptions{
+		RemoteHost:           Host{Hostname: "127.0.0.1", CustomPort: port, SshUserName: "terratest", SshKeyPair: keyPair},
+		RemoteDir:            "/var/log/app",
+		LocalDir:             localDir,
+		MaxParallelTransfers: 2,
+	}
+	require.NoError(t, ScpDirFromE(t, options, false))
+
+	for _, file := range remoteFiles {
+		contents, err := ioutil.ReadFile(filepath.Join(localDir, file))
+		require.NoError(t, err)
+		assert.Equal(t, "contents of "+file, string(contents))
+	}
+	assert.True(t, atomic.LoadInt32(&maxInFlight) <= 2, "expected at most 2 downloads at once, got %d", maxInFlight)
+}
+
+func TestScpDirFromWithNoMatchingFiles(t *testing.T) {
+	t.Parallel()
+
+	keyPair := GenerateRSAKeyPair(t, 2048)
+	port := runTestSshServerWithHandler(t, keyPair, func(command string) string {
+		return ""
+	})
+
+	localDir, err := ioutil.TempDir("", "scp-dir-from")
+	require.NoError(t, err)
+	defer os.RemoveAll(localDir)
+
+	options := ScpDownloadOptions{
+		RemoteHost: Host{Hostname: "127.0.0.1", CustomPort: port, SshUserName: "terratest", SshKeyPair: keyPair},
+		RemoteDir:  "/var/log/app",
+		LocalDir:   localDir,
+	}
+	require.NoError(t, ScpDirFromE(t, options, false))
+
+	localFiles, err := ioutil.ReadDir(localDir)
+	require.NoError(t, err)
+	assert.Empty(t, localFiles)
+}
+
+// runTestSshServer starts an in-process SSH server on a free local port that only accepts the given key pair. It
+// answers every exec request with "ran: <command>" and forwards direct-tcpip channels, so it can act as a bastion.
+func runTestSshServer(t *testing.T, authorizedKeyPair *KeyPair) int {
+	return runTestSshServerWithHandler(t, authorizedKeyPair, func(command string) string {
+		return fmt.Sprintf("ran: %s\n", command)
+	})
+}
+
+// runTestSshServerWithHandler works like runTestSshServer, but answers exec requests with the output of the given
+// handler instead.
+func runTestSshServerWithHandler(t *testing.T, authorizedKeyPair *KeyPair, handler func(command string) string) int {
+	authorizedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKeyPair.PublicKey))
+	require.NoError(t, err)
+
+	hostKey, err := ssh.ParsePrivateKey([]byte(GenerateRSAKeyPair(t, 2048).PrivateKey))
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown public key for %s", conn.User())
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSshConn(conn, config, handler)
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func serveTestSshConn(conn net.Conn, config *ssh.ServerConfig, handler func(command string) string) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go serveTestSshSession(newChannel, handler)
+		case "direct-tcpip":
+			go serveTestSshForward(newChannel)
+		default:
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+func serveTestSshSession(newChannel ssh.NewChannel, handler func(command string) string) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+
+	for request := range requests {
+		if request.Type != "exec" {
+			request.Reply(false, nil)
+			continue
+		}
+
+		var payload struct{ Command string }
+		if err := ssh.Unmarshal(request.Payload, &payload); err != nil {
+			request.Reply(false, nil)
+			continue
+		}
+		request.Reply(true, nil)
+
+		io.WriteString(channel, handler(payload.Command))
+		exitStatus := make([]byte, 4)
+		binary.BigEndian.PutUint32(exitStatus, 0)
+		channel.SendRequest("exit-status", false, exitStatus)
+		return
+	}
+}
+
+func serveTestSshForward(newChannel ssh.NewChannel) {
+	var payload struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		target.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	go func() {
+		io.Copy(target, channel)
+		target.Close()
+	}()
+	io.Copy(channel, target)
+	channel.Close()
+}
@@ -0,0 +1,137 @@
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// SshTunnel forwards a local port through an SSH bastion host to an address that is only reachable from that bastion
+// (e.g., the private IP of a database or Redis instance), similar to `ssh -L`.
+type SshTunnel struct {
+	bastionHost Host
+	remoteHost  string
+	remotePort  int
+	localPort   int
+	client      *ssh.Client
+	listener    net.Listener
+	closeOnce   sync.Once
+}
+
+// NewSshTunnel will create a new SshTunnel struct that forwards connections made to the local port through the given
+// bastion host to remoteHost:remotePort. Note that if you use 0 for the local port, an open port on the host system
+// will be selected automatically when the tunnel is opened, and the SshTunnel struct will be updated with the selected
+// port.
+func NewSshTunnel(bastionHost Host, remoteHost string, remotePort int, localPort int) *SshTunnel {
+	return &SshTunnel{
+		bastionHost: bastionHost,
+		remoteHost:  remoteHost,
+		remotePort:  remotePort,
+		localPort:   localPort,
+	}
+}
+
+// Endpoint returns the local endpoint of the tunnel. Connect to this address to reach the remote address.
+func (tunnel *SshTunnel) Endpoint() string {
+	return fmt.Sprintf("localhost:%d", tunnel.localPort)
+}
+
+// LocalPort returns the local port of the tunnel.
+func (tunnel *SshTunnel) LocalPort() int {
+	return tunnel.localPort
+}
+
+// ForwardPort connects to the bastion host and starts forwarding the local port to the remote address. This will fail
+// the test if there is an error attempting to open the tunnel. You should defer a call to Close to clean the tunnel up
+// when the test is done.
+func (tunnel *SshTunnel) ForwardPort(t testing.TB) {
+	require.NoError(t, tunnel.ForwardPortE(t))
+}
+
+// ForwardPortE connects to the bastion host and starts forwarding the local port to the remote address. You should
+// defer a call to Close to clean the tunnel up when the test is done.
+func (tunnel *SshTunnel) ForwardPortE(t testing.TB) error {
+	remoteAddress := net.JoinHostPort(tunnel.remoteHost, strconv.Itoa(tunnel.remotePort))
+	logger.Logf(t, "Creating an SSH tunnel via %s routing local port %d to %s", tunnel.bastionHost.Hostname, tunnel.localPort, remoteAddress)
+
+	authMethods, err := createAuthMethodsForHost(tunnel.bastionHost)
+	if err != nil {
+		return err
+	}
+
+	bastionOptions := SshConnectionOptions{
+		Username:    tunnel.bastionHost.SshUserName,
+		Address:     tunnel.bastionHost.Hostname,
+		Port:        tunnel.bastionHost.getPort(),
+		AuthMethods: authMethods,
+	}
+
+	client, err := createSSHClient(&bastionOptions)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", tunnel.localPort))
+	if err != nil {
+		client.Close()
+		return err
+	}
+
+	tunnel.client = client
+	tunnel.listener = listener
+	tunnel.localPort = listener.Addr().(*net.TCPAddr).Port
+
+	go tunnel.acceptConnections(t, remoteAddress)
+
+	logger.Logf(t, "Successfully created an SSH tunnel via %s routing local port %d to %s", tunnel.bastionHost.Hostname, tunnel.localPort, remoteAddress)
+	return nil
+}
+
+// Close stops accepting connections on the local port and disconnects from the bastion host, which also closes any
+// connections that are still being forwarded.
+func (tunnel *SshTunnel) Close() {
+	tunnel.closeOnce.Do(func() {
+		if tunnel.listener != nil {
+			tunnel.listener.Close()
+		}
+		if tunnel.client != nil {
+			tunnel.client.Close()
+		}
+	})
+}
+
+// acceptConnections forwards each connection made to the local port through the bastion host until the tunnel is
+// closed.
+func (tunnel *SshTunnel) acceptConnections(t testing.TB, remoteAddress string) {
+	for {
+		localConn, err := tunnel.listener.Accept()
+		if err != nil {
+			// The listener was closed, so the tunnel is shutting down
+			return
+		}
+
+		remoteConn, err := tunnel.client.Dial("tcp", remoteAddress)
+		if err != nil {
+			logger.Logf(t, "Failed to connect to %s via %s: %v", remoteAddress, tunnel.bastionHost.Hostname, err)
+			localConn.Close()
+			continue
+		}
+
+		go copyAndClose(localConn, remoteConn)
+		go copyAndClose(remoteConn, localConn)
+	}
+}
+
+// copyAndClose copies everything from src to dst and closes both once src is exhausted.
+func copyAndClose(dst io.WriteCloser, src io.ReadCloser) {
+	defer dst.Close()
+	defer src.Close()
+	io.Copy(dst, src)
+}
@@ -0,0 +1,73 @@
+package ssh
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSshTunnelForwardsToPrivateAddress(t *testing.T) {
+	t.Parallel()
+
+	keyPair := GenerateRSAKeyPair(t, 2048)
+	bastionPort := runTestSshServer(t, keyPair)
+	bastionHost := Host{Hostname: "127.0.0.1", CustomPort: bastionPort, SshUserName: "bastion", SshKeyPair: keyPair}
+
+	// Stands in for a private-only service, such as a database, that the test can only reach via the bastion
+	privateListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer privateListener.Close()
+	go func() {
+		for {
+			conn, err := privateListener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				fmt.Fprintf(conn, "echo: %s", line)
+			}()
+		}
+	}()
+
+	tunnel := NewSshTunnel(bastionHost, "127.0.0.1", privateListener.Addr().(*net.TCPAddr).Port, 0)
+	defer tunnel.Close()
+	tunnel.ForwardPort(t)
+
+	require.NotEqual(t, 0, tunnel.LocalPort())
+	assert.Equal(t, fmt.Sprintf("localhost:%d", tunnel.LocalPort()), tunnel.Endpoint())
+
+	// Make sure the tunnel can be used more than once
+	for i := 0; i < 2; i++ {
+		conn, err := net.Dial("tcp", tunnel.Endpoint())
+		require.NoError(t, err)
+
+		fmt.Fprintf(conn, "hello %d\n", i)
+		response, err := bufio.NewReader(conn).ReadString('\n')
+		conn.Close()
+
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("echo: hello %d\n", i), response)
+	}
+}
+
+func TestSshTunnelFailsWithUnreachableBastion(t *testing.T) {
+	t.Parallel()
+
+	// Grab a free port and release it again, so nothing is listening on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	bastionHost := Host{Hostname: "127.0.0.1", CustomPort: port, SshUserName: "bastion", SshKeyPair: GenerateRSAKeyPair(t, 2048)}
+
+	tunnel := NewSshTunnel(bastionHost, "10.0.0.1", 5432, 0)
+	defer tunnel.Close()
+	assert.Error(t, tunnel.ForwardPortE(t))
+}
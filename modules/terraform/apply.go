@@ -9,7 +9,7 @@ import (
 // InitAndApply runs terraform init and apply with the given options and return stdout/stderr from the apply command. Note that this
 // method does NOT call destroy and assumes the caller is responsible for cleaning up any resources created by running
 // apply.
-func InitAndApply(t *testing.T, options *Options) string {
+func InitAndApply(t testing.TB, options *Options) string {
 	out, err := InitAndApplyE(t, options)
 	require.NoError(t, err)
 	return out
@@ -18,7 +18,7 @@ func InitAndApply(t *testing.T, options *Options) string {
 // InitAndApplyE runs terraform init and apply with the given options and return stdout/stderr from the apply command. Note that this
 // method does NOT call destroy and assumes the caller is responsible for cleaning up any resources created by running
 // apply.
-func InitAndApplyE(t *testing.T, options *Options) (string, error) {
+func InitAndApplyE(t testing.TB, options *Options) (string, error) {
 	if _, err := InitE(t, options); err != nil {
 		return "", err
 	}
@@ -32,7 +32,7 @@ func InitAndApplyE(t *testing.T, options *Options) (string, error) {
 
 // Apply runs terraform apply with the given options and return stdout/stderr. Note that this method does NOT call destroy and
 // assumes the caller is responsible for cleaning up any resources created by running apply.
-func Apply(t *testing.T, options *Options) string {
+func Apply(t testing.TB, options *Options) string {
 	out, err := ApplyE(t, options)
 	require.NoError(t, err)
 	return out
@@ -40,7 +40,7 @@ func Apply(t *testing.T, options *Options) string {
 
 // TgApplyAll runs terragrunt apply with the given options and return stdout/stderr. Note that this method does NOT call destroy and
 // assumes the caller is responsible for cleaning up any resources created by running apply.
-func TgApplyAll(t *testing.T, options *Options) string {
+func TgApplyAll(t testing.TB, options *Options) string {
 	out, err := TgApplyAllE(t, options)
 	require.NoError(t, err)
 	return out
@@ -48,16 +48,72 @@ func TgApplyAll(t *testing.T, options *Options) string {
 
 // ApplyE runs terraform apply with the given options and return stdout/stderr. Note that this method does NOT call destroy and
 // assumes the caller is responsible for cleaning up any resources created by running apply.
-func ApplyE(t *testing.T, options *Options) (string, error) {
+func ApplyE(t testing.TB, options *Options) (string, error) {
 	return RunTerraformCommandE(t, options, FormatArgs(options, "apply", "-input=false", "-lock=false", "-auto-approve")...)
 }
 
 // TgApplyAllE runs terragrunt apply-all with the given options and return stdout/stderr. Note that this method does NOT call destroy and
 // assumes the caller is responsible for cleaning up any resources created by running apply.
-func TgApplyAllE(t *testing.T, options *Options) (string, error) {
-	if options.TerraformBinary != "terragrunt" {
+func TgApplyAllE(t testing.TB, options *Options) (string, error) {
+	if options.TerraformBinary != TerragruntDefaultPath {
 		return "", TgInvalidBinary(options.TerraformBinary)
 	}
 
 	return RunTerraformCommandE(t, options, FormatArgs(options, "apply-all", "-input=false", "-lock=false", "-auto-approve")...)
 }
+
+// ApplyAndIdempotent runs terraform apply with the given options and return stdout/stderr from the apply command. It
+// then runs plan again and will fail the test if plan requires additional changes. Note that this method does NOT call
+// destroy and assumes the caller is responsible for cleaning up any resources created by running apply.
+func ApplyAndIdempotent(t testing.TB, options *Options) string {
+	out, err := ApplyAndIdempotentE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// ApplyAndIdempotentE runs terraform apply with the given options and return stdout/stderr from the apply command. It
+// then runs plan again and will return an error if plan requires additional changes. Note that this method does NOT
+// call destroy and assumes the caller is responsible for cleaning up any resources created by running apply.
+func ApplyAndIdempotentE(t testing.TB, options *Options) (string, error) {
+	out, err := ApplyE(t, options)
+	if err != nil {
+		return out, err
+	}
+
+	exitCode, err := PlanExitCodeE(t, options)
+	if err != nil {
+		return out, err
+	}
+
+	if exitCode != DefaultSuccessExitCode {
+		return out, NotIdempotent{ExitCode: exitCode}
+	}
+
+	return out, nil
+}
+
+// InitAndApplyAndIdempotent runs terraform init and apply with the given options and return stdout/stderr from the
+// apply command. It then runs plan again and will fail the test if plan requires additional changes. Note that this
+// method does NOT call destroy and assumes the caller is responsible for cleaning up any resources created by running
+// apply.
+func InitAndApplyAndIdempotent(t testing.TB, options *Options) string {
+	out, err := InitAndApplyAndIdempotentE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// InitAndApplyAndIdempotentE runs terraform init and apply with the given options and return stdout/stderr from the
+// apply command. It then runs plan again and will return an error if plan requires additional changes. Note that this
+// method does NOT call destroy and assumes the caller is responsible for cleaning up any resources created by running
+// apply.
+func InitAndApplyAndIdempotentE(t testing.TB, options *Options) (string, error) {
+	if _, err := InitE(t, options); err != nil {
+		return "", err
+	}
+
+	if _, err := GetE(t, options); err != nil {
+		return "", err
+	}
+
+	return ApplyAndIdempotentE(t, options)
+}
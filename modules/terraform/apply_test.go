@@ -95,3 +95,64 @@ func TestTgApplyAllError(t *testing.T) {
 
 	require.Contains(t, out, "This is the first run, exiting with an error")
 }
+
+func TestApplyAndIdempotentNoError(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-no-error", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir: testFolder,
+	}
+
+	out := InitAndApplyAndIdempotent(t, options)
+
+	require.Contains(t, out, "Hello, World")
+}
+
+func TestApplyAndIdempotentWithChanges(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-not-idempotent", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir: testFolder,
+	}
+	defer Destroy(t, options)
+
+	_, err = InitAndApplyAndIdempotentE(t, options)
+
+	require.Error(t, err)
+	require.IsType(t, NotIdempotent{}, err)
+}
+
+func TestApplyAndDestroyWithTargets(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-state", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir: testFolder,
+	}
+	defer Destroy(t, options)
+
+	targetedOptions := &Options{
+		TerraformDir: testFolder,
+		Targets:      []string{"random_id.test"},
+	}
+
+	// Only the targeted resource is created
+	InitAndApply(t, targetedOptions)
+	require.Equal(t, []string{"random_id.test"}, StateList(t, options))
+
+	// Applying without targets creates the rest
+	Apply(t, options)
+	require.Equal(t, []string{"null_resource.test", "random_id.test"}, StateList(t, options))
+
+	// Only the targeted resource is destroyed
+	Destroy(t, targetedOptions)
+	require.Equal(t, []string{"null_resource.test"}, StateList(t, options))
+}
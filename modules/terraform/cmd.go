@@ -2,12 +2,16 @@ package terraform
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terratest/modules/collections"
-	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/dryrun"
 	"github.com/gruntwork-io/terratest/modules/retry"
 	"github.com/gruntwork-io/terratest/modules/shell"
+	"github.com/gruntwork-io/terratest/modules/timing"
 )
 
 // GetCommonOptions extracts commons terraform options
@@ -17,13 +21,21 @@ func GetCommonOptions(options *Options, args ...string) (*Options, []string) {
 	}
 
 	if options.TerraformBinary == "" {
-		options.TerraformBinary = "terraform"
+		options.TerraformBinary = defaultTerraformBinary()
 	}
 
-	if options.TerraformBinary == "terragrunt" {
+	if options.TerraformBinary == TerragruntDefaultPath {
 		args = append(args, "--terragrunt-non-interactive")
 	}
 
+	if options.PluginCacheDir != "" {
+		// Initialize EnvVars, if it hasn't been set yet
+		if options.EnvVars == nil {
+			options.EnvVars = map[string]string{}
+		}
+		options.EnvVars["TF_PLUGIN_CACHE_DIR"] = options.PluginCacheDir
+	}
+
 	// if SshAgent is provided, override the local SSH agent with the socket of our in-process agent
 	if options.SshAgent != nil {
 		// Initialize EnvVars, if it hasn't been set yet
@@ -36,7 +48,7 @@ func GetCommonOptions(options *Options, args ...string) (*Options, []string) {
 }
 
 // RunTerraformCommand runs terraform with the given arguments and options and return stdout/stderr.
-func RunTerraformCommand(t *testing.T, additionalOptions *Options, args ...string) string {
+func RunTerraformCommand(t testing.TB, additionalOptions *Options, args ...string) string {
 	out, err := RunTerraformCommandE(t, additionalOptions, args...)
 	if err != nil {
 		t.Fatal(err)
@@ -45,24 +57,43 @@ func RunTerraformCommand(t *testing.T, additionalOptions *Options, args ...strin
 }
 
 // RunTerraformCommandE runs terraform with the given arguments and options and return stdout/stderr.
-func RunTerraformCommandE(t *testing.T, additionalOptions *Options, additionalArgs ...string) (string, error) {
+func RunTerraformCommandE(t testing.TB, additionalOptions *Options, additionalArgs ...string) (string, error) {
+	return runTerraformCommandWithStdinE(t, additionalOptions, nil, additionalArgs...)
+}
+
+// runTerraformCommandWithStdinE runs terraform with the given arguments and options, passing it the given stdin (if it
+// isn't nil), and returns stdout/stderr.
+func runTerraformCommandWithStdinE(t testing.TB, additionalOptions *Options, stdin *string, additionalArgs ...string) (string, error) {
 	options, args := GetCommonOptions(additionalOptions, additionalArgs...)
 
-	cmd := shell.Command{
-		Command:    options.TerraformBinary,
-		Args:       args,
-		WorkingDir: options.TerraformDir,
-		Env:        options.EnvVars,
+	if terraformCommandChangesResources(args) && dryrun.Skip(t, "run %s %v in %s", options.TerraformBinary, args, options.TerraformDir) {
+		return "", nil
 	}
 
+	start := time.Now()
 	description := fmt.Sprintf("%s %v", options.TerraformBinary, args)
-	return retry.DoWithRetryableErrorsE(t, description, options.RetryableTerraformErrors, options.MaxRetries, options.TimeBetweenRetries, func() (string, error) {
-		return shell.RunCommandAndGetOutputE(t, cmd)
+	out, err := retry.DoWithRetryableErrorsE(t, description, options.RetryableTerraformErrors, options.MaxRetries, options.TimeBetweenRetries, func() (string, error) {
+		cmd := shell.Command{
+			Command:    options.TerraformBinary,
+			Args:       args,
+			WorkingDir: options.TerraformDir,
+			Env:        options.EnvVars,
+			Logger:     options.Logger,
+		}
+		// Each retry needs its own reader, as the previous attempt consumed the last one
+		if stdin != nil {
+			cmd.Stdin = strings.NewReader(*stdin)
+		}
+		out, err := shell.RunCommandAndGetOutputE(t, cmd)
+		appendToLogFile(t, options, args, out)
+		return out, err
 	})
+	timing.Record(t, terraformCommandName(options.TerraformBinary, args), time.Since(start), err)
+	return out, err
 }
 
 // GetExitCodeForTerraformCommand runs terraform with the given arguments and options and returns exit code
-func GetExitCodeForTerraformCommand(t *testing.T, additionalOptions *Options, args ...string) int {
+func GetExitCodeForTerraformCommand(t testing.TB, additionalOptions *Options, args ...string) int {
 	exitCode, err := GetExitCodeForTerraformCommandE(t, additionalOptions, args...)
 	if err != nil {
 		t.Fatal(err)
@@ -71,18 +102,26 @@ func GetExitCodeForTerraformCommand(t *testing.T, additionalOptions *Options, ar
 }
 
 // GetExitCodeForTerraformCommandE runs terraform with the given arguments and options and returns exit code
-func GetExitCodeForTerraformCommandE(t *testing.T, additionalOptions *Options, additionalArgs ...string) (int, error) {
+func GetExitCodeForTerraformCommandE(t testing.TB, additionalOptions *Options, additionalArgs ...string) (int, error) {
 	options, args := GetCommonOptions(additionalOptions, additionalArgs...)
 
-	logger.Logf(t, "Running %s with args %v", options.TerraformBinary, args)
+	if terraformCommandChangesResources(args) && dryrun.Skip(t, "run %s %v in %s", options.TerraformBinary, args, options.TerraformDir) {
+		return DefaultSuccessExitCode, nil
+	}
+
+	options.Logger.Logf(t, "Running %s with args %v", options.TerraformBinary, args)
 	cmd := shell.Command{
 		Command:    options.TerraformBinary,
 		Args:       args,
 		WorkingDir: options.TerraformDir,
 		Env:        options.EnvVars,
+		Logger:     options.Logger,
 	}
 
-	_, err := shell.RunCommandAndGetOutputE(t, cmd)
+	start := time.Now()
+	out, err := shell.RunCommandAndGetOutputE(t, cmd)
+	timing.Record(t, terraformCommandName(options.TerraformBinary, args), time.Since(start), err)
+	appendToLogFile(t, options, args, out)
 	if err == nil {
 		return DefaultSuccessExitCode, nil
 	}
@@ -92,3 +131,38 @@ func GetExitCodeForTerraformCommandE(t *testing.T, additionalOptions *Options, a
 	}
 	return DefaultErrorExitCode, getExitCodeErr
 }
+
+// terraformCommandName returns the name to record the time taken by the command with the given binary and args under
+// (see the timing package): the binary and the command, e.g. "terraform apply" or "terraform state rm". The rest of the
+// args are left out, so every call of the same command adds up to a single entry, and var values don't end up in the
+// timing summary.
+func terraformCommandName(binary string, args []string) string {
+	name := filepath.Base(binary)
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		name += " " + arg
+		if arg != "state" && arg != "workspace" {
+			break
+		}
+	}
+	return name
+}
+
+// terraformCommandChangesResources returns true if the terraform (or terragrunt) command with the given args can
+// create, change, or delete resources or their state, and so must not run in a dry run.
+func terraformCommandChangesResources(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch strings.TrimSuffix(args[0], "-all") {
+	case "apply", "destroy", "import", "taint", "untaint":
+		return true
+	case "state":
+		return len(args) > 1 && collections.ListContains([]string{"mv", "push", "rm"}, args[1])
+	default:
+		return false
+	}
+}
@@ -0,0 +1,89 @@
+package terraform
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/dryrun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformCommandChangesResources(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"apply", "-input=false", "-auto-approve"}, true},
+		{[]string{"destroy", "-auto-approve"}, true},
+		{[]string{"apply-all", "--terragrunt-non-interactive"}, true},
+		{[]string{"import", "aws_instance.web", "i-0123456789"}, true},
+		{[]string{"taint", "aws_instance.web"}, true},
+		{[]string{"state", "rm", "aws_instance.web"}, true},
+		{[]string{"state", "list"}, false},
+		{[]string{"init", "-upgrade=false"}, false},
+		{[]string{"plan", "-input=false"}, false},
+		{[]string{"output", "-json"}, false},
+		{[]string{}, false},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(fmt.Sprintf("%v", testCase.args), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, terraformCommandChangesResources(testCase.args))
+		})
+	}
+}
+
+func TestTerraformCommandName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		binary   string
+		args     []string
+		expected string
+	}{
+		{"terraform", []string{"apply", "-input=false", "-var", "password=hunter2"}, "terraform apply"},
+		{"/usr/local/bin/terraform", []string{"output", "-json", "ip"}, "terraform output"},
+		{"terragrunt", []string{"apply-all", "--terragrunt-non-interactive"}, "terragrunt apply-all"},
+		{"terraform", []string{"state", "rm", "aws_instance.web"}, "terraform state rm"},
+		{"terraform", []string{"workspace", "select", "dev"}, "terraform workspace select"},
+		{"terraform", []string{}, "terraform"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.expected, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, terraformCommandName(testCase.binary, testCase.args))
+		})
+	}
+}
+
+// This test does not call t.Parallel, as it enables dry run for the whole process.
+func TestRunTerraformCommandSkipsChangesInDryRun(t *testing.T) {
+	restore := dryrun.Enable(t)
+	defer restore()
+
+	// The binary doesn't exist, so any command that actually runs fails
+	options := &Options{TerraformDir: ".", TerraformBinary: "terraform-that-does-not-exist"}
+
+	out, err := RunTerraformCommandE(t, options, FormatArgs(options, "apply", "-input=false", "-auto-approve")...)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = RunTerraformCommandE(t, options, FormatArgs(options, "destroy", "-auto-approve")...)
+	require.NoError(t, err)
+
+	_, err = RunTerraformCommandE(t, options, "plan", "-input=false")
+	assert.Error(t, err)
+}
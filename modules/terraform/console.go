@@ -0,0 +1,78 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// EvaluateExpression runs terraform console with the given options to evaluate the given expression against the
+// current state, and returns the result as printed by terraform console. This lets tests check locals and other
+// expressions without exposing them as outputs, e.g. EvaluateExpression(t, options, "local.name_prefix"). Run apply
+// first, so the state has values for the expression to use.
+func EvaluateExpression(t testing.TB, options *Options, expression string) string {
+	out, err := EvaluateExpressionE(t, options, expression)
+	require.NoError(t, err)
+	return out
+}
+
+// EvaluateExpressionE runs terraform console with the given options to evaluate the given expression against the
+// current state, and returns the result as printed by terraform console. This lets tests check locals and other
+// expressions without exposing them as outputs, e.g. EvaluateExpressionE(t, options, "local.name_prefix"). Run apply
+// first, so the state has values for the expression to use.
+func EvaluateExpressionE(t testing.TB, options *Options, expression string) (string, error) {
+	stdin := expression + "\n"
+	args := append([]string{"console"}, formatVariableArgs(options)...)
+
+	out, err := runTerraformCommandWithStdinE(t, options, &stdin, args...)
+	if err != nil {
+		return "", err
+	}
+	return parseConsoleOutput(out), nil
+}
+
+// EvaluateExpressionJson evaluates the given expression with terraform console, like EvaluateExpression, and decodes
+// the result into the value pointed to by v, so that lists, maps, and objects can be checked as Go values. This wraps
+// the expression in jsonencode, which requires Terraform 0.12 or newer.
+func EvaluateExpressionJson(t testing.TB, options *Options, expression string, v interface{}) {
+	require.NoError(t, EvaluateExpressionJsonE(t, options, expression, v))
+}
+
+// EvaluateExpressionJsonE evaluates the given expression with terraform console, like EvaluateExpressionE, and decodes
+// the result into the value pointed to by v, so that lists, maps, and objects can be checked as Go values. This wraps
+// the expression in jsonencode, which requires Terraform 0.12 or newer.
+func EvaluateExpressionJsonE(t testing.TB, options *Options, expression string, v interface{}) error {
+	out, err := EvaluateExpressionE(t, options, fmt.Sprintf("jsonencode(%s)", expression))
+	if err != nil {
+		return err
+	}
+	return decodeConsoleJson(expression, out, v)
+}
+
+// decodeConsoleJson decodes the output of terraform console for a jsonencode expression. terraform console prints
+// strings as quoted string literals, so the JSON is first unquoted, and then decoded into v.
+func decodeConsoleJson(expression string, out string, v interface{}) error {
+	var jsonString string
+	if err := json.Unmarshal([]byte(out), &jsonString); err != nil {
+		return ExpressionDecodeError{Expression: expression, Err: err}
+	}
+	if err := json.Unmarshal([]byte(jsonString), v); err != nil {
+		return ExpressionDecodeError{Expression: expression, Err: err}
+	}
+	return nil
+}
+
+// parseConsoleOutput returns the result printed by terraform console, without the log lines of terragrunt (if any)
+// and the surrounding whitespace.
+func parseConsoleOutput(out string) string {
+	lines := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, "[terragrunt]") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,65 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConsoleOutput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		out      string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"string", "Hello, World\n", "Hello, World"},
+		{"terragrunt", "[terragrunt] 2019/03/01 12:00:00 Running command: terraform console\n\"Hello, World\"\n", "\"Hello, World\""},
+		{"multiline", "{\n  \"a\" = 1\n}\n", "{\n  \"a\" = 1\n}"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, parseConsoleOutput(testCase.out))
+		})
+	}
+}
+
+func TestDecodeConsoleJson(t *testing.T) {
+	t.Parallel()
+
+	var value map[string][]int
+	require.NoError(t, decodeConsoleJson("local.ports", `"{\"http\":[80,8080]}"`, &value))
+	assert.Equal(t, map[string][]int{"http": {80, 8080}}, value)
+
+	err := decodeConsoleJson("local.ports", "not json", &value)
+	assert.IsType(t, ExpressionDecodeError{}, err)
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-console", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir: testFolder,
+		Vars: map[string]interface{}{
+			"name": "Terratest",
+		},
+	}
+	defer Destroy(t, options)
+
+	InitAndApply(t, options)
+
+	assert.Equal(t, "Hello, Terratest", EvaluateExpression(t, options, "local.greeting"))
+}
@@ -7,27 +7,27 @@ import (
 )
 
 // Destroy runs terraform destroy with the given options and return stdout/stderr.
-func Destroy(t *testing.T, options *Options) string {
+func Destroy(t testing.TB, options *Options) string {
 	out, err := DestroyE(t, options)
 	require.NoError(t, err)
 	return out
 }
 
 // TgDestroyAll runs terragrunt destroy with the given options and return stdout.
-func TgDestroyAll(t *testing.T, options *Options) string {
+func TgDestroyAll(t testing.TB, options *Options) string {
 	out, err := TgDestroyAllE(t, options)
 	require.NoError(t, err)
 	return out
 }
 
 // DestroyE runs terraform destroy with the given options and return stdout/stderr.
-func DestroyE(t *testing.T, options *Options) (string, error) {
+func DestroyE(t testing.TB, options *Options) (string, error) {
 	return RunTerraformCommandE(t, options, FormatArgs(options, "destroy", "-auto-approve", "-input=false", "-lock=false")...)
 }
 
 // TgDestroyAllE runs terragrunt destroy with the given options and return stdout.
-func TgDestroyAllE(t *testing.T, options *Options) (string, error) {
-	if options.TerraformBinary != "terragrunt" {
+func TgDestroyAllE(t testing.TB, options *Options) (string, error) {
+	if options.TerraformBinary != TerragruntDefaultPath {
 		return "", TgInvalidBinary(options.TerraformBinary)
 	}
 
@@ -2,6 +2,9 @@ package terraform
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 )
 
 // TgInvalidBinary occurs when a terragrunt function is called and the TerraformBinary is
@@ -56,3 +59,190 @@ type UnexpectedOutputType struct {
 func (err UnexpectedOutputType) Error() string {
 	return fmt.Sprintf("Expected output '%s' to be of type '%s' but got '%s'", err.Key, err.ExpectedType, err.ActualType)
 }
+
+// OutputDecodeError is an error that occurs when the JSON value of an output can't be decoded into the given target
+type OutputDecodeError struct {
+	Key string
+	Err error
+}
+
+func (err OutputDecodeError) Error() string {
+	return fmt.Sprintf("Failed to decode output '%s': %v", err.Key, err.Err)
+}
+
+// DuplicateTargetName occurs when two targets passed to ApplyTargets or DestroyTargets have the same name
+type DuplicateTargetName string
+
+func (err DuplicateTargetName) Error() string {
+	return fmt.Sprintf("more than one target is named %q", string(err))
+}
+
+// TargetNotFound occurs when a target wires in the outputs of a target that does not exist
+type TargetNotFound struct {
+	Target     string
+	Dependency string
+}
+
+func (err TargetNotFound) Error() string {
+	return fmt.Sprintf("target %q uses outputs of target %q, which does not exist", err.Target, err.Dependency)
+}
+
+// TargetDependencyCycle occurs when targets wire in each other's outputs in a cycle, so there is no order in which
+// they can be applied
+type TargetDependencyCycle []string
+
+func (err TargetDependencyCycle) Error() string {
+	return fmt.Sprintf("targets %v depend on each other's outputs in a cycle", []string(err))
+}
+
+// TargetErrors occurs when running Terraform fails for one or more targets. It maps target name to the error for
+// that target.
+type TargetErrors map[string]error
+
+func (errs TargetErrors) Error() string {
+	messages := []string{}
+	for _, name := range sortedTargetNames(errs) {
+		messages = append(messages, fmt.Sprintf("%s: %v", name, errs[name]))
+	}
+	return fmt.Sprintf("Terraform failed for %d target(s): %s", len(errs), strings.Join(messages, "; "))
+}
+
+// FuzzCaseNotRejected occurs when terraform plan succeeds with a value for a variable that should have been rejected
+type FuzzCaseNotRejected struct {
+	FuzzCase VariableFuzzCase
+}
+
+func (err FuzzCaseNotRejected) Error() string {
+	return fmt.Sprintf("Expected variable %s to reject %s (%v), but terraform plan succeeded", err.FuzzCase.Variable, err.FuzzCase.Description, err.FuzzCase.Value)
+}
+
+// FuzzCaseErrorNotUseful occurs when terraform plan rejects a value for a variable with an error that does not mention
+// the variable
+type FuzzCaseErrorNotUseful struct {
+	FuzzCase VariableFuzzCase
+	Output   string
+}
+
+func (err FuzzCaseErrorNotUseful) Error() string {
+	return fmt.Sprintf("terraform plan rejected %s for variable %s, but the error does not mention the variable:\n%s", err.FuzzCase.Description, err.FuzzCase.Variable, err.Output)
+}
+
+// MissingDescriptions occurs when variables or outputs of a Terraform module do not have a description
+type MissingDescriptions struct {
+	Kind  string
+	Names []string
+}
+
+func (err MissingDescriptions) Error() string {
+	return fmt.Sprintf("The following %s do not have a description: %v", err.Kind, err.Names)
+}
+
+// InlineProviderBlocks occurs when a Terraform module that should not configure providers has provider blocks
+type InlineProviderBlocks []string
+
+func (err InlineProviderBlocks) Error() string {
+	return fmt.Sprintf("Expected no provider blocks, but found provider blocks for %v", []string(err))
+}
+
+// PlanFilePathRequired occurs when a function that saves or reads a plan file is called without Options.PlanFilePath set
+type PlanFilePathRequired struct{}
+
+func (err PlanFilePathRequired) Error() string {
+	return "PlanFilePath must be set in the terraform Options to save or read a plan file"
+}
+
+// NotIdempotent occurs when terraform plan still has changes to make right after terraform apply, which means some
+// resources change on every run or drift straight after they are created
+type NotIdempotent struct {
+	ExitCode int
+}
+
+func (err NotIdempotent) Error() string {
+	if err.ExitCode == TerraformPlanChangesPresentExitCode {
+		return "terraform configuration not idempotent: plan found changes to make right after apply"
+	}
+	return fmt.Sprintf("terraform configuration not idempotent: plan after apply exited with code %d", err.ExitCode)
+}
+
+// InvalidVersion occurs when a version number, or the output of the version command, can't be parsed
+type InvalidVersion string
+
+func (err InvalidVersion) Error() string {
+	return fmt.Sprintf("Could not parse a version number from %q", string(err))
+}
+
+// InvalidVersionConstraint occurs when a version constraint passed to CheckVersion can't be parsed
+type InvalidVersionConstraint string
+
+func (err InvalidVersionConstraint) Error() string {
+	return fmt.Sprintf("Could not parse version constraint %q", string(err))
+}
+
+// VersionConstraintNotMet occurs when the version of terraform (or tofu) does not satisfy the constraint passed to
+// CheckVersion
+type VersionConstraintNotMet struct {
+	Binary     string
+	Version    string
+	Constraint string
+}
+
+func (err VersionConstraintNotMet) Error() string {
+	return fmt.Sprintf("%s version %s does not satisfy the constraint %q", err.Binary, err.Version, err.Constraint)
+}
+
+// UnsupportedVarFileValue occurs when a variable passed to WriteVarFile has a value that can't be written as HCL
+type UnsupportedVarFileValue struct {
+	Name string
+	Err  error
+}
+
+func (err UnsupportedVarFileValue) Error() string {
+	return fmt.Sprintf("Can't write variable %s to a var file: %v", err.Name, err.Err)
+}
+
+// PluginCacheLockTimeout occurs when init can't get the lock on the plugin cache dir because other tests hold it for
+// too long
+type PluginCacheLockTimeout struct {
+	CacheDir string
+	Timeout  time.Duration
+}
+
+func (err PluginCacheLockTimeout) Error() string {
+	return fmt.Sprintf("Timed out after %s waiting for the lock on plugin cache %s", err.Timeout, err.CacheDir)
+}
+
+// TerraformNotFormatted occurs when Terraform files are not formatted the way terraform fmt would format them. It
+// lists the files.
+type TerraformNotFormatted []string
+
+func (err TerraformNotFormatted) Error() string {
+	return fmt.Sprintf("The following files are not formatted; run terraform fmt to fix them: %v", []string(err))
+}
+
+// ModuleValidationErrors occurs when terraform validate fails for one or more modules. It maps the folder of the
+// module to the error for that module.
+type ModuleValidationErrors map[string]error
+
+func (errs ModuleValidationErrors) Error() string {
+	dirs := []string{}
+	for dir := range errs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	messages := []string{}
+	for _, dir := range dirs {
+		messages = append(messages, fmt.Sprintf("%s: %v", dir, errs[dir]))
+	}
+	return fmt.Sprintf("terraform validate failed for %d module(s): %s", len(errs), strings.Join(messages, "; "))
+}
+
+// ExpressionDecodeError occurs when the result of an expression evaluated with terraform console can't be decoded
+type ExpressionDecodeError struct {
+	Expression string
+	Err        error
+}
+
+func (err ExpressionDecodeError) Error() string {
+	return fmt.Sprintf("Failed to decode the result of expression '%s': %v", err.Expression, err.Err)
+}
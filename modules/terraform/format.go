@@ -17,6 +17,13 @@ func FormatArgs(options *Options, args ...string) []string {
 	return terraformArgs
 }
 
+// formatVariableArgs returns the -var and -var-file args for the given options, for commands that accept variables but
+// not -target (e.g. import and console).
+func formatVariableArgs(options *Options) []string {
+	args := FormatTerraformVarsAsArgs(options.Vars)
+	return append(args, FormatTerraformArgs("-var-file", options.VarFiles)...)
+}
+
 // FormatTerraformVarsAsArgs formats the given variables as command-line args for Terraform (e.g. of the format
 // -var key=value).
 func FormatTerraformVarsAsArgs(vars map[string]interface{}) []string {
@@ -215,3 +215,15 @@ func TestTryToConvertToGenericMap(t *testing.T) {
 		assert.Equal(t, testCase.expectedIsMap, actualIsMap, "Value: %v", testCase.value)
 	}
 }
+
+func TestFormatArgsTargets(t *testing.T) {
+	t.Parallel()
+
+	options := &Options{
+		VarFiles: []string{"test.tfvars"},
+		Targets:  []string{"null_resource.test", "module.vpc"},
+	}
+
+	expected := []string{"apply", "-input=false", "-var-file", "test.tfvars", "-target", "null_resource.test", "-target", "module.vpc"}
+	assert.Equal(t, expected, FormatArgs(options, "apply", "-input=false"))
+}
@@ -0,0 +1,156 @@
+package terraform
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/customerrors"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// VariableFuzzCase is an input value for a single variable of a Terraform module that the module should reject.
+type VariableFuzzCase struct {
+	Variable    string      // The name of the variable
+	Value       interface{} // The value to pass for the variable
+	Description string      // A human-readable description of why the value should be rejected
+	Boundary    bool        // Whether the value is a boundary value of the right type (e.g. an empty string), which the module only rejects if it validates the variable
+}
+
+// fuzzLongStringLength is the length of the long string boundary value, which is longer than most names and IDs in
+// cloud APIs allow
+const fuzzLongStringLength = 4096
+
+// GenerateVariableFuzzCases reads the variable definitions of the Terraform module in the given folder and returns
+// fuzz cases for each variable: first the boundary values of its type (e.g. an empty string, zero, a negative number,
+// or an empty list), and then values of the wrong type. Append your own VariableFuzzCase values for other inputs your
+// module validates itself.
+func GenerateVariableFuzzCases(t testing.TB, terraformDir string) []VariableFuzzCase {
+	fuzzCases, err := GenerateVariableFuzzCasesE(t, terraformDir)
+	require.NoError(t, err)
+	return fuzzCases
+}
+
+// GenerateVariableFuzzCasesE reads the variable definitions of the Terraform module in the given folder and returns
+// fuzz cases for each variable: first the boundary values of its type (e.g. an empty string, zero, a negative number,
+// or an empty list), and then values of the wrong type.
+func GenerateVariableFuzzCasesE(t testing.TB, terraformDir string) ([]VariableFuzzCase, error) {
+	logger.Logf(t, "Reading variable definitions from %s", terraformDir)
+
+	module, diags := tfconfig.LoadModule(terraformDir)
+	if diags.HasErrors() {
+		return nil, diags.Err()
+	}
+
+	fuzzCases := []VariableFuzzCase{}
+	for _, variable := range module.Variables {
+		fuzzCases = append(fuzzCases, fuzzCasesForVariable(variable)...)
+	}
+
+	return fuzzCases, nil
+}
+
+// AssertVariableFuzzCasesRejected runs terraform plan once for each of the given fuzz cases and fails the test unless
+// every plan fails with an error that mentions the name of the fuzzed variable. Boundary cases may also be accepted,
+// as whether they are valid depends on the module, but if they are rejected, the error must mention the variable too.
+// All other variables are taken from the given options, so they must be set to valid values.
+func AssertVariableFuzzCasesRejected(t testing.TB, options *Options, fuzzCases []VariableFuzzCase) {
+	require.NoError(t, AssertVariableFuzzCasesRejectedE(t, options, fuzzCases))
+}
+
+// AssertVariableFuzzCasesRejectedE runs terraform plan once for each of the given fuzz cases and returns an error
+// unless every plan fails with an error that mentions the name of the fuzzed variable. Boundary cases may also be
+// accepted, but if they are rejected, the error must mention the variable too. All cases are run, and the error lists
+// every case that was not rejected properly. All other variables are taken from the given options, so they must be set
+// to valid values.
+func AssertVariableFuzzCasesRejectedE(t testing.TB, options *Options, fuzzCases []VariableFuzzCase) error {
+	if _, err := InitE(t, options); err != nil {
+		return err
+	}
+
+	errorsOccurred := []error{}
+	for _, fuzzCase := range fuzzCases {
+		options.Logger.Logf(t, "Checking that variable %s rejects %s", fuzzCase.Variable, fuzzCase.Description)
+
+		fuzzOptions := optionsWithVar(options, fuzzCase.Variable, fuzzCase.Value)
+		out, err := RunTerraformCommandE(t, fuzzOptions, FormatArgs(fuzzOptions, "plan", "-input=false", "-lock=false")...)
+		errorsOccurred = append(errorsOccurred, checkFuzzCaseRejected(fuzzCase, out, err))
+	}
+
+	return customerrors.NewMultiError(errorsOccurred...)
+}
+
+// checkFuzzCaseRejected returns an error unless the terraform plan for the given fuzz case, which returned the given
+// output and error, failed with an error that mentions the fuzzed variable. Boundary cases may also succeed.
+func checkFuzzCaseRejected(fuzzCase VariableFuzzCase, out string, planErr error) error {
+	if planErr == nil {
+		if fuzzCase.Boundary {
+			return nil
+		}
+		return FuzzCaseNotRejected{FuzzCase: fuzzCase}
+	}
+	if !strings.Contains(out, fuzzCase.Variable) {
+		return FuzzCaseErrorNotUseful{FuzzCase: fuzzCase, Output: out}
+	}
+	return nil
+}
+
+// fuzzCasesForVariable returns the fuzz cases for the given variable based on its type constraint: the boundary values
+// of the type, followed by values of the wrong type. Terraform 0.11 type names (e.g. "list") and Terraform 0.12 type
+// expressions (e.g. "list(string)") are both supported.
+func fuzzCasesForVariable(variable *tfconfig.Variable) []VariableFuzzCase {
+	fuzzCase := func(value interface{}, description string) VariableFuzzCase {
+		return VariableFuzzCase{Variable: variable.Name, Value: value, Description: description}
+	}
+	boundaryCase := func(value interface{}, description string) VariableFuzzCase {
+		return VariableFuzzCase{Variable: variable.Name, Value: value, Description: description, Boundary: true}
+	}
+
+	typeName := variable.Type
+	if index := strings.Index(typeName, "("); index >= 0 {
+		typeName = typeName[:index]
+	}
+
+	switch strings.TrimSpace(typeName) {
+	case "number":
+		return []VariableFuzzCase{
+			boundaryCase(0, "zero"),
+			boundaryCase(-1, "a negative number"),
+			boundaryCase(int64(math.MaxInt32)+1, "a number too large for a 32-bit integer"),
+			fuzzCase("not-a-number", "a non-numeric value for a number"),
+		}
+	case "bool":
+		return []VariableFuzzCase{fuzzCase("not-a-bool", "a non-boolean value for a bool")}
+	case "list", "set", "tuple":
+		return []VariableFuzzCase{
+			boundaryCase([]interface{}{}, "an empty list"),
+			fuzzCase("not-a-list", "a string for a collection"),
+		}
+	case "map", "object":
+		return []VariableFuzzCase{
+			boundaryCase(map[string]interface{}{}, "an empty map"),
+			fuzzCase("not-a-map", "a string for a map"),
+		}
+	default:
+		// Variables of type string or any, or with no type constraint, accept every value passed with -var, so there
+		// are only boundary values to try
+		return []VariableFuzzCase{
+			boundaryCase("", "an empty string"),
+			boundaryCase(strings.Repeat("a", fuzzLongStringLength), fmt.Sprintf("a string of %d characters", fuzzLongStringLength)),
+		}
+	}
+}
+
+// optionsWithVar returns a copy of the given options with the given var set to the given value.
+func optionsWithVar(options *Options, name string, value interface{}) *Options {
+	copied := *options
+	copied.Vars = map[string]interface{}{}
+	for key, existingValue := range options.Vars {
+		copied.Vars[key] = existingValue
+	}
+	copied.Vars[name] = value
+	return &copied
+}
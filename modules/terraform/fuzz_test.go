@@ -0,0 +1,76 @@
+package terraform
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzCasesForVariable(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		typeName       string
+		expectedValues []interface{}
+	}{
+		{"number", []interface{}{0, -1, int64(2147483648), "not-a-number"}},
+		{"bool", []interface{}{"not-a-bool"}},
+		{"list", []interface{}{[]interface{}{}, "not-a-list"}},
+		{"list(string)", []interface{}{[]interface{}{}, "not-a-list"}},
+		{"set(number)", []interface{}{[]interface{}{}, "not-a-list"}},
+		{"map(string)", []interface{}{map[string]interface{}{}, "not-a-map"}},
+		{"object({name = string})", []interface{}{map[string]interface{}{}, "not-a-map"}},
+		{"string", []interface{}{"", strings.Repeat("a", 4096)}},
+		{"any", []interface{}{"", strings.Repeat("a", 4096)}},
+		{"", []interface{}{"", strings.Repeat("a", 4096)}},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.typeName, func(t *testing.T) {
+			t.Parallel()
+
+			fuzzCases := fuzzCasesForVariable(&tfconfig.Variable{Name: "foo", Type: testCase.typeName})
+
+			values := []interface{}{}
+			for _, fuzzCase := range fuzzCases {
+				assert.Equal(t, "foo", fuzzCase.Variable)
+				values = append(values, fuzzCase.Value)
+			}
+			assert.Equal(t, testCase.expectedValues, values)
+		})
+	}
+}
+
+func TestCheckFuzzCaseRejected(t *testing.T) {
+	t.Parallel()
+
+	fuzzCase := VariableFuzzCase{Variable: "instance_count", Value: "not-a-number", Description: "a non-numeric value for a number"}
+
+	assert.NoError(t, checkFuzzCaseRejected(fuzzCase, `Error: Invalid value for input variable "instance_count"`, errors.New("exit status 1")))
+	assert.IsType(t, FuzzCaseNotRejected{}, checkFuzzCaseRejected(fuzzCase, "Plan: 1 to add, 0 to change, 0 to destroy.", nil))
+	assert.IsType(t, FuzzCaseErrorNotUseful{}, checkFuzzCaseRejected(fuzzCase, "Error: something went wrong", errors.New("exit status 1")))
+
+	// Boundary values may be valid for the module, but if it rejects them, the error must still be useful
+	boundaryCase := VariableFuzzCase{Variable: "name", Value: "", Description: "an empty string", Boundary: true}
+	assert.NoError(t, checkFuzzCaseRejected(boundaryCase, "Plan: 1 to add, 0 to change, 0 to destroy.", nil))
+	assert.NoError(t, checkFuzzCaseRejected(boundaryCase, `Error: Invalid value for variable "name"`, errors.New("exit status 1")))
+	assert.IsType(t, FuzzCaseErrorNotUseful{}, checkFuzzCaseRejected(boundaryCase, "Error: something went wrong", errors.New("exit status 1")))
+}
+
+func TestOptionsWithVarDoesNotModifyOriginal(t *testing.T) {
+	t.Parallel()
+
+	options := &Options{TerraformDir: "/tmp/fixture", Vars: map[string]interface{}{"foo": "bar", "baz": 1}}
+	fuzzOptions := optionsWithVar(options, "foo", "not-a-number")
+
+	assert.Equal(t, map[string]interface{}{"foo": "bar", "baz": 1}, options.Vars)
+	assert.Equal(t, map[string]interface{}{"foo": "not-a-number", "baz": 1}, fuzzOptions.Vars)
+	assert.Equal(t, options.TerraformDir, fuzzOptions.TerraformDir)
+}
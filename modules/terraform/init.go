@@ -6,7 +6,7 @@ import (
 )
 
 // Init calls terraform init and return stdout/stderr.
-func Init(t *testing.T, options *Options) string {
+func Init(t testing.TB, options *Options) string {
 	out, err := InitE(t, options)
 	if err != nil {
 		t.Fatal(err)
@@ -14,9 +14,22 @@ func Init(t *testing.T, options *Options) string {
 	return out
 }
 
-// InitE calls terraform init and return stdout/stderr.
-func InitE(t *testing.T, options *Options) (string, error) {
+// InitE calls terraform init and return stdout/stderr. If options.PluginCacheDir is set, it holds the lock on the
+// plugin cache while init runs.
+func InitE(t testing.TB, options *Options) (string, error) {
 	args := []string{"init", fmt.Sprintf("-upgrade=%t", options.Upgrade)}
 	args = append(args, FormatTerraformBackendConfigAsArgs(options.BackendConfig)...)
+	if options.PluginDir != "" {
+		args = append(args, fmt.Sprintf("-plugin-dir=%s", options.PluginDir))
+	}
+
+	if options.PluginCacheDir != "" {
+		unlock, err := lockPluginCacheE(t, options.PluginCacheDir)
+		if err != nil {
+			return "", err
+		}
+		defer unlock()
+	}
+
 	return RunTerraformCommandE(t, options, args...)
 }
@@ -0,0 +1,254 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// providerBlockSchema matches the provider blocks in a Terraform file, and ignores every other block.
+var providerBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"name"}}},
+}
+
+// ModuleInfo describes the inputs, outputs, and contents of a Terraform module, as parsed from its HCL code.
+type ModuleInfo struct {
+	Path              string         // The folder the module was read from
+	Variables         []VariableInfo // The input variables of the module, sorted by name
+	Outputs           []OutputInfo   // The outputs of the module, sorted by name
+	RequiredProviders []string       // The names of the providers the module requires, sorted
+	ProviderConfigs   []string       // The names of the providers the module configures with inline provider blocks, sorted
+	ResourceTypes     []string       // The distinct types of the resources the module manages, sorted
+	DataSourceTypes   []string       // The distinct types of the data sources the module reads, sorted
+	ModuleCalls       []ModuleCall   // The modules this module calls, sorted by name
+}
+
+// VariableInfo describes an input variable of a Terraform module.
+type VariableInfo struct {
+	Name        string
+	Type        string
+	Description string
+	Default     interface{}
+	Required    bool // Whether the variable has no default, so a value must be passed for it
+}
+
+// OutputInfo describes an output of a Terraform module.
+type OutputInfo struct {
+	Name        string
+	Description string
+}
+
+// ModuleCall describes a module block in a Terraform module.
+type ModuleCall struct {
+	Name    string
+	Source  string
+	Version string
+}
+
+// InspectModule parses the HCL code of the Terraform module in the given folder. This does not run Terraform, so it
+// works without credentials and without running terraform init.
+func InspectModule(t testing.TB, terraformDir string) *ModuleInfo {
+	info, err := InspectModuleE(t, terraformDir)
+	require.NoError(t, err)
+	return info
+}
+
+// InspectModuleE parses the HCL code of the Terraform module in the given folder. This does not run Terraform, so it
+// works without credentials and without running terraform init.
+func InspectModuleE(t testing.TB, terraformDir string) (*ModuleInfo, error) {
+	logger.Logf(t, "Inspecting Terraform module in %s", terraformDir)
+
+	module, diags := tfconfig.LoadModule(terraformDir)
+	if diags.HasErrors() {
+		return nil, diags.Err()
+	}
+
+	info := &ModuleInfo{
+		Path:              terraformDir,
+		Variables:         []VariableInfo{},
+		Outputs:           []OutputInfo{},
+		RequiredProviders: []string{},
+		ProviderConfigs:   []string{},
+		ModuleCalls:       []ModuleCall{},
+	}
+
+	for _, variable := range module.Variables {
+		info.Variables = append(info.Variables, VariableInfo{
+			Name:        variable.Name,
+			Type:        variable.Type,
+			Description: variable.Description,
+			Default:     variable.Default,
+			Required:    variable.Default == nil,
+		})
+	}
+	sort.Slice(info.Variables, func(i, j int) bool { return info.Variables[i].Name < info.Variables[j].Name })
+
+	for _, output := range module.Outputs {
+		info.Outputs = append(info.Outputs, OutputInfo{Name: output.Name, Description: output.Description})
+	}
+	sort.Slice(info.Outputs, func(i, j int) bool { return info.Outputs[i].Name < info.Outputs[j].Name })
+
+	for name := range module.RequiredProviders {
+		info.RequiredProviders = append(info.RequiredProviders, name)
+	}
+	sort.Strings(info.RequiredProviders)
+
+	providerConfigs, err := readProviderBlocksE(terraformDir)
+	if err != nil {
+		return nil, err
+	}
+	info.ProviderConfigs = providerConfigs
+
+	resourceTypes := map[string]bool{}
+	for _, resource := range module.ManagedResources {
+		resourceTypes[resource.Type] = true
+	}
+	info.ResourceTypes = sortedKeys(resourceTypes)
+
+	dataSourceTypes := map[string]bool{}
+	for _, dataSource := range module.DataResources {
+		dataSourceTypes[dataSource.Type] = true
+	}
+	info.DataSourceTypes = sortedKeys(dataSourceTypes)
+
+	for _, call := range module.ModuleCalls {
+		info.ModuleCalls = append(info.ModuleCalls, ModuleCall{Name: call.Name, Source: call.Source, Version: call.Version})
+	}
+	sort.Slice(info.ModuleCalls, func(i, j int) bool { return info.ModuleCalls[i].Name < info.ModuleCalls[j].Name })
+
+	return info, nil
+}
+
+// AssertAllVariablesHaveDescriptions checks that every input variable of the Terraform module in the given folder has
+// a description, and fails the test if any do not.
+func AssertAllVariablesHaveDescriptions(t testing.TB, terraformDir string) {
+	require.NoError(t, AssertAllVariablesHaveDescriptionsE(t, terraformDir))
+}
+
+// AssertAllVariablesHaveDescriptionsE checks that every input variable of the Terraform module in the given folder
+// has a description, and returns an error if any do not.
+func AssertAllVariablesHaveDescriptionsE(t testing.TB, terraformDir string) error {
+	info, err := InspectModuleE(t, terraformDir)
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, variable := range info.Variables {
+		if variable.Description == "" {
+			missing = append(missing, variable.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return MissingDescriptions{Kind: "variables", Names: missing}
+	}
+	return nil
+}
+
+// AssertAllOutputsHaveDescriptions checks that every output of the Terraform module in the given folder has a
+// description, and fails the test if any do not.
+func AssertAllOutputsHaveDescriptions(t testing.TB, terraformDir string) {
+	require.NoError(t, AssertAllOutputsHaveDescriptionsE(t, terraformDir))
+}
+
+// AssertAllOutputsHaveDescriptionsE checks that every output of the Terraform module in the given folder has a
+// description, and returns an error if any do not.
+func AssertAllOutputsHaveDescriptionsE(t testing.TB, terraformDir string) error {
+	info, err := InspectModuleE(t, terraformDir)
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, output := range info.Outputs {
+		if output.Description == "" {
+			missing = append(missing, output.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return MissingDescriptions{Kind: "outputs", Names: missing}
+	}
+	return nil
+}
+
+// AssertNoProviderBlocks checks that the Terraform module in the given folder does not configure any providers inline,
+// which is a common convention for reusable modules, and fails the test if it does.
+func AssertNoProviderBlocks(t testing.TB, terraformDir string) {
+	require.NoError(t, AssertNoProviderBlocksE(t, terraformDir))
+}
+
+// AssertNoProviderBlocksE checks that the Terraform module in the given folder does not configure any providers
+// inline, which is a common convention for reusable modules, and returns an error if it does.
+func AssertNoProviderBlocksE(t testing.TB, terraformDir string) error {
+	info, err := InspectModuleE(t, terraformDir)
+	if err != nil {
+		return err
+	}
+
+	if len(info.ProviderConfigs) > 0 {
+		return InlineProviderBlocks(info.ProviderConfigs)
+	}
+	return nil
+}
+
+// readProviderBlocksE returns the sorted, distinct names of the providers that the Terraform files in the given folder
+// configure with provider blocks. terraform-config-inspect merges provider blocks into the required providers, so the
+// files are parsed here to tell them apart.
+func readProviderBlocksE(terraformDir string) ([]string, error) {
+	files, err := ioutil.ReadDir(terraformDir)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	names := map[string]bool{}
+
+	for _, file := range files {
+		path := filepath.Join(terraformDir, file.Name())
+
+		var parsed *hcl.File
+		var diags hcl.Diagnostics
+		switch {
+		case file.IsDir():
+			continue
+		case strings.HasSuffix(file.Name(), ".tf"):
+			parsed, diags = parser.ParseHCLFile(path)
+		case strings.HasSuffix(file.Name(), ".tf.json"):
+			parsed, diags = parser.ParseJSONFile(path)
+		default:
+			continue
+		}
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		content, _, diags := parsed.Body.PartialContent(providerBlockSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for _, block := range content.Blocks {
+			names[block.Labels[0]] = true
+		}
+	}
+
+	return sortedKeys(names), nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const inspectTestModule = `
+provider "aws" {
+  region = "us-east-1"
+}
+
+variable "name" {
+  description = "The name of the instance"
+  type        = "string"
+}
+
+variable "instance_count" {
+  default = 1
+}
+
+resource "aws_instance" "web" {
+  count = "${var.instance_count}"
+  ami   = "${data.aws_ami.ubuntu.id}"
+}
+
+resource "aws_instance" "db" {
+  ami = "${data.aws_ami.ubuntu.id}"
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+}
+
+module "vpc" {
+  source = "./vpc"
+}
+
+output "web_ids" {
+  value = "${aws_instance.web.*.id}"
+}
+`
+
+func TestInspectModule(t *testing.T) {
+	t.Parallel()
+
+	terraformDir := writeInspectTestModule(t)
+	defer os.RemoveAll(terraformDir)
+
+	info := InspectModule(t, terraformDir)
+
+	require.Len(t, info.Variables, 2)
+	assert.Equal(t, "instance_count", info.Variables[0].Name)
+	assert.False(t, info.Variables[0].Required)
+	assert.Equal(t, "name", info.Variables[1].Name)
+	assert.Equal(t, "The name of the instance", info.Variables[1].Description)
+	assert.True(t, info.Variables[1].Required)
+
+	assert.Equal(t, []OutputInfo{{Name: "web_ids"}}, info.Outputs)
+	assert.Equal(t, []string{"aws"}, info.ProviderConfigs)
+	assert.Equal(t, []string{"aws_instance"}, info.ResourceTypes)
+	assert.Equal(t, []string{"aws_ami"}, info.DataSourceTypes)
+	assert.Equal(t, []ModuleCall{{Name: "vpc", Source: "./vpc"}}, info.ModuleCalls)
+}
+
+func TestInspectModuleConventionAssertions(t *testing.T) {
+	t.Parallel()
+
+	terraformDir := writeInspectTestModule(t)
+	defer os.RemoveAll(terraformDir)
+
+	assert.Equal(t, MissingDescriptions{Kind: "variables", Names: []string{"instance_count"}}, AssertAllVariablesHaveDescriptionsE(t, terraformDir))
+	assert.Equal(t, MissingDescriptions{Kind: "outputs", Names: []string{"web_ids"}}, AssertAllOutputsHaveDescriptionsE(t, terraformDir))
+	assert.Equal(t, InlineProviderBlocks{"aws"}, AssertNoProviderBlocksE(t, terraformDir))
+}
+
+func TestInspectModuleRequiredProvidersAreNotProviderBlocks(t *testing.T) {
+	t.Parallel()
+
+	terraformDir := writeInspectTestModuleWithContents(t, `
+terraform {
+  required_providers {
+    aws = "~> 2.0"
+  }
+}
+`)
+	defer os.RemoveAll(terraformDir)
+
+	info := InspectModule(t, terraformDir)
+
+	assert.Equal(t, []string{"aws"}, info.RequiredProviders)
+	assert.Equal(t, []string{}, info.ProviderConfigs)
+	assert.NoError(t, AssertNoProviderBlocksE(t, terraformDir))
+}
+
+func writeInspectTestModule(t *testing.T) string {
+	return writeInspectTestModuleWithContents(t, inspectTestModule)
+}
+
+func writeInspectTestModuleWithContents(t *testing.T, contents string) string {
+	terraformDir, err := ioutil.TempDir("", "terratest-inspect")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(terraformDir, "main.tf"), []byte(contents), 0644))
+	return terraformDir
+}
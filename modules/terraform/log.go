@@ -0,0 +1,96 @@
+package terraform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// ResourceTimings maps the address of each resource that apply created to how long it took to create it
+type ResourceTimings map[string]time.Duration
+
+// resourceCreationRegexp matches the line terraform apply prints when it has created a resource, e.g.
+// "aws_instance.example: Creation complete after 1m2s (ID: i-0123)" or
+// "module.vpc.aws_subnet.private[0]: Creation complete after 2s [id=subnet-0123]"
+var resourceCreationRegexp = regexp.MustCompile(`^(\S+): Creation complete after (\S+)`)
+
+// ansiEscapeRegexp matches the color codes terraform adds to its output, unless -no-color is set
+var ansiEscapeRegexp = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// ApplyAndGetResourceTimings runs terraform apply with the given options and returns how long it took to create each
+// resource, so that slow resources can be spotted (e.g. by reporting them to a CI dashboard). Note that this method
+// does NOT call destroy and assumes the caller is responsible for cleaning up any resources created by running apply.
+func ApplyAndGetResourceTimings(t testing.TB, options *Options) ResourceTimings {
+	timings, err := ApplyAndGetResourceTimingsE(t, options)
+	require.NoError(t, err)
+	return timings
+}
+
+// ApplyAndGetResourceTimingsE runs terraform apply with the given options and returns how long it took to create each
+// resource, so that slow resources can be spotted (e.g. by reporting them to a CI dashboard). Note that this method
+// does NOT call destroy and assumes the caller is responsible for cleaning up any resources created by running apply.
+func ApplyAndGetResourceTimingsE(t testing.TB, options *Options) (ResourceTimings, error) {
+	out, err := ApplyE(t, options)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResourceTimings(out), nil
+}
+
+// ParseResourceTimings parses the output of terraform apply and returns how long it took to create each resource.
+// Resources that apply didn't create (e.g. because they were updated in place, or were already up to date) are not
+// included.
+func ParseResourceTimings(out string) ResourceTimings {
+	timings := ResourceTimings{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(ansiEscapeRegexp.ReplaceAllString(line, ""))
+		matches := resourceCreationRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		duration, err := time.ParseDuration(matches[2])
+		if err != nil {
+			continue
+		}
+		timings[matches[1]] = duration
+	}
+	return timings
+}
+
+// appendToLogFile appends the given output of the Terraform command with the given args to the log file configured
+// in the given options, if any. Failing to write the log is only logged, so that it doesn't fail the command.
+func appendToLogFile(t testing.TB, options *Options, args []string, out string) {
+	if options.LogFilePath == "" {
+		return
+	}
+	if err := appendToLogFileE(options.LogFilePath, options.TerraformBinary, args, out); err != nil {
+		options.Logger.Warnf(t, "Failed to write the output of %s to log file %s: %v", options.TerraformBinary, options.LogFilePath, err)
+	}
+}
+
+// appendToLogFileE appends the given output of the command with the given binary and args to the given log file,
+// preceded by the command, and creates the file (and its parent dirs) if it doesn't exist. Secrets registered with the
+// logger package are redacted from both the command and the output.
+func appendToLogFileE(path string, binary string, args []string, out string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	command := logger.Redact(fmt.Sprintf("%s %s", binary, strings.Join(args, " ")))
+	_, err = fmt.Fprintf(file, "$ %s\n%s\n", command, logger.Redact(out))
+	return err
+}
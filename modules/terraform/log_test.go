@@ -0,0 +1,108 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceTimings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		out      string
+		expected ResourceTimings
+	}{
+		{"empty", "", ResourceTimings{}},
+		{
+			"terraform 0.11",
+			"null_resource.test: Creating...\nnull_resource.test: Creation complete after 0s (ID: 123)\naws_instance.example: Still creating... (10s elapsed)\naws_instance.example: Creation complete after 1m2s (ID: i-0123)\n",
+			ResourceTimings{"null_resource.test": 0, "aws_instance.example": time.Minute + 2*time.Second},
+		},
+		{
+			"terraform 0.12",
+			"module.vpc.aws_subnet.private[0]: Creation complete after 2s [id=subnet-0123]\naws_instance.example: Modifications complete after 5s [id=i-0123]\n",
+			ResourceTimings{"module.vpc.aws_subnet.private[0]": 2 * time.Second},
+		},
+		{
+			"color",
+			"\x1b[0m\x1b[1mnull_resource.test: Creation complete after 3s (ID: 123)\x1b[0m\x1b[0m\n",
+			ResourceTimings{"null_resource.test": 3 * time.Second},
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, ParseResourceTimings(testCase.out))
+		})
+	}
+}
+
+func TestAppendToLogFileE(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", t.Name())
+	require.NoError(t, err)
+	path := filepath.Join(dir, "logs", "terraform.log")
+
+	require.NoError(t, appendToLogFileE(path, "terraform", []string{"init"}, "Initialized"))
+	require.NoError(t, appendToLogFileE(path, "terraform", []string{"apply", "-auto-approve"}, "Apply complete!"))
+
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "$ terraform init\nInitialized\n$ terraform apply -auto-approve\nApply complete!\n", string(contents))
+}
+
+func TestAppendToLogFileERedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", t.Name())
+	require.NoError(t, err)
+	path := filepath.Join(dir, "terraform.log")
+
+	logger.RegisterSecret("terratest-log-file-secret")
+	args := []string{"apply", "-var", "password=terratest-log-file-secret"}
+	require.NoError(t, appendToLogFileE(path, "terraform", args, "password = terratest-log-file-secret"))
+
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(contents), "terratest-log-file-secret")
+	assert.Equal(t, fmt.Sprintf("$ terraform apply -var password=%s\npassword = %s\n", logger.RedactedValue, logger.RedactedValue), string(contents))
+}
+
+func TestApplyAndGetResourceTimings(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-state", t.Name())
+	require.NoError(t, err)
+
+	logFile := filepath.Join(testFolder, "terraform.log")
+	options := &Options{
+		TerraformDir: testFolder,
+		LogFilePath:  logFile,
+	}
+	defer Destroy(t, options)
+
+	Init(t, options)
+	timings := ApplyAndGetResourceTimings(t, options)
+
+	assert.Contains(t, timings, "null_resource.test")
+	assert.Contains(t, timings, "random_id.test")
+
+	contents, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "Creation complete")
+}
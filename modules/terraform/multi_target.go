@@ -0,0 +1,257 @@
+package terraform
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Target is a single Terraform fixture in a test that spans several AWS accounts or GCP projects, such as the hub and
+// the spokes of a hub and spoke network, or the host and service projects of a shared VPC.
+type Target struct {
+	Name               string                  // A unique name used to refer to this target from other targets
+	Options            *Options                // The options for running Terraform against this target. Each target needs its own Options, but they may share maps, such as EnvVars, with each other.
+	AwsProfile         string                  // If set, Terraform runs with AWS_PROFILE set to this value
+	AwsRegion          string                  // If set, Terraform runs with AWS_DEFAULT_REGION set to this value
+	GcpProjectID       string                  // If set, Terraform runs with GOOGLE_PROJECT and GOOGLE_CLOUD_PROJECT set to this value
+	GcpCredentialsFile string                  // If set, Terraform runs with GOOGLE_APPLICATION_CREDENTIALS set to this value
+	OutputsAsVars      map[string]TargetOutput // Terraform vars to set from the outputs of other targets, keyed by var name
+}
+
+// TargetOutput refers to an output of another target.
+type TargetOutput struct {
+	Target string // The name of the target
+	Output string // The name of the output
+}
+
+// ApplyTargets runs terraform init and apply on all the given targets and returns the outputs of each target, keyed by
+// target name. Targets that do not depend on each other's outputs are applied in parallel. Note that this method does
+// NOT call destroy and assumes the caller is responsible for cleaning up by calling DestroyTargets.
+func ApplyTargets(t testing.TB, targets []*Target) map[string]map[string]interface{} {
+	outputs, err := ApplyTargetsE(t, targets)
+	require.NoError(t, err)
+	return outputs
+}
+
+// ApplyTargetsE runs terraform init and apply on all the given targets and returns the outputs of each target, keyed by
+// target name. Targets that do not depend on each other's outputs are applied in parallel. Note that this method does
+// NOT call destroy and assumes the caller is responsible for cleaning up by calling DestroyTargetsE.
+func ApplyTargetsE(t testing.TB, targets []*Target) (map[string]map[string]interface{}, error) {
+	waves, err := planTargetWaves(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]map[string]interface{}{}
+
+	for _, wave := range waves {
+		for _, target := range wave {
+			setTargetCredentials(target)
+			if err := setTargetVarsFromOutputs(target, outputs); err != nil {
+				return outputs, err
+			}
+		}
+
+		var mutex sync.Mutex
+		waveErrors := TargetErrors{}
+
+		runInParallel(wave, func(target *Target) {
+			target.Options.Logger.Logf(t, "Applying target %s", target.Name)
+
+			_, err := InitAndApplyE(t, target.Options)
+			var targetOutputs map[string]interface{}
+			if err == nil {
+				targetOutputs, err = OutputAllE(t, target.Options)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				waveErrors[target.Name] = err
+			} else {
+				outputs[target.Name] = targetOutputs
+			}
+		})
+
+		if len(waveErrors) > 0 {
+			return outputs, waveErrors
+		}
+	}
+
+	return outputs, nil
+}
+
+// DestroyTargets runs terraform destroy on all the given targets, in the reverse order they were applied by
+// ApplyTargets. All targets are destroyed even if some of them fail.
+func DestroyTargets(t testing.TB, targets []*Target) {
+	require.NoError(t, DestroyTargetsE(t, targets))
+}
+
+// DestroyTargetsE runs terraform destroy on all the given targets, in the reverse order they were applied by
+// ApplyTargetsE. All targets are destroyed even if some of them fail. Vars wired from the outputs of other targets are
+// read from the Options of each target, where ApplyTargetsE left them.
+func DestroyTargetsE(t testing.TB, targets []*Target) error {
+	waves, err := planTargetWaves(targets)
+	if err != nil {
+		return err
+	}
+
+	// Set the credentials before starting any goroutines, as it replaces the EnvVars map of each target
+	for _, target := range targets {
+		setTargetCredentials(target)
+	}
+
+	var mutex sync.Mutex
+	destroyErrors := TargetErrors{}
+
+	for i := len(waves) - 1; i >= 0; i-- {
+		runInParallel(waves[i], func(target *Target) {
+			target.Options.Logger.Logf(t, "Destroying target %s", target.Name)
+
+			if _, err := DestroyE(t, target.Options); err != nil {
+				mutex.Lock()
+				defer mutex.Unlock()
+				destroyErrors[target.Name] = err
+			}
+		})
+	}
+
+	if len(destroyErrors) > 0 {
+		return destroyErrors
+	}
+	return nil
+}
+
+// planTargetWaves groups the given targets into waves, where every target only depends on the outputs of targets in
+// earlier waves, so the targets within a wave can be applied in parallel.
+func planTargetWaves(targets []*Target) ([][]*Target, error) {
+	targetsByName := map[string]*Target{}
+	for _, target := range targets {
+		if _, exists := targetsByName[target.Name]; exists {
+			return nil, DuplicateTargetName(target.Name)
+		}
+		targetsByName[target.Name] = target
+	}
+
+	for _, target := range targets {
+		for _, targetOutput := range target.OutputsAsVars {
+			if _, exists := targetsByName[targetOutput.Target]; !exists {
+				return nil, TargetNotFound{Target: target.Name, Dependency: targetOutput.Target}
+			}
+		}
+	}
+
+	waves := [][]*Target{}
+	planned := map[string]bool{}
+
+	for len(planned) < len(targets) {
+		wave := []*Target{}
+		for _, target := range targets {
+			if !planned[target.Name] && allDependenciesPlanned(target, planned) {
+				wave = append(wave, target)
+			}
+		}
+
+		if len(wave) == 0 {
+			remaining := []string{}
+			for _, target := range targets {
+				if !planned[target.Name] {
+					remaining = append(remaining, target.Name)
+				}
+			}
+			return nil, TargetDependencyCycle(remaining)
+		}
+
+		for _, target := range wave {
+			planned[target.Name] = true
+		}
+		waves = append(waves, wave)
+	}
+
+	return waves, nil
+}
+
+func allDependenciesPlanned(target *Target, planned map[string]bool) bool {
+	for _, targetOutput := range target.OutputsAsVars {
+		if !planned[targetOutput.Target] {
+			return false
+		}
+	}
+	return true
+}
+
+// setTargetCredentials sets the env vars Terraform uses to pick the AWS account or GCP project for the given target. The
+// EnvVars of the target are copied first, as targets built from one base Options share the same map, and Terraform
+// commands (see GetCommonOptions) write to it while the targets run in parallel.
+func setTargetCredentials(target *Target) {
+	envVars := map[string]string{}
+	for key, value := range target.Options.EnvVars {
+		envVars[key] = value
+	}
+	target.Options.EnvVars = envVars
+
+	if target.AwsProfile != "" {
+		target.Options.EnvVars["AWS_PROFILE"] = target.AwsProfile
+	}
+	if target.AwsRegion != "" {
+		target.Options.EnvVars["AWS_DEFAULT_REGION"] = target.AwsRegion
+	}
+	if target.GcpProjectID != "" {
+		target.Options.EnvVars["GOOGLE_PROJECT"] = target.GcpProjectID
+		target.Options.EnvVars["GOOGLE_CLOUD_PROJECT"] = target.GcpProjectID
+	}
+	if target.GcpCredentialsFile != "" {
+		target.Options.EnvVars["GOOGLE_APPLICATION_CREDENTIALS"] = target.GcpCredentialsFile
+	}
+}
+
+// setTargetVarsFromOutputs sets the Terraform vars of the given target that are wired to outputs of other targets. The
+// Vars of the target are copied first, like its EnvVars in setTargetCredentials.
+func setTargetVarsFromOutputs(target *Target, outputs map[string]map[string]interface{}) error {
+	if len(target.OutputsAsVars) == 0 {
+		return nil
+	}
+
+	// Copy the vars, as other targets may share the same map
+	vars := map[string]interface{}{}
+	for key, value := range target.Options.Vars {
+		vars[key] = value
+	}
+	target.Options.Vars = vars
+
+	for varName, targetOutput := range target.OutputsAsVars {
+		value, exists := outputs[targetOutput.Target][targetOutput.Output]
+		if !exists {
+			return OutputKeyNotFound(targetOutput.Output)
+		}
+		target.Options.Vars[varName] = value
+	}
+
+	return nil
+}
+
+// runInParallel calls the given function for each of the given targets in its own goroutine and waits for all of them
+// to finish.
+func runInParallel(targets []*Target, action func(target *Target)) {
+	var waitGroup sync.WaitGroup
+	for _, target := range targets {
+		waitGroup.Add(1)
+		go func(target *Target) {
+			defer waitGroup.Done()
+			action(target)
+		}(target)
+	}
+	waitGroup.Wait()
+}
+
+// sortedTargetNames returns the names of the targets in the given error map in alphabetical order.
+func sortedTargetNames(errs TargetErrors) []string {
+	names := []string{}
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,76 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanTargetWaves(t *testing.T) {
+	t.Parallel()
+
+	hub := &Target{Name: "hub"}
+	spokeA := &Target{Name: "spoke-a", OutputsAsVars: map[string]TargetOutput{"hub_vpc_id": {Target: "hub", Output: "vpc_id"}}}
+	spokeB := &Target{Name: "spoke-b", OutputsAsVars: map[string]TargetOutput{"hub_vpc_id": {Target: "hub", Output: "vpc_id"}}}
+	peering := &Target{Name: "peering", OutputsAsVars: map[string]TargetOutput{
+		"spoke_a_vpc_id": {Target: "spoke-a", Output: "vpc_id"},
+		"spoke_b_vpc_id": {Target: "spoke-b", Output: "vpc_id"},
+	}}
+
+	waves, err := planTargetWaves([]*Target{peering, spokeB, hub, spokeA})
+	require.NoError(t, err)
+	assert.Equal(t, [][]*Target{{hub}, {spokeB, spokeA}, {peering}}, waves)
+}
+
+func TestPlanTargetWavesErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := planTargetWaves([]*Target{{Name: "hub"}, {Name: "hub"}})
+	assert.Equal(t, DuplicateTargetName("hub"), err)
+
+	_, err = planTargetWaves([]*Target{{Name: "spoke", OutputsAsVars: map[string]TargetOutput{"vpc_id": {Target: "hub", Output: "vpc_id"}}}})
+	assert.Equal(t, TargetNotFound{Target: "spoke", Dependency: "hub"}, err)
+
+	_, err = planTargetWaves([]*Target{
+		{Name: "a", OutputsAsVars: map[string]TargetOutput{"x": {Target: "b", Output: "x"}}},
+		{Name: "b", OutputsAsVars: map[string]TargetOutput{"x": {Target: "a", Output: "x"}}},
+	})
+	assert.Equal(t, TargetDependencyCycle{"a", "b"}, err)
+}
+
+func TestSetTargetVarsFromOutputs(t *testing.T) {
+	t.Parallel()
+
+	target := &Target{
+		Name:          "spoke",
+		Options:       &Options{},
+		OutputsAsVars: map[string]TargetOutput{"hub_vpc_id": {Target: "hub", Output: "vpc_id"}},
+	}
+
+	err := setTargetVarsFromOutputs(target, map[string]map[string]interface{}{"hub": {}})
+	assert.Equal(t, OutputKeyNotFound("vpc_id"), err)
+
+	err = setTargetVarsFromOutputs(target, map[string]map[string]interface{}{"hub": {"vpc_id": "vpc-123"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"hub_vpc_id": "vpc-123"}, target.Options.Vars)
+}
+
+func TestSetTargetCredentialsCopiesEnvVars(t *testing.T) {
+	t.Parallel()
+
+	// Targets built from one base Options share its EnvVars map
+	base := Options{EnvVars: map[string]string{"TF_LOG": "DEBUG"}}
+	hubOptions := base
+	spokeOptions := base
+
+	hub := &Target{Name: "hub", Options: &hubOptions, AwsProfile: "hub-account"}
+	spoke := &Target{Name: "spoke", Options: &spokeOptions, GcpProjectID: "spoke-project"}
+
+	setTargetCredentials(hub)
+	setTargetCredentials(spoke)
+
+	assert.Equal(t, map[string]string{"TF_LOG": "DEBUG"}, base.EnvVars)
+	assert.Equal(t, map[string]string{"TF_LOG": "DEBUG", "AWS_PROFILE": "hub-account"}, hub.Options.EnvVars)
+	assert.Equal(t, map[string]string{"TF_LOG": "DEBUG", "GOOGLE_PROJECT": "spoke-project", "GOOGLE_CLOUD_PROJECT": "spoke-project"}, spoke.Options.EnvVars)
+}
@@ -1,25 +1,80 @@
 package terraform
 
 import (
+	"testing"
 	"time"
 
+	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/ssh"
 )
 
 // Options for running Terraform commands
 type Options struct {
-	TerraformBinary          string                 // Name of the binary that will be used
+	TerraformBinary          string                 // Name of the binary that will be used: terraform, terragrunt, or tofu. Defaults to terraform, or tofu if only tofu is installed.
 	TerraformDir             string                 // The path to the folder where the Terraform code is defined.
 	Vars                     map[string]interface{} // The vars to pass to Terraform commands using the -var option.
 	VarFiles                 []string               // The var file paths to pass to Terraform commands using -var-file option.
 	Targets                  []string               // The target resources to pass to the terraform command with -target
 	EnvVars                  map[string]string      // Environment variables to set when running Terraform
 	BackendConfig            map[string]interface{} // The vars to pass to the terraform init command for extra configuration for the backend
-	RetryableTerraformErrors map[string]string      // If Terraform apply fails with one of these (transient) errors, retry. The keys are a regexp to match against the error and the message is what to display to a user if that error is matched.
+	RetryableTerraformErrors map[string]string      // If a Terraform command fails with one of these (transient) errors, retry. The keys are a regexp to match against the error and the message is what to display to a user if that error is matched.
 	MaxRetries               int                    // Maximum number of times to retry errors matching RetryableTerraformErrors
 	TimeBetweenRetries       time.Duration          // The amount of time to wait between retries
 	Upgrade                  bool                   // Whether the -upgrade flag of the terraform init command should be set to true or not
 	NoColor                  bool                   // Whether the -no-color flag will be set for any Terraform command or not
 	SshAgent                 *ssh.SshAgent          // Overrides local SSH agent with the given in-process agent
 	NoStderr                 bool                   // Disable stderr redirection
+	NoStateLock              bool                   // Whether terraform import runs with -lock=false, skipping the state lock. Defaults to locking the state.
+	PlanFilePath             string                 // The path to write the plan file to when running plan -out, and to read it from when running show
+	PluginCacheDir           string                 // The provider plugin cache dir (TF_PLUGIN_CACHE_DIR) to share between tests. Init locks it, so parallel tests can share it safely.
+	PluginDir                string                 // If set, init installs providers only from this dir (the -plugin-dir option), instead of downloading them
+	LogFilePath              string                 // If set, the stdout/stderr of each Terraform command is appended to this file, e.g. to keep a log per test as a CI artifact
+	Logger                   *logger.Logger         // The logger to log Terraform commands and their output with, e.g. logger.Discard to silence them. Defaults to logger.Global.
+}
+
+// DefaultRetryableTerraformErrors are the transient errors that commonly break Terraform runs for reasons unrelated to
+// the code being tested: provider and module download flakes, cloud API throttling, and eventual consistency. Use
+// WithDefaultRetryableErrors to retry them.
+var DefaultRetryableTerraformErrors = map[string]string{
+	// Provider plugin and module downloads
+	".*Failed to query available provider packages.*":                  "Failed to retrieve plugin due to transient network error.",
+	".*Error installing provider.*":                                    "Failed to install provider due to transient network error.",
+	".*Failed to download module.*":                                    "Failed to download module due to transient network error.",
+	".*error downloading.*":                                            "Failed to download due to transient network error.",
+	".*(TLS handshake timeout|i/o timeout|connection reset by peer).*": "Failed due to transient network error.",
+
+	// Cloud API throttling
+	".*(Throttling|RequestLimitExceeded|Rate exceeded).*": "AWS API request was throttled.",
+	".*rateLimitExceeded.*":                               "GCP API request was throttled.",
+	".*(429 Too Many Requests|Error 429).*":               "API request was throttled.",
+
+	// Eventual consistency
+	".*does not have permission to assume role.*":                                             "IAM role was not yet usable after being created.",
+	".*InvalidParameterValueException: The role defined for the function cannot be assumed.*": "IAM role was not yet usable after being created.",
+	".*Error 409.*is not ready.*":                                                             "GCP resource was not yet ready.",
+}
+
+// WithDefaultRetryableErrors returns a copy of the given Options with DefaultRetryableTerraformErrors added to
+// RetryableTerraformErrors. Errors already configured in the Options take precedence. If MaxRetries and
+// TimeBetweenRetries are not set, they are set to 3 retries, 5 seconds apart.
+func WithDefaultRetryableErrors(t testing.TB, originalOptions *Options) *Options {
+	newOptions := *originalOptions
+
+	newOptions.RetryableTerraformErrors = map[string]string{}
+	for regex, message := range DefaultRetryableTerraformErrors {
+		newOptions.RetryableTerraformErrors[regex] = message
+	}
+	for regex, message := range originalOptions.RetryableTerraformErrors {
+		newOptions.RetryableTerraformErrors[regex] = message
+	}
+
+	if newOptions.MaxRetries == 0 {
+		newOptions.MaxRetries = 3
+	}
+	if newOptions.TimeBetweenRetries == 0 {
+		newOptions.TimeBetweenRetries = 5 * time.Second
+	}
+
+	newOptions.Logger.Logf(t, "Retrying up to %d times on %d known transient Terraform errors", newOptions.MaxRetries, len(newOptions.RetryableTerraformErrors))
+	return &newOptions
 }
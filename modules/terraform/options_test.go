@@ -0,0 +1,52 @@
+package terraform
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRetryableTerraformErrorsCompile(t *testing.T) {
+	t.Parallel()
+
+	for regex := range DefaultRetryableTerraformErrors {
+		_, err := regexp.Compile(regex)
+		require.NoError(t, err, regex)
+	}
+}
+
+func TestWithDefaultRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	originalOptions := &Options{
+		TerraformDir: "../../test/fixtures/terraform-no-error",
+		RetryableTerraformErrors: map[string]string{
+			".*rateLimitExceeded.*": "Custom message",
+			".*flaky.*":             "Flaky test fixture",
+		},
+	}
+
+	options := WithDefaultRetryableErrors(t, originalOptions)
+
+	assert.Equal(t, 3, options.MaxRetries)
+	assert.Equal(t, 5*time.Second, options.TimeBetweenRetries)
+	assert.Equal(t, "Flaky test fixture", options.RetryableTerraformErrors[".*flaky.*"])
+	assert.Equal(t, "Custom message", options.RetryableTerraformErrors[".*rateLimitExceeded.*"])
+	assert.Equal(t, DefaultRetryableTerraformErrors[".*error downloading.*"], options.RetryableTerraformErrors[".*error downloading.*"])
+
+	// The original options are left untouched
+	assert.Equal(t, 0, originalOptions.MaxRetries)
+	assert.Len(t, originalOptions.RetryableTerraformErrors, 2)
+}
+
+func TestWithDefaultRetryableErrorsKeepsRetrySettings(t *testing.T) {
+	t.Parallel()
+
+	options := WithDefaultRetryableErrors(t, &Options{MaxRetries: 10, TimeBetweenRetries: time.Minute})
+
+	assert.Equal(t, 10, options.MaxRetries)
+	assert.Equal(t, time.Minute, options.TimeBetweenRetries)
+}
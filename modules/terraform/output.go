@@ -11,14 +11,14 @@ import (
 )
 
 // Output calls terraform output for the given variable and return its value.
-func Output(t *testing.T, options *Options, key string) string {
+func Output(t testing.TB, options *Options, key string) string {
 	out, err := OutputE(t, options, key)
 	require.NoError(t, err)
 	return out
 }
 
 // OutputE calls terraform output for the given variable and return its value.
-func OutputE(t *testing.T, options *Options, key string) (string, error) {
+func OutputE(t testing.TB, options *Options, key string) (string, error) {
 	output, err := RunTerraformCommandE(t, options, "output", "-no-color", key)
 
 	if err != nil {
@@ -29,14 +29,14 @@ func OutputE(t *testing.T, options *Options, key string) (string, error) {
 }
 
 // OutputRequired calls terraform output for the given variable and return its value. If the value is empty, fail the test.
-func OutputRequired(t *testing.T, options *Options, key string) string {
+func OutputRequired(t testing.TB, options *Options, key string) string {
 	out, err := OutputRequiredE(t, options, key)
 	require.NoError(t, err)
 	return out
 }
 
 // OutputRequiredE calls terraform output for the given variable and return its value. If the value is empty, return an error.
-func OutputRequiredE(t *testing.T, options *Options, key string) (string, error) {
+func OutputRequiredE(t testing.TB, options *Options, key string) (string, error) {
 	out, err := OutputE(t, options, key)
 
 	if err != nil {
@@ -51,7 +51,7 @@ func OutputRequiredE(t *testing.T, options *Options, key string) (string, error)
 
 // OutputList calls terraform output for the given variable and returns its value as a list.
 // If the output value is not a list type, then it fails the test.
-func OutputList(t *testing.T, options *Options, key string) []string {
+func OutputList(t testing.TB, options *Options, key string) []string {
 	out, err := OutputListE(t, options, key)
 	require.NoError(t, err)
 	return out
@@ -59,7 +59,7 @@ func OutputList(t *testing.T, options *Options, key string) []string {
 
 // OutputListE calls terraform output for the given variable and returns its value as a list.
 // If the output value is not a list type, then it returns an error.
-func OutputListE(t *testing.T, options *Options, key string) ([]string, error) {
+func OutputListE(t testing.TB, options *Options, key string) ([]string, error) {
 	out, err := RunTerraformCommandE(t, options, "output", "-no-color", "-json", key)
 	if err != nil {
 		return nil, err
@@ -112,7 +112,7 @@ func parseListOutputTerraform11OrOlder(outputMap map[string]interface{}, key str
 
 // OutputMap calls terraform output for the given variable and returns its value as a map.
 // If the output value is not a map type, then it fails the test.
-func OutputMap(t *testing.T, options *Options, key string) map[string]string {
+func OutputMap(t testing.TB, options *Options, key string) map[string]string {
 	out, err := OutputMapE(t, options, key)
 	require.NoError(t, err)
 	return out
@@ -120,7 +120,7 @@ func OutputMap(t *testing.T, options *Options, key string) map[string]string {
 
 // OutputMapE calls terraform output for the given variable and returns its value as a map.
 // If the output value is not a map type, then it returns an error.
-func OutputMapE(t *testing.T, options *Options, key string) (map[string]string, error) {
+func OutputMapE(t testing.TB, options *Options, key string) (map[string]string, error) {
 	out, err := RunTerraformCommandE(t, options, "output", "-no-color", "-json", key)
 	if err != nil {
 		return nil, err
@@ -155,7 +155,7 @@ func OutputMapE(t *testing.T, options *Options, key string) (map[string]string,
 
 // OutputForKeys calls terraform output for the given key list and returns values as a map.
 // If keys not found in the output, fails the test
-func OutputForKeys(t *testing.T, options *Options, keys []string) map[string]interface{} {
+func OutputForKeys(t testing.TB, options *Options, keys []string) map[string]interface{} {
 	out, err := OutputForKeysE(t, options, keys)
 	require.NoError(t, err)
 	return out
@@ -163,7 +163,7 @@ func OutputForKeys(t *testing.T, options *Options, keys []string) map[string]int
 
 // OutputForKeysE calls terraform output for the given key list and returns values as a map.
 // The returned values are of type interface{} and need to be type casted as necessary. Refer to output_test.go
-func OutputForKeysE(t *testing.T, options *Options, keys []string) (map[string]interface{}, error) {
+func OutputForKeysE(t testing.TB, options *Options, keys []string) (map[string]interface{}, error) {
 	out, err := RunTerraformCommandE(t, options, "output", "-no-color", "-json")
 	if err != nil {
 		return nil, err
@@ -195,13 +195,51 @@ func OutputForKeysE(t *testing.T, options *Options, keys []string) (map[string]i
 
 // OutputAll calls terraform output returns all values as a map.
 // If there is error fetching the output, fails the test
-func OutputAll(t *testing.T, options *Options) map[string]interface{} {
+func OutputAll(t testing.TB, options *Options) map[string]interface{} {
 	out, err := OutputAllE(t, options)
 	require.NoError(t, err)
 	return out
 }
 
 // OutputAllE calls terraform and returns all the outputs as a map
-func OutputAllE(t *testing.T, options *Options) (map[string]interface{}, error) {
+func OutputAllE(t testing.TB, options *Options) (map[string]interface{}, error) {
 	return OutputForKeysE(t, options, nil)
 }
+
+// OutputStruct calls terraform output for the given variable and decodes its JSON value into the value pointed to by
+// v, which is typically a pointer to a struct with json tags. If the output can't be decoded, it fails the test.
+func OutputStruct(t testing.TB, options *Options, key string, v interface{}) {
+	err := OutputStructE(t, options, key, v)
+	require.NoError(t, err)
+}
+
+// OutputStructE calls terraform output for the given variable and decodes its JSON value into the value pointed to by
+// v, which is typically a pointer to a struct with json tags. If the output can't be decoded, it returns an error.
+func OutputStructE(t testing.TB, options *Options, key string, v interface{}) error {
+	out, err := RunTerraformCommandE(t, options, "output", "-no-color", "-json", key)
+	if err != nil {
+		return err
+	}
+
+	return decodeOutputJson(out, key, v)
+}
+
+// decodeOutputJson decodes the JSON returned by terraform output -json into v. Terraform 0.11 and older wrap the value
+// in an object with the keys "sensitive", "type", and "value", so in that case only the wrapped value is decoded.
+func decodeOutputJson(out string, key string, v interface{}) error {
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(out), &wrapper); err == nil {
+		value, containsValue := wrapper["value"]
+		_, containsSensitive := wrapper["sensitive"]
+		_, containsType := wrapper["type"]
+		if containsValue && containsSensitive && containsType {
+			out = string(value)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(out), v); err != nil {
+		return OutputDecodeError{Key: key, Err: err}
+	}
+
+	return nil
+}
@@ -199,3 +199,66 @@ func TestOutputsForKeysError(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestOutputStruct(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-output-map", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir: testFolder,
+	}
+
+	InitAndApply(t, options)
+
+	var out struct {
+		Guitar1 string `json:"guitar_1"`
+		Bass    string `json:"bass"`
+	}
+	OutputStruct(t, options, "mogwai", &out)
+
+	require.Equal(t, "Stuart Braithwaite", out.Guitar1)
+	require.Equal(t, "Dominic Aitchison", out.Bass)
+}
+
+func TestDecodeOutputJson(t *testing.T) {
+	t.Parallel()
+
+	type band struct {
+		Name    string   `json:"name"`
+		Members []string `json:"members"`
+	}
+
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{"terraform-0.12", `{"name": "Mogwai", "members": ["Stuart", "Barry"]}`},
+		{"terraform-0.11", `{"sensitive": false, "type": "map", "value": {"name": "Mogwai", "members": ["Stuart", "Barry"]}}`},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out band
+			require.NoError(t, decodeOutputJson(testCase.json, "band", &out))
+			require.Equal(t, band{Name: "Mogwai", Members: []string{"Stuart", "Barry"}}, out)
+		})
+	}
+}
+
+func TestDecodeOutputJsonError(t *testing.T) {
+	t.Parallel()
+
+	var out []string
+	err := decodeOutputJson(`"not a list"`, "not_a_list", &out)
+
+	require.Error(t, err)
+	require.IsType(t, OutputDecodeError{}, err)
+}
@@ -0,0 +1,293 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PlanStruct is the output of terraform show -json for a plan file, parsed into Go structs. Besides the raw structure,
+// it indexes the planned values and the resource changes by resource address (e.g. module.vpc.aws_subnet.private[0])
+// so tests can look up a resource directly.
+type PlanStruct struct {
+	FormatVersion    string            `json:"format_version"`
+	TerraformVersion string            `json:"terraform_version"`
+	PlannedValues    PlanValues        `json:"planned_values"`
+	ResourceChanges  []*ResourceChange `json:"resource_changes"`
+
+	ResourcePlannedValuesMap map[string]*PlannedResource `json:"-"`
+	ResourceChangesMap       map[string]*ResourceChange  `json:"-"`
+}
+
+// PlanValues are the values Terraform expects the resources and outputs to have after the plan is applied.
+type PlanValues struct {
+	Outputs    map[string]PlannedOutput `json:"outputs"`
+	RootModule PlannedModule            `json:"root_module"`
+}
+
+// PlannedOutput is the value an output is expected to have after the plan is applied. Value is nil if the value is
+// only known after apply.
+type PlannedOutput struct {
+	Sensitive bool        `json:"sensitive"`
+	Value     interface{} `json:"value"`
+}
+
+// PlannedModule holds the planned resources of a module and its child modules.
+type PlannedModule struct {
+	Address      string             `json:"address"`
+	Resources    []*PlannedResource `json:"resources"`
+	ChildModules []*PlannedModule   `json:"child_modules"`
+}
+
+// PlannedResource is the value a resource is expected to have after the plan is applied. Attributes that are only
+// known after apply are missing from Values.
+type PlannedResource struct {
+	Address      string                 `json:"address"`
+	Mode         string                 `json:"mode"`
+	Type         string                 `json:"type"`
+	Name         string                 `json:"name"`
+	Index        interface{}            `json:"index"`
+	ProviderName string                 `json:"provider_name"`
+	Values       map[string]interface{} `json:"values"`
+}
+
+// ResourceChange describes what the plan will do to a resource.
+type ResourceChange struct {
+	Address       string      `json:"address"`
+	ModuleAddress string      `json:"module_address"`
+	Mode          string      `json:"mode"`
+	Type          string      `json:"type"`
+	Name          string      `json:"name"`
+	Index         interface{} `json:"index"`
+	ProviderName  string      `json:"provider_name"`
+	Change        Change      `json:"change"`
+}
+
+// Change is the set of actions (no-op, create, read, update, delete) the plan takes on a resource, with the resource's
+// values before and after. A replacement is either [delete, create] or [create, delete].
+type Change struct {
+	Actions []string    `json:"actions"`
+	Before  interface{} `json:"before"`
+	After   interface{} `json:"after"`
+}
+
+// The actions that can appear in a Change
+const (
+	ActionNoop   = "no-op"
+	ActionCreate = "create"
+	ActionRead   = "read"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Plan runs terraform plan with the given options, saving the plan to options.PlanFilePath, and returns
+// stdout/stderr.
+func Plan(t testing.TB, options *Options) string {
+	out, err := PlanE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// PlanE runs terraform plan with the given options, saving the plan to options.PlanFilePath, and returns
+// stdout/stderr.
+func PlanE(t testing.TB, options *Options) (string, error) {
+	if options.PlanFilePath == "" {
+		return "", PlanFilePathRequired{}
+	}
+
+	return RunTerraformCommandE(t, options, FormatArgs(options, "plan", "-input=false", "-lock=false", "-out="+options.PlanFilePath)...)
+}
+
+// Show runs terraform show -json on the plan file at options.PlanFilePath and returns the JSON.
+func Show(t testing.TB, options *Options) string {
+	out, err := ShowE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// ShowE runs terraform show -json on the plan file at options.PlanFilePath and returns the JSON.
+func ShowE(t testing.TB, options *Options) (string, error) {
+	if options.PlanFilePath == "" {
+		return "", PlanFilePathRequired{}
+	}
+
+	out, err := RunTerraformCommandE(t, options, "show", "-no-color", "-json", options.PlanFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	return extractJsonLine(out)
+}
+
+// InitAndPlanAndShow runs terraform init, plan (saving the plan to options.PlanFilePath), and show -json, and returns
+// the JSON of the plan.
+func InitAndPlanAndShow(t testing.TB, options *Options) string {
+	out, err := InitAndPlanAndShowE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// InitAndPlanAndShowE runs terraform init, plan (saving the plan to options.PlanFilePath), and show -json, and
+// returns the JSON of the plan.
+func InitAndPlanAndShowE(t testing.TB, options *Options) (string, error) {
+	if _, err := InitE(t, options); err != nil {
+		return "", err
+	}
+
+	if _, err := PlanE(t, options); err != nil {
+		return "", err
+	}
+
+	return ShowE(t, options)
+}
+
+// InitAndPlanAndShowWithStruct runs terraform init, plan (saving the plan to options.PlanFilePath), and show -json,
+// and returns the plan parsed into a PlanStruct. This lets tests check what a module would do without applying it.
+func InitAndPlanAndShowWithStruct(t testing.TB, options *Options) *PlanStruct {
+	plan, err := InitAndPlanAndShowWithStructE(t, options)
+	require.NoError(t, err)
+	return plan
+}
+
+// InitAndPlanAndShowWithStructE runs terraform init, plan (saving the plan to options.PlanFilePath), and show -json,
+// and returns the plan parsed into a PlanStruct. This lets tests check what a module would do without applying it.
+func InitAndPlanAndShowWithStructE(t testing.TB, options *Options) (*PlanStruct, error) {
+	jsonOut, err := InitAndPlanAndShowE(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePlanJSON(jsonOut)
+}
+
+// ParsePlanJSON parses the JSON output of terraform show -json for a plan file into a PlanStruct.
+func ParsePlanJSON(jsonOut string) (*PlanStruct, error) {
+	plan := &PlanStruct{}
+	if err := json.Unmarshal([]byte(jsonOut), plan); err != nil {
+		return nil, err
+	}
+
+	normalizeProviderNames(plan)
+
+	plan.ResourcePlannedValuesMap = map[string]*PlannedResource{}
+	indexPlannedModule(&plan.PlannedValues.RootModule, plan.ResourcePlannedValuesMap)
+
+	plan.ResourceChangesMap = map[string]*ResourceChange{}
+	for _, change := range plan.ResourceChanges {
+		plan.ResourceChangesMap[change.Address] = change
+	}
+
+	return plan, nil
+}
+
+func indexPlannedModule(module *PlannedModule, index map[string]*PlannedResource) {
+	for _, resource := range module.Resources {
+		index[resource.Address] = resource
+	}
+	for _, child := range module.ChildModules {
+		indexPlannedModule(child, index)
+	}
+}
+
+// The registry hosts at the start of provider names in plans from OpenTofu and Terraform
+const (
+	tofuRegistryPrefix      = "registry.opentofu.org/"
+	terraformRegistryPrefix = "registry.terraform.io/"
+)
+
+// normalizeProviderNames rewrites provider names in plans from OpenTofu, which are named after the OpenTofu registry
+// (registry.opentofu.org/hashicorp/aws), to the form Terraform uses (registry.terraform.io/hashicorp/aws), so the same
+// assertions work on plans from both.
+func normalizeProviderNames(plan *PlanStruct) {
+	normalize := func(name string) string {
+		if strings.HasPrefix(name, tofuRegistryPrefix) {
+			return terraformRegistryPrefix + strings.TrimPrefix(name, tofuRegistryPrefix)
+		}
+		return name
+	}
+
+	var normalizeModule func(module *PlannedModule)
+	normalizeModule = func(module *PlannedModule) {
+		for _, resource := range module.Resources {
+			resource.ProviderName = normalize(resource.ProviderName)
+		}
+		for _, child := range module.ChildModules {
+			normalizeModule(child)
+		}
+	}
+
+	normalizeModule(&plan.PlannedValues.RootModule)
+	for _, change := range plan.ResourceChanges {
+		change.ProviderName = normalize(change.ProviderName)
+	}
+}
+
+// extractJsonLine returns the last line of the given command output that is valid JSON. The output of terraform
+// commands includes stderr, so warnings may surround the JSON.
+func extractJsonLine(out string) (string, error) {
+	lines := strings.Split(out, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "{") && json.Valid([]byte(line)) {
+			return line, nil
+		}
+	}
+
+	return "", fmt.Errorf("terraform show did not return any JSON: %s", out)
+}
+
+// ResourceChangesCount returns the number of resources the plan takes the given action (e.g. ActionCreate) on.
+// Resources that are replaced count towards both ActionCreate and ActionDelete.
+func (plan *PlanStruct) ResourceChangesCount(action string) int {
+	count := 0
+	for _, change := range plan.ResourceChanges {
+		if collections.ListContains(change.Change.Actions, action) {
+			count++
+		}
+	}
+	return count
+}
+
+// AssertResourceChangesCount checks that the plan takes the given action (e.g. ActionCreate) on exactly the given
+// number of resources.
+func AssertResourceChangesCount(t testing.TB, plan *PlanStruct, action string, expected int) {
+	actual := plan.ResourceChangesCount(action)
+	assert.Equal(t, expected, actual, "Expected the plan to %s %d resources, but it would %s %d", action, expected, action, actual)
+}
+
+// AssertPlannedValuesMapKeyExists checks that the plan has planned values for the resource with the given address.
+func AssertPlannedValuesMapKeyExists(t testing.TB, plan *PlanStruct, address string) {
+	_, exists := plan.ResourcePlannedValuesMap[address]
+	assert.True(t, exists, "Expected the plan to have planned values for %s", address)
+}
+
+// RequirePlannedValuesMapKeyExists checks that the plan has planned values for the resource with the given address,
+// failing the test immediately if it does not.
+func RequirePlannedValuesMapKeyExists(t testing.TB, plan *PlanStruct, address string) {
+	_, exists := plan.ResourcePlannedValuesMap[address]
+	require.True(t, exists, "Expected the plan to have planned values for %s", address)
+}
+
+// AssertResourceChangesMapKeyExists checks that the plan has a resource change for the resource with the given
+// address.
+func AssertResourceChangesMapKeyExists(t testing.TB, plan *PlanStruct, address string) {
+	_, exists := plan.ResourceChangesMap[address]
+	assert.True(t, exists, "Expected the plan to have a resource change for %s", address)
+}
+
+// RequireNoDestroys checks that the plan does not delete or replace any resources, failing the test immediately and
+// listing the offending resources if it does.
+func RequireNoDestroys(t testing.TB, plan *PlanStruct) {
+	destroyed := []string{}
+	for _, change := range plan.ResourceChanges {
+		if collections.ListContains(change.Change.Actions, ActionDelete) {
+			destroyed = append(destroyed, change.Address)
+		}
+	}
+	require.Empty(t, destroyed, "Expected the plan not to destroy any resources, but it would destroy %v", destroyed)
+}
@@ -0,0 +1,131 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const examplePlanJSON = `{
+  "format_version": "0.1",
+  "terraform_version": "0.12.6",
+  "planned_values": {
+    "outputs": {"bucket_name": {"sensitive": false, "value": "terratest-bucket"}},
+    "root_module": {
+      "resources": [
+        {"address": "google_storage_bucket.bucket", "mode": "managed", "type": "google_storage_bucket", "name": "bucket", "provider_name": "google", "values": {"name": "terratest-bucket", "location": "US"}}
+      ],
+      "child_modules": [
+        {
+          "address": "module.network",
+          "resources": [
+            {"address": "module.network.google_compute_network.vpc", "mode": "managed", "type": "google_compute_network", "name": "vpc", "provider_name": "google", "values": {"name": "terratest-vpc"}}
+          ]
+        }
+      ]
+    }
+  },
+  "resource_changes": [
+    {"address": "google_storage_bucket.bucket", "mode": "managed", "type": "google_storage_bucket", "name": "bucket", "provider_name": "google", "change": {"actions": ["create"], "before": null, "after": {"name": "terratest-bucket"}}},
+    {"address": "module.network.google_compute_network.vpc", "module_address": "module.network", "mode": "managed", "type": "google_compute_network", "name": "vpc", "provider_name": "google", "change": {"actions": ["delete", "create"], "before": {"name": "old-vpc"}, "after": {"name": "terratest-vpc"}}},
+    {"address": "google_project_service.compute", "mode": "managed", "type": "google_project_service", "name": "compute", "provider_name": "google", "change": {"actions": ["no-op"], "before": {}, "after": {}}}
+  ]
+}`
+
+func TestParsePlanJSON(t *testing.T) {
+	t.Parallel()
+
+	plan, err := ParsePlanJSON(examplePlanJSON)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.12.6", plan.TerraformVersion)
+	assert.Equal(t, "terratest-bucket", plan.PlannedValues.Outputs["bucket_name"].Value)
+
+	AssertPlannedValuesMapKeyExists(t, plan, "google_storage_bucket.bucket")
+	RequirePlannedValuesMapKeyExists(t, plan, "module.network.google_compute_network.vpc")
+	assert.Equal(t, "US", plan.ResourcePlannedValuesMap["google_storage_bucket.bucket"].Values["location"])
+
+	AssertResourceChangesMapKeyExists(t, plan, "google_project_service.compute")
+	assert.Equal(t, "module.network", plan.ResourceChangesMap["module.network.google_compute_network.vpc"].ModuleAddress)
+}
+
+func TestResourceChangesCount(t *testing.T) {
+	t.Parallel()
+
+	plan, err := ParsePlanJSON(examplePlanJSON)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		action   string
+		expected int
+	}{
+		{ActionCreate, 2},
+		{ActionDelete, 1},
+		{ActionUpdate, 0},
+		{ActionNoop, 1},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.action, func(t *testing.T) {
+			t.Parallel()
+			AssertResourceChangesCount(t, plan, testCase.action, testCase.expected)
+		})
+	}
+}
+
+func TestRequireNoDestroysPassesWithoutDeletes(t *testing.T) {
+	t.Parallel()
+
+	plan, err := ParsePlanJSON(`{"resource_changes": [{"address": "a.b", "change": {"actions": ["update"]}}]}`)
+	require.NoError(t, err)
+
+	RequireNoDestroys(t, plan)
+}
+
+func TestExtractJsonLine(t *testing.T) {
+	t.Parallel()
+
+	out, err := extractJsonLine("Warning: something\n\n{\"format_version\": \"0.1\"}\n")
+	require.NoError(t, err)
+	assert.Equal(t, `{"format_version": "0.1"}`, out)
+
+	_, err = extractJsonLine("No JSON here")
+	require.Error(t, err)
+}
+
+func TestPlanRequiresPlanFilePath(t *testing.T) {
+	t.Parallel()
+
+	_, err := PlanE(t, &Options{TerraformDir: "."})
+	require.Error(t, err)
+	assert.IsType(t, PlanFilePathRequired{}, err)
+}
+
+func TestParsePlanJSONNormalizesOpenTofuProviderNames(t *testing.T) {
+	t.Parallel()
+
+	tofuPlanJSON := `{
+  "format_version": "1.2",
+  "terraform_version": "1.6.0",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {"address": "null_resource.test", "mode": "managed", "type": "null_resource", "name": "test", "provider_name": "registry.opentofu.org/hashicorp/null", "values": {}}
+      ]
+    }
+  },
+  "resource_changes": [
+    {"address": "null_resource.test", "mode": "managed", "type": "null_resource", "name": "test", "provider_name": "registry.opentofu.org/hashicorp/null", "change": {"actions": ["create"], "before": null, "after": {}}}
+  ]
+}`
+
+	plan, err := ParsePlanJSON(tofuPlanJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "registry.terraform.io/hashicorp/null", plan.ResourcePlannedValuesMap["null_resource.test"].ProviderName)
+	assert.Equal(t, "registry.terraform.io/hashicorp/null", plan.ResourceChangesMap["null_resource.test"].ProviderName)
+}
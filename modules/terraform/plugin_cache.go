@@ -0,0 +1,155 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// The name of the lock file init creates in Options.PluginCacheDir while it runs
+const pluginCacheLockFileName = ".terratest-plugin-cache.lock"
+
+var (
+	// How long to wait for the lock on a plugin cache before giving up
+	pluginCacheLockTimeout = 30 * time.Minute
+
+	// How often to check whether the lock on a plugin cache has been released
+	pluginCacheLockPollInterval = 1 * time.Second
+
+	// Lock files older than this were left behind by a test process that crashed, and are removed
+	pluginCacheLockStaleAfter = 15 * time.Minute
+
+	// Locks the plugin caches within this process, keyed by the absolute path of the cache dir. The lock file only
+	// guards against other processes, e.g. the test binaries of other packages that go test runs in parallel.
+	pluginCacheMutexes     = map[string]*sync.Mutex{}
+	pluginCacheMutexesLock sync.Mutex
+)
+
+// lockPluginCacheE takes the lock on the given plugin cache dir, waiting for other tests in this process and in other
+// processes to release it, and returns a function that releases it. Terraform does not support concurrent writes to
+// the plugin cache, so parallel runs of terraform init that share a cache must take turns.
+func lockPluginCacheE(t testing.TB, cacheDir string) (func(), error) {
+	absCacheDir, err := filepath.Abs(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(absCacheDir, 0755); err != nil {
+		return nil, err
+	}
+
+	pluginCacheMutexesLock.Lock()
+	mutex, exists := pluginCacheMutexes[absCacheDir]
+	if !exists {
+		mutex = &sync.Mutex{}
+		pluginCacheMutexes[absCacheDir] = mutex
+	}
+	pluginCacheMutexesLock.Unlock()
+
+	mutex.Lock()
+
+	lockFile := filepath.Join(absCacheDir, pluginCacheLockFileName)
+	deadline := time.Now().Add(pluginCacheLockTimeout)
+	for {
+		file, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d", os.Getpid())
+			file.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			mutex.Unlock()
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(lockFile); statErr == nil && time.Since(info.ModTime()) > pluginCacheLockStaleAfter {
+			logger.Logf(t, "Removing stale lock file %s, which is older than %s", lockFile, pluginCacheLockStaleAfter)
+			os.Remove(lockFile)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			mutex.Unlock()
+			return nil, PluginCacheLockTimeout{CacheDir: absCacheDir, Timeout: pluginCacheLockTimeout}
+		}
+
+		logger.Logf(t, "Waiting for another test to release the lock on plugin cache %s", absCacheDir)
+		time.Sleep(pluginCacheLockPollInterval)
+	}
+
+	var releaseOnce sync.Once
+	return func() {
+		releaseOnce.Do(func() {
+			os.Remove(lockFile)
+			mutex.Unlock()
+		})
+	}, nil
+}
+
+// SetupProviderMirror configures the given Options to install providers only from the provider filesystem mirror in
+// the given dir, instead of downloading them from the registry. It writes a CLI configuration file that sets up the
+// mirror and points TF_CLI_CONFIG_FILE at it. Use MirrorProviders to fill the mirror. This requires Terraform 0.13 or
+// newer (or OpenTofu); for older versions, set Options.PluginDir instead.
+func SetupProviderMirror(t testing.TB, options *Options, mirrorDir string) {
+	require.NoError(t, SetupProviderMirrorE(t, options, mirrorDir))
+}
+
+// SetupProviderMirrorE configures the given Options to install providers only from the provider filesystem mirror in
+// the given dir, instead of downloading them from the registry. It writes a CLI configuration file that sets up the
+// mirror and points TF_CLI_CONFIG_FILE at it. Use MirrorProvidersE to fill the mirror. This requires Terraform 0.13 or
+// newer (or OpenTofu); for older versions, set Options.PluginDir instead.
+func SetupProviderMirrorE(t testing.TB, options *Options, mirrorDir string) error {
+	absMirrorDir, err := filepath.Abs(mirrorDir)
+	if err != nil {
+		return err
+	}
+
+	configDir, err := ioutil.TempDir("", "terratest-cli-config")
+	if err != nil {
+		return err
+	}
+
+	configFile := filepath.Join(configDir, "terraform.rc")
+	if err := ioutil.WriteFile(configFile, []byte(formatProviderMirrorConfig(absMirrorDir)), 0644); err != nil {
+		return err
+	}
+
+	options.Logger.Logf(t, "Using provider mirror %s (CLI config file %s)", absMirrorDir, configFile)
+	if options.EnvVars == nil {
+		options.EnvVars = map[string]string{}
+	}
+	options.EnvVars["TF_CLI_CONFIG_FILE"] = configFile
+	return nil
+}
+
+// formatProviderMirrorConfig returns a CLI configuration that installs all providers from the filesystem mirror in the
+// given dir
+func formatProviderMirrorConfig(mirrorDir string) string {
+	return fmt.Sprintf(`provider_installation {
+  filesystem_mirror {
+    path    = %s
+    include = ["*/*/*"]
+  }
+}
+`, quoteHclString(mirrorDir))
+}
+
+// MirrorProviders runs terraform providers mirror to download the providers the code in options.TerraformDir needs
+// into the given dir, so it can be used as a provider mirror with SetupProviderMirror.
+func MirrorProviders(t testing.TB, options *Options, mirrorDir string) string {
+	out, err := MirrorProvidersE(t, options, mirrorDir)
+	require.NoError(t, err)
+	return out
+}
+
+// MirrorProvidersE runs terraform providers mirror to download the providers the code in options.TerraformDir needs
+// into the given dir, so it can be used as a provider mirror with SetupProviderMirrorE.
+func MirrorProvidersE(t testing.TB, options *Options, mirrorDir string) (string, error) {
+	return RunTerraformCommandE(t, options, "providers", "mirror", mirrorDir)
+}
@@ -0,0 +1,133 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockPluginCacheWithinProcess(t *testing.T) {
+	t.Parallel()
+
+	cacheDir, err := ioutil.TempDir("", "plugin-cache")
+	require.NoError(t, err)
+
+	unlock, err := lockPluginCacheE(t, cacheDir)
+	require.NoError(t, err)
+	assert.True(t, files.FileExists(filepath.Join(cacheDir, pluginCacheLockFileName)))
+
+	acquired := make(chan func())
+	go func() {
+		secondUnlock, err := lockPluginCacheE(t, cacheDir)
+		assert.NoError(t, err)
+		acquired <- secondUnlock
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Second lock was acquired while the first was still held")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case secondUnlock := <-acquired:
+		secondUnlock()
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the second lock")
+	}
+
+	assert.False(t, files.FileExists(filepath.Join(cacheDir, pluginCacheLockFileName)))
+}
+
+func TestLockPluginCacheWaitsForOtherProcess(t *testing.T) {
+	t.Parallel()
+
+	cacheDir, err := ioutil.TempDir("", "plugin-cache")
+	require.NoError(t, err)
+
+	// Simulate another test process holding the lock
+	lockFile := filepath.Join(cacheDir, pluginCacheLockFileName)
+	require.NoError(t, ioutil.WriteFile(lockFile, []byte("12345"), 0644))
+
+	acquired := make(chan func())
+	go func() {
+		unlock, err := lockPluginCacheE(t, cacheDir)
+		assert.NoError(t, err)
+		acquired <- unlock
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Lock was acquired while another process held it")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	require.NoError(t, os.Remove(lockFile))
+	select {
+	case unlock := <-acquired:
+		unlock()
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the lock")
+	}
+}
+
+func TestLockPluginCacheRemovesStaleLock(t *testing.T) {
+	t.Parallel()
+
+	cacheDir, err := ioutil.TempDir("", "plugin-cache")
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(cacheDir, pluginCacheLockFileName)
+	require.NoError(t, ioutil.WriteFile(lockFile, []byte("12345"), 0644))
+	staleTime := time.Now().Add(-2 * pluginCacheLockStaleAfter)
+	require.NoError(t, os.Chtimes(lockFile, staleTime, staleTime))
+
+	unlock, err := lockPluginCacheE(t, cacheDir)
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestSetupProviderMirror(t *testing.T) {
+	t.Parallel()
+
+	options := &Options{}
+	SetupProviderMirror(t, options, "/tmp/providers")
+
+	configFile := options.EnvVars["TF_CLI_CONFIG_FILE"]
+	contents, err := ioutil.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "provider_installation {\n  filesystem_mirror {\n    path    = \"/tmp/providers\"\n    include = [\"*/*/*\"]\n  }\n}\n", string(contents))
+}
+
+func TestInitWithSharedPluginCache(t *testing.T) {
+	t.Parallel()
+
+	cacheDir, err := ioutil.TempDir("", "plugin-cache")
+	require.NoError(t, err)
+
+	for _, name := range []string{"first", "second", "third"} {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-state", t.Name())
+			require.NoError(t, err)
+
+			options := &Options{
+				TerraformDir:   testFolder,
+				PluginCacheDir: cacheDir,
+			}
+			Init(t, options)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/collections"
+	"github.com/stretchr/testify/require"
+)
+
+// ShowState runs terraform show with the given options and returns the contents of the state in human-readable form.
+func ShowState(t testing.TB, options *Options) string {
+	out, err := ShowStateE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// ShowStateE runs terraform show with the given options and returns the contents of the state in human-readable form.
+func ShowStateE(t testing.TB, options *Options) (string, error) {
+	return RunTerraformCommandE(t, options, "show", "-no-color")
+}
+
+// StateList runs terraform state list with the given options and returns the addresses of the resources in the state
+// (e.g. aws_instance.example or module.vpc.aws_subnet.private[0]).
+func StateList(t testing.TB, options *Options) []string {
+	addresses, err := StateListE(t, options)
+	require.NoError(t, err)
+	return addresses
+}
+
+// StateListE runs terraform state list with the given options and returns the addresses of the resources in the state
+// (e.g. aws_instance.example or module.vpc.aws_subnet.private[0]).
+func StateListE(t testing.TB, options *Options) ([]string, error) {
+	out, err := RunTerraformCommandE(t, options, "state", "list")
+	if err != nil {
+		return nil, err
+	}
+	return parseStateList(out), nil
+}
+
+// parseStateList returns the resource addresses in the output of terraform state list, which has one per line. The
+// output may include log lines when running terragrunt, which are dropped.
+func parseStateList(out string) []string {
+	addresses := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "[terragrunt]") {
+			continue
+		}
+		addresses = append(addresses, line)
+	}
+	return addresses
+}
+
+// StateResourceExists returns true if the state has a resource with the given address.
+func StateResourceExists(t testing.TB, options *Options, address string) bool {
+	exists, err := StateResourceExistsE(t, options, address)
+	require.NoError(t, err)
+	return exists
+}
+
+// StateResourceExistsE returns true if the state has a resource with the given address.
+func StateResourceExistsE(t testing.TB, options *Options, address string) (bool, error) {
+	addresses, err := StateListE(t, options)
+	if err != nil {
+		return false, err
+	}
+	return collections.ListContains(addresses, address), nil
+}
+
+// Import runs terraform import with the given options to import the existing resource with the given ID into the state
+// at the given address, and returns stdout/stderr.
+func Import(t testing.TB, options *Options, address string, id string) string {
+	out, err := ImportE(t, options, address, id)
+	require.NoError(t, err)
+	return out
+}
+
+// ImportE runs terraform import with the given options to import the existing resource with the given ID into the
+// state at the given address, and returns stdout/stderr. The state is locked during the import unless NoStateLock is
+// set in the options.
+func ImportE(t testing.TB, options *Options, address string, id string) (string, error) {
+	return RunTerraformCommandE(t, options, formatImportArgs(options, address, id)...)
+}
+
+// formatImportArgs returns the args for terraform import. terraform import accepts variables, but not -target, so
+// FormatArgs can't be used here.
+func formatImportArgs(options *Options, address string, id string) []string {
+	args := []string{"import", "-input=false"}
+	if options.NoStateLock {
+		args = append(args, "-lock=false")
+	}
+	args = append(args, formatVariableArgs(options)...)
+	return append(args, address, id)
+}
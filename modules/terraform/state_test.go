@@ -0,0 +1,65 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStateList(t *testing.T) {
+	t.Parallel()
+
+	out := "[terragrunt] 2019/03/01 12:00:00 Running command: terraform state list\nnull_resource.test\nmodule.vpc.aws_subnet.private[0]\n\n"
+	assert.Equal(t, []string{"null_resource.test", "module.vpc.aws_subnet.private[0]"}, parseStateList(out))
+	assert.Empty(t, parseStateList(""))
+}
+
+func TestFormatImportArgs(t *testing.T) {
+	t.Parallel()
+
+	options := &Options{Vars: map[string]interface{}{"foo": "bar"}}
+	assert.Equal(t, []string{"import", "-input=false", "-var", "foo=bar", "random_id.test", "p-9hUg"}, formatImportArgs(options, "random_id.test", "p-9hUg"))
+
+	options.NoStateLock = true
+	assert.Equal(t, []string{"import", "-input=false", "-lock=false", "-var", "foo=bar", "random_id.test", "p-9hUg"}, formatImportArgs(options, "random_id.test", "p-9hUg"))
+}
+
+func TestStateList(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-state", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir: testFolder,
+	}
+	defer Destroy(t, options)
+
+	InitAndApply(t, options)
+
+	assert.Equal(t, []string{"null_resource.test", "random_id.test"}, StateList(t, options))
+	assert.True(t, StateResourceExists(t, options, "random_id.test"))
+	assert.False(t, StateResourceExists(t, options, "random_id.missing"))
+	assert.Contains(t, ShowState(t, options), "random_id.test")
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-state", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir: testFolder,
+	}
+	defer Destroy(t, options)
+
+	Init(t, options)
+	Import(t, options, "random_id.test", "p-9hUg")
+
+	assert.True(t, StateResourceExists(t, options, "random_id.test"))
+	assert.False(t, StateResourceExists(t, options, "null_resource.test"))
+	assert.Contains(t, ShowState(t, options), "a7ef6152")
+}
@@ -1,6 +1,32 @@
 // Package terraform allows to interact with Terraform.
+//
+// Most tests configure an Options struct, run init and apply, check the outputs, and destroy everything at the end:
+//
+//	terraformOptions := &terraform.Options{
+//		TerraformDir:  "../examples/my-module",
+//		Vars:          map[string]interface{}{"name": "terratest"},
+//		BackendConfig: map[string]interface{}{"bucket": "my-state-bucket"},
+//		EnvVars:       map[string]string{"GOOGLE_PROJECT": projectID},
+//	}
+//	defer terraform.Destroy(t, terraformOptions)
+//
+//	terraform.InitAndApply(t, terraformOptions)
+//	address := terraform.Output(t, terraformOptions, "address")
+//
+// Every function also has a variant ending in E (e.g. InitAndApplyE) that returns an error instead of failing the
+// test.
 package terraform
 
+// TerraformDefaultPath is the name of the terraform binary, used when Options.TerraformBinary is not set (unless only
+// tofu is installed)
+const TerraformDefaultPath = "terraform"
+
+// TerragruntDefaultPath is the name of the terragrunt binary. Set Options.TerraformBinary to it to run terragrunt.
+const TerragruntDefaultPath = "terragrunt"
+
+// TofuDefaultPath is the name of the OpenTofu binary. Set Options.TerraformBinary to it to run OpenTofu.
+const TofuDefaultPath = "tofu"
+
 // https://www.terraform.io/docs/commands/plan.html#detailed-exitcode
 
 // TerraformPlanChangesPresentExitCode is the exit code returned by terraform plan detailed exitcode when changes are present
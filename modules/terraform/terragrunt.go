@@ -0,0 +1,179 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TgOutputAll runs terragrunt output in every module of the stack in options.TerraformDir and returns the outputs of
+// each module, keyed by the path of the module relative to options.TerraformDir (e.g. "vpc" or "services/app"). Run it
+// after TgApplyAll.
+func TgOutputAll(t testing.TB, options *Options) map[string]map[string]interface{} {
+	out, err := TgOutputAllE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// TgOutputAllE runs terragrunt output in every module of the stack in options.TerraformDir and returns the outputs of
+// each module, keyed by the path of the module relative to options.TerraformDir (e.g. "vpc" or "services/app"). Run it
+// after TgApplyAllE.
+func TgOutputAllE(t testing.TB, options *Options) (map[string]map[string]interface{}, error) {
+	if options.TerraformBinary != TerragruntDefaultPath {
+		return nil, TgInvalidBinary(options.TerraformBinary)
+	}
+
+	modules, err := findTerragruntModulesE(options.TerraformDir)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]map[string]interface{}{}
+	for _, module := range modules {
+		moduleOptions := *options
+		moduleOptions.TerraformDir = filepath.Join(options.TerraformDir, module)
+
+		out, err := RunTerraformCommandE(t, &moduleOptions, "output", "-no-color", "-json")
+		if err != nil {
+			return nil, err
+		}
+
+		moduleOutputs, err := parseTgOutputJson(out)
+		if err != nil {
+			return nil, err
+		}
+		outputs[module] = moduleOutputs
+	}
+
+	return outputs, nil
+}
+
+// parseTgOutputJson parses the output of terragrunt output -json into a map of output name to value. The output of
+// terragrunt includes its log lines (which go to stderr), so those are dropped first.
+func parseTgOutputJson(out string) (map[string]interface{}, error) {
+	jsonLines := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, "[terragrunt]") {
+			jsonLines = append(jsonLines, line)
+		}
+	}
+
+	rawOutputs := map[string]map[string]interface{}{}
+	if err := json.Unmarshal([]byte(strings.Join(jsonLines, "\n")), &rawOutputs); err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]interface{}{}
+	for key, rawOutput := range rawOutputs {
+		outputs[key] = rawOutput["value"]
+	}
+	return outputs, nil
+}
+
+// findTerragruntModulesE returns the paths, relative to the given folder, of the terragrunt modules in the folder: the
+// folders that have a terragrunt.hcl file, or a terraform.tfvars file with a terragrunt block. The terragrunt cache
+// and hidden folders are skipped. The given folder itself is only a module if it also has Terraform code: a terragrunt
+// config at the root of a stack is usually a parent config that the modules include, and terragrunt doesn't run it.
+func findTerragruntModulesE(rootDir string) ([]string, error) {
+	modules := []string{}
+
+	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != rootDir && (strings.HasPrefix(info.Name(), ".") || info.Name() == ".terragrunt-cache") {
+			return filepath.SkipDir
+		}
+
+		isModule, err := isTerragruntModuleE(path)
+		if err != nil {
+			return err
+		}
+		if isModule && path == rootDir {
+			isModule, err = isTerragruntRootModuleE(path)
+			if err != nil {
+				return err
+			}
+		}
+		if isModule {
+			relativePath, err := filepath.Rel(rootDir, path)
+			if err != nil {
+				return err
+			}
+			modules = append(modules, filepath.ToSlash(relativePath))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(modules)
+	return modules, nil
+}
+
+// terragruntBlockRegexp matches the terragrunt block of a terraform.tfvars file, but not variables whose names start with
+// terragrunt (e.g. terragrunt_version).
+var terragruntBlockRegexp = regexp.MustCompile(`(?m)^\s*terragrunt\s*=\s*\{`)
+
+// terraformSourceRegexp matches a terraform block with a source, in both the terragrunt.hcl and the terraform.tfvars
+// syntax.
+var terraformSourceRegexp = regexp.MustCompile(`terraform\s*=?\s*\{[^}]*\bsource\s*=`)
+
+func isTerragruntModuleE(dir string) (bool, error) {
+	_, found, err := readTerragruntConfigE(dir)
+	return found, err
+}
+
+// isTerragruntRootModuleE returns true if the terragrunt config in the given folder is a module in its own right, rather
+// than a parent config: the folder has .tf files, or the config has a terraform block with a source.
+func isTerragruntRootModuleE(dir string) (bool, error) {
+	tfFiles, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return false, err
+	}
+	if len(tfFiles) > 0 {
+		return true, nil
+	}
+
+	config, _, err := readTerragruntConfigE(dir)
+	if err != nil {
+		return false, err
+	}
+	return terraformSourceRegexp.MatchString(config), nil
+}
+
+// readTerragruntConfigE returns the contents of the terragrunt config in the given folder: the terragrunt.hcl file, or
+// the terraform.tfvars file if it has a terragrunt block. The boolean is false if the folder has no terragrunt config.
+func readTerragruntConfigE(dir string) (string, bool, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "terragrunt.hcl"))
+	if err == nil {
+		return string(contents), true, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", false, err
+	}
+
+	contents, err = ioutil.ReadFile(filepath.Join(dir, "terraform.tfvars"))
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if !terragruntBlockRegexp.Match(contents) {
+		return "", false, nil
+	}
+	return string(contents), true, nil
+}
@@ -0,0 +1,82 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTerragruntModules(t *testing.T) {
+	t.Parallel()
+
+	modules, err := findTerragruntModulesE("../../test/fixtures/terragrunt/terragrunt-multi-plan")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar", "foo"}, modules)
+
+	modules, err = findTerragruntModulesE("../../test/fixtures/terraform-no-error")
+	require.NoError(t, err)
+	assert.Empty(t, modules)
+}
+
+func TestFindTerragruntModulesSkipsParentConfig(t *testing.T) {
+	t.Parallel()
+
+	// The root holds a parent config with no source, and shared/terraform.tfvars only has a terragrunt_version variable
+	modules, err := findTerragruntModulesE("../../test/fixtures/terragrunt/terragrunt-multi-plan-with-parent")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar", "foo"}, modules)
+}
+
+func TestFindTerragruntModulesKeepsRootModule(t *testing.T) {
+	t.Parallel()
+
+	modules, err := findTerragruntModulesE("../../test/fixtures/terragrunt/terragrunt-multi-plan/foo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"."}, modules)
+}
+
+func TestParseTgOutputJson(t *testing.T) {
+	t.Parallel()
+
+	out := `[terragrunt] 2019/03/01 12:00:00 Running command: terraform output -json
+{
+    "test": {
+        "sensitive": false,
+        "type": "string",
+        "value": "foo"
+    }
+}`
+
+	outputs, err := parseTgOutputJson(out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"test": "foo"}, outputs)
+}
+
+func TestTgOutputAll(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerragruntFolderToTemp("../../test/fixtures/terragrunt/terragrunt-multi-plan", t.Name())
+	require.NoError(t, err)
+
+	options := &Options{
+		TerraformDir:    testFolder,
+		TerraformBinary: TerragruntDefaultPath,
+	}
+	defer TgDestroyAll(t, options)
+
+	TgApplyAll(t, options)
+	outputs := TgOutputAll(t, options)
+
+	assert.Equal(t, "foo", outputs["foo"]["test"])
+	assert.Equal(t, "foo", outputs["bar"]["test"])
+}
+
+func TestTgOutputAllRequiresTerragrunt(t *testing.T) {
+	t.Parallel()
+
+	_, err := TgOutputAllE(t, &Options{TerraformDir: "."})
+	require.Error(t, err)
+	assert.IsType(t, TgInvalidBinary(""), err)
+}
@@ -0,0 +1,156 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// Validate runs terraform validate with the given options and returns stdout/stderr. Run Init first, or use
+// InitAndValidate.
+func Validate(t testing.TB, options *Options) string {
+	out, err := ValidateE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// ValidateE runs terraform validate with the given options and returns stdout/stderr. Run InitE first, or use
+// InitAndValidateE.
+func ValidateE(t testing.TB, options *Options) (string, error) {
+	return RunTerraformCommandE(t, options, "validate")
+}
+
+// InitAndValidate runs terraform init without configuring the backend, and then terraform validate, so that structural
+// errors in the code fail the test before the expensive apply stage.
+func InitAndValidate(t testing.TB, options *Options) string {
+	out, err := InitAndValidateE(t, options)
+	require.NoError(t, err)
+	return out
+}
+
+// InitAndValidateE runs terraform init without configuring the backend, and then terraform validate, so that
+// structural errors in the code fail the test before the expensive apply stage.
+func InitAndValidateE(t testing.TB, options *Options) (string, error) {
+	if _, err := RunTerraformCommandE(t, options, "init", "-backend=false"); err != nil {
+		return "", err
+	}
+	return ValidateE(t, options)
+}
+
+// ValidateAllModules runs InitAndValidate in every folder under rootDir that contains Terraform code, such as a
+// module and all of its examples, and fails the test if any of them is invalid. Note that init creates a .terraform
+// folder in each of them.
+func ValidateAllModules(t testing.TB, rootDir string) {
+	require.NoError(t, ValidateAllModulesE(t, rootDir))
+}
+
+// ValidateAllModulesE runs InitAndValidateE in every folder under rootDir that contains Terraform code, such as a
+// module and all of its examples, and returns a ModuleValidationErrors error if any of them is invalid. Note that init
+// creates a .terraform folder in each of them.
+func ValidateAllModulesE(t testing.TB, rootDir string) error {
+	moduleDirs, err := findTerraformDirsE(rootDir)
+	if err != nil {
+		return err
+	}
+
+	errs := ModuleValidationErrors{}
+	for _, moduleDir := range moduleDirs {
+		if _, err := InitAndValidateE(t, &Options{TerraformDir: moduleDir, NoColor: true}); err != nil {
+			errs[moduleDir] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// AssertTerraformFmt fails the test if any of the Terraform files in the given folder, or any folder under it, is not
+// formatted the way terraform fmt would format it.
+func AssertTerraformFmt(t testing.TB, dir string) {
+	require.NoError(t, AssertTerraformFmtE(t, dir))
+}
+
+// AssertTerraformFmtE returns a TerraformNotFormatted error listing the Terraform files in the given folder, or any
+// folder under it, that are not formatted the way terraform fmt would format them.
+func AssertTerraformFmtE(t testing.TB, dir string) error {
+	terraformDirs, err := findTerraformDirsE(dir)
+	if err != nil {
+		return err
+	}
+
+	unformatted := map[string]bool{}
+	for _, terraformDir := range terraformDirs {
+		// Older versions of terraform fmt look in sub folders too, and newer versions don't, so the same file may be
+		// listed more than once
+		out, err := RunTerraformCommandE(t, &Options{TerraformDir: terraformDir}, "fmt", "-list=true", "-write=false")
+		if err != nil {
+			return err
+		}
+		for _, file := range parseFmtOutput(out) {
+			unformatted[filepath.Join(terraformDir, file)] = true
+		}
+	}
+
+	if len(unformatted) == 0 {
+		logger.Logf(t, "All Terraform files in %s are formatted", dir)
+		return nil
+	}
+
+	files := []string{}
+	for file := range unformatted {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return TerraformNotFormatted(files)
+}
+
+// parseFmtOutput returns the file names in the output of terraform fmt -list=true, which has one per line
+func parseFmtOutput(out string) []string {
+	files := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasSuffix(line, ".tf") || strings.HasSuffix(line, ".tfvars") {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// findTerraformDirsE returns the given folder and the folders under it that contain .tf files, sorted. The .terraform
+// folders, where terraform init downloads modules, and hidden folders are skipped.
+func findTerraformDirsE(rootDir string) ([]string, error) {
+	dirs := map[string]bool{}
+
+	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != rootDir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(info.Name(), ".tf") {
+			dirs[filepath.Dir(path)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortedDirs := []string{}
+	for dir := range dirs {
+		sortedDirs = append(sortedDirs, dir)
+	}
+	sort.Strings(sortedDirs)
+	return sortedDirs, nil
+}
@@ -0,0 +1,65 @@
+package terraform
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFmtOutput(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"main.tf", "nested/main.tf", "terraform.tfvars"}, parseFmtOutput("main.tf\nnested/main.tf\nterraform.tfvars\n"))
+	assert.Empty(t, parseFmtOutput(""))
+}
+
+func TestFindTerraformDirs(t *testing.T) {
+	t.Parallel()
+
+	dirs, err := findTerraformDirsE("../../test/fixtures/terraform-not-formatted")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"../../test/fixtures/terraform-not-formatted", "../../test/fixtures/terraform-not-formatted/nested"}, dirs)
+}
+
+func TestInitAndValidate(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-state", t.Name())
+	require.NoError(t, err)
+
+	InitAndValidate(t, &Options{TerraformDir: testFolder})
+}
+
+func TestInitAndValidateError(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-with-plan-error", t.Name())
+	require.NoError(t, err)
+
+	_, err = InitAndValidateE(t, &Options{TerraformDir: testFolder})
+	require.Error(t, err)
+
+	err = ValidateAllModulesE(t, testFolder)
+	require.Error(t, err)
+	assert.IsType(t, ModuleValidationErrors{}, err)
+}
+
+func TestAssertTerraformFmt(t *testing.T) {
+	t.Parallel()
+
+	AssertTerraformFmt(t, "../../test/fixtures/terraform-state")
+}
+
+func TestAssertTerraformFmtNotFormatted(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-not-formatted", t.Name())
+	require.NoError(t, err)
+
+	err = AssertTerraformFmtE(t, testFolder)
+	require.Error(t, err)
+	assert.Equal(t, TerraformNotFormatted{filepath.Join(testFolder, "main.tf"), filepath.Join(testFolder, "nested", "main.tf")}, err)
+}
@@ -0,0 +1,160 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// WriteVarFile writes the given variables to a var file at the given path, which can then be passed to Terraform in
+// Options.VarFiles. If the path ends in .json, the file is written as JSON; otherwise, it is written as HCL. Strings
+// are escaped, and nested maps and lists are supported.
+func WriteVarFile(t testing.TB, path string, vars map[string]interface{}) {
+	require.NoError(t, WriteVarFileE(t, path, vars))
+}
+
+// WriteVarFileE writes the given variables to a var file at the given path, which can then be passed to Terraform in
+// Options.VarFiles. If the path ends in .json, the file is written as JSON; otherwise, it is written as HCL. Strings
+// are escaped, and nested maps and lists are supported.
+func WriteVarFileE(t testing.TB, path string, vars map[string]interface{}) error {
+	logger.Logf(t, "Writing %d variables to var file %s", len(vars), path)
+
+	var contents []byte
+	var err error
+	if strings.HasSuffix(path, ".json") {
+		contents, err = json.MarshalIndent(vars, "", "  ")
+	} else {
+		var hcl string
+		hcl, err = formatVarFileHclE(vars)
+		contents = []byte(hcl)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, contents, 0644)
+}
+
+// formatVarFileHclE formats the given variables as the contents of an HCL var file, with one variable per line, sorted
+// by name.
+func formatVarFileHclE(vars map[string]interface{}) (string, error) {
+	names := []string{}
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	for _, name := range names {
+		value, err := toVarFileHclE(vars[name], "")
+		if err != nil {
+			return "", UnsupportedVarFileValue{Name: name, Err: err}
+		}
+		fmt.Fprintf(&builder, "%s = %s\n", name, value)
+	}
+	return builder.String(), nil
+}
+
+// toVarFileHclE formats the given value as HCL. Unlike toHclString, which formats values for -var arguments, this
+// quotes and escapes every string and map key, sorts map keys so the output is stable, and formats nested values over
+// multiple lines, indented by the given prefix.
+func toVarFileHclE(value interface{}, indent string) (string, error) {
+	if value == nil {
+		return "", fmt.Errorf("null values can't be written to a var file")
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.String:
+		return quoteHclString(reflectValue.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(reflectValue.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflectValue.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflectValue.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(reflectValue.Float(), 'f', -1, 64), nil
+	case reflect.Slice, reflect.Array:
+		if reflectValue.Len() == 0 {
+			return "[]", nil
+		}
+		items := []string{}
+		for i := 0; i < reflectValue.Len(); i++ {
+			item, err := toVarFileHclE(reflectValue.Index(i).Interface(), indent+"  ")
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s  %s,\n", indent, item))
+		}
+		return fmt.Sprintf("[\n%s%s]", strings.Join(items, ""), indent), nil
+	case reflect.Map:
+		m, isMap := tryToConvertToGenericMap(value)
+		if !isMap {
+			return "", fmt.Errorf("map keys must be strings, got %s", reflectValue.Type().Key())
+		}
+		if len(m) == 0 {
+			return "{}", nil
+		}
+		keys := []string{}
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := []string{}
+		for _, key := range keys {
+			item, err := toVarFileHclE(m[key], indent+"  ")
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s  %s = %s\n", indent, quoteHclString(key), item))
+		}
+		return fmt.Sprintf("{\n%s%s}", strings.Join(items, ""), indent), nil
+	default:
+		return "", fmt.Errorf("values of type %T can't be written to a var file", value)
+	}
+}
+
+// quoteHclString quotes the given string, escaping backslashes, quotes, and control characters, as well as the "${" and
+// "%{" sequences that would otherwise start a template interpolation or directive.
+func quoteHclString(value string) string {
+	var builder strings.Builder
+	builder.WriteString(`"`)
+	chars := []rune(value)
+	for i, char := range chars {
+		switch char {
+		case '\\':
+			builder.WriteString(`\\`)
+		case '"':
+			builder.WriteString(`\"`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '$', '%':
+			if i+1 < len(chars) && chars[i+1] == '{' {
+				builder.WriteRune(char)
+			}
+			builder.WriteRune(char)
+		default:
+			builder.WriteRune(char)
+		}
+	}
+	builder.WriteString(`"`)
+	return builder.String()
+}
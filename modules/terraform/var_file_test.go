@@ -0,0 +1,106 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatVarFileHcl(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		vars     map[string]interface{}
+		expected string
+	}{
+		{"string", map[string]interface{}{"foo": "bar"}, "foo = \"bar\"\n"},
+		{"escaped string", map[string]interface{}{"foo": "say \"hi\"\\\n"}, "foo = \"say \\\"hi\\\"\\\\\\n\"\n"},
+		{"escaped interpolation", map[string]interface{}{"foo": "${var.bar} costs $5"}, "foo = \"$${var.bar} costs $5\"\n"},
+		{"escaped directive", map[string]interface{}{"foo": "%{ if true }100%"}, "foo = \"%%{ if true }100%\"\n"},
+		{"primitives", map[string]interface{}{"a": 1, "b": 2.5, "c": true}, "a = 1\nb = 2.5\nc = true\n"},
+		{"empty list and map", map[string]interface{}{"a": []string{}, "b": map[string]string{}}, "a = []\nb = {}\n"},
+		{"list", map[string]interface{}{"foo": []string{"a", "b"}}, "foo = [\n  \"a\",\n  \"b\",\n]\n"},
+		{"map", map[string]interface{}{"foo": map[string]int{"b": 2, "a key": 1}}, "foo = {\n  \"a key\" = 1\n  \"b\" = 2\n}\n"},
+		{
+			"nested",
+			map[string]interface{}{"foo": map[string]interface{}{"list": []interface{}{"a", map[string]string{"b": "c"}}}},
+			"foo = {\n  \"list\" = [\n    \"a\",\n    {\n      \"b\" = \"c\"\n    },\n  ]\n}\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := formatVarFileHclE(testCase.vars)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestFormatVarFileHclUnsupportedValues(t *testing.T) {
+	t.Parallel()
+
+	_, err := formatVarFileHclE(map[string]interface{}{"foo": nil})
+	assert.IsType(t, UnsupportedVarFileValue{}, err)
+
+	_, err = formatVarFileHclE(map[string]interface{}{"foo": map[int]string{1: "a"}})
+	assert.IsType(t, UnsupportedVarFileValue{}, err)
+
+	_, err = formatVarFileHclE(map[string]interface{}{"foo": struct{}{}})
+	assert.IsType(t, UnsupportedVarFileValue{}, err)
+}
+
+func TestWriteVarFileJson(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, err := ioutil.TempDir("", "var-file")
+	require.NoError(t, err)
+
+	path := filepath.Join(tmpDir, "nested", "terraform.tfvars.json")
+	vars := map[string]interface{}{"foo": "bar", "names": []string{"a", "b"}}
+	WriteVarFile(t, path, vars)
+
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	actual := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(contents, &actual))
+	assert.Equal(t, map[string]interface{}{"foo": "bar", "names": []interface{}{"a", "b"}}, actual)
+}
+
+func TestWriteVarFile(t *testing.T) {
+	t.Parallel()
+
+	testFolder, err := files.CopyTerraformFolderToTemp("../../test/fixtures/terraform-var-file", t.Name())
+	require.NoError(t, err)
+
+	varFile := filepath.Join(testFolder, "test.tfvars")
+	WriteVarFile(t, varFile, map[string]interface{}{
+		"message": "A \"quoted\" message with a \\ backslash",
+		"names":   []string{"foo", "bar"},
+		"tags":    map[string]string{"Name": "terratest", "Owner Team": "infra"},
+	})
+
+	options := &Options{
+		TerraformDir: testFolder,
+		VarFiles:     []string{varFile},
+	}
+	defer Destroy(t, options)
+
+	InitAndApply(t, options)
+
+	assert.Equal(t, "A \"quoted\" message with a \\ backslash", Output(t, options, "message"))
+	assert.Equal(t, []string{"foo", "bar"}, OutputList(t, options, "names"))
+	assert.Equal(t, map[string]string{"Name": "terratest", "Owner Team": "infra"}, OutputMap(t, options, "tags"))
+}
@@ -0,0 +1,229 @@
+package terraform
+
+import (
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The names terraform version and tofu version use for themselves at the start of their output
+const (
+	terraformVersionName = "Terraform"
+	tofuVersionName      = "OpenTofu"
+)
+
+var versionOutputRegexp = regexp.MustCompile(`(?m)^(Terraform|OpenTofu) v(\S+)`)
+
+// defaultTerraformBinary returns the binary to run when Options.TerraformBinary is not set: terraform, or tofu if
+// terraform is not installed but tofu is. This lets the same test suite run on machines that have moved to OpenTofu.
+func defaultTerraformBinary() string {
+	if _, err := exec.LookPath(TerraformDefaultPath); err != nil {
+		if _, err := exec.LookPath(TofuDefaultPath); err == nil {
+			return TofuDefaultPath
+		}
+	}
+	return TerraformDefaultPath
+}
+
+// Version runs terraform version (or tofu version) and returns the version number, such as 0.11.13 or 1.6.0.
+func Version(t testing.TB, options *Options) string {
+	version, err := VersionE(t, options)
+	require.NoError(t, err)
+	return version
+}
+
+// VersionE runs terraform version (or tofu version) and returns the version number, such as 0.11.13 or 1.6.0.
+func VersionE(t testing.TB, options *Options) (string, error) {
+	_, version, err := runVersionE(t, options)
+	return version, err
+}
+
+// IsOpenTofu returns true if the binary configured in the given options is OpenTofu rather than Terraform. This also
+// works when TerraformBinary is terragrunt, as terragrunt passes the version command on to the binary it wraps.
+func IsOpenTofu(t testing.TB, options *Options) bool {
+	isTofu, err := IsOpenTofuE(t, options)
+	require.NoError(t, err)
+	return isTofu
+}
+
+// IsOpenTofuE returns true if the binary configured in the given options is OpenTofu rather than Terraform. This also
+// works when TerraformBinary is terragrunt, as terragrunt passes the version command on to the binary it wraps.
+func IsOpenTofuE(t testing.TB, options *Options) (bool, error) {
+	name, _, err := runVersionE(t, options)
+	return name == tofuVersionName, err
+}
+
+// CheckVersion fails the test if the version of terraform (or tofu) does not satisfy the given constraint, which uses
+// the same syntax as required_version in Terraform code (e.g. ">= 0.11.0, < 0.12.0" or "~> 1.6").
+func CheckVersion(t testing.TB, options *Options, constraint string) {
+	require.NoError(t, CheckVersionE(t, options, constraint))
+}
+
+// CheckVersionE returns a VersionConstraintNotMet error if the version of terraform (or tofu) does not satisfy the
+// given constraint, which uses the same syntax as required_version in Terraform code (e.g. ">= 0.11.0, < 0.12.0" or
+// "~> 1.6").
+func CheckVersionE(t testing.TB, options *Options, constraint string) error {
+	name, version, err := runVersionE(t, options)
+	if err != nil {
+		return err
+	}
+
+	meetsConstraint, err := versionMeetsConstraintE(version, constraint)
+	if err != nil {
+		return err
+	}
+	if !meetsConstraint {
+		return VersionConstraintNotMet{Binary: name, Version: version, Constraint: constraint}
+	}
+	return nil
+}
+
+func runVersionE(t testing.TB, options *Options) (string, string, error) {
+	out, err := RunTerraformCommandE(t, options, "version")
+	if err != nil {
+		return "", "", err
+	}
+	return parseVersionOutputE(out)
+}
+
+// parseVersionOutputE returns the name (Terraform or OpenTofu) and the version number from the output of the version
+// command, which looks like "Terraform v0.11.13" or "OpenTofu v1.6.0", followed by the provider versions.
+func parseVersionOutputE(out string) (string, string, error) {
+	match := versionOutputRegexp.FindStringSubmatch(out)
+	if match == nil {
+		return "", "", InvalidVersion(strings.TrimSpace(out))
+	}
+	return match[1], match[2], nil
+}
+
+// versionMeetsConstraintE returns true if the given version satisfies all the comma separated conditions in the given
+// constraint. Each condition is a version optionally preceded by one of =, !=, >, >=, <, <=, or ~>.
+func versionMeetsConstraintE(version string, constraint string) (bool, error) {
+	parsedVersion, err := parseVersionE(version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, condition := range strings.Split(constraint, ",") {
+		condition = strings.TrimSpace(condition)
+
+		operator := "="
+		for _, candidate := range []string{"~>", ">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(condition, candidate) {
+				operator = candidate
+				condition = strings.TrimSpace(strings.TrimPrefix(condition, candidate))
+				break
+			}
+		}
+
+		conditionVersion, err := parseVersionE(condition)
+		if err != nil {
+			return false, InvalidVersionConstraint(constraint)
+		}
+
+		comparison := parsedVersion.compare(conditionVersion)
+		var met bool
+		switch operator {
+		case "=":
+			met = comparison == 0
+		case "!=":
+			met = comparison != 0
+		case ">":
+			met = comparison > 0
+		case ">=":
+			met = comparison >= 0
+		case "<":
+			met = comparison < 0
+		case "<=":
+			met = comparison <= 0
+		case "~>":
+			met = comparison >= 0 && parsedVersion.compare(conditionVersion.pessimisticUpperBound()) < 0
+		}
+
+		if !met {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// semanticVersion is a version number like 1.6.0 or 0.12.0-beta1
+type semanticVersion struct {
+	segments   []int
+	prerelease string
+}
+
+func parseVersionE(version string) (semanticVersion, error) {
+	original := version
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+
+	parsed := semanticVersion{}
+	if dash := strings.Index(version, "-"); dash >= 0 {
+		parsed.prerelease = version[dash+1:]
+		version = version[:dash]
+	}
+
+	for _, segment := range strings.Split(version, ".") {
+		number, err := strconv.Atoi(segment)
+		if err != nil || number < 0 {
+			return semanticVersion{}, InvalidVersion(original)
+		}
+		parsed.segments = append(parsed.segments, number)
+	}
+	if len(parsed.segments) > 3 {
+		return semanticVersion{}, InvalidVersion(original)
+	}
+
+	return parsed, nil
+}
+
+// compare returns -1, 0, or 1 if this version is lower than, equal to, or higher than the other version. Missing
+// segments count as 0, and a prerelease is lower than the release itself.
+func (version semanticVersion) compare(other semanticVersion) int {
+	for i := 0; i < 3; i++ {
+		a, b := version.segment(i), other.segment(i)
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+	}
+
+	switch {
+	case version.prerelease == other.prerelease:
+		return 0
+	case version.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	case version.prerelease < other.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func (version semanticVersion) segment(i int) int {
+	if i < len(version.segments) {
+		return version.segments[i]
+	}
+	return 0
+}
+
+// pessimisticUpperBound returns the first version ~> this version no longer allows: ~> 1.6 allows anything below 2.0,
+// and ~> 1.6.2 allows anything below 1.7.0.
+func (version semanticVersion) pessimisticUpperBound() semanticVersion {
+	if len(version.segments) <= 1 {
+		return semanticVersion{segments: []int{version.segment(0) + 1}}
+	}
+
+	segments := append([]int{}, version.segments[:len(version.segments)-1]...)
+	segments[len(segments)-1]++
+	return semanticVersion{segments: segments}
+}
@@ -0,0 +1,101 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionOutput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		output          string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"Terraform v0.11.13\n+ provider.null v2.1.0\n", "Terraform", "0.11.13"},
+		{"Terraform v0.12.0-beta1\n", "Terraform", "0.12.0-beta1"},
+		{"OpenTofu v1.6.0\non linux_amd64\n", "OpenTofu", "1.6.0"},
+		{"[terragrunt] 2019/03/01 12:00:00 Running command: terraform version\nTerraform v0.11.13\n", "Terraform", "0.11.13"},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.expectedName+testCase.expectedVersion, func(t *testing.T) {
+			t.Parallel()
+			name, version, err := parseVersionOutputE(testCase.output)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedName, name)
+			assert.Equal(t, testCase.expectedVersion, version)
+		})
+	}
+
+	_, _, err := parseVersionOutputE("command not found")
+	assert.IsType(t, InvalidVersion(""), err)
+}
+
+func TestVersionMeetsConstraint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		version    string
+		constraint string
+		expected   bool
+	}{
+		{"0.11.13", ">= 0.11.0", true},
+		{"0.11.13", ">= 0.11.0, < 0.12.0", true},
+		{"0.12.0", ">= 0.11.0, < 0.12.0", false},
+		{"0.12.0-beta1", "< 0.12.0", true},
+		{"0.11.13", "0.11.13", true},
+		{"0.11.13", "= 0.11.14", false},
+		{"0.11.13", "!= 0.11.14", true},
+		{"1.6.0", "~> 1.6", true},
+		{"1.9.3", "~> 1.6", true},
+		{"2.0.0", "~> 1.6", false},
+		{"1.6.5", "~> 1.6.2", true},
+		{"1.7.0", "~> 1.6.2", false},
+		{"1.6.1", "~> 1.6.2", false},
+		{"1.6.0", "> 1.5", true},
+		{"1.5.0", "<= 1.5", true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.version+" "+testCase.constraint, func(t *testing.T) {
+			t.Parallel()
+			meetsConstraint, err := versionMeetsConstraintE(testCase.version, testCase.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, meetsConstraint)
+		})
+	}
+}
+
+func TestVersionMeetsConstraintInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := versionMeetsConstraintE("0.11.13", ">= banana")
+	assert.IsType(t, InvalidVersionConstraint(""), err)
+
+	_, err = versionMeetsConstraintE("banana", ">= 0.11.0")
+	assert.IsType(t, InvalidVersion(""), err)
+}
+
+func TestCheckVersion(t *testing.T) {
+	t.Parallel()
+
+	options := &Options{TerraformDir: "../../test/fixtures/terraform-no-error"}
+
+	CheckVersion(t, options, ">= 0.11.0")
+
+	err := CheckVersionE(t, options, "< 0.1.0")
+	require.Error(t, err)
+	assert.IsType(t, VersionConstraintNotMet{}, err)
+}
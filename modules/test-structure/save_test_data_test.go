@@ -240,3 +240,45 @@ func TestSaveAndLoadKubectlOptions(t *testing.T) {
 	actualData := LoadKubectlOptions(t, tmpFolder)
 	assert.Equal(t, expectedData, actualData)
 }
+
+func TestSaveAndLoadNamedBools(t *testing.T) {
+	t.Parallel()
+
+	tmpFolder, err := ioutil.TempDir("", "save-and-load-named-bools")
+	require.NoError(t, err)
+
+	SaveBool(t, tmpFolder, "test-bool1", true)
+	SaveBool(t, tmpFolder, "test-bool2", false)
+
+	assert.True(t, LoadBool(t, tmpFolder, "test-bool1"))
+	assert.False(t, LoadBool(t, tmpFolder, "test-bool2"))
+}
+
+func TestSaveAndLoadNamedValues(t *testing.T) {
+	t.Parallel()
+
+	tmpFolder, err := ioutil.TempDir("", "save-and-load-named-values")
+	require.NoError(t, err)
+
+	assert.False(t, IsValuePresent(t, tmpFolder, "test-data"))
+
+	expectedData := testData{
+		Foo: "foo",
+		Bar: true,
+		Baz: map[string]interface{}{"abc": "def", "ghi": 1.0, "klm": false},
+	}
+	expectedSubnets := []string{"subnet-abcd1234", "subnet-xyz98765"}
+
+	SaveValue(t, tmpFolder, "test-data", expectedData)
+	SaveValue(t, tmpFolder, "test-subnets", expectedSubnets)
+
+	assert.True(t, IsValuePresent(t, tmpFolder, "test-data"))
+
+	actualData := testData{}
+	LoadValue(t, tmpFolder, "test-data", &actualData)
+	assert.Equal(t, expectedData, actualData)
+
+	actualSubnets := []string{}
+	LoadValue(t, tmpFolder, "test-subnets", &actualSubnets)
+	assert.Equal(t, expectedSubnets, actualSubnets)
+}
@@ -0,0 +1,356 @@
+package test_structure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// SuiteFixture is an expensive piece of infrastructure (e.g. a GKE cluster) that is provisioned once for all the tests
+// in a package, instead of once per test. Pass it to RunWithSuiteFixtures in TestMain.
+type SuiteFixture struct {
+	// Name of the fixture. It must be unique within the package, and is used to save and load the fixture's handle.
+	Name string
+
+	// Folder in which to save the fixture's handle, usually the folder with the Terraform code of the fixture. Tests
+	// load the handle from the same folder with LoadSuiteFixture.
+	Folder string
+
+	// Setup provisions the fixture and returns its handle: whatever the tests need to use the fixture, such as the
+	// terraform.Options or the kubectl options of the cluster. The handle must be serializable to JSON.
+	Setup func(t testing.TB) interface{}
+
+	// Teardown destroys the fixture. Use LoadSuiteFixture to get the handle returned by Setup. Teardown also runs when
+	// Setup fails, so it should clean up partially provisioned fixtures.
+	Teardown func(t testing.TB)
+}
+
+// RunWithSuiteFixtures sets up the given fixtures, runs the tests in the package, tears down the fixtures in reverse
+// order, and returns the exit code to pass to os.Exit. Call it from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(test_structure.RunWithSuiteFixtures(m, test_structure.SuiteFixture{
+//			Name:     "gke-cluster",
+//			Folder:   "../examples/gke-cluster",
+//			Setup:    deployCluster,
+//			Teardown: destroyCluster,
+//		}))
+//	}
+//
+// Tests then get the handle of a fixture with LoadSuiteFixture. If setup of any fixture fails, no tests are run. Setup
+// and teardown of each fixture are test stages named setup_<name> and teardown_<name>, so you can set e.g.
+// SKIP_teardown_gke-cluster to keep the fixture around, and then SKIP_setup_gke-cluster to reuse it on the next run.
+//
+// The testing.TB passed to Setup and Teardown supports Cleanup (and so TempDir, Setenv, and Chdir), but the cleanup
+// functions run after all fixtures are torn down, not when Setup or Teardown returns, so e.g. a temp dir created in Setup
+// stays around while the tests run.
+func RunWithSuiteFixtures(m *testing.M, fixtures ...SuiteFixture) int {
+	return runWithSuiteFixtures(m.Run, fixtures)
+}
+
+func runWithSuiteFixtures(runTests func() int, fixtures []SuiteFixture) int {
+	suite := newSuiteRun()
+	exitCode := 0
+
+	setUp := []SuiteFixture{}
+	for _, fixture := range fixtures {
+		// Add the fixture before running setup, so that a partially provisioned fixture is torn down too
+		setUp = append(setUp, fixture)
+		if !setupSuiteFixture(suite, fixture) {
+			exitCode = 1
+			break
+		}
+	}
+
+	if exitCode == 0 {
+		exitCode = runTests()
+	}
+
+	for i := len(setUp) - 1; i >= 0; i-- {
+		if !teardownSuiteFixture(suite, setUp[i]) && exitCode == 0 {
+			exitCode = 1
+		}
+	}
+
+	if !suite.runCleanups() && exitCode == 0 {
+		exitCode = 1
+	}
+
+	return exitCode
+}
+
+func setupSuiteFixture(suite *suiteRun, fixture SuiteFixture) bool {
+	stageName := fmt.Sprintf("setup_%s", fixture.Name)
+	return runAsSuiteTest(suite, stageName, func(t testing.TB) {
+		RunTestStage(t, stageName, func() {
+			handle := fixture.Setup(t)
+			SaveTestData(t, formatSuiteFixturePath(fixture.Folder, fixture.Name), handle)
+		})
+	})
+}
+
+func teardownSuiteFixture(suite *suiteRun, fixture SuiteFixture) bool {
+	stageName := fmt.Sprintf("teardown_%s", fixture.Name)
+	return runAsSuiteTest(suite, stageName, func(t testing.TB) {
+		RunTestStage(t, stageName, func() {
+			fixture.Teardown(t)
+			CleanupTestData(t, formatSuiteFixturePath(fixture.Folder, fixture.Name))
+		})
+	})
+}
+
+// LoadSuiteFixture loads the handle of the suite fixture with the given name from the given folder into the value
+// pointed to by handle. The fixture must have been set up by RunWithSuiteFixtures.
+func LoadSuiteFixture(t testing.TB, folder string, name string, handle interface{}) {
+	path := formatSuiteFixturePath(folder, name)
+	if !IsTestDataPresent(t, path) {
+		t.Fatalf("Suite fixture %s has not been set up. Call RunWithSuiteFixtures from TestMain to set it up.", name)
+	}
+	LoadTestData(t, path, handle)
+}
+
+// formatSuiteFixturePath formats a path to save the handle of a suite fixture in the given folder.
+func formatSuiteFixturePath(folder string, name string) string {
+	return FormatTestDataPath(folder, fmt.Sprintf("SuiteFixture-%s.json", name))
+}
+
+// runAsSuiteTest runs the given function with a suiteT, which stands in for a testing.T outside of a test, and returns
+// true if the function did not fail. Cleanup functions the function registers are added to the given suite.
+func runAsSuiteTest(suite *suiteRun, name string, fn func(t testing.TB)) bool {
+	t := &suiteT{name: fmt.Sprintf("TestMain/%s", name), suite: suite}
+
+	runInGoroutine(func() { fn(t) })
+
+	if t.Failed() {
+		logger.Logf(t, "FAILED")
+		return false
+	}
+	return true
+}
+
+// runInGoroutine runs the given function in its own goroutine and waits for it to return, or to stop because it
+// called FailNow or SkipNow on a suiteT.
+func runInGoroutine(fn func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	<-done
+}
+
+// suiteRun is the state shared by the suiteTs of one RunWithSuiteFixtures call: the context they return, which is
+// canceled when the suite ends, and the functions registered with Cleanup, which run after that.
+type suiteRun struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mutex    sync.Mutex
+	cleanups []suiteCleanup
+}
+
+// suiteCleanup is a function registered with Cleanup, along with the suiteT it was registered on.
+type suiteCleanup struct {
+	t  *suiteT
+	fn func()
+}
+
+func newSuiteRun() *suiteRun {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &suiteRun{ctx: ctx, cancel: cancel}
+}
+
+// runCleanups cancels the context of the suite and then runs the registered cleanup functions, last registered first,
+// like the testing package does. It returns true if none of them failed.
+func (suite *suiteRun) runCleanups() bool {
+	suite.cancel()
+
+	succeeded := true
+	for {
+		// Pop one function at a time, as a cleanup function may register more of them
+		suite.mutex.Lock()
+		if len(suite.cleanups) == 0 {
+			suite.mutex.Unlock()
+			return succeeded
+		}
+		cleanup := suite.cleanups[len(suite.cleanups)-1]
+		suite.cleanups = suite.cleanups[:len(suite.cleanups)-1]
+		suite.mutex.Unlock()
+
+		runInGoroutine(cleanup.fn)
+		if cleanup.t.Failed() {
+			succeeded = false
+		}
+	}
+}
+
+// suiteT implements testing.TB for code that runs in TestMain, where there is no testing.T. Like a testing.T,
+// FailNow (and so Fatal) stops the goroutine, which is why runAsSuiteTest runs the function in its own goroutine.
+type suiteT struct {
+	// testing.TB has an unexported method so that it can't be implemented outside of the testing package. Embedding
+	// the interface satisfies it. The methods of testing.TB up to Go 1.26 are implemented below; calling a method added
+	// in a later Go version would call the nil value and panic.
+	testing.TB
+
+	name  string
+	suite *suiteRun
+
+	mutex   sync.Mutex
+	failed  bool
+	skipped bool
+}
+
+func (t *suiteT) Name() string {
+	return t.name
+}
+
+func (t *suiteT) Helper() {}
+
+func (t *suiteT) Log(args ...interface{}) {
+	logger.DoLog(t, 2, os.Stdout, args...)
+}
+
+func (t *suiteT) Logf(format string, args ...interface{}) {
+	logger.DoLog(t, 2, os.Stdout, fmt.Sprintf(format, args...))
+}
+
+func (t *suiteT) Error(args ...interface{}) {
+	logger.DoLog(t, 2, os.Stderr, args...)
+	t.Fail()
+}
+
+func (t *suiteT) Errorf(format string, args ...interface{}) {
+	logger.DoLog(t, 2, os.Stderr, fmt.Sprintf(format, args...))
+	t.Fail()
+}
+
+func (t *suiteT) Fail() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.failed = true
+}
+
+func (t *suiteT) Failed() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.failed
+}
+
+func (t *suiteT) FailNow() {
+	t.Fail()
+	runtime.Goexit()
+}
+
+func (t *suiteT) Fatal(args ...interface{}) {
+	logger.DoLog(t, 2, os.Stderr, args...)
+	t.FailNow()
+}
+
+func (t *suiteT) Fatalf(format string, args ...interface{}) {
+	logger.DoLog(t, 2, os.Stderr, fmt.Sprintf(format, args...))
+	t.FailNow()
+}
+
+func (t *suiteT) Skip(args ...interface{}) {
+	logger.DoLog(t, 2, os.Stdout, args...)
+	t.SkipNow()
+}
+
+func (t *suiteT) Skipf(format string, args ...interface{}) {
+	logger.DoLog(t, 2, os.Stdout, fmt.Sprintf(format, args...))
+	t.SkipNow()
+}
+
+func (t *suiteT) SkipNow() {
+	t.mutex.Lock()
+	t.skipped = true
+	t.mutex.Unlock()
+	runtime.Goexit()
+}
+
+func (t *suiteT) Skipped() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.skipped
+}
+
+// Cleanup registers the given function to run after all fixtures of the suite are torn down.
+func (t *suiteT) Cleanup(fn func()) {
+	t.suite.mutex.Lock()
+	defer t.suite.mutex.Unlock()
+	t.suite.cleanups = append(t.suite.cleanups, suiteCleanup{t: t, fn: fn})
+}
+
+// TempDir creates a new temp dir, which is removed after all fixtures of the suite are torn down.
+func (t *suiteT) TempDir() string {
+	dir, err := ioutil.TempDir("", strings.Replace(t.name, "/", "_", -1))
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("Failed to remove temp dir %s: %v", dir, err)
+		}
+	})
+	return dir
+}
+
+// Setenv sets the given environment variable, and restores its previous value after all fixtures of the suite are torn
+// down.
+func (t *suiteT) Setenv(key string, value string) {
+	previous, wasSet := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatalf("Failed to set environment variable %s: %v", key, err)
+	}
+	t.Cleanup(func() {
+		if wasSet {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// Chdir changes the working directory to the given dir, and changes it back after all fixtures of the suite are torn
+// down.
+func (t *suiteT) Chdir(dir string) {
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get the working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change the working directory to %s: %v", dir, err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(previous); err != nil {
+			t.Errorf("Failed to change the working directory back to %s: %v", previous, err)
+		}
+	})
+}
+
+// Context returns a context that is canceled when the suite ends, just before the cleanup functions run.
+func (t *suiteT) Context() context.Context {
+	return t.suite.ctx
+}
+
+func (t *suiteT) Attr(key string, value string) {
+	logger.DoLog(t, 2, os.Stdout, fmt.Sprintf("Attribute %s: %s", key, value))
+}
+
+func (t *suiteT) Output() io.Writer {
+	return os.Stdout
+}
+
+// ArtifactDir is not supported, as there is no -artifacts flag for TestMain to honor. Use TempDir instead.
+func (t *suiteT) ArtifactDir() string {
+	t.Fatalf("ArtifactDir is not supported in suite fixtures. Use TempDir instead.")
+	return ""
+}
@@ -0,0 +1,165 @@
+package test_structure
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clusterHandle struct {
+	Endpoint string
+}
+
+func newRecordingFixture(folder string, name string, events *[]string, failSetup bool) SuiteFixture {
+	return SuiteFixture{
+		Name:   name,
+		Folder: folder,
+		Setup: func(t testing.TB) interface{} {
+			*events = append(*events, "setup "+name)
+			if failSetup {
+				t.Fatal("Intentional setup failure")
+			}
+			return clusterHandle{Endpoint: name + ".example.com"}
+		},
+		Teardown: func(t testing.TB) {
+			*events = append(*events, "teardown "+name)
+		},
+	}
+}
+
+func TestRunWithSuiteFixtures(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "suite-fixture")
+	require.NoError(t, err)
+
+	events := []string{}
+	fixtures := []SuiteFixture{
+		newRecordingFixture(folder, "network", &events, false),
+		newRecordingFixture(folder, "cluster", &events, false),
+	}
+
+	exitCode := runWithSuiteFixtures(func() int {
+		var handle clusterHandle
+		LoadSuiteFixture(t, folder, "cluster", &handle)
+		assert.Equal(t, "cluster.example.com", handle.Endpoint)
+
+		events = append(events, "tests")
+		return 0
+	}, fixtures)
+
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, []string{"setup network", "setup cluster", "tests", "teardown cluster", "teardown network"}, events)
+	assert.False(t, files.FileExists(formatSuiteFixturePath(folder, "cluster")))
+}
+
+func TestRunWithSuiteFixturesSetupFailure(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "suite-fixture")
+	require.NoError(t, err)
+
+	events := []string{}
+	fixtures := []SuiteFixture{
+		newRecordingFixture(folder, "network", &events, false),
+		newRecordingFixture(folder, "cluster", &events, true),
+		newRecordingFixture(folder, "app", &events, false),
+	}
+
+	exitCode := runWithSuiteFixtures(func() int {
+		events = append(events, "tests")
+		return 0
+	}, fixtures)
+
+	// The tests don't run, and the partially set up cluster is torn down too
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, []string{"setup network", "setup cluster", "teardown cluster", "teardown network"}, events)
+}
+
+func TestRunWithSuiteFixturesKeepsTestExitCode(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "suite-fixture")
+	require.NoError(t, err)
+
+	events := []string{}
+	exitCode := runWithSuiteFixtures(func() int { return 2 }, []SuiteFixture{newRecordingFixture(folder, "cluster", &events, false)})
+
+	assert.Equal(t, 2, exitCode)
+	assert.Equal(t, []string{"setup cluster", "teardown cluster"}, events)
+}
+
+func TestSuiteTFatalStopsFunction(t *testing.T) {
+	t.Parallel()
+
+	reachedEnd := false
+	succeeded := runAsSuiteTest(newSuiteRun(), "fatal", func(t testing.TB) {
+		require.Equal(t, "TestMain/fatal", t.Name())
+		t.Fatalf("Intentional failure %d", 1)
+		reachedEnd = true
+	})
+
+	assert.False(t, succeeded)
+	assert.False(t, reachedEnd)
+	assert.True(t, runAsSuiteTest(newSuiteRun(), "passing", func(t testing.TB) { t.Log("all good") }))
+}
+
+func TestSuiteTCleanupRunsAfterSuite(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "suite-fixture")
+	require.NoError(t, err)
+
+	// The variable is unique to this test, so setting it doesn't affect tests running in parallel
+	envVarName := "TERRATEST_SUITE_FIXTURE_TEST_" + random.UniqueId()
+
+	events := []string{}
+	tempDir := ""
+	fixture := SuiteFixture{
+		Name:   "cluster",
+		Folder: folder,
+		Setup: func(t testing.TB) interface{} {
+			tempDir = t.TempDir()
+			t.Setenv(envVarName, "set-up")
+			t.Cleanup(func() { events = append(events, "first cleanup") })
+			t.Cleanup(func() { events = append(events, "second cleanup") })
+			return clusterHandle{}
+		},
+		Teardown: func(t testing.TB) {
+			events = append(events, "teardown")
+		},
+	}
+
+	exitCode := runWithSuiteFixtures(func() int {
+		// The cleanup functions of setup have not run yet while the tests run
+		assert.True(t, files.FileExists(tempDir))
+		assert.Equal(t, "set-up", os.Getenv(envVarName))
+
+		events = append(events, "tests")
+		return 0
+	}, []SuiteFixture{fixture})
+
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, []string{"tests", "teardown", "second cleanup", "first cleanup"}, events)
+	assert.False(t, files.FileExists(tempDir))
+	_, isSet := os.LookupEnv(envVarName)
+	assert.False(t, isSet)
+}
+
+func TestSuiteTCleanupFailureFailsSuite(t *testing.T) {
+	t.Parallel()
+
+	suite := newSuiteRun()
+	require.True(t, runAsSuiteTest(suite, "setup", func(t testing.TB) {
+		t.Cleanup(func() { t.Fatal("Intentional cleanup failure") })
+	}))
+	require.NoError(t, suite.ctx.Err())
+
+	assert.False(t, suite.runCleanups())
+	assert.Error(t, suite.ctx.Err())
+}
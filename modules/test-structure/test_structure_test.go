@@ -1,6 +1,11 @@
 package test_structure
 
-import "testing"
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestCopyToTempFolder(t *testing.T) {
 	tempFolder := CopyTerraformFolderToTemp(t, "../../", "examples")
@@ -13,3 +18,17 @@ func TestCopySubtestToTempFolder(t *testing.T) {
 		t.Log(tempFolder)
 	})
 }
+
+func TestRunTestStage(t *testing.T) {
+	ran := false
+	RunTestStage(t, "terratest_run_test_stage", func() { ran = true })
+	assert.True(t, ran)
+
+	os.Setenv("SKIP_terratest_skip_test_stage", "true")
+	defer os.Unsetenv("SKIP_terratest_skip_test_stage")
+
+	skipped := true
+	RunTestStage(t, "terratest_skip_test_stage", func() { skipped = false })
+	assert.True(t, skipped)
+	assert.True(t, SkipStageEnvVarSet())
+}
@@ -0,0 +1,172 @@
+// Package timing records how long the helpers called by a test take and prints a summary at the end of the test.
+package timing
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// Entry is the elapsed time of a single helper invocation.
+type Entry struct {
+	Name    string
+	Elapsed time.Duration
+	Failed  bool
+}
+
+// Summary aggregates all the invocations of a single helper.
+type Summary struct {
+	Name    string
+	Calls   int
+	Failed  int
+	Total   time.Duration
+	Longest time.Duration
+}
+
+// Recorder records the entries for a single test.
+type Recorder struct {
+	mutex   sync.Mutex
+	entries []Entry
+}
+
+var (
+	recordersMutex sync.Mutex
+	recorders      = map[string]*Recorder{}
+)
+
+// Start starts recording timing entries for the given test. Entries are only recorded for tests that have called
+// Start, so the instrumentation built into Terratest (e.g. in the terraform package) costs nothing otherwise. You will
+// typically call this at the beginning of the test and defer a call to PrintSummary:
+//
+//	timing.Start(t)
+//	defer timing.PrintSummary(t)
+func Start(t testing.TB) *Recorder {
+	recordersMutex.Lock()
+	defer recordersMutex.Unlock()
+
+	recorder := &Recorder{}
+	recorders[t.Name()] = recorder
+	return recorder
+}
+
+// Time runs the given action and records how long it took under the given name.
+func Time(t testing.TB, name string, action func()) {
+	start := time.Now()
+	action()
+	Record(t, name, time.Since(start), nil)
+}
+
+// TimeE runs the given action and records how long it took under the given name, and whether it returned an error.
+func TimeE(t testing.TB, name string, action func() error) error {
+	start := time.Now()
+	err := action()
+	Record(t, name, time.Since(start), err)
+	return err
+}
+
+// RecordSince records an entry with the given name for the given test, covering the time since start, and whether the
+// error that err points to is set. It is meant to be deferred at the start of a helper with a named error result, so it
+// records the error the helper returns:
+//
+//	func WaitUntilReadyE(t testing.TB) (err error) {
+//		defer timing.RecordSince(t, "WaitUntilReady", time.Now(), &err)
+//		...
+//	}
+func RecordSince(t testing.TB, name string, start time.Time, err *error) {
+	Record(t, name, time.Since(start), *err)
+}
+
+// Record records an entry with the given name and elapsed time for the given test. The error is the error returned by
+// the timed action, if any. This does nothing if Start has not been called for the test.
+//
+// Entries are aggregated by name, so the name should identify the helper (e.g. "terraform apply" or
+// "k8s.WaitUntilPodAvailable") rather than describe the call: a name that holds the arguments of the call would get a
+// row of its own for every call, and would print variable values, which may be secrets, in the summary.
+func Record(t testing.TB, name string, elapsed time.Duration, err error) {
+	recordersMutex.Lock()
+	recorder, exists := recorders[t.Name()]
+	recordersMutex.Unlock()
+
+	if !exists {
+		return
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.entries = append(recorder.entries, Entry{Name: name, Elapsed: elapsed, Failed: err != nil})
+}
+
+// Entries returns all the entries recorded so far, in the order they were recorded.
+func (recorder *Recorder) Entries() []Entry {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]Entry{}, recorder.entries...)
+}
+
+// Summaries aggregates the entries recorded so far by name, sorted by total elapsed time, slowest first.
+func (recorder *Recorder) Summaries() []Summary {
+	summariesByName := map[string]*Summary{}
+	summaries := []*Summary{}
+
+	for _, entry := range recorder.Entries() {
+		summary, exists := summariesByName[entry.Name]
+		if !exists {
+			summary = &Summary{Name: entry.Name}
+			summariesByName[entry.Name] = summary
+			summaries = append(summaries, summary)
+		}
+
+		summary.Calls++
+		summary.Total += entry.Elapsed
+		if entry.Elapsed > summary.Longest {
+			summary.Longest = entry.Elapsed
+		}
+		if entry.Failed {
+			summary.Failed++
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].Total > summaries[j].Total })
+
+	result := []Summary{}
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	return result
+}
+
+// PrintSummary logs a table of the time spent in each helper during the given test and stops recording entries for
+// the test.
+func PrintSummary(t testing.TB) {
+	recordersMutex.Lock()
+	recorder, exists := recorders[t.Name()]
+	delete(recorders, t.Name())
+	recordersMutex.Unlock()
+
+	if !exists {
+		return
+	}
+
+	logger.Logf(t, "Timing summary:\n%s", formatSummaries(recorder.Summaries()))
+}
+
+// formatSummaries formats the given summaries as a table.
+func formatSummaries(summaries []Summary) string {
+	var buffer bytes.Buffer
+
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "HELPER\tCALLS\tFAILED\tTOTAL\tLONGEST")
+	for _, summary := range summaries {
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%s\t%s\n", summary.Name, summary.Calls, summary.Failed, summary.Total.Round(time.Millisecond), summary.Longest.Round(time.Millisecond))
+	}
+	writer.Flush()
+
+	return buffer.String()
+}
@@ -0,0 +1,82 @@
+package timing
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordWithoutStartDoesNothing(t *testing.T) {
+	t.Parallel()
+
+	Record(t, "terraform apply", time.Second, nil)
+
+	recorder := Start(t)
+	defer PrintSummary(t)
+	assert.Empty(t, recorder.Entries())
+}
+
+func TestSummaries(t *testing.T) {
+	t.Parallel()
+
+	recorder := Start(t)
+	defer PrintSummary(t)
+
+	Record(t, "terraform apply", 3*time.Minute, nil)
+	Record(t, "wait for pod", 10*time.Second, fmt.Errorf("timed out"))
+	Record(t, "wait for pod", 30*time.Second, nil)
+	Record(t, "terraform apply", time.Minute, nil)
+
+	summaries := recorder.Summaries()
+	require.Len(t, summaries, 2)
+	assert.Equal(t, Summary{Name: "terraform apply", Calls: 2, Failed: 0, Total: 4 * time.Minute, Longest: 3 * time.Minute}, summaries[0])
+	assert.Equal(t, Summary{Name: "wait for pod", Calls: 2, Failed: 1, Total: 40 * time.Second, Longest: 30 * time.Second}, summaries[1])
+}
+
+func TestTimeE(t *testing.T) {
+	t.Parallel()
+
+	recorder := Start(t)
+	defer PrintSummary(t)
+
+	expectedErr := fmt.Errorf("expected error")
+	err := TimeE(t, "failing helper", func() error { return expectedErr })
+	assert.Equal(t, expectedErr, err)
+
+	Time(t, "passing helper", func() {})
+
+	entries := recorder.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "failing helper", entries[0].Name)
+	assert.True(t, entries[0].Failed)
+	assert.Equal(t, "passing helper", entries[1].Name)
+	assert.False(t, entries[1].Failed)
+}
+
+func TestRecordSince(t *testing.T) {
+	t.Parallel()
+
+	recorder := Start(t)
+	defer PrintSummary(t)
+
+	waitUntilReadyE := func() (err error) {
+		defer RecordSince(t, "WaitUntilReady", time.Now(), &err)
+		return fmt.Errorf("not ready")
+	}
+	assert.Error(t, waitUntilReadyE())
+
+	entries := recorder.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "WaitUntilReady", entries[0].Name)
+	assert.True(t, entries[0].Failed)
+}
+
+func TestFormatSummaries(t *testing.T) {
+	t.Parallel()
+
+	out := formatSummaries([]Summary{{Name: "terraform apply", Calls: 1, Total: 90 * time.Second, Longest: 90 * time.Second}})
+	assert.Equal(t, "HELPER           CALLS  FAILED  TOTAL  LONGEST\nterraform apply  1      0       1m30s  1m30s\n", out)
+}
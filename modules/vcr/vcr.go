@@ -0,0 +1,387 @@
+// Package vcr records the HTTP interactions between a test and cloud APIs to a cassette file, and replays them on later
+// runs, so expensive integration tests can be re-run quickly and deterministically while developing them. The GCP and
+// AWS helpers in terratest (and the Azure Blob Storage store in the blobstore package) send their requests through
+// this package, so wrapping a test in Start and Stop is all it takes:
+//
+//	recorder := vcr.Start(t, "testdata/cassettes/"+t.Name()+".json")
+//	defer recorder.Stop(t)
+//
+// Recording and replaying is off unless the TERRATEST_VCR_MODE environment variable is set to record, replay, or auto
+// (replay if the cassette exists and record otherwise), so CI keeps testing against the real APIs. Only one recorder
+// can be active at a time, so tests that use it must not run in parallel with each other.
+//
+// Request headers (including credentials) are never recorded, and signatures in URLs are stripped, but response
+// bodies are recorded as is: review cassettes before committing them.
+package vcr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+)
+
+// ModeEnvVar is the environment variable that sets the Mode of recorders created with Start.
+const ModeEnvVar = "TERRATEST_VCR_MODE"
+
+// Mode is what a Recorder does with HTTP requests.
+type Mode string
+
+const (
+	// ModeDisabled sends requests to the real APIs without recording them.
+	ModeDisabled Mode = ""
+	// ModeRecord sends requests to the real APIs and records the interactions to the cassette.
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests from the cassette without sending them, failing requests that were not recorded.
+	ModeReplay Mode = "replay"
+	// ModeAuto replays if the cassette exists and records otherwise.
+	ModeAuto Mode = "auto"
+)
+
+// Query parameters that are dropped from recorded URLs (and ignored when matching) because they hold credentials or
+// signatures that change on every run.
+var redactedQueryParams = []string{"sig", "access_token", "key", "X-Amz-Signature", "X-Amz-Credential", "X-Amz-Security-Token", "X-Amz-Date"}
+
+// Cassette is the file a Recorder records interactions to and replays them from.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+	used     bool
+}
+
+// RecordedRequest is the part of a request that is recorded and used for matching.
+type RecordedRequest struct {
+	Method     string `json:"method"`
+	Url        string `json:"url"`
+	Body       string `json:"body,omitempty"`
+	BodyBase64 string `json:"body_base64,omitempty"`
+}
+
+// RecordedResponse is a recorded response. Bodies that are not valid UTF-8 are stored in BodyBase64.
+type RecordedResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers"`
+	Body       string              `json:"body,omitempty"`
+	BodyBase64 string              `json:"body_base64,omitempty"`
+}
+
+// Recorder records or replays HTTP interactions. Create one with Start.
+type Recorder struct {
+	cassettePath string
+	mode         Mode
+	mutex        sync.Mutex
+	cassette     Cassette
+}
+
+var (
+	activeMutex sync.RWMutex
+	active      *Recorder
+)
+
+// Start creates a Recorder for the cassette at the given path, in the mode set by ModeEnvVar, and makes it the active
+// recorder. Call Stop, typically with defer, to save the cassette and deactivate the recorder. It fails the test if
+// the mode is invalid, the cassette can't be read, or another recorder is already active.
+func Start(t testing.TB, cassettePath string) *Recorder {
+	recorder, err := StartE(t, cassettePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return recorder
+}
+
+// StartE creates a Recorder for the cassette at the given path, in the mode set by ModeEnvVar, and makes it the active
+// recorder. Call Stop, typically with defer, to save the cassette and deactivate the recorder.
+func StartE(t testing.TB, cassettePath string) (*Recorder, error) {
+	mode := Mode(os.Getenv(ModeEnvVar))
+	switch mode {
+	case ModeDisabled, ModeRecord, ModeReplay, ModeAuto:
+	default:
+		return nil, InvalidMode(mode)
+	}
+
+	return StartWithModeE(t, cassettePath, mode)
+}
+
+// StartWithModeE creates a Recorder for the cassette at the given path in the given mode, ignoring ModeEnvVar, and
+// makes it the active recorder. Call Stop, typically with defer, to save the cassette and deactivate the recorder.
+func StartWithModeE(t testing.TB, cassettePath string, mode Mode) (*Recorder, error) {
+	if mode == ModeAuto {
+		if _, err := os.Stat(cassettePath); err == nil {
+			mode = ModeReplay
+		} else {
+			mode = ModeRecord
+		}
+	}
+
+	recorder := &Recorder{cassettePath: cassettePath, mode: mode}
+	if mode == ModeReplay {
+		contents, err := ioutil.ReadFile(cassettePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(contents, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("Failed to parse cassette %s: %v", cassettePath, err)
+		}
+	}
+
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+
+	if active != nil {
+		return nil, RecorderAlreadyActive(active.cassettePath)
+	}
+	active = recorder
+
+	if mode != ModeDisabled {
+		logger.Logf(t, "Using cassette %s in %s mode", cassettePath, mode)
+	}
+	return recorder, nil
+}
+
+// Mode returns the mode the recorder is in. ModeAuto is resolved to ModeRecord or ModeReplay when the recorder starts.
+func (recorder *Recorder) Mode() Mode {
+	return recorder.mode
+}
+
+// Stop deactivates the recorder and, if it is recording, saves the cassette. It fails the test if the cassette can't
+// be saved.
+func (recorder *Recorder) Stop(t testing.TB) {
+	err := recorder.StopE(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// StopE deactivates the recorder and, if it is recording, saves the cassette.
+func (recorder *Recorder) StopE(t testing.TB) error {
+	activeMutex.Lock()
+	if active == recorder {
+		active = nil
+	}
+	activeMutex.Unlock()
+
+	if recorder.mode != ModeRecord {
+		return nil
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	contents, err := json.MarshalIndent(recorder.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(recorder.cassettePath), 0755); err != nil {
+		return err
+	}
+
+	logger.Logf(t, "Saving %d interactions to cassette %s", len(recorder.cassette.Interactions), recorder.cassettePath)
+	return ioutil.WriteFile(recorder.cassettePath, contents, 0644)
+}
+
+// Replaying returns true if there is an active recorder in replay mode. Clients use it to skip looking up real
+// credentials, which are not needed to replay a cassette.
+func Replaying() bool {
+	activeMutex.RLock()
+	defer activeMutex.RUnlock()
+
+	return active != nil && active.mode == ModeReplay
+}
+
+// WrapTransport returns an http.RoundTripper that sends requests through the active recorder, if there is one, and
+// through the given transport otherwise. The recorder is looked up on every request, so clients can be created before
+// the recorder starts.
+func WrapTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+// HttpClient returns an HTTP client that sends requests through the active recorder, if there is one.
+func HttpClient() *http.Client {
+	return &http.Client{Transport: WrapTransport(http.DefaultTransport)}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (transport *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	activeMutex.RLock()
+	recorder := active
+	activeMutex.RUnlock()
+
+	if recorder == nil || recorder.mode == ModeDisabled {
+		return transport.base.RoundTrip(req)
+	}
+	return recorder.roundTrip(req, transport.base)
+}
+
+func (recorder *Recorder) roundTrip(req *http.Request, base http.RoundTripper) (*http.Response, error) {
+	requestBody := []byte{}
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestBody = body
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recordedRequest := RecordedRequest{Method: req.Method, Url: redactUrl(req.URL)}
+	recordedRequest.Body, recordedRequest.BodyBase64 = encodeBody(requestBody)
+
+	if recorder.mode == ModeReplay {
+		interaction := recorder.findInteraction(recordedRequest)
+		if interaction == nil {
+			return nil, InteractionNotFound{Method: recordedRequest.Method, Url: recordedRequest.Url, Cassette: recorder.cassettePath}
+		}
+		return interaction.Response.toHttpResponse(req)
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	headers := map[string][]string{}
+	for name, values := range resp.Header {
+		if http.CanonicalHeaderKey(name) != "Set-Cookie" {
+			headers[name] = values
+		}
+	}
+
+	recordedResponse := RecordedResponse{StatusCode: resp.StatusCode, Headers: headers}
+	recordedResponse.Body, recordedResponse.BodyBase64 = encodeBody(responseBody)
+
+	recorder.mutex.Lock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, &Interaction{Request: recordedRequest, Response: recordedResponse})
+	recorder.mutex.Unlock()
+
+	return resp, nil
+}
+
+// findInteraction returns the first unused interaction with the same method, URL, and body as the given request, or
+// failing that, the first unused interaction with the same method and URL (as bodies may contain random tokens, such
+// as idempotency keys). Each interaction is replayed once, so repeated requests (e.g. polling) get the recorded
+// responses in order.
+func (recorder *Recorder) findInteraction(request RecordedRequest) *Interaction {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	var sameUrl *Interaction
+	for _, interaction := range recorder.cassette.Interactions {
+		if interaction.used || interaction.Request.Method != request.Method || interaction.Request.Url != request.Url {
+			continue
+		}
+		if interaction.Request.Body == request.Body && interaction.Request.BodyBase64 == request.BodyBase64 {
+			interaction.used = true
+			return interaction
+		}
+		if sameUrl == nil {
+			sameUrl = interaction
+		}
+	}
+
+	if sameUrl != nil {
+		sameUrl.used = true
+	}
+	return sameUrl
+}
+
+func (response RecordedResponse) toHttpResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(response.Body)
+	if response.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(response.BodyBase64)
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+
+	header := http.Header{}
+	for name, values := range response.Headers {
+		header[name] = values
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// encodeBody returns the body as text if it is valid UTF-8, and base64 encoded otherwise.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+// redactUrl returns the URL without the query parameters in redactedQueryParams.
+func redactUrl(requestUrl *url.URL) string {
+	redacted := *requestUrl
+	query := redacted.Query()
+	for _, param := range redactedQueryParams {
+		query.Del(param)
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// InvalidMode is returned when ModeEnvVar is set to something other than record, replay, or auto.
+type InvalidMode string
+
+func (mode InvalidMode) Error() string {
+	return fmt.Sprintf("Invalid %s %q: must be record, replay, or auto", ModeEnvVar, string(mode))
+}
+
+// RecorderAlreadyActive is returned when starting a recorder while another one, for the given cassette, is active.
+type RecorderAlreadyActive string
+
+func (cassettePath RecorderAlreadyActive) Error() string {
+	return fmt.Sprintf("A recorder for cassette %s is already active. Tests that use vcr must not run in parallel.", string(cassettePath))
+}
+
+// InteractionNotFound is returned when replaying a request that is not in the cassette.
+type InteractionNotFound struct {
+	Method   string
+	Url      string
+	Cassette string
+}
+
+func (err InteractionNotFound) Error() string {
+	return fmt.Sprintf("No recorded response for %s %s in cassette %s. Record the cassette again with %s=record.", err.Method, err.Url, err.Cassette, ModeEnvVar)
+}
@@ -0,0 +1,122 @@
+package vcr
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file do not call t.Parallel, as only one recorder can be active at a time.
+
+func newCassettePath(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "terratest-vcr")
+	require.NoError(t, err)
+	return filepath.Join(dir, "cassettes", "test.json")
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	cassettePath := newCassettePath(t)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(cassettePath)))
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Set-Cookie", "session=secret")
+		fmt.Fprintf(w, "response %d to %s", requests, r.URL.Path)
+	}))
+	client := HttpClient()
+
+	recorder, err := StartWithModeE(t, cassettePath, ModeRecord)
+	require.NoError(t, err)
+	_, first := get(t, client, server.URL+"/status?sig=secret")
+	_, second := get(t, client, server.URL+"/status?sig=other-secret")
+	recorder.Stop(t)
+	server.Close()
+
+	assert.Equal(t, "response 1 to /status", first)
+	assert.Equal(t, "response 2 to /status", second)
+
+	contents, err := ioutil.ReadFile(cassettePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(contents), "secret")
+
+	recorder, err = StartWithModeE(t, cassettePath, ModeAuto)
+	require.NoError(t, err)
+	defer recorder.Stop(t)
+	assert.Equal(t, ModeReplay, recorder.Mode())
+	assert.True(t, Replaying())
+
+	status, replayedFirst := get(t, client, server.URL+"/status?sig=third-secret")
+	_, replayedSecond := get(t, client, server.URL+"/status")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, first, replayedFirst)
+	assert.Equal(t, second, replayedSecond)
+
+	_, err = client.Get(server.URL + "/status")
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "No recorded response"), err.Error())
+}
+
+func TestReplayMatchesOnBody(t *testing.T) {
+	recorder := &Recorder{mode: ModeReplay, cassette: Cassette{Interactions: []*Interaction{
+		{Request: RecordedRequest{Method: "POST", Url: "https://ec2.amazonaws.com/", Body: "Action=DescribeVpcs"}, Response: RecordedResponse{StatusCode: 200, Body: "vpcs"}},
+		{Request: RecordedRequest{Method: "POST", Url: "https://ec2.amazonaws.com/", Body: "Action=DescribeSubnets"}, Response: RecordedResponse{StatusCode: 200, Body: "subnets"}},
+	}}}
+
+	interaction := recorder.findInteraction(RecordedRequest{Method: "POST", Url: "https://ec2.amazonaws.com/", Body: "Action=DescribeSubnets"})
+	require.NotNil(t, interaction)
+	assert.Equal(t, "subnets", interaction.Response.Body)
+
+	// Falls back to the first unused interaction with the same URL when no body matches
+	interaction = recorder.findInteraction(RecordedRequest{Method: "POST", Url: "https://ec2.amazonaws.com/", Body: "Action=RunInstances&ClientToken=random"})
+	require.NotNil(t, interaction)
+	assert.Equal(t, "vpcs", interaction.Response.Body)
+
+	assert.Nil(t, recorder.findInteraction(RecordedRequest{Method: "POST", Url: "https://ec2.amazonaws.com/"}))
+}
+
+func TestOnlyOneRecorderActive(t *testing.T) {
+	cassettePath := newCassettePath(t)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(cassettePath)))
+
+	recorder, err := StartWithModeE(t, cassettePath, ModeDisabled)
+	require.NoError(t, err)
+
+	_, err = StartWithModeE(t, cassettePath, ModeDisabled)
+	require.Error(t, err)
+	assert.IsType(t, RecorderAlreadyActive(""), err)
+
+	recorder.Stop(t)
+
+	recorder, err = StartWithModeE(t, cassettePath, ModeDisabled)
+	require.NoError(t, err)
+	recorder.Stop(t)
+}
+
+func TestEncodeBody(t *testing.T) {
+	text, encoded := encodeBody([]byte("hello"))
+	assert.Equal(t, "hello", text)
+	assert.Equal(t, "", encoded)
+
+	text, encoded = encodeBody([]byte{0xff, 0xfe})
+	assert.Equal(t, "", text)
+	assert.Equal(t, "//4=", encoded)
+}
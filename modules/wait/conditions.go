@@ -0,0 +1,120 @@
+package wait
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/gcp"
+	http_helper "github.com/gruntwork-io/terratest/modules/http-helper"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// HttpStatus returns a Condition that holds once an HTTP GET of the given URL returns the given status code.
+func HttpStatus(url string, expectedStatus int) Condition {
+	return Func(fmt.Sprintf("GET %s to return %d", url, expectedStatus), func(t testing.TB) error {
+		status, _, err := http_helper.HttpGetE(t, url, nil)
+		if err != nil {
+			return err
+		}
+		if status != expectedStatus {
+			return fmt.Errorf("got status %d", status)
+		}
+		return nil
+	})
+}
+
+// TcpOpen returns a Condition that holds once a TCP connection to the given address (host:port) can be opened.
+func TcpOpen(address string) Condition {
+	return Func(fmt.Sprintf("%s to accept TCP connections", address), func(t testing.TB) error {
+		conn, err := net.DialTimeout("tcp", address, 10*time.Second)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
+
+// PodReady returns a Condition that holds once the given pod is running and all of its containers are ready. It stops
+// waiting if the pod fails or completes, as it will never become ready.
+func PodReady(options *k8s.KubectlOptions, podName string) Condition {
+	return Func(fmt.Sprintf("pod %s to be ready", podName), func(t testing.TB) error {
+		pod, err := k8s.GetPodE(t, options, podName)
+		if err != nil {
+			return err
+		}
+		return checkPodReady(pod)
+	})
+}
+
+func checkPodReady(pod *corev1.Pod) error {
+	switch pod.Status.Phase {
+	case corev1.PodFailed, corev1.PodSucceeded:
+		return retry.FatalError{Underlying: fmt.Errorf("pod %s is in phase %s and will never become ready", pod.Name, pod.Status.Phase)}
+	case corev1.PodRunning:
+	default:
+		return fmt.Errorf("pod %s is in phase %s", pod.Name, pod.Status.Phase)
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return nil
+		}
+	}
+	return fmt.Errorf("pod %s is running but not ready", pod.Name)
+}
+
+// ServiceAvailable returns a Condition that holds once the given service is available, which for a LoadBalancer
+// service means it has been given an ingress address.
+func ServiceAvailable(options *k8s.KubectlOptions, serviceName string) Condition {
+	return Func(fmt.Sprintf("service %s to be available", serviceName), func(t testing.TB) error {
+		service, err := k8s.GetServiceE(t, options, serviceName)
+		if err != nil {
+			return err
+		}
+		if !k8s.IsServiceAvailable(service) {
+			return k8s.NewServiceNotAvailableError(service)
+		}
+		return nil
+	})
+}
+
+// ResourceState returns a Condition that holds once the given function returns the expected state. Use it for cloud
+// resources that report a state or status, e.g. an RDS instance that becomes "available". If the function returns
+// one of the failedStates, waiting stops immediately.
+func ResourceState(description string, getState func(t testing.TB) (string, error), expectedState string, failedStates ...string) Condition {
+	return Func(fmt.Sprintf("%s to be %s", description, expectedState), func(t testing.TB) error {
+		state, err := getState(t)
+		if err != nil {
+			return err
+		}
+		return checkState(description, state, expectedState, failedStates)
+	})
+}
+
+func checkState(description string, state string, expectedState string, failedStates []string) error {
+	if state == expectedState {
+		return nil
+	}
+	for _, failedState := range failedStates {
+		if state == failedState {
+			return retry.FatalError{Underlying: fmt.Errorf("%s is %s and will never be %s", description, state, expectedState)}
+		}
+	}
+	return fmt.Errorf("%s is %s", description, state)
+}
+
+// GcpInstanceStatus returns a Condition that holds once the given Compute Instance has the given status (e.g.
+// RUNNING or TERMINATED).
+func GcpInstanceStatus(projectID string, name string, expectedStatus string) Condition {
+	return ResourceState(fmt.Sprintf("Compute Instance %s", name), func(t testing.TB) (string, error) {
+		instance, err := gcp.FetchInstanceE(t, projectID, name)
+		if err != nil {
+			return "", err
+		}
+		return instance.Status, nil
+	}, expectedStatus)
+}
@@ -0,0 +1,136 @@
+// Package wait provides a small declarative API for waiting until a condition holds, e.g.:
+//
+//	wait.For(t, "load balancer to serve traffic").
+//		WithTimeout(10 * time.Minute).
+//		WithInterval(10 * time.Second).
+//		Until(wait.HttpStatus(url, 200))
+//
+// It comes with conditions for the things tests most often wait for (HTTP endpoints, open TCP ports, Kubernetes
+// readiness, and cloud resource states), and any func(t testing.TB) error can be turned into a Condition.
+package wait
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/retry"
+)
+
+// DefaultTimeout is how long Until waits if WithTimeout is not called.
+const DefaultTimeout = 5 * time.Minute
+
+// DefaultInterval is how long Until sleeps between checks if WithInterval is not called.
+const DefaultInterval = 5 * time.Second
+
+// Condition is something to wait for. Check returns nil once the condition holds and an error describing why it does
+// not hold yet otherwise. If Check returns a retry.FatalError, waiting stops immediately, as the condition can never
+// hold (e.g. a pod that has failed will never become ready).
+type Condition struct {
+	Description string
+	Check       func(t testing.TB) error
+}
+
+// Func returns a Condition with the given description that holds once the given function returns nil.
+func Func(description string, check func(t testing.TB) error) Condition {
+	return Condition{Description: description, Check: check}
+}
+
+// All returns a Condition that holds once all of the given conditions hold at the same time.
+func All(conditions ...Condition) Condition {
+	descriptions := []string{}
+	for _, condition := range conditions {
+		descriptions = append(descriptions, condition.Description)
+	}
+
+	return Condition{
+		Description: strings.Join(descriptions, " and "),
+		Check: func(t testing.TB) error {
+			for _, condition := range conditions {
+				if err := condition.Check(t); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Waiter waits for a condition. Create one with For.
+type Waiter struct {
+	t           testing.TB
+	description string
+	timeout     time.Duration
+	interval    time.Duration
+}
+
+// For starts describing a wait. The description is used in log messages and errors, e.g. "the database to accept
+// connections".
+func For(t testing.TB, description string) *Waiter {
+	return &Waiter{t: t, description: description, timeout: DefaultTimeout, interval: DefaultInterval}
+}
+
+// WithTimeout sets how long to wait for the condition before giving up.
+func (waiter *Waiter) WithTimeout(timeout time.Duration) *Waiter {
+	waiter.timeout = timeout
+	return waiter
+}
+
+// WithInterval sets how long to sleep between checks of the condition.
+func (waiter *Waiter) WithInterval(interval time.Duration) *Waiter {
+	waiter.interval = interval
+	return waiter
+}
+
+// Until waits until the given condition holds, failing the test if it does not hold before the timeout or can never
+// hold.
+func (waiter *Waiter) Until(condition Condition) {
+	err := waiter.UntilE(condition)
+	if err != nil {
+		waiter.t.Fatal(err)
+	}
+}
+
+// UntilE waits until the given condition holds. It returns a ConditionNotMet error if the condition does not hold
+// before the timeout, and the underlying error of a retry.FatalError if the condition can never hold.
+func (waiter *Waiter) UntilE(condition Condition) error {
+	t := waiter.t
+	description := fmt.Sprintf("%s (%s)", waiter.description, condition.Description)
+
+	heartbeat := retry.StartHeartbeatFromEnv(t, "Waiting for "+description)
+	defer heartbeat.Stop()
+
+	deadline := time.Now().Add(waiter.timeout)
+	for attempt := 1; ; attempt++ {
+		err := condition.Check(t)
+		if err == nil {
+			logger.Logf(t, "Done waiting for %s after %d checks", description, attempt)
+			return nil
+		}
+
+		if fatalErr, isFatal := err.(retry.FatalError); isFatal {
+			logger.Logf(t, "Stopped waiting for %s: %v", description, fatalErr.Underlying)
+			return fatalErr.Underlying
+		}
+
+		if time.Now().Add(waiter.interval).After(deadline) {
+			return ConditionNotMet{Description: description, Timeout: waiter.timeout, LastErr: err}
+		}
+
+		logger.Logf(t, "Waiting for %s: %v. Checking again in %s.", description, err, waiter.interval)
+		time.Sleep(waiter.interval)
+	}
+}
+
+// ConditionNotMet is returned when a condition does not hold before the timeout.
+type ConditionNotMet struct {
+	Description string
+	Timeout     time.Duration
+	LastErr     error
+}
+
+func (err ConditionNotMet) Error() string {
+	return fmt.Sprintf("Timed out after %s waiting for %s. Last error: %v", err.Timeout, err.Description, err.LastErr)
+}
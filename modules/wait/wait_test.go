@@ -0,0 +1,139 @@
+package wait
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestUntilWaitsForCondition(t *testing.T) {
+	t.Parallel()
+
+	checks := 0
+	condition := Func("third check", func(t testing.TB) error {
+		checks++
+		if checks < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+
+	For(t, "test").WithTimeout(time.Second).WithInterval(time.Millisecond).Until(condition)
+	assert.Equal(t, 3, checks)
+}
+
+func TestUntilTimesOut(t *testing.T) {
+	t.Parallel()
+
+	condition := Func("never", func(t testing.TB) error { return errors.New("not yet") })
+
+	err := For(t, "test").WithTimeout(50 * time.Millisecond).WithInterval(10 * time.Millisecond).UntilE(condition)
+	require.Error(t, err)
+	assert.IsType(t, ConditionNotMet{}, err)
+	assert.Contains(t, err.Error(), "not yet")
+}
+
+func TestUntilStopsOnFatalError(t *testing.T) {
+	t.Parallel()
+
+	checks := 0
+	condition := Func("fatal", func(t testing.TB) error {
+		checks++
+		return retry.FatalError{Underlying: errors.New("never going to happen")}
+	})
+
+	err := For(t, "test").WithTimeout(time.Second).WithInterval(time.Millisecond).UntilE(condition)
+	require.Error(t, err)
+	assert.Equal(t, "never going to happen", err.Error())
+	assert.Equal(t, 1, checks)
+}
+
+func TestAllRequiresEveryCondition(t *testing.T) {
+	t.Parallel()
+
+	holds := Func("holds", func(t testing.TB) error { return nil })
+	fails := Func("fails", func(t testing.TB) error { return errors.New("fails") })
+
+	assert.NoError(t, All(holds, holds).Check(t))
+	assert.Error(t, All(holds, fails).Check(t))
+	assert.Equal(t, "holds and fails", All(holds, fails).Description)
+}
+
+func TestHttpStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	assert.NoError(t, HttpStatus(server.URL, http.StatusTeapot).Check(t))
+	assert.Error(t, HttpStatus(server.URL, http.StatusOK).Check(t))
+}
+
+func TestTcpOpen(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	assert.NoError(t, TcpOpen(address).Check(t))
+
+	listener.Close()
+	assert.Error(t, TcpOpen(address).Check(t))
+}
+
+func TestCheckPodReady(t *testing.T) {
+	t.Parallel()
+
+	readyCondition := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+
+	testCases := []struct {
+		name       string
+		phase      corev1.PodPhase
+		conditions []corev1.PodCondition
+		ready      bool
+		fatal      bool
+	}{
+		{"running-and-ready", corev1.PodRunning, readyCondition, true, false},
+		{"running-not-ready", corev1.PodRunning, nil, false, false},
+		{"pending", corev1.PodPending, nil, false, false},
+		{"failed", corev1.PodFailed, nil, false, true},
+	}
+
+	for _, testCase := range testCases {
+		// Capture the range value and force it into this scope. Otherwise, it is defined outside this block so it can
+		// change when the subtests parallelize and switch contexts.
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := &corev1.Pod{Status: corev1.PodStatus{Phase: testCase.phase, Conditions: testCase.conditions}}
+			err := checkPodReady(pod)
+
+			assert.Equal(t, testCase.ready, err == nil)
+			_, isFatal := err.(retry.FatalError)
+			assert.Equal(t, testCase.fatal, isFatal)
+		})
+	}
+}
+
+func TestCheckState(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, checkState("db", "available", "available", []string{"failed"}))
+	assert.Error(t, checkState("db", "creating", "available", []string{"failed"}))
+
+	err := checkState("db", "failed", "available", []string{"failed"})
+	assert.IsType(t, retry.FatalError{}, err)
+}
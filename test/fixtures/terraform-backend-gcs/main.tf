@@ -0,0 +1,7 @@
+terraform {
+  backend "gcs" {}
+}
+
+output "test" {
+  value = "Hello, World"
+}
@@ -0,0 +1,9 @@
+variable "name" {
+  default = "World"
+}
+
+locals {
+  greeting = "Hello, ${var.name}"
+}
+
+resource "null_resource" "test" {}
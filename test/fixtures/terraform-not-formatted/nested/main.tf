@@ -0,0 +1,3 @@
+output "nested" {
+value = "Hello, World"
+}
@@ -0,0 +1,7 @@
+# The timestamp changes on every run, so this resource is replaced on every apply and the configuration is never
+# idempotent.
+resource "null_resource" "not_idempotent" {
+  triggers {
+    time = "${timestamp()}"
+  }
+}
@@ -0,0 +1,21 @@
+variable "message" {}
+
+variable "names" {
+  type = "list"
+}
+
+variable "tags" {
+  type = "map"
+}
+
+output "message" {
+  value = "${var.message}"
+}
+
+output "names" {
+  value = "${var.names}"
+}
+
+output "tags" {
+  value = "${var.tags}"
+}
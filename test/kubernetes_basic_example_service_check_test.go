@@ -62,6 +62,7 @@ func TestKubernetesBasicExampleServiceCheck(t *testing.T) {
 	http_helper.HttpGetWithRetryWithCustomValidation(
 		t,
 		fmt.Sprintf("http://%s", endpoint),
+		nil,
 		30,
 		10*time.Second,
 		func(statusCode int, body string) bool {
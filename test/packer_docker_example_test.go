@@ -59,5 +59,5 @@ func TestPackerDockerExampleLocal(t *testing.T) {
 	url := fmt.Sprintf("http://localhost:%d", serverPort)
 
 	// Verify that we get back a 200 OK with the expected text
-	http_helper.HttpGetWithRetry(t, url, 200, expectedServerText, maxRetries, timeBetweenRetries)
+	http_helper.HttpGetWithRetry(t, url, nil, 200, expectedServerText, maxRetries, timeBetweenRetries)
 }
@@ -55,7 +55,10 @@ func TestTerraformAwsDynamoDBExample(t *testing.T) {
 	table := aws.GetDynamoDBTable(t, awsRegion, expectedTableName)
 
 	assert.Equal(t, "ACTIVE", awsSDK.StringValue(table.TableStatus))
-	assert.ElementsMatch(t, expectedKeySchema, table.KeySchema)
+	aws.AssertDynamoDBTableKeySchema(t, awsRegion, expectedTableName, expectedKeySchema)
+
+	// Verify point-in-time recovery is enabled
+	aws.AssertDynamoDBPointInTimeRecoveryEnabled(t, awsRegion, expectedTableName)
 
 	// Verify server-side encryption configuration
 	assert.Equal(t, expectedKmsKeyArn, awsSDK.StringValue(table.SSEDescription.KMSMasterKeyArn))
@@ -70,4 +73,20 @@ func TestTerraformAwsDynamoDBExample(t *testing.T) {
 	// Verify resource tags
 	tags := aws.GetDynamoDbTableTags(t, awsRegion, expectedTableName)
 	assert.ElementsMatch(t, expectedTags, tags)
+
+	// Verify the table can actually be written to and read from
+	type user struct {
+		UserID     string `dynamodbav:"userId"`
+		Department string `dynamodbav:"department"`
+		Name       string `dynamodbav:"name"`
+	}
+	expectedUser := user{UserID: random.UniqueId(), Department: "engineering", Name: "terratest"}
+	key := map[string]string{"userId": expectedUser.UserID, "department": expectedUser.Department}
+
+	aws.PutDynamoDBItem(t, awsRegion, expectedTableName, expectedUser)
+	defer aws.DeleteDynamoDBItem(t, awsRegion, expectedTableName, key)
+
+	var actualUser user
+	aws.GetDynamoDBItem(t, awsRegion, expectedTableName, key, &actualUser)
+	assert.Equal(t, expectedUser, actualUser)
 }
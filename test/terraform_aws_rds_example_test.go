@@ -64,6 +64,17 @@ func TestTerraformAwsRdsExample(t *testing.T) {
 	address := aws.GetAddressOfRdsInstance(t, dbInstanceID, awsRegion)
 	port := aws.GetPortOfRdsInstance(t, dbInstanceID, awsRegion)
 	schemaExistsInRdsInstance := aws.GetWhetherSchemaExistsInRdsMySqlInstance(t, address, port, username, password, expectedDatabaseName)
+
+	// Verify that the database accepts connections with the credentials we passed in
+	aws.CheckRdsConnection(t, aws.RdsConnectionOptions{
+		Engine:       aws.RdsEngineMySql,
+		Address:      address,
+		Port:         port,
+		Username:     username,
+		Password:     password,
+		DatabaseName: expectedDatabaseName,
+	})
+
 	// Lookup parameter values. All defined values are strings in the API call response
 	generalLogParameterValue := aws.GetParameterValueForParameterOfRdsInstance(t, "general_log", dbInstanceID, awsRegion)
 	allowSuspiciousUdfsParameterValue := aws.GetParameterValueForParameterOfRdsInstance(t, "allow-suspicious-udfs", dbInstanceID, awsRegion)
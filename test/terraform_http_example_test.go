@@ -55,5 +55,5 @@ func TestTerraformHttpExample(t *testing.T) {
 	timeBetweenRetries := 5 * time.Second
 
 	// Verify that we get back a 200 OK with the expected instanceText
-	http_helper.HttpGetWithRetry(t, instanceURL, 200, instanceText, maxRetries, timeBetweenRetries)
+	http_helper.HttpGetWithRetry(t, instanceURL, nil, 200, instanceText, maxRetries, timeBetweenRetries)
 }